@@ -21,10 +21,14 @@ import (
 	_ "github.com/EliCDavis/polyform/drawing/texturing/pattern"
 
 	_ "github.com/EliCDavis/polyform/formats/colmap"
+	_ "github.com/EliCDavis/polyform/formats/e57"
 	_ "github.com/EliCDavis/polyform/formats/gltf"
+	_ "github.com/EliCDavis/polyform/formats/las"
 	_ "github.com/EliCDavis/polyform/formats/obj"
 	_ "github.com/EliCDavis/polyform/formats/opensfm"
 	_ "github.com/EliCDavis/polyform/formats/ply"
+	_ "github.com/EliCDavis/polyform/formats/potree"
+	_ "github.com/EliCDavis/polyform/formats/ptx"
 	_ "github.com/EliCDavis/polyform/formats/splat"
 	_ "github.com/EliCDavis/polyform/formats/spz"
 	_ "github.com/EliCDavis/polyform/formats/stl"
@@ -56,16 +60,22 @@ import (
 	_ "github.com/EliCDavis/polyform/modeling"
 	_ "github.com/EliCDavis/polyform/modeling/animation"
 	_ "github.com/EliCDavis/polyform/modeling/extrude"
+	_ "github.com/EliCDavis/polyform/modeling/hull"
 	_ "github.com/EliCDavis/polyform/modeling/marching"
 	_ "github.com/EliCDavis/polyform/modeling/meshops"
 	_ "github.com/EliCDavis/polyform/modeling/meshops/gausops"
 	_ "github.com/EliCDavis/polyform/modeling/primitives"
 	_ "github.com/EliCDavis/polyform/modeling/repeat"
+	_ "github.com/EliCDavis/polyform/modeling/simplify"
 	_ "github.com/EliCDavis/polyform/modeling/triangulation"
+	_ "github.com/EliCDavis/polyform/modeling/unwrap"
 	_ "github.com/EliCDavis/polyform/modeling/voxelize"
 
 	_ "github.com/EliCDavis/polyform/nodes/experimental"
 	_ "github.com/EliCDavis/polyform/nodes/opearations"
+
+	_ "github.com/EliCDavis/polyform/rendering"
+	_ "github.com/EliCDavis/polyform/rendering/materials"
 )
 
 func main() {
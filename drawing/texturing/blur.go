@@ -134,6 +134,10 @@ type GaussianBlurFloatNode struct {
 	Signma  nodes.Output[float64]          `description:"standard deviation of the gaussian"`
 }
 
+func (n GaussianBlurFloatNode) CacheVersion() int {
+	return 1
+}
+
 func (n GaussianBlurFloatNode) Value(out *nodes.StructOutput[Texture[float64]]) {
 	if n.Texture == nil {
 		return
@@ -170,6 +174,10 @@ type GaussianBlurFloat3Node struct {
 	Signma  nodes.Output[float64]                  `description:"standard deviation of the gaussian"`
 }
 
+func (n GaussianBlurFloat3Node) CacheVersion() int {
+	return 1
+}
+
 func (n GaussianBlurFloat3Node) Value(out *nodes.StructOutput[Texture[vector3.Float64]]) {
 	if n.Texture == nil {
 		return
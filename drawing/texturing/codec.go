@@ -0,0 +1,122 @@
+package texturing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/EliCDavis/bitlib"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func init() {
+	nodes.RegisterOutputCodec[Texture[float64]](textureCodec[float64]{
+		size:  8,
+		write: func(w *bitlib.Writer, v float64) { w.Float64(v) },
+		read:  func(r *bitlib.Reader) float64 { return r.Float64() },
+	})
+
+	nodes.RegisterOutputCodec[Texture[bool]](textureCodec[bool]{
+		size: 1,
+		write: func(w *bitlib.Writer, v bool) {
+			if v {
+				w.Byte(1)
+			} else {
+				w.Byte(0)
+			}
+		},
+		read: func(r *bitlib.Reader) bool { return r.Byte() == 1 },
+	})
+
+	nodes.RegisterOutputCodec[Texture[vector3.Float64]](textureCodec[vector3.Float64]{
+		size: 24,
+		write: func(w *bitlib.Writer, v vector3.Float64) {
+			w.Float64(v.X())
+			w.Float64(v.Y())
+			w.Float64(v.Z())
+		},
+		read: func(r *bitlib.Reader) vector3.Float64 {
+			return vector3.New(r.Float64(), r.Float64(), r.Float64())
+		},
+	})
+
+	// Colors come back as RGBA64, which is the most any color.Color can
+	// report about itself
+	nodes.RegisterOutputCodec[Texture[color.Color]](textureCodec[color.Color]{
+		size: 8,
+		write: func(w *bitlib.Writer, v color.Color) {
+			if v == nil {
+				v = color.RGBA64{}
+			}
+			r, g, b, a := v.RGBA()
+			w.UInt16(uint16(r))
+			w.UInt16(uint16(g))
+			w.UInt16(uint16(b))
+			w.UInt16(uint16(a))
+		},
+		read: func(r *bitlib.Reader) color.Color {
+			return color.RGBA64{R: r.UInt16(), G: r.UInt16(), B: r.UInt16(), A: r.UInt16()}
+		},
+	})
+}
+
+// textureCodec serializes textures for the persistent node output cache,
+// one texel after another following the texture's dimensions
+type textureCodec[T any] struct {
+	// size is the number of bytes each texel is written as
+	size  int
+	write func(*bitlib.Writer, T)
+	read  func(*bitlib.Reader) T
+}
+
+func (tc textureCodec[T]) Encode(out io.Writer, tex Texture[T]) error {
+	buf := bufio.NewWriter(out)
+	writer := bitlib.NewWriter(buf, binary.LittleEndian)
+
+	writer.UInt64(uint64(tex.width))
+	writer.UInt64(uint64(tex.height))
+	for _, v := range tex.data[:tex.width*tex.height] {
+		tc.write(writer, v)
+	}
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// Decode reads the entire entry up front so the texture's dimensions can be
+// checked against the texels actually available before allocating
+func (tc textureCodec[T]) Decode(in io.Reader) (Texture[T], error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return Texture[T]{}, err
+	}
+	remaining := bytes.NewReader(data)
+	reader := bitlib.NewReader(remaining, binary.LittleEndian)
+
+	width := reader.UInt64()
+	height := reader.UInt64()
+	if err := reader.Error(); err != nil {
+		return Texture[T]{}, err
+	}
+
+	texels := uint64(remaining.Len() / tc.size)
+	if width != 0 && height > texels/width {
+		return Texture[T]{}, fmt.Errorf("%dx%d texture exceeds the %d bytes remaining in the entry", width, height, remaining.Len())
+	}
+
+	tex := Empty[T](int(width), int(height))
+	for i := range tex.data {
+		tex.data[i] = tc.read(reader)
+	}
+
+	if err := reader.Error(); err != nil {
+		return Texture[T]{}, err
+	}
+	return tex, nil
+}
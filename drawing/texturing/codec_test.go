@@ -0,0 +1,62 @@
+package texturing_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"testing"
+
+	"github.com/EliCDavis/polyform/drawing/texturing"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func roundTrip[T any](t *testing.T, tex texturing.Texture[T]) texturing.Texture[T] {
+	t.Helper()
+	codec, ok := nodes.LookupOutputCodec[texturing.Texture[T]]()
+	require.True(t, ok)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, codec.Encode(buf, tex))
+	decoded, err := codec.Decode(buf)
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestTextureCodec_RoundTrip(t *testing.T) {
+	floats := texturing.FromArray([]float64{0.5, 1, 2, 3, 4, 5}, 3, 2)
+	assert.Equal(t, floats, roundTrip(t, floats))
+
+	bools := texturing.FromArray([]bool{true, false, false, true}, 2, 2)
+	assert.Equal(t, bools, roundTrip(t, bools))
+
+	vectors := texturing.FromArray([]vector3.Float64{vector3.New(1., 2., 3.), vector3.New(4., 5., 6.)}, 2, 1)
+	assert.Equal(t, vectors, roundTrip(t, vectors))
+
+	colors := texturing.FromArray([]color.Color{color.RGBA{R: 255, A: 255}, color.White}, 1, 2)
+	decoded := roundTrip(t, colors)
+	for x := range 1 {
+		for y := range 2 {
+			r, g, b, a := colors.Get(x, y).RGBA()
+			dr, dg, db, da := decoded.Get(x, y).RGBA()
+			assert.Equal(t, []uint32{r, g, b, a}, []uint32{dr, dg, db, da})
+		}
+	}
+}
+
+func TestTextureCodec_CorruptDimensions(t *testing.T) {
+	codec, ok := nodes.LookupOutputCodec[texturing.Texture[float64]]()
+	require.True(t, ok)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, codec.Encode(buf, texturing.FromArray([]float64{1, 2}, 2, 1)))
+
+	// Claim far more texels than the entry could ever hold
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint64(data[8:], 1<<40)
+
+	_, err := codec.Decode(bytes.NewReader(data))
+	assert.EqualError(t, err, "2x1099511627776 texture exceeds the 16 bytes remaining in the entry")
+}
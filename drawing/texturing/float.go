@@ -20,6 +20,10 @@ type OneMinusNode struct {
 	Texture nodes.Output[Texture[float64]]
 }
 
+func (n OneMinusNode) CacheVersion() int {
+	return 1
+}
+
 func (n OneMinusNode) Result(out *nodes.StructOutput[Texture[float64]]) {
 	if n.Texture == nil {
 		return
@@ -31,6 +35,10 @@ type MultiplyFloat1Node struct {
 	Textures []nodes.Output[Texture[float64]]
 }
 
+func (n MultiplyFloat1Node) CacheVersion() int {
+	return 1
+}
+
 func (n MultiplyFloat1Node) Result(out *nodes.StructOutput[Texture[float64]]) {
 	textures := nodes.GetOutputValues(out, n.Textures)
 	if len(textures) == 0 {
@@ -40,6 +40,10 @@ type RadialGradientNode[T any] struct {
 	Gradient    nodes.Output[coloring.Gradient[T]]
 }
 
+func (n RadialGradientNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n RadialGradientNode[T]) LinearGradient(out *nodes.StructOutput[Texture[T]]) {
 	width := nodes.TryGetOutputValue(out, n.Width, 1)
 	height := nodes.TryGetOutputValue(out, n.Height, 1)
@@ -104,6 +108,10 @@ type LinearGradientNode[T any] struct {
 	Gradient    nodes.Output[coloring.Gradient[T]]
 }
 
+func (n LinearGradientNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n LinearGradientNode[T]) LinearGradient(out *nodes.StructOutput[Texture[T]]) {
 	width := nodes.TryGetOutputValue(out, n.Width, 1)
 	height := nodes.TryGetOutputValue(out, n.Height, 1)
@@ -138,6 +146,10 @@ type ApplyGradientNode[T any] struct {
 	Gradient nodes.Output[coloring.Gradient[T]]
 }
 
+func (n ApplyGradientNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n ApplyGradientNode[T]) Texture(out *nodes.StructOutput[Texture[T]]) {
 	if n.Time == nil {
 		return
@@ -90,6 +90,10 @@ type NoiseNode struct {
 	Polar       nodes.Output[bool]            `description:"Whether or not to use polar coordinates to sample the random function"`
 }
 
+func (n NoiseNode) CacheVersion() int {
+	return 1
+}
+
 func (n NoiseNode) Value(out *nodes.StructOutput[Texture[float64]]) {
 	texture(
 		out,
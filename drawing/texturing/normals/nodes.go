@@ -54,6 +54,10 @@ func (n FromImageNode) Normalmap(out *nodes.StructOutput[NormalMap]) {
 	out.Set(FromHeightmap(heightmap, 1))
 }
 
+func (n FromImageNode) CacheVersion() int {
+	return 1
+}
+
 func (n FromImageNode) NormalMapImage(out *nodes.StructOutput[image.Image]) {
 	img := nodes.TryGetOutputValue(out, n.In, nil)
 	if img == nil {
@@ -73,6 +77,10 @@ type FromHeightMapNode struct {
 	Scale nodes.Output[float64]
 }
 
+func (n FromHeightMapNode) CacheVersion() int {
+	return 1
+}
+
 func (n FromHeightMapNode) NormalMapImage(out *nodes.StructOutput[image.Image]) {
 	if n.In == nil {
 		return
@@ -101,6 +109,10 @@ type FromNormalMapNode struct {
 	Normals nodes.Output[NormalMap]
 }
 
+func (n FromNormalMapNode) CacheVersion() int {
+	return 1
+}
+
 func (n FromNormalMapNode) Image(out *nodes.StructOutput[image.Image]) {
 	if n.Normals == nil {
 		return
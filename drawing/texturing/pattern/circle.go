@@ -102,6 +102,10 @@ type CircleNode[T any] struct {
 	OuterBorderThickness nodes.Output[float64]
 }
 
+func (c CircleNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (c CircleNode[T]) Texture(out *nodes.StructOutput[texturing.Texture[T]]) {
 	dimensions := nodes.TryGetOutputValue(out, c.Dimensions, vector2.New(256, 256))
 	if dimensions.MinComponent() <= 0 {
@@ -82,6 +82,10 @@ type GridNode[T any] struct {
 	VerticalLineWidth   nodes.Output[float64]
 }
 
+func (gnd GridNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (gnd GridNode[T]) Texture(out *nodes.StructOutput[texturing.Texture[T]]) {
 	dimensions := nodes.TryGetOutputValue(out, gnd.Dimensions, vector2.New(256, 256))
 	if dimensions.MinComponent() <= 0 {
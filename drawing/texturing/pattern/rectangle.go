@@ -13,6 +13,10 @@ type RectanglesNode[T any] struct {
 	In        nodes.Output[texturing.Texture[T]]
 }
 
+func (node RectanglesNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (node RectanglesNode[T]) Out(out *nodes.StructOutput[texturing.Texture[T]]) {
 	if node.In == nil {
 		out.CaptureError(nodes.UnsetInputError{
@@ -46,6 +46,10 @@ type RepeatNode[T any] struct {
 	Element    nodes.Output[texturing.Texture[T]]
 }
 
+func (gnd RepeatNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (gnd RepeatNode[T]) Texture(out *nodes.StructOutput[texturing.Texture[T]]) {
 	if gnd.Element == nil {
 		return
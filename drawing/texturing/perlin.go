@@ -14,6 +14,10 @@ type SeamlessPerlinNode struct {
 	Frequency  nodes.Output[float64]
 }
 
+func (an SeamlessPerlinNode) CacheVersion() int {
+	return 1
+}
+
 func (an SeamlessPerlinNode) Out(out *nodes.StructOutput[Texture[float64]]) {
 	dim := nodes.TryGetOutputValue(out, an.Dimensions, 256)
 	n := noise.NewTilingNoise(
@@ -47,6 +51,10 @@ type PerlinNode struct {
 	Frequency nodes.Output[vector2.Float64]
 }
 
+func (n PerlinNode) CacheVersion() int {
+	return 1
+}
+
 func (n PerlinNode) Out(out *nodes.StructOutput[Texture[float64]]) {
 	tex := Empty[float64](
 		nodes.TryGetOutputValue(out, n.Width, 1),
@@ -39,6 +39,10 @@ type MaskToSDFNode struct {
 	Mask nodes.Output[Texture[bool]]
 }
 
+func (n MaskToSDFNode) CacheVersion() int {
+	return 1
+}
+
 func (n MaskToSDFNode) SDF(out *nodes.StructOutput[Texture[float64]]) {
 	if n.Mask == nil {
 		return
@@ -248,6 +248,10 @@ type UniformNode[T any] struct {
 	Height nodes.Output[int]
 }
 
+func (n UniformNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n UniformNode[T]) Texture(out *nodes.StructOutput[Texture[T]]) {
 	t := Empty[T](
 		nodes.TryGetOutputValue(out, n.Width, 1),
@@ -296,6 +300,10 @@ func (n CompareValueNode[T]) compare(out *nodes.StructOutput[Texture[T]], f func
 	}))
 }
 
+func (n CompareValueNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n CompareValueNode[T]) GreaterThanMask(out *nodes.StructOutput[Texture[bool]]) {
 	n.compareMask(out, func(in, value T) bool { return in > value })
 }
@@ -344,6 +352,10 @@ type FromArrayNode[T any] struct {
 	Height nodes.Output[int]
 }
 
+func (n FromArrayNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n FromArrayNode[T]) Texture(out *nodes.StructOutput[Texture[T]]) {
 	if n.Width == nil && n.Height == nil {
 		return
@@ -410,6 +422,10 @@ func (n SelectColorNode) Array(out *nodes.StructOutput[[]coloring.Color]) {
 	selectArray(out, n.Texture)
 }
 
+func (n SelectColorNode) CacheVersion() int {
+	return 1
+}
+
 func (n SelectColorNode) R(out *nodes.StructOutput[Texture[float64]]) {
 	if n.Texture == nil {
 		return
@@ -456,6 +472,10 @@ type ColorToImageNode struct {
 	Texture nodes.Output[Texture[coloring.Color]]
 }
 
+func (n ColorToImageNode) CacheVersion() int {
+	return 1
+}
+
 func (n ColorToImageNode) Image(out *nodes.StructOutput[image.Image]) {
 	if n.Texture == nil {
 		return
@@ -554,6 +574,10 @@ func (n FloatToImageNode) Texture(out *nodes.StructOutput[Texture[coloring.Color
 	out.Set(n.tex(out))
 }
 
+func (n FloatToImageNode) CacheVersion() int {
+	return 1
+}
+
 func (n FloatToImageNode) Image(out *nodes.StructOutput[image.Image]) {
 	texture := n.tex(out)
 	out.Set(texture.ToImage(func(c coloring.Color) color.Color {
@@ -605,6 +629,10 @@ func (n ApplyMaskNode[T]) process(out *nodes.StructOutput[Texture[T]], keep bool
 	out.Set(result)
 }
 
+func (n ApplyMaskNode[T]) CacheVersion() int {
+	return 1
+}
+
 func (n ApplyMaskNode[T]) Kept(out *nodes.StructOutput[Texture[T]]) {
 	n.process(out, true)
 }
@@ -648,6 +676,10 @@ type AddFloat1Node struct {
 	Textures []nodes.Output[Texture[float64]]
 }
 
+func (n AddFloat1Node) CacheVersion() int {
+	return 1
+}
+
 func (n AddFloat1Node) Result(out *nodes.StructOutput[Texture[float64]]) {
 	addTextures(nodes.GetOutputValues(out, n.Textures), out, vector1.Space[float64]{})
 }
@@ -664,6 +696,10 @@ type AddFloat3Node struct {
 	Textures []nodes.Output[Texture[vector3.Float64]]
 }
 
+func (n AddFloat3Node) CacheVersion() int {
+	return 1
+}
+
 func (n AddFloat3Node) Result(out *nodes.StructOutput[Texture[vector3.Float64]]) {
 	addTextures(nodes.GetOutputValues(out, n.Textures), out, vector3.Space[float64]{})
 }
@@ -770,6 +806,10 @@ type ScaleFloat1UniformNode struct {
 	Scale   nodes.Output[float64]
 }
 
+func (n ScaleFloat1UniformNode) CacheVersion() int {
+	return 1
+}
+
 func (n ScaleFloat1UniformNode) Result(out *nodes.StructOutput[Texture[float64]]) {
 	scaleTextureUniform(n.Texture, out, vector1.Space[float64]{}, n.Scale)
 }
@@ -788,6 +828,10 @@ type ScaleFloat3UniformNode struct {
 	Scale   nodes.Output[float64]
 }
 
+func (n ScaleFloat3UniformNode) CacheVersion() int {
+	return 1
+}
+
 func (n ScaleFloat3UniformNode) Result(out *nodes.StructOutput[Texture[vector3.Float64]]) {
 	scaleTextureUniform(n.Texture, out, vector3.Space[float64]{}, n.Scale)
 }
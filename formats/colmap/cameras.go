@@ -0,0 +1,98 @@
+package colmap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/EliCDavis/bitlib"
+	"github.com/EliCDavis/sfm/colmap"
+)
+
+// Model names as they appear in COLMAP's text format, indexed by model id
+var cameraModelNames = []string{
+	"SIMPLE_PINHOLE",
+	"PINHOLE",
+	"SIMPLE_RADIAL",
+	"RADIAL",
+	"OPENCV",
+	"OPENCV_FISHEYE",
+	"FULL_OPENCV",
+	"FOV",
+	"SIMPLE_RADIAL_FISHEYE",
+	"RADIAL_FISHEYE",
+	"THIN_PRISM_FISHEYE",
+}
+
+func validateCamera(camera colmap.Camera) error {
+	if camera.Model < 0 || int(camera.Model) >= len(cameraModelNames) {
+		return fmt.Errorf("camera %d: unrecognized camera model %d", camera.ID, camera.Model)
+	}
+
+	if len(camera.Params) != camera.Model.NumParameters() {
+		return fmt.Errorf(
+			"camera %d: %s model requires %d parameters, found %d",
+			camera.ID, cameraModelNames[camera.Model], camera.Model.NumParameters(), len(camera.Params),
+		)
+	}
+	return nil
+}
+
+// WriteCamerasBinary writes cameras in the layout of COLMAP's cameras.bin
+func WriteCamerasBinary(out io.Writer, cameras []colmap.Camera) error {
+	for _, camera := range cameras {
+		if err := validateCamera(camera); err != nil {
+			return err
+		}
+	}
+
+	buf := bufio.NewWriter(out)
+	writer := bitlib.NewWriter(buf, binary.LittleEndian)
+
+	writer.UInt64(uint64(len(cameras)))
+	for _, camera := range cameras {
+		writer.Int32(int32(camera.ID))
+		writer.Int32(int32(camera.Model))
+		writer.UInt64(camera.Width)
+		writer.UInt64(camera.Height)
+		for _, param := range camera.Params {
+			writer.Float64(param)
+		}
+	}
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// WriteCamerasText writes cameras in the layout of COLMAP's cameras.txt
+func WriteCamerasText(out io.Writer, cameras []colmap.Camera) error {
+	for _, camera := range cameras {
+		if err := validateCamera(camera); err != nil {
+			return err
+		}
+	}
+
+	writer := bufio.NewWriter(out)
+	writer.WriteString("# Camera list with one line of data per camera:\n")
+	writer.WriteString("#   CAMERA_ID, MODEL, WIDTH, HEIGHT, PARAMS[]\n")
+	fmt.Fprintf(writer, "# Number of cameras: %d\n", len(cameras))
+
+	for _, camera := range cameras {
+		fmt.Fprintf(writer, "%d %s %d %d", camera.ID, cameraModelNames[camera.Model], camera.Width, camera.Height)
+		for _, param := range camera.Params {
+			writer.WriteByte(' ')
+			writer.WriteString(formatFloat(param))
+		}
+		writer.WriteByte('\n')
+	}
+
+	return writer.Flush()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -1,6 +1,13 @@
 package colmap
 
 import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/EliCDavis/bitlib"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/sfm/colmap"
 	"github.com/EliCDavis/vector/vector3"
@@ -46,3 +53,144 @@ func LoadImageData(filename string) (modeling.Mesh, error) {
 	}
 	return ImageDataToPointCloud(points), nil
 }
+
+func validateImage(image colmap.Image) error {
+	if strings.ContainsAny(image.Name, "\x00\r\n") {
+		return fmt.Errorf("image %d: name %q can not contain null characters or line breaks", image.Id, image.Name)
+	}
+	return nil
+}
+
+// WriteImagesBinary writes images in the layout of COLMAP's images.bin.
+//
+// Rotations are written component by component in the same order
+// colmap.ReadImagesBinary reads them, which on disk is QW, QX, QY, QZ, so
+// images read in are written back out unchanged.
+func WriteImagesBinary(out io.Writer, images []colmap.Image) error {
+	for _, image := range images {
+		if err := validateImage(image); err != nil {
+			return err
+		}
+	}
+
+	buf := bufio.NewWriter(out)
+	writer := bitlib.NewWriter(buf, binary.LittleEndian)
+
+	writer.UInt64(uint64(len(images)))
+	for _, image := range images {
+		writer.Int32(int32(image.Id))
+
+		writer.Float64(image.Rotation.X())
+		writer.Float64(image.Rotation.Y())
+		writer.Float64(image.Rotation.Z())
+		writer.Float64(image.Rotation.W())
+
+		writer.Float64(image.Translation.X())
+		writer.Float64(image.Translation.Y())
+		writer.Float64(image.Translation.Z())
+
+		writer.Int32(int32(image.CameraId))
+
+		writer.Write([]byte(image.Name))
+		writer.Byte(0)
+
+		writer.UInt64(uint64(len(image.Points)))
+		for _, point := range image.Points {
+			writer.Float64(point.Position.X())
+			writer.Float64(point.Position.Y())
+			writer.Int64(point.Id)
+		}
+	}
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// DetachMissingPoints returns a copy of the images where every observation
+// of a point that's not among the points, or whose track no longer includes
+// the observation, is marked as unmatched with an ID of -1. This keeps the
+// images consistent with a points3D file that's been filtered or renumbered.
+func DetachMissingPoints(images []colmap.Image, points []colmap.Point3D) []colmap.Image {
+	type observation struct {
+		point          uint64
+		image, point2D int
+	}
+
+	tracked := make(map[observation]struct{})
+	for _, p := range points {
+		for _, track := range p.Tracks {
+			tracked[observation{point: p.ID, image: track.ImageID, point2D: track.Point2DID}] = struct{}{}
+		}
+	}
+
+	results := make([]colmap.Image, len(images))
+	for i, image := range images {
+		image.Points = append([]colmap.ImagePoint{}, image.Points...)
+		for j, point := range image.Points {
+			if point.Id < 0 {
+				continue
+			}
+			if _, ok := tracked[observation{point: uint64(point.Id), image: image.Id, point2D: j}]; !ok {
+				image.Points[j].Id = -1
+			}
+		}
+		results[i] = image
+	}
+	return results
+}
+
+// WriteImagesText writes images in the layout of COLMAP's images.txt, with
+// rotations ordered the same way as WriteImagesBinary
+func WriteImagesText(out io.Writer, images []colmap.Image) error {
+	observations := 0
+	for _, image := range images {
+		if err := validateImage(image); err != nil {
+			return err
+		}
+		for _, point := range image.Points {
+			if point.Id != -1 {
+				observations++
+			}
+		}
+	}
+
+	meanObservations := 0.
+	if len(images) > 0 {
+		meanObservations = float64(observations) / float64(len(images))
+	}
+
+	writer := bufio.NewWriter(out)
+	writer.WriteString("# Image list with two lines of data per image:\n")
+	writer.WriteString("#   IMAGE_ID, QW, QX, QY, QZ, TX, TY, TZ, CAMERA_ID, NAME\n")
+	writer.WriteString("#   POINTS2D[] as (X, Y, POINT3D_ID)\n")
+	fmt.Fprintf(writer, "# Number of images: %d, mean observations per image: %s\n", len(images), formatFloat(meanObservations))
+
+	for _, image := range images {
+		fmt.Fprintf(
+			writer,
+			"%d %s %s %s %s %s %s %s %d %s\n",
+			image.Id,
+			formatFloat(image.Rotation.X()),
+			formatFloat(image.Rotation.Y()),
+			formatFloat(image.Rotation.Z()),
+			formatFloat(image.Rotation.W()),
+			formatFloat(image.Translation.X()),
+			formatFloat(image.Translation.Y()),
+			formatFloat(image.Translation.Z()),
+			image.CameraId,
+			image.Name,
+		)
+
+		for i, point := range image.Points {
+			if i > 0 {
+				writer.WriteByte(' ')
+			}
+			fmt.Fprintf(writer, "%s %s %d", formatFloat(point.Position.X()), formatFloat(point.Position.Y()), point.Id)
+		}
+		writer.WriteByte('\n')
+	}
+
+	return writer.Flush()
+}
@@ -1,8 +1,15 @@
 package colmap
 
 import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/color"
 	"io"
+	"math"
 
+	"github.com/EliCDavis/bitlib"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/sfm/colmap"
 	"github.com/EliCDavis/vector/vector3"
@@ -54,3 +61,130 @@ func LoadSparsePointData(filename string) (modeling.Mesh, error) {
 	}
 	return PointDataToPointCloud(points), nil
 }
+
+// PointCloudToPointData converts a point cloud back into COLMAP points.
+// Points are matched against the original reconstruction by their "id"
+// attribute to recover their tracks, so tracks survive any filtering or
+// transformations applied to the point cloud in between. Points without an
+// "id" attribute are numbered sequentially and written without tracks.
+func PointCloudToPointData(cloud modeling.Mesh, original []colmap.Point3D) ([]colmap.Point3D, error) {
+	if !cloud.HasFloat3Attribute(modeling.PositionAttribute) {
+		return nil, errors.New("point cloud requires a position attribute")
+	}
+
+	tracks := make(map[uint64][]colmap.Point3DTrack, len(original))
+	for _, p := range original {
+		tracks[p.ID] = p.Tracks
+	}
+
+	positions := cloud.Float3Attribute(modeling.PositionAttribute)
+	points := make([]colmap.Point3D, positions.Len())
+	for i := range points {
+		points[i] = colmap.Point3D{
+			ID:       uint64(i + 1),
+			Position: positions.At(i),
+			Color:    color.RGBA{A: 255},
+			Tracks:   make([]colmap.Point3DTrack, 0),
+		}
+	}
+
+	if cloud.HasFloat3Attribute(modeling.ColorAttribute) {
+		colors := cloud.Float3Attribute(modeling.ColorAttribute)
+		for i := range points {
+			c := colors.At(i).Clamp(0, 1).Scale(255).Round()
+			points[i].Color = color.RGBA{R: uint8(c.X()), G: uint8(c.Y()), B: uint8(c.Z()), A: 255}
+		}
+	}
+
+	if cloud.HasFloat1Attribute("error") {
+		errorData := cloud.Float1Attribute("error")
+		for i := range points {
+			points[i].Error = errorData.At(i)
+		}
+	}
+
+	if cloud.HasFloat1Attribute("id") {
+		ids := cloud.Float1Attribute("id")
+		for i := range points {
+			id := ids.At(i)
+			if id < 0 || id != math.Trunc(id) {
+				return nil, fmt.Errorf("point %d has an invalid id %g", i, id)
+			}
+			points[i].ID = uint64(id)
+			if t, ok := tracks[points[i].ID]; ok {
+				points[i].Tracks = t
+			}
+		}
+	}
+
+	return points, nil
+}
+
+// WritePoints3DBinary writes points in the layout of COLMAP's points3D.bin
+func WritePoints3DBinary(out io.Writer, points []colmap.Point3D) error {
+	buf := bufio.NewWriter(out)
+	writer := bitlib.NewWriter(buf, binary.LittleEndian)
+
+	writer.UInt64(uint64(len(points)))
+	for _, p := range points {
+		writer.UInt64(p.ID)
+
+		writer.Float64(p.Position.X())
+		writer.Float64(p.Position.Y())
+		writer.Float64(p.Position.Z())
+
+		writer.Byte(p.Color.R)
+		writer.Byte(p.Color.G)
+		writer.Byte(p.Color.B)
+
+		writer.Float64(p.Error)
+
+		writer.UInt64(uint64(len(p.Tracks)))
+		for _, track := range p.Tracks {
+			writer.Int32(int32(track.ImageID))
+			writer.Int32(int32(track.Point2DID))
+		}
+	}
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// WritePoints3DText writes points in the layout of COLMAP's points3D.txt
+func WritePoints3DText(out io.Writer, points []colmap.Point3D) error {
+	trackLength := 0
+	for _, p := range points {
+		trackLength += len(p.Tracks)
+	}
+
+	meanTrackLength := 0.
+	if len(points) > 0 {
+		meanTrackLength = float64(trackLength) / float64(len(points))
+	}
+
+	writer := bufio.NewWriter(out)
+	writer.WriteString("# 3D point list with one line of data per point:\n")
+	writer.WriteString("#   POINT3D_ID, X, Y, Z, R, G, B, ERROR, TRACK[] as (IMAGE_ID, POINT2D_IDX)\n")
+	fmt.Fprintf(writer, "# Number of points: %d, mean track length: %s\n", len(points), formatFloat(meanTrackLength))
+
+	for _, p := range points {
+		fmt.Fprintf(
+			writer,
+			"%d %s %s %s %d %d %d %s",
+			p.ID,
+			formatFloat(p.Position.X()),
+			formatFloat(p.Position.Y()),
+			formatFloat(p.Position.Z()),
+			p.Color.R, p.Color.G, p.Color.B,
+			formatFloat(p.Error),
+		)
+		for _, track := range p.Tracks {
+			fmt.Fprintf(writer, " %d %d", track.ImageID, track.Point2DID)
+		}
+		writer.WriteByte('\n')
+	}
+
+	return writer.Flush()
+}
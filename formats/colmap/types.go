@@ -2,17 +2,21 @@ package colmap
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/generator/manifest"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/polyform/nodes"
 	"github.com/EliCDavis/polyform/refutil"
+	"github.com/EliCDavis/sfm/colmap"
 )
 
 func init() {
 	factory := &refutil.TypeFactory{}
 
 	refutil.RegisterType[nodes.Struct[ReadPointsNode]](factory)
+	refutil.RegisterType[nodes.Struct[ManifestNode]](factory)
 
 	generator.RegisterTypes(factory)
 }
@@ -21,6 +25,10 @@ type ReadPointsNode struct {
 	In nodes.Output[[]byte]
 }
 
+func (pn ReadPointsNode) CacheVersion() int {
+	return 1
+}
+
 func (pn ReadPointsNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if pn.In == nil {
 		out.Set(modeling.EmptyMesh(modeling.PointTopology))
@@ -31,3 +39,132 @@ func (pn ReadPointsNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	out.Set(data)
 	out.CaptureError(err)
 }
+
+// ============================================================================
+
+func artifactMime(text bool) string {
+	if text {
+		return "text/plain"
+	}
+	return "application/octet-stream"
+}
+
+func artifactName(name string, text bool) string {
+	if text {
+		return name + ".txt"
+	}
+	return name + ".bin"
+}
+
+type CamerasArtifact struct {
+	Cameras []colmap.Camera
+	Text    bool
+}
+
+func (ca CamerasArtifact) Write(w io.Writer) error {
+	if ca.Text {
+		return WriteCamerasText(w, ca.Cameras)
+	}
+	return WriteCamerasBinary(w, ca.Cameras)
+}
+
+func (ca CamerasArtifact) Mime() string {
+	return artifactMime(ca.Text)
+}
+
+type ImagesArtifact struct {
+	Images []colmap.Image
+	Text   bool
+}
+
+func (ia ImagesArtifact) Write(w io.Writer) error {
+	if ia.Text {
+		return WriteImagesText(w, ia.Images)
+	}
+	return WriteImagesBinary(w, ia.Images)
+}
+
+func (ia ImagesArtifact) Mime() string {
+	return artifactMime(ia.Text)
+}
+
+type PointsArtifact struct {
+	Points []colmap.Point3D
+	Text   bool
+}
+
+func (pa PointsArtifact) Write(w io.Writer) error {
+	if pa.Text {
+		return WritePoints3DText(w, pa.Points)
+	}
+	return WritePoints3DBinary(w, pa.Points)
+}
+
+func (pa PointsArtifact) Mime() string {
+	return artifactMime(pa.Text)
+}
+
+type ManifestNode struct {
+	Points         nodes.Output[modeling.Mesh]
+	OriginalPoints nodes.Output[[]byte] `description:"Binary points3D file the point cloud was read from, used to recover each point's track"`
+	Images         nodes.Output[[]byte] `description:"Binary images file to include in the reconstruction"`
+	Cameras        nodes.Output[[]byte] `description:"Binary cameras file to include in the reconstruction"`
+	Text           nodes.Output[bool]   `description:"Write the reconstruction in COLMAP's text format instead of binary"`
+}
+
+func (mn ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	text := nodes.TryGetOutputValue(out, mn.Text, false)
+
+	var original []colmap.Point3D
+	if data := nodes.TryGetOutputValue(out, mn.OriginalPoints, nil); len(data) > 0 {
+		var err error
+		original, err = colmap.ReadPoints3DBinary(bytes.NewReader(data))
+		if err != nil {
+			out.CaptureError(err)
+			return
+		}
+	}
+
+	points, err := PointCloudToPointData(
+		nodes.TryGetOutputValue(out, mn.Points, modeling.EmptyPointcloud()),
+		original,
+	)
+	if err != nil {
+		out.CaptureError(err)
+		return
+	}
+
+	pointsName := artifactName("points3D", text)
+	result := manifest.Manifest{
+		Main: pointsName,
+		Entries: map[string]manifest.Entry{
+			pointsName: {Artifact: PointsArtifact{Points: points, Text: text}},
+		},
+	}
+
+	if data := nodes.TryGetOutputValue(out, mn.Images, nil); len(data) > 0 {
+		images, err := colmap.ReadImagesBinary(bytes.NewReader(data))
+		if err != nil {
+			out.CaptureError(err)
+			return
+		}
+		// Points may have been filtered out of the cloud, and images can't
+		// reference points that are no longer written
+		result.Entries[artifactName("images", text)] = manifest.Entry{
+			Artifact: ImagesArtifact{Images: DetachMissingPoints(images, points), Text: text},
+		}
+	}
+
+	if data := nodes.TryGetOutputValue(out, mn.Cameras, nil); len(data) > 0 {
+		cameras, err := colmap.ReadCamerasBinary(bytes.NewReader(data))
+		if err != nil {
+			out.CaptureError(err)
+			return
+		}
+		result.Entries[artifactName("cameras", text)] = manifest.Entry{
+			Artifact: CamerasArtifact{Cameras: cameras, Text: text},
+		}
+	}
+
+	out.Set(result)
+}
@@ -0,0 +1,257 @@
+package colmap_test
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/colmap"
+	"github.com/EliCDavis/polyform/modeling"
+	colmapFormat "github.com/EliCDavis/sfm/colmap"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCameras = []colmapFormat.Camera{
+	{ID: 1, Model: colmapFormat.PINHOLE, Width: 640, Height: 480, Params: []float64{500, 501, 320, 240}},
+	{ID: 2, Model: colmapFormat.SIMPLE_RADIAL, Width: 1920, Height: 1080, Params: []float64{1000, 960, 540, 0.01}},
+}
+
+var testImages = []colmapFormat.Image{
+	{
+		Id:          3,
+		CameraId:    1,
+		Name:        "frame 0001.jpg",
+		Rotation:    vector4.New(1., 0., 0., 0.),
+		Translation: vector3.New(0.5, -1., 2.),
+		Points: []colmapFormat.ImagePoint{
+			{Id: 7, Position: vector2.New(10.5, 20.25)},
+			{Id: -1, Position: vector2.New(1., 2.)},
+		},
+	},
+	{
+		Id:          4,
+		CameraId:    2,
+		Name:        "frame_0002.jpg",
+		Rotation:    vector4.New(0.5, 0.5, 0.5, 0.5),
+		Translation: vector3.New(1., 2., 3.),
+		Points:      []colmapFormat.ImagePoint{},
+	},
+}
+
+var testPoints = []colmapFormat.Point3D{
+	{
+		ID:       7,
+		Position: vector3.New(1., 2., 3.),
+		Color:    color.RGBA{R: 255, G: 128, B: 0, A: 255},
+		Error:    0.25,
+		Tracks: []colmapFormat.Point3DTrack{
+			{ImageID: 3, Point2DID: 0},
+			{ImageID: 4, Point2DID: 5},
+		},
+	},
+	{
+		ID:       9,
+		Position: vector3.New(-1., 0., 0.5),
+		Color:    color.RGBA{R: 1, G: 2, B: 3, A: 255},
+		Error:    1,
+		Tracks:   []colmapFormat.Point3DTrack{},
+	},
+}
+
+func TestWriteCamerasBinary_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := colmap.WriteCamerasBinary(buf, testCameras)
+	require.NoError(t, err)
+	cameras, err := colmapFormat.ReadCamerasBinary(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, testCameras, cameras)
+}
+
+func TestWriteCameras_InvalidParameterCount(t *testing.T) {
+	cameras := []colmapFormat.Camera{
+		{ID: 1, Model: colmapFormat.PINHOLE, Params: []float64{1, 2}},
+	}
+
+	err := colmap.WriteCamerasBinary(&bytes.Buffer{}, cameras)
+	assert.EqualError(t, err, "camera 1: PINHOLE model requires 4 parameters, found 2")
+
+	err = colmap.WriteCamerasText(&bytes.Buffer{}, cameras)
+	assert.EqualError(t, err, "camera 1: PINHOLE model requires 4 parameters, found 2")
+}
+
+func TestWriteCamerasText(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := colmap.WriteCamerasText(buf, testCameras)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, `# Camera list with one line of data per camera:
+#   CAMERA_ID, MODEL, WIDTH, HEIGHT, PARAMS[]
+# Number of cameras: 2
+1 PINHOLE 640 480 500 501 320 240
+2 SIMPLE_RADIAL 1920 1080 1000 960 540 0.01
+`, buf.String())
+}
+
+func TestWriteImagesBinary_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := colmap.WriteImagesBinary(buf, testImages)
+	require.NoError(t, err)
+	images, err := colmapFormat.ReadImagesBinary(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, testImages, images)
+}
+
+func TestWriteImagesText(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := colmap.WriteImagesText(buf, testImages)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, `# Image list with two lines of data per image:
+#   IMAGE_ID, QW, QX, QY, QZ, TX, TY, TZ, CAMERA_ID, NAME
+#   POINTS2D[] as (X, Y, POINT3D_ID)
+# Number of images: 2, mean observations per image: 0.5
+3 1 0 0 0 0.5 -1 2 1 frame 0001.jpg
+10.5 20.25 7 1 2 -1
+4 0.5 0.5 0.5 0.5 1 2 3 2 frame_0002.jpg
+
+`, buf.String())
+}
+
+func TestWriteImages_InvalidName(t *testing.T) {
+	images := []colmapFormat.Image{{Id: 1, Name: "bad\nname"}}
+	err := colmap.WriteImagesBinary(&bytes.Buffer{}, images)
+	assert.EqualError(t, err, "image 1: name \"bad\\nname\" can not contain null characters or line breaks")
+}
+
+func TestWritePoints3DBinary_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := colmap.WritePoints3DBinary(buf, testPoints)
+	require.NoError(t, err)
+	points, err := colmapFormat.ReadPoints3DBinary(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, testPoints, points)
+}
+
+func TestWritePoints3DText(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := colmap.WritePoints3DText(buf, testPoints)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, `# 3D point list with one line of data per point:
+#   POINT3D_ID, X, Y, Z, R, G, B, ERROR, TRACK[] as (IMAGE_ID, POINT2D_IDX)
+# Number of points: 2, mean track length: 1
+7 1 2 3 255 128 0 0.25 3 0 4 5
+9 -1 0 0.5 1 2 3 1
+`, buf.String())
+}
+
+func TestPointCloudToPointData_PreservesTracks(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := colmap.PointDataToPointCloud(testPoints)
+
+	// Keep only the first point, moving and recoloring it
+	filtered := modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {vector3.New(5., 5., 5.)},
+			modeling.ColorAttribute:    {cloud.Float3Attribute(modeling.ColorAttribute).At(1)},
+		},
+		nil,
+		map[string][]float64{
+			"id":    {cloud.Float1Attribute("id").At(0)},
+			"error": {cloud.Float1Attribute("error").At(0)},
+		},
+	)
+
+	// ACT ====================================================================
+	points, err := colmap.PointCloudToPointData(filtered, testPoints)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	assert.Equal(t, uint64(7), points[0].ID)
+	assert.Equal(t, vector3.New(5., 5., 5.), points[0].Position)
+	assert.Equal(t, color.RGBA{R: 1, G: 2, B: 3, A: 255}, points[0].Color)
+	assert.Equal(t, 0.25, points[0].Error)
+	assert.Equal(t, testPoints[0].Tracks, points[0].Tracks)
+}
+
+func TestPointCloudToPointData_NoIDs(t *testing.T) {
+	cloud := modeling.NewPointCloud(nil, map[string][]vector3.Float64{
+		modeling.PositionAttribute: {vector3.New(1., 2., 3.), vector3.New(4., 5., 6.)},
+	}, nil, nil)
+
+	points, err := colmap.PointCloudToPointData(cloud, testPoints)
+
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, uint64(1), points[0].ID)
+	assert.Equal(t, uint64(2), points[1].ID)
+	assert.Empty(t, points[0].Tracks)
+	assert.Equal(t, color.RGBA{A: 255}, points[1].Color)
+}
+
+func TestDetachMissingPoints(t *testing.T) {
+	// ARRANGE ================================================================
+	images := []colmapFormat.Image{
+		{
+			Id: 3,
+			Points: []colmapFormat.ImagePoint{
+				{Id: 7, Position: vector2.New(1., 2.)},
+				{Id: 9, Position: vector2.New(3., 4.)},
+				{Id: -1, Position: vector2.New(5., 6.)},
+				{Id: 7, Position: vector2.New(7., 8.)},
+			},
+		},
+	}
+
+	// Point 9 was filtered out, and point 7 is only tracked by the first
+	// observation
+	points := []colmapFormat.Point3D{
+		{ID: 7, Tracks: []colmapFormat.Point3DTrack{{ImageID: 3, Point2DID: 0}}},
+	}
+
+	// ACT ====================================================================
+	detached := colmap.DetachMissingPoints(images, points)
+
+	// ASSERT =================================================================
+	require.Len(t, detached, 1)
+	assert.Equal(t, []colmapFormat.ImagePoint{
+		{Id: 7, Position: vector2.New(1., 2.)},
+		{Id: -1, Position: vector2.New(3., 4.)},
+		{Id: -1, Position: vector2.New(5., 6.)},
+		{Id: -1, Position: vector2.New(7., 8.)},
+	}, detached[0].Points)
+	assert.Equal(t, int64(9), images[0].Points[1].Id, "original images are left untouched")
+}
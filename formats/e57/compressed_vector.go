@@ -0,0 +1,161 @@
+package e57
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	compressedVectorSectionID = 1
+	sectionHeaderSize         = 32
+	packetHeaderSize          = 4
+
+	indexPacket = 0
+	dataPacket  = 1
+	emptyPacket = 2
+)
+
+// compressedVector is a binary section of the file containing a table of
+// records, with the values of each field packed into their own byte stream
+type compressedVector struct {
+	fileOffset  uint64
+	recordCount uint64
+	fields      []field
+}
+
+// readBits reads an n bit little endian value starting at the bit position
+func readBits(data []byte, position uint64, n int) uint64 {
+	var value uint64
+	read := 0
+	for read < n {
+		shift := int(position & 7)
+		take := min(8-shift, n-read)
+		b := uint64(data[position>>3]>>shift) & (1<<take - 1)
+		value |= b << read
+		read += take
+		position += uint64(take)
+	}
+	return value
+}
+
+func (f field) decode(stream []byte, count uint64) []float64 {
+	values := make([]float64, count)
+	switch f.kind {
+	case "Float":
+		if f.single {
+			for i := range values {
+				values[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(stream[i*4:])))
+			}
+		} else {
+			for i := range values {
+				values[i] = math.Float64frombits(binary.LittleEndian.Uint64(stream[i*8:]))
+			}
+		}
+
+	default:
+		n := f.bits()
+		for i := range values {
+			raw := int64(readBits(stream, uint64(i)*uint64(n), n) + uint64(f.minimum))
+			values[i] = float64(raw)*f.scale + f.offset
+		}
+	}
+	return values
+}
+
+// read decodes every record of the compressed vector, returning the values
+// of each field by name
+func (cv compressedVector) read(pr pagedReader) (map[string][]float64, error) {
+	header, err := pr.read(cv.fileOffset, sectionHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read section header: %w", err)
+	}
+	if header[0] != compressedVectorSectionID {
+		return nil, fmt.Errorf("expected compressed vector section at offset %d, found section type %d", cv.fileOffset, header[0])
+	}
+	sectionEnd := pr.logical(cv.fileOffset) + binary.LittleEndian.Uint64(header[8:])
+	position := binary.LittleEndian.Uint64(header[16:])
+
+	if cv.recordCount > math.MaxInt32 {
+		return nil, fmt.Errorf("compressed vector has too many records: %d", cv.recordCount)
+	}
+
+	required := make([]uint64, len(cv.fields))
+	streams := make([][]byte, len(cv.fields))
+	for i, f := range cv.fields {
+		required[i] = (cv.recordCount*uint64(f.bits()) + 7) / 8
+	}
+
+	complete := func() bool {
+		for i, stream := range streams {
+			if uint64(len(stream)) < required[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for !complete() {
+		if pr.logical(position) >= sectionEnd {
+			return nil, fmt.Errorf("section ended before all %d records were read", cv.recordCount)
+		}
+
+		packetHeader, err := pr.read(position, packetHeaderSize)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read packet header: %w", err)
+		}
+		packetLength := uint64(binary.LittleEndian.Uint16(packetHeader[2:])) + 1
+
+		switch packetHeader[0] {
+		case indexPacket, emptyPacket:
+
+		case dataPacket:
+			packet, err := pr.read(position, packetLength)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read data packet: %w", err)
+			}
+			if err := appendStreams(packet, streams); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("unrecognized packet type %d", packetHeader[0])
+		}
+
+		position = pr.physical(pr.logical(position) + packetLength)
+	}
+
+	values := make(map[string][]float64, len(cv.fields))
+	for i, f := range cv.fields {
+		values[f.name] = f.decode(streams[i], cv.recordCount)
+	}
+	return values, nil
+}
+
+// appendStreams adds the contents of each byte stream within the data packet
+// to the data read so far
+func appendStreams(packet []byte, streams [][]byte) error {
+	if len(packet) < 6 {
+		return fmt.Errorf("data packet of %d bytes is too small", len(packet))
+	}
+
+	count := int(binary.LittleEndian.Uint16(packet[4:]))
+	if count != len(streams) {
+		return fmt.Errorf("data packet contains %d byte streams, expected %d", count, len(streams))
+	}
+
+	offset := 6 + 2*count
+	if offset > len(packet) {
+		return fmt.Errorf("data packet of %d bytes is too small for %d byte streams", len(packet), count)
+	}
+
+	for i := range streams {
+		length := int(binary.LittleEndian.Uint16(packet[6+2*i:]))
+		if offset+length > len(packet) {
+			return fmt.Errorf("byte stream %d extends past the end of its data packet", i)
+		}
+		streams[i] = append(streams[i], packet[offset:offset+length]...)
+		offset += length
+	}
+	return nil
+}
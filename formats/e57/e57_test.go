@@ -0,0 +1,288 @@
+package e57_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/e57"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pageSize = 1024
+
+type testField struct {
+	prototype string
+	stream    []byte
+}
+
+type testScan struct {
+	xml     string
+	records int
+	fields  []testField
+}
+
+func packBits(values []uint64, bits int) []byte {
+	data := make([]byte, (len(values)*bits+7)/8)
+	for i, v := range values {
+		for b := 0; b < bits; b++ {
+			if v&(1<<b) != 0 {
+				position := i*bits + b
+				data[position/8] |= 1 << (position % 8)
+			}
+		}
+	}
+	return data
+}
+
+func packFloat64(values []float64) []byte {
+	data := make([]byte, len(values)*8)
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(data[i*8:], math.Float64bits(v))
+	}
+	return data
+}
+
+func packFloat32(values []float64) []byte {
+	data := make([]byte, len(values)*4)
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(float32(v)))
+	}
+	return data
+}
+
+func physical(logical int) int {
+	return (logical/(pageSize-4))*pageSize + logical%(pageSize-4)
+}
+
+// buildE57 lays out the scans as an E57 file, splitting each byte stream
+// across two data packets so values straddle packet boundaries
+func buildE57(scans []testScan) []byte {
+	logical := &bytes.Buffer{}
+	logical.Write(make([]byte, 48))
+
+	xmlScans := &strings.Builder{}
+	for _, scan := range scans {
+		sectionStart := logical.Len()
+
+		packets := &bytes.Buffer{}
+		for half := 0; half < 2; half++ {
+			packet := &bytes.Buffer{}
+			packet.Write(make([]byte, 4))
+			binary.Write(packet, binary.LittleEndian, uint16(len(scan.fields)))
+			parts := make([][]byte, len(scan.fields))
+			for i, f := range scan.fields {
+				middle := len(f.stream) / 2
+				parts[i] = f.stream[:middle]
+				if half == 1 {
+					parts[i] = f.stream[middle:]
+				}
+				binary.Write(packet, binary.LittleEndian, uint16(len(parts[i])))
+			}
+			for _, part := range parts {
+				packet.Write(part)
+			}
+			for packet.Len()%4 != 0 {
+				packet.WriteByte(0)
+			}
+
+			data := packet.Bytes()
+			data[0] = 1
+			binary.LittleEndian.PutUint16(data[2:], uint16(len(data)-1))
+			packets.Write(data)
+		}
+
+		sectionHeader := make([]byte, 32)
+		sectionHeader[0] = 1
+		binary.LittleEndian.PutUint64(sectionHeader[8:], uint64(32+packets.Len()))
+		binary.LittleEndian.PutUint64(sectionHeader[16:], uint64(physical(sectionStart+32)))
+		logical.Write(sectionHeader)
+		logical.Write(packets.Bytes())
+
+		prototype := &strings.Builder{}
+		for _, f := range scan.fields {
+			prototype.WriteString(f.prototype)
+		}
+		fmt.Fprintf(
+			xmlScans,
+			`<vectorChild type="Structure">%s<points type="CompressedVector" fileOffset="%d" recordCount="%d"><prototype type="Structure">%s</prototype><codecs type="Vector"/></points></vectorChild>`,
+			scan.xml, physical(sectionStart), scan.records, prototype.String(),
+		)
+	}
+
+	xmlStart := logical.Len()
+	fmt.Fprintf(
+		logical,
+		`<?xml version="1.0" encoding="UTF-8"?><e57Root type="Structure" xmlns="http://www.astm.org/COMMIT/E57/2010-e57-v1.0"><formatName type="String"><![CDATA[ASTM E57 3D Imaging Data File]]></formatName><data3D type="Vector">%s</data3D></e57Root>`,
+		xmlScans.String(),
+	)
+	xmlLength := logical.Len() - xmlStart
+
+	// Page the logical contents, leaving the checksums zeroed
+	paged := &bytes.Buffer{}
+	content := logical.Bytes()
+	for start := 0; start < len(content); start += pageSize - 4 {
+		page := make([]byte, pageSize)
+		copy(page, content[start:min(len(content), start+pageSize-4)])
+		paged.Write(page)
+	}
+
+	file := paged.Bytes()
+	copy(file, "ASTM-E57")
+	binary.LittleEndian.PutUint32(file[8:], 1)
+	binary.LittleEndian.PutUint64(file[16:], uint64(len(file)))
+	binary.LittleEndian.PutUint64(file[24:], uint64(physical(xmlStart)))
+	binary.LittleEndian.PutUint64(file[32:], uint64(xmlLength))
+	binary.LittleEndian.PutUint64(file[40:], pageSize)
+	return file
+}
+
+func testFile() []byte {
+	// A cartesian scan large enough to span several pages, rotated 90 degrees
+	// about Z and moved 10 along X
+	const records = 600
+	x := make([]uint64, records)
+	y := make([]uint64, records)
+	z := make([]uint64, records)
+	invalid := make([]uint64, records)
+	intensity := make([]float64, records)
+	red := make([]uint64, records)
+	green := make([]uint64, records)
+	blue := make([]uint64, records)
+	for i := 0; i < records; i++ {
+		// Stored relative to the minimum of -1000
+		x[i] = uint64(i + 1000)
+		y[i] = 1000
+		z[i] = 500
+		if i%10 == 0 {
+			invalid[i] = 2
+		}
+		intensity[i] = float64(i) / records
+		red[i] = uint64(i % 256)
+		blue[i] = 255
+	}
+
+	scaled := `type="ScaledInteger" minimum="-1000" maximum="1000" scale="0.001"`
+	cartesian := testScan{
+		xml: fmt.Sprintf(
+			`<name type="String"><![CDATA[Setup 1]]></name><guid type="String">abc</guid><pose type="Structure"><rotation type="Structure"><w type="Float">%v</w><x type="Float">0</x><y type="Float">0</y><z type="Float">%v</z></rotation><translation type="Structure"><x type="Float">10</x><y type="Float">0</y><z type="Float">0</z></translation></pose><colorLimits type="Structure"><colorRedMinimum type="Integer">0</colorRedMinimum><colorRedMaximum type="Integer">255</colorRedMaximum><colorGreenMinimum type="Integer">0</colorGreenMinimum><colorGreenMaximum type="Integer">255</colorGreenMaximum><colorBlueMinimum type="Integer">0</colorBlueMinimum><colorBlueMaximum type="Integer">255</colorBlueMaximum></colorLimits>`,
+			math.Cos(math.Pi/4), math.Sin(math.Pi/4),
+		),
+		records: records,
+		fields: []testField{
+			{prototype: `<cartesianX ` + scaled + `/>`, stream: packBits(x, 11)},
+			{prototype: `<cartesianY ` + scaled + `/>`, stream: packBits(y, 11)},
+			{prototype: `<cartesianZ ` + scaled + `/>`, stream: packBits(z, 11)},
+			{prototype: `<cartesianInvalidState type="Integer" minimum="0" maximum="2"/>`, stream: packBits(invalid, 2)},
+			{prototype: `<intensity type="Float" precision="single" minimum="0" maximum="1"/>`, stream: packFloat32(intensity)},
+			{prototype: `<colorRed type="Integer" minimum="0" maximum="255"/>`, stream: packBits(red, 8)},
+			{prototype: `<colorGreen type="Integer" minimum="0" maximum="255"/>`, stream: packBits(green, 8)},
+			{prototype: `<colorBlue type="Integer" minimum="0" maximum="255"/>`, stream: packBits(blue, 8)},
+		},
+	}
+
+	// A small spherical scan without a pose, with intensity normalized by
+	// the scan's limits
+	spherical := testScan{
+		xml:     `<name type="String">Setup 2</name><intensityLimits type="Structure"><intensityMinimum type="Integer">0</intensityMinimum><intensityMaximum type="Integer">1000</intensityMaximum></intensityLimits>`,
+		records: 2,
+		fields: []testField{
+			{prototype: `<sphericalRange type="Float"/>`, stream: packFloat64([]float64{2, 2})},
+			{prototype: `<sphericalAzimuth type="Float"/>`, stream: packFloat64([]float64{0, math.Pi / 2})},
+			{prototype: `<sphericalElevation type="Float"/>`, stream: packFloat64([]float64{0, 0})},
+			{prototype: `<intensity type="Integer" minimum="0" maximum="4095"/>`, stream: packBits([]uint64{500, 1000}, 12)},
+		},
+	}
+
+	return buildE57([]testScan{cartesian, spherical})
+}
+
+func TestRead(t *testing.T) {
+	// ACT ====================================================================
+	scans, err := e57.Read(bytes.NewReader(testFile()))
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scans, 2)
+
+	cartesian := scans[0]
+	assert.Equal(t, "Setup 1", cartesian.Name)
+	assert.Equal(t, "abc", cartesian.GUID)
+	assert.InDelta(t, 0, cartesian.Pose.Position().Distance(vector3.New(10., 0., 0.)), 1e-9)
+
+	// Every tenth point is invalid
+	require.Equal(t, 540, cartesian.Mesh.AttributeLength())
+
+	// The first valid point is record 1, at (0.001, 0, -0.5) locally
+	position := cartesian.Mesh.Float3Attribute(modeling.PositionAttribute).At(0)
+	assert.InDelta(t, 0, position.Distance(vector3.New(10., 0.001, -0.5)), 1e-9)
+	assert.InDelta(t, 1./600., cartesian.Mesh.Float1Attribute(modeling.IntensityAttribute).At(0), 1e-6)
+	assert.Equal(t, vector3.New(1./255., 0., 1.), cartesian.Mesh.Float3Attribute(modeling.ColorAttribute).At(0))
+
+	last := cartesian.Mesh.Float3Attribute(modeling.PositionAttribute).At(539)
+	assert.InDelta(t, 0, last.Distance(vector3.New(10., 0.599, -0.5)), 1e-9)
+
+	spherical := scans[1]
+	assert.Equal(t, "Setup 2", spherical.Name)
+	assert.False(t, spherical.Mesh.HasFloat3Attribute(modeling.ColorAttribute))
+	positions := spherical.Mesh.Float3Attribute(modeling.PositionAttribute)
+	require.Equal(t, 2, positions.Len())
+	assert.InDelta(t, 0, positions.At(0).Distance(vector3.New(2., 0., 0.)), 1e-9)
+	assert.InDelta(t, 0, positions.At(1).Distance(vector3.New(0., 2., 0.)), 1e-9)
+	assert.Equal(t, 0.5, spherical.Mesh.Float1Attribute(modeling.IntensityAttribute).At(0))
+	assert.Equal(t, 1., spherical.Mesh.Float1Attribute(modeling.IntensityAttribute).At(1))
+}
+
+func TestRead_Errors(t *testing.T) {
+	valid := testFile()
+
+	badSignature := bytes.Clone(valid)
+	copy(badSignature, "ASTM-E58")
+
+	badVersion := bytes.Clone(valid)
+	binary.LittleEndian.PutUint32(badVersion[8:], 2)
+
+	oversizedXML := bytes.Clone(valid[:48])
+	binary.LittleEndian.PutUint64(oversizedXML[32:], 1<<62)
+
+	tests := map[string]struct {
+		data []byte
+		err  string
+	}{
+		"empty":         {data: nil, err: "unable to read header: EOF"},
+		"bad signature": {data: badSignature, err: `invalid file signature "ASTM-E58"`},
+		"bad version":   {data: badVersion, err: "unsupported version 2.0"},
+		"truncated":     {data: valid[:2048], err: "unable to read xml section"},
+		"oversized xml": {data: oversizedXML, err: "extends past the end of the 48 byte file"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := e57.Read(bytes.NewReader(tc.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestReadNode(t *testing.T) {
+	node := &nodes.Struct[e57.ReadNode]{
+		Data: e57.ReadNode{
+			Data: nodes.ConstOutput[[]byte]{Val: testFile()},
+		},
+	}
+
+	combined := nodes.GetNodeOutputPort[modeling.Mesh](node, "Out").Value()
+	scans := nodes.GetNodeOutputPort[[]modeling.Mesh](node, "Scans").Value()
+
+	assert.Equal(t, 542, combined.AttributeLength())
+	require.Len(t, scans, 2)
+	assert.Equal(t, 540, scans[0].AttributeLength())
+}
@@ -0,0 +1,17 @@
+package e57
+
+import (
+	"os"
+)
+
+// Load opens the E57 file located at the filePath and deserializes every 3D
+// scan within
+func Load(filePath string) ([]Scan, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
@@ -0,0 +1,46 @@
+package e57
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const headerSize = 48
+
+// Header is found at the start of every E57 file and locates the XML section
+// describing the rest of the file's contents
+type Header struct {
+	Signature          [8]byte
+	MajorVersion       uint32
+	MinorVersion       uint32
+	FilePhysicalLength uint64
+	XMLPhysicalOffset  uint64
+	XMLLogicalLength   uint64
+	PageSize           uint64
+}
+
+func (h Header) Validate() error {
+	if string(h.Signature[:]) != "ASTM-E57" {
+		return fmt.Errorf("invalid file signature %q", string(h.Signature[:]))
+	}
+
+	if h.MajorVersion != 1 {
+		return fmt.Errorf("unsupported version %d.%d", h.MajorVersion, h.MinorVersion)
+	}
+
+	// Every page ends with a checksum, so smaller pages can't hold any data
+	if h.PageSize <= checksumSize || h.PageSize < headerSize+checksumSize {
+		return fmt.Errorf("invalid page size %d", h.PageSize)
+	}
+
+	return nil
+}
+
+func ReadHeader(in io.Reader) (*Header, error) {
+	header := &Header{}
+	if err := binary.Read(in, binary.LittleEndian, header); err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+	return header, header.Validate()
+}
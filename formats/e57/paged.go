@@ -0,0 +1,72 @@
+package e57
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+const checksumSize = 4
+
+// pagedReader reads the logical contents of an E57 file. The file is
+// physically split into pages which each end in a checksum, and every offset
+// stored within the file is a physical one.
+type pagedReader struct {
+	in       io.ReaderAt
+	pageSize uint64
+
+	// size is the physical length of the file, used to reject reads that
+	// run past its end before allocating anything for them
+	size uint64
+}
+
+// sizeOf determines how many bytes the reader holds, if it's able to say
+func sizeOf(in io.ReaderAt) (uint64, bool) {
+	switch v := in.(type) {
+	case interface{ Size() int64 }:
+		return uint64(v.Size()), true
+
+	case interface{ Stat() (fs.FileInfo, error) }:
+		if info, err := v.Stat(); err == nil {
+			return uint64(info.Size()), true
+		}
+	}
+	return 0, false
+}
+
+func (pr pagedReader) payloadSize() uint64 {
+	return pr.pageSize - checksumSize
+}
+
+func (pr pagedReader) logical(physical uint64) uint64 {
+	return (physical/pr.pageSize)*pr.payloadSize() + physical%pr.pageSize
+}
+
+func (pr pagedReader) physical(logical uint64) uint64 {
+	return (logical/pr.payloadSize())*pr.pageSize + logical%pr.payloadSize()
+}
+
+// read reads length logical bytes starting at the physical offset, skipping
+// over page checksums
+func (pr pagedReader) read(physical, length uint64) ([]byte, error) {
+	if physical%pr.pageSize >= pr.payloadSize() {
+		return nil, fmt.Errorf("offset %d points into a page checksum", physical)
+	}
+
+	if physical >= pr.size || length > pr.logical(pr.size)-pr.logical(physical) {
+		return nil, fmt.Errorf("%d bytes at offset %d extends past the end of the %d byte file", length, physical, pr.size)
+	}
+
+	data := make([]byte, length)
+	read := uint64(0)
+	for read < length {
+		available := pr.payloadSize() - physical%pr.pageSize
+		chunk := min(available, length-read)
+		if _, err := pr.in.ReadAt(data[read:read+chunk], int64(physical)); err != nil {
+			return nil, fmt.Errorf("unable to read %d bytes at offset %d: %w", chunk, physical, err)
+		}
+		read += chunk
+		physical = pr.physical(pr.logical(physical) + chunk)
+	}
+	return data, nil
+}
@@ -0,0 +1,282 @@
+package e57
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Scan is the point cloud captured from a single scanner setup
+type Scan struct {
+	Name string
+	GUID string
+
+	// Pose takes points from the scanner's local space into world space
+	Pose trs.TRS
+
+	// Mesh is the point cloud of the scan, with the pose applied. Points the
+	// scanner flagged as invalid are left out.
+	Mesh modeling.Mesh
+}
+
+// Read deserializes every 3D scan within the E57 file. Reading requires random
+// access to the file, as the XML section describing the file's contents sits
+// at the end.
+func Read(in io.ReaderAt) ([]Scan, error) {
+	header, err := ReadHeader(io.NewSectionReader(in, 0, headerSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// The header's claimed length can't be trusted to match what's there
+	size := header.FilePhysicalLength
+	if actual, ok := sizeOf(in); ok {
+		size = min(size, actual)
+	}
+
+	pr := pagedReader{in: in, pageSize: header.PageSize, size: size}
+	xmlData, err := pr.read(header.XMLPhysicalOffset, header.XMLLogicalLength)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read xml section: %w", err)
+	}
+
+	root := element{}
+	if err := xml.NewDecoder(bytes.NewReader(xmlData)).Decode(&root); err != nil {
+		return nil, fmt.Errorf("unable to parse xml section: %w", err)
+	}
+
+	scans := make([]Scan, 0)
+	data3D := root.child("data3D")
+	if data3D == nil {
+		return scans, nil
+	}
+
+	for i, child := range data3D.Children {
+		scan, err := readScan(pr, child)
+		if err != nil {
+			return nil, fmt.Errorf("scan %d: %w", i, err)
+		}
+		scans = append(scans, *scan)
+	}
+	return scans, nil
+}
+
+func readPose(e *element) (trs.TRS, error) {
+	if e == nil {
+		return trs.Identity(), nil
+	}
+
+	rotation := quaternion.Identity()
+	if r := e.child("rotation"); r != nil {
+		components := [4]float64{}
+		for i, name := range []string{"w", "x", "y", "z"} {
+			fallback := 0.
+			if name == "w" {
+				fallback = 1
+			}
+			v, err := r.childNumber(name, fallback)
+			if err != nil {
+				return trs.TRS{}, err
+			}
+			components[i] = v
+		}
+		rotation = quaternion.New(vector3.New(components[1], components[2], components[3]), components[0])
+	}
+
+	translation := vector3.Zero[float64]()
+	if t := e.child("translation"); t != nil {
+		components := [3]float64{}
+		for i, name := range []string{"x", "y", "z"} {
+			v, err := t.childNumber(name, 0)
+			if err != nil {
+				return trs.TRS{}, err
+			}
+			components[i] = v
+		}
+		translation = vector3.New(components[0], components[1], components[2])
+	}
+
+	return trs.New(translation, rotation, vector3.One[float64]()), nil
+}
+
+// readLimits looks up the range values are expected to lie within, first
+// from the limits structure of the scan, then from the field's definition
+func readLimits(limits *element, minName, maxName string, f field) (float64, float64, bool, error) {
+	if limits != nil {
+		lower, upper := limits.child(minName), limits.child(maxName)
+		if lower != nil && upper != nil {
+			l, err := lower.number()
+			if err != nil {
+				return 0, 0, false, err
+			}
+			u, err := upper.number()
+			if err != nil {
+				return 0, 0, false, err
+			}
+			return l, u, true, nil
+		}
+	}
+	return f.lower, f.upper, f.bounded, nil
+}
+
+func normalize(values []float64, lower, upper float64) {
+	if upper <= lower {
+		return
+	}
+	size := upper - lower
+	for i, v := range values {
+		values[i] = (v - lower) / size
+	}
+}
+
+func readScan(pr pagedReader, e element) (*Scan, error) {
+	scan := &Scan{}
+	if name := e.child("name"); name != nil {
+		scan.Name = name.text()
+	}
+	if guid := e.child("guid"); guid != nil {
+		scan.GUID = guid.text()
+	}
+
+	pose, err := readPose(e.child("pose"))
+	if err != nil {
+		return nil, err
+	}
+	scan.Pose = pose
+
+	points := e.child("points")
+	if points == nil {
+		return nil, errors.New("missing points")
+	}
+	if points.kind() != "CompressedVector" {
+		return nil, fmt.Errorf("expected points to be a compressed vector, found %q", points.kind())
+	}
+
+	cv := compressedVector{}
+	for name, dst := range map[string]*uint64{"fileOffset": &cv.fileOffset, "recordCount": &cv.recordCount} {
+		v, ok := points.attr(name)
+		if !ok {
+			return nil, fmt.Errorf("points missing %s", name)
+		}
+		if *dst, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("points has invalid %s %q: %w", name, v, err)
+		}
+	}
+
+	prototype := points.child("prototype")
+	if prototype == nil {
+		return nil, errors.New("points missing prototype")
+	}
+
+	fields := make(map[string]field)
+	for _, c := range prototype.Children {
+		f, err := parseField(c)
+		if err != nil {
+			return nil, err
+		}
+		cv.fields = append(cv.fields, f)
+		fields[f.name] = f
+	}
+
+	values, err := cv.read(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(cv.recordCount)
+	positions := make([]vector3.Float64, count)
+	var invalid []float64
+	switch {
+	case values["cartesianX"] != nil && values["cartesianY"] != nil && values["cartesianZ"] != nil:
+		x, y, z := values["cartesianX"], values["cartesianY"], values["cartesianZ"]
+		for i := range positions {
+			positions[i] = vector3.New(x[i], y[i], z[i])
+		}
+		invalid = values["cartesianInvalidState"]
+
+	case values["sphericalRange"] != nil && values["sphericalAzimuth"] != nil && values["sphericalElevation"] != nil:
+		r, azimuth, elevation := values["sphericalRange"], values["sphericalAzimuth"], values["sphericalElevation"]
+		for i := range positions {
+			cosElevation := math.Cos(elevation[i])
+			positions[i] = vector3.New(
+				r[i]*cosElevation*math.Cos(azimuth[i]),
+				r[i]*cosElevation*math.Sin(azimuth[i]),
+				r[i]*math.Sin(elevation[i]),
+			)
+		}
+		invalid = values["sphericalInvalidState"]
+
+	default:
+		return nil, errors.New("points contain neither cartesian nor spherical coordinates")
+	}
+
+	v1Data := make(map[string][]float64)
+	if intensity := values["intensity"]; intensity != nil {
+		lower, upper, ok, err := readLimits(e.child("intensityLimits"), "intensityMinimum", "intensityMaximum", fields["intensity"])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			normalize(intensity, lower, upper)
+		}
+		v1Data[modeling.IntensityAttribute] = intensity
+	}
+
+	v3Data := map[string][]vector3.Float64{
+		modeling.PositionAttribute: positions,
+	}
+	channels := []string{"Red", "Green", "Blue"}
+	if values["color"+channels[0]] != nil && values["color"+channels[1]] != nil && values["color"+channels[2]] != nil {
+		for _, channel := range channels {
+			name := "color" + channel
+			lower, upper, ok, err := readLimits(e.child("colorLimits"), name+"Minimum", name+"Maximum", fields[name])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				normalize(values[name], lower, upper)
+			}
+		}
+
+		colors := make([]vector3.Float64, count)
+		for i := range colors {
+			colors[i] = vector3.New(values["colorRed"][i], values["colorGreen"][i], values["colorBlue"][i])
+		}
+		v3Data[modeling.ColorAttribute] = colors
+	}
+
+	// Drop the points without a valid position, and move the rest into world
+	// space
+	kept := 0
+	for i := range positions {
+		if invalid != nil && invalid[i] != 0 {
+			continue
+		}
+		for _, data := range v3Data {
+			data[kept] = data[i]
+		}
+		for _, data := range v1Data {
+			data[kept] = data[i]
+		}
+		positions[kept] = scan.Pose.Transform(positions[kept])
+		kept++
+	}
+	for name, data := range v3Data {
+		v3Data[name] = data[:kept]
+	}
+	for name, data := range v1Data {
+		v1Data[name] = data[:kept]
+	}
+
+	scan.Mesh = modeling.NewPointCloud(nil, v3Data, nil, v1Data)
+	return scan, nil
+}
@@ -0,0 +1,215 @@
+package e57
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// element is a node of the XML section, which describes the file as a tree
+// of typed values
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []element  `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+func (e element) name() string {
+	return e.XMLName.Local
+}
+
+func (e element) attr(name string) (string, bool) {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+func (e element) kind() string {
+	kind, _ := e.attr("type")
+	return kind
+}
+
+func (e element) child(name string) *element {
+	for i, c := range e.Children {
+		if c.name() == name {
+			return &e.Children[i]
+		}
+	}
+	return nil
+}
+
+func (e element) text() string {
+	return strings.TrimSpace(e.Text)
+}
+
+func (e element) intAttr(name string, fallback int64) (int64, error) {
+	v, ok := e.attr(name)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid %s %q: %w", e.name(), name, v, err)
+	}
+	return parsed, nil
+}
+
+func (e element) floatAttr(name string, fallback float64) (float64, error) {
+	v, ok := e.attr(name)
+	if !ok {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid %s %q: %w", e.name(), name, v, err)
+	}
+	return parsed, nil
+}
+
+// number interprets the element as a numeric value, whether it's a Float,
+// Integer or ScaledInteger
+func (e element) number() (float64, error) {
+	text := e.text()
+	switch e.kind() {
+	case "Float":
+		if text == "" {
+			return 0, nil
+		}
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s: invalid float %q: %w", e.name(), text, err)
+		}
+		return v, nil
+
+	case "Integer", "ScaledInteger":
+		raw := int64(0)
+		if text != "" {
+			v, err := strconv.ParseInt(text, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%s: invalid integer %q: %w", e.name(), text, err)
+			}
+			raw = v
+		}
+		if e.kind() == "Integer" {
+			return float64(raw), nil
+		}
+
+		scale, err := e.floatAttr("scale", 1)
+		if err != nil {
+			return 0, err
+		}
+		offset, err := e.floatAttr("offset", 0)
+		if err != nil {
+			return 0, err
+		}
+		return float64(raw)*scale + offset, nil
+	}
+	return 0, fmt.Errorf("%s: expected a number, found %q", e.name(), e.kind())
+}
+
+// childNumber returns the numeric value of the named child, or the fallback
+// if it doesn't exist
+func (e element) childNumber(name string, fallback float64) (float64, error) {
+	c := e.child(name)
+	if c == nil {
+		return fallback, nil
+	}
+	return c.number()
+}
+
+// field is a single value of each record within a compressed vector
+type field struct {
+	name string
+	kind string
+
+	// Float
+	single bool
+
+	// Integer and ScaledInteger
+	minimum int64
+	maximum int64
+	scale   float64
+	offset  float64
+
+	// Range of values the field holds, when known
+	lower, upper float64
+	bounded      bool
+}
+
+func parseField(e element) (field, error) {
+	f := field{
+		name:  e.name(),
+		kind:  e.kind(),
+		scale: 1,
+	}
+
+	switch f.kind {
+	case "Float":
+		precision, _ := e.attr("precision")
+		f.single = precision == "single"
+
+		// Writers tend to fill in the limits of the floating point type when
+		// the actual range isn't known, which isn't useful for normalizing
+		_, hasMin := e.attr("minimum")
+		_, hasMax := e.attr("maximum")
+		if hasMin && hasMax {
+			var err error
+			if f.lower, err = e.floatAttr("minimum", 0); err != nil {
+				return f, err
+			}
+			if f.upper, err = e.floatAttr("maximum", 0); err != nil {
+				return f, err
+			}
+			f.bounded = f.lower > -math.MaxFloat32 && f.upper < math.MaxFloat32
+		}
+
+	case "Integer", "ScaledInteger":
+		var err error
+		if f.minimum, err = e.intAttr("minimum", math.MinInt64); err != nil {
+			return f, err
+		}
+		if f.maximum, err = e.intAttr("maximum", math.MaxInt64); err != nil {
+			return f, err
+		}
+		if f.maximum < f.minimum {
+			return f, fmt.Errorf("%s: maximum %d is less than minimum %d", f.name, f.maximum, f.minimum)
+		}
+		if f.kind == "ScaledInteger" {
+			if f.scale, err = e.floatAttr("scale", 1); err != nil {
+				return f, err
+			}
+			if f.offset, err = e.floatAttr("offset", 0); err != nil {
+				return f, err
+			}
+		}
+
+		_, hasMin := e.attr("minimum")
+		_, hasMax := e.attr("maximum")
+		f.bounded = hasMin && hasMax
+		f.lower = float64(f.minimum)*f.scale + f.offset
+		f.upper = float64(f.maximum)*f.scale + f.offset
+
+	default:
+		return f, fmt.Errorf("%s: unsupported field type %q", f.name, f.kind)
+	}
+
+	return f, nil
+}
+
+// bits is the number of bits each value of the field occupies
+func (f field) bits() int {
+	if f.kind == "Float" {
+		if f.single {
+			return 32
+		}
+		return 64
+	}
+	return bits.Len64(uint64(f.maximum) - uint64(f.minimum))
+}
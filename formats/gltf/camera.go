@@ -0,0 +1,129 @@
+package gltf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// PolyformCamera is a camera placed within a scene. Exactly one of
+// Perspective or Orthographic must be set.
+type PolyformCamera struct {
+	Name string
+
+	// Placement of the camera within the scene. The camera looks down its
+	// local -Z axis, with +Y up. Identity when left nil.
+	TRS *trs.TRS
+
+	Perspective  *CameraPerspective
+	Orthographic *CameraOrthographic
+}
+
+// CameraLookAt builds the transform of a camera positioned at position and
+// facing target, accounting for GLTF cameras looking down their -Z axis
+func CameraLookAt(position, target vector3.Float64) trs.TRS {
+	// LookAt points +Z towards the target, so look directly away from it
+	// instead
+	return trs.Position(position).LookAt(position.Scale(2).Sub(target))
+}
+
+func (pc PolyformCamera) toGltf() (Camera, error) {
+	camera := Camera{
+		ChildOfRootProperty: ChildOfRootProperty{Name: pc.Name},
+	}
+
+	switch {
+	case pc.Perspective != nil && pc.Orthographic != nil:
+		return camera, errors.New("camera can not be both perspective and orthographic")
+
+	case pc.Perspective != nil:
+		if pc.Perspective.YFov <= 0 {
+			return camera, fmt.Errorf("perspective camera field of view must be greater than 0, received %g", pc.Perspective.YFov)
+		}
+		if pc.Perspective.ZNear <= 0 {
+			return camera, fmt.Errorf("perspective camera near plane must be greater than 0, received %g", pc.Perspective.ZNear)
+		}
+		if pc.Perspective.ZFar != nil && *pc.Perspective.ZFar <= pc.Perspective.ZNear {
+			return camera, fmt.Errorf("perspective camera far plane %g must be greater than near plane %g", *pc.Perspective.ZFar, pc.Perspective.ZNear)
+		}
+		camera.Type = CameraType_Perspective
+		camera.Perspective = pc.Perspective
+
+	case pc.Orthographic != nil:
+		if pc.Orthographic.XMag == 0 || pc.Orthographic.YMag == 0 {
+			return camera, errors.New("orthographic camera magnification must not be 0")
+		}
+		if pc.Orthographic.ZFar <= pc.Orthographic.ZNear {
+			return camera, fmt.Errorf("orthographic camera far plane %g must be greater than near plane %g", pc.Orthographic.ZFar, pc.Orthographic.ZNear)
+		}
+		camera.Type = CameraType_Orthographic
+		camera.Orthographic = pc.Orthographic
+
+	default:
+		return camera, errors.New("camera must be either perspective or orthographic")
+	}
+
+	return camera, nil
+}
+
+// cameraOnlyNode reports whether the node exists purely to place a camera
+// within the scene, and has nothing worth decoding as a model
+func cameraOnlyNode(n Node) bool {
+	return n.Camera != nil && n.Mesh == nil && len(n.Children) == 0 && len(n.Extensions) == 0
+}
+
+// decodeCameras builds a camera for each decoded node referencing one, placed
+// with the node's transform relative to the root of the document
+func decodeCameras(doc *Gltf, nodes []GltfId) ([]PolyformCamera, error) {
+	sort.Ints(nodes)
+	parents := nodeParents(doc)
+
+	cameras := make([]PolyformCamera, 0)
+	for _, nodeIndex := range nodes {
+		n := doc.Nodes[nodeIndex]
+		if n.Camera == nil {
+			continue
+		}
+
+		if *n.Camera < 0 || *n.Camera >= len(doc.Cameras) {
+			return nil, fmt.Errorf("node %d references invalid camera %d", nodeIndex, *n.Camera)
+		}
+		camera := doc.Cameras[*n.Camera]
+
+		name := camera.Name
+		if name == "" {
+			name = n.Name
+		}
+
+		transform := trs.FromMatrix(nodeWorldMatrix(doc, parents, nodeIndex))
+		decoded := PolyformCamera{
+			Name: name,
+			TRS:  &transform,
+		}
+
+		switch camera.Type {
+		case CameraType_Perspective:
+			if camera.Perspective == nil {
+				return nil, fmt.Errorf("perspective camera %d is missing its perspective properties", *n.Camera)
+			}
+			perspective := *camera.Perspective
+			decoded.Perspective = &perspective
+
+		case CameraType_Orthographic:
+			if camera.Orthographic == nil {
+				return nil, fmt.Errorf("orthographic camera %d is missing its orthographic properties", *n.Camera)
+			}
+			orthographic := *camera.Orthographic
+			decoded.Orthographic = &orthographic
+
+		default:
+			return nil, fmt.Errorf("camera %d has unrecognized type %q", *n.Camera, camera.Type)
+		}
+
+		cameras = append(cameras, decoded)
+	}
+	return cameras, nil
+}
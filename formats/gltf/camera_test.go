@@ -0,0 +1,158 @@
+package gltf_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCameraLookAt(t *testing.T) {
+	// ARRANGE ================================================================
+	position := vector3.New(3., 4., 5.)
+
+	// ACT ====================================================================
+	transform := gltf.CameraLookAt(position, vector3.Zero[float64]())
+	forward := transform.RotateDirection(vector3.New(0., 0., -1.))
+
+	// ASSERT =================================================================
+	assert.InDelta(t, 3., transform.Position().X(), epsilon)
+	assert.InDelta(t, 4., transform.Position().Y(), epsilon)
+	assert.InDelta(t, 5., transform.Position().Z(), epsilon)
+
+	expected := position.Scale(-1).Normalized()
+	assert.InDelta(t, expected.X(), forward.X(), epsilon)
+	assert.InDelta(t, expected.Y(), forward.Y(), epsilon)
+	assert.InDelta(t, expected.Z(), forward.Z(), epsilon)
+}
+
+func TestDecodeScene_Cameras(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+		})
+
+	perspectiveTransform := gltf.CameraLookAt(vector3.New(0., 2., 10.), vector3.Zero[float64]())
+	far := 100.
+	aspect := 1.5
+
+	scene := gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{{Name: "tri", Mesh: &tri}},
+		Cameras: []gltf.PolyformCamera{
+			{
+				Name: "main",
+				TRS:  &perspectiveTransform,
+				Perspective: &gltf.CameraPerspective{
+					YFov:        1,
+					AspectRatio: &aspect,
+					ZNear:       0.1,
+					ZFar:        &far,
+				},
+			},
+			{
+				Name: "top",
+				Orthographic: &gltf.CameraOrthographic{
+					XMag:  2,
+					YMag:  3,
+					ZNear: 0.5,
+					ZFar:  50,
+				},
+			},
+		},
+	}
+
+	// ACT ====================================================================
+	doc, decoded := roundTrip(t, scene, nil)
+
+	// ASSERT =================================================================
+	require.Len(t, doc.Cameras, 2)
+	assert.Equal(t, gltf.CameraType_Perspective, doc.Cameras[0].Type)
+	assert.Equal(t, gltf.CameraType_Orthographic, doc.Cameras[1].Type)
+
+	require.Len(t, decoded.Models, 1)
+	require.Len(t, decoded.Cameras, 2)
+
+	main := decoded.Cameras[0]
+	assert.Equal(t, "main", main.Name)
+	assert.Nil(t, main.Orthographic)
+	require.NotNil(t, main.Perspective)
+	assert.InDelta(t, 1., main.Perspective.YFov, epsilon)
+	assert.InDelta(t, 0.1, main.Perspective.ZNear, epsilon)
+	require.NotNil(t, main.Perspective.AspectRatio)
+	assert.InDelta(t, 1.5, *main.Perspective.AspectRatio, epsilon)
+	require.NotNil(t, main.Perspective.ZFar)
+	assert.InDelta(t, 100., *main.Perspective.ZFar, epsilon)
+	require.NoError(t, main.TRS.WithinDelta(perspectiveTransform, epsilon))
+
+	top := decoded.Cameras[1]
+	assert.Equal(t, "top", top.Name)
+	assert.Nil(t, top.Perspective)
+	require.NotNil(t, top.Orthographic)
+	assert.Equal(t, gltf.CameraOrthographic{XMag: 2, YMag: 3, ZNear: 0.5, ZFar: 50}, *top.Orthographic)
+	require.NoError(t, top.TRS.WithinDelta(trs.Identity(), epsilon))
+}
+
+func TestDecodeScene_CameraWithinHierarchy(t *testing.T) {
+	// ARRANGE ================================================================
+	parent := trs.Position(vector3.New(1., 0., 0.))
+	scene := gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{{Name: "rig", TRS: &parent}},
+		Cameras: []gltf.PolyformCamera{{
+			Name:        "attached",
+			Perspective: &gltf.CameraPerspective{YFov: 1, ZNear: 0.1},
+		}},
+	}
+
+	// Move the camera node underneath the model
+	attach := func(doc *gltf.Gltf) {
+		doc.Nodes[0].Children = []gltf.GltfId{1}
+		doc.Nodes[1].Translation = &[3]float64{0, 2, 0}
+		doc.Scenes[0].Nodes = []gltf.GltfId{0}
+	}
+
+	// ACT ====================================================================
+	_, decoded := roundTrip(t, scene, attach)
+
+	// ASSERT =================================================================
+	require.Len(t, decoded.Models, 1)
+	require.Len(t, decoded.Models[0].Children, 1)
+	require.Len(t, decoded.Cameras, 1)
+	assert.Equal(t, "attached", decoded.Cameras[0].Name)
+
+	position := decoded.Cameras[0].TRS.Position()
+	assert.InDelta(t, 1., position.X(), epsilon)
+	assert.InDelta(t, 2., position.Y(), epsilon)
+	assert.InDelta(t, 0., position.Z(), epsilon)
+}
+
+func TestWrite_InvalidCamera(t *testing.T) {
+	tests := map[string]gltf.PolyformCamera{
+		"no projection": {},
+		"both projections": {
+			Perspective:  &gltf.CameraPerspective{YFov: 1, ZNear: 0.1},
+			Orthographic: &gltf.CameraOrthographic{XMag: 1, YMag: 1, ZNear: 0.1, ZFar: 10},
+		},
+		"zero near plane": {
+			Perspective: &gltf.CameraPerspective{YFov: 1},
+		},
+		"far before near": {
+			Orthographic: &gltf.CameraOrthographic{XMag: 1, YMag: 1, ZNear: 10, ZFar: 1},
+		},
+	}
+
+	for name, camera := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := gltf.NewWriterFromScene(gltf.PolyformScene{
+				Cameras: []gltf.PolyformCamera{camera},
+			})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package gltf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+// CollisionNamingConvention determines how convex collision meshes are
+// named, so game engines recognize them as collision shapes on import rather
+// than as geometry to render
+type CollisionNamingConvention string
+
+const (
+	// UCX_<name>_<index>, recognized by Unreal Engine as convex collision for
+	// the mesh named <name>
+	CollisionNamingUnreal CollisionNamingConvention = "unreal"
+
+	// <name>_<index>-convcolonly, recognized by Godot as a convex collision
+	// shape without a visible mesh
+	CollisionNamingGodot CollisionNamingConvention = "godot"
+)
+
+func (c CollisionNamingConvention) name(model string, index int) (string, error) {
+	switch CollisionNamingConvention(strings.ToLower(string(c))) {
+	case CollisionNamingUnreal, "":
+		return fmt.Sprintf("UCX_%s_%02d", model, index), nil
+
+	case CollisionNamingGodot:
+		return fmt.Sprintf("%s_%02d-convcolonly", model, index), nil
+	}
+	return "", fmt.Errorf("unrecognized collision naming convention %q", string(c))
+}
+
+// ConvexCollisionModels wraps each convex hull in its own model, named so the
+// engine the convention targets treats it as collision for the model named
+// name. Hulls are expected to be convex, such as those built by the hull
+// package.
+func ConvexCollisionModels(name string, hulls []modeling.Mesh, convention CollisionNamingConvention) ([]*PolyformModel, error) {
+	models := make([]*PolyformModel, 0, len(hulls))
+	for i, hull := range hulls {
+		modelName, err := convention.name(name, i)
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, &PolyformModel{
+			Name: modelName,
+			Mesh: &hull,
+		})
+	}
+	return models, nil
+}
@@ -0,0 +1,83 @@
+package gltf_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvexCollisionModels(t *testing.T) {
+	hulls := []modeling.Mesh{
+		primitives.UnitCube(),
+		primitives.UnitCube().Translate(vector3.New(1., 0., 0.)),
+	}
+
+	tests := map[string]struct {
+		convention gltf.CollisionNamingConvention
+		names      []string
+	}{
+		"unreal": {
+			convention: gltf.CollisionNamingUnreal,
+			names:      []string{"UCX_Chair_00", "UCX_Chair_01"},
+		},
+		"godot": {
+			convention: gltf.CollisionNamingGodot,
+			names:      []string{"Chair_00-convcolonly", "Chair_01-convcolonly"},
+		},
+		"defaults to unreal": {
+			names: []string{"UCX_Chair_00", "UCX_Chair_01"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ACT ============================================================
+			models, err := gltf.ConvexCollisionModels("Chair", hulls, tc.convention)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			require.Len(t, models, len(tc.names))
+			for i, model := range models {
+				assert.Equal(t, tc.names[i], model.Name)
+				assert.Equal(t, hulls[i], *model.Mesh)
+				assert.Nil(t, model.Material)
+			}
+		})
+	}
+}
+
+func TestConvexCollisionModels_UnknownConvention(t *testing.T) {
+	_, err := gltf.ConvexCollisionModels("Chair", []modeling.Mesh{primitives.UnitCube()}, "unity")
+	assert.EqualError(t, err, `unrecognized collision naming convention "unity"`)
+}
+
+func TestConvexCollisionNode_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	node := &nodes.Struct[gltf.ConvexCollisionNode]{
+		Data: gltf.ConvexCollisionNode{
+			Name: nodes.ConstOutput[string]{Val: "Chair"},
+			Hulls: nodes.ConstOutput[[]modeling.Mesh]{Val: []modeling.Mesh{
+				primitives.UnitCube(),
+				primitives.UnitCube().Translate(vector3.New(2., 0., 0.)),
+			}},
+		},
+	}
+	collision := nodes.GetNodeOutputPort[*gltf.PolyformModel](node, "Out").Value()
+
+	// ACT ====================================================================
+	doc, _ := roundTrip(t, gltf.PolyformScene{Models: []*gltf.PolyformModel{collision}}, nil)
+
+	// ASSERT =================================================================
+	names := make([]string, 0)
+	for _, n := range doc.Nodes {
+		names = append(names, n.Name)
+	}
+	assert.ElementsMatch(t, []string{"Chair_collision", "UCX_Chair_00", "UCX_Chair_01"}, names)
+	assert.Len(t, doc.Meshes, 2)
+}
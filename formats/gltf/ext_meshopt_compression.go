@@ -0,0 +1,285 @@
+package gltf
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+const extMeshoptCompressionID = "EXT_meshopt_compression"
+
+type MeshoptCompressionMode string
+
+const (
+	MeshoptCompressionMode_ATTRIBUTES MeshoptCompressionMode = "ATTRIBUTES"
+	MeshoptCompressionMode_TRIANGLES  MeshoptCompressionMode = "TRIANGLES"
+	MeshoptCompressionMode_INDICES    MeshoptCompressionMode = "INDICES"
+)
+
+type MeshoptCompressionFilter string
+
+const (
+	MeshoptCompressionFilter_NONE        MeshoptCompressionFilter = "NONE"
+	MeshoptCompressionFilter_OCTAHEDRAL  MeshoptCompressionFilter = "OCTAHEDRAL"
+	MeshoptCompressionFilter_QUATERNION  MeshoptCompressionFilter = "QUATERNION"
+	MeshoptCompressionFilter_EXPONENTIAL MeshoptCompressionFilter = "EXPONENTIAL"
+)
+
+// Compressed representation of a buffer view's data. The buffer view itself
+// describes the layout of the data once decompressed, and typically
+// references a fallback buffer with no data of its own.
+// https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Vendor/EXT_meshopt_compression
+type ExtMeshoptCompression struct {
+	Buffer     GltfId                   `json:"buffer"`               // The index of the buffer with compressed data.
+	ByteOffset int                      `json:"byteOffset,omitempty"` // The offset into the buffer in bytes.
+	ByteLength int                      `json:"byteLength"`           // The length of the compressed data in bytes.
+	ByteStride int                      `json:"byteStride"`           // The stride, in bytes.
+	Count      int                      `json:"count"`                // The number of elements.
+	Mode       MeshoptCompressionMode   `json:"mode"`                 // The compression mode.
+	Filter     MeshoptCompressionFilter `json:"filter,omitempty"`     // The compression filter.
+}
+
+func meshoptFallbackBuffer(buffer Buffer) bool {
+	ext, ok := buffer.Extensions[extMeshoptCompressionID].(map[string]any)
+	if !ok {
+		return false
+	}
+	fallback, _ := ext["fallback"].(bool)
+	return fallback && buffer.URI == ""
+}
+
+// meshoptLayout describes how the data of a buffer view gets compressed
+type meshoptLayout struct {
+	mode   MeshoptCompressionMode
+	stride int
+	count  int
+}
+
+// meshoptLayouts determines which of the written buffer views contain vertex
+// attributes or indices that can be compressed
+func (w Writer) meshoptLayouts() map[GltfId]meshoptLayout {
+	layouts := make(map[GltfId]meshoptLayout)
+
+	attribute := func(accessorIndex GltfId) {
+		accessor := w.accessors[accessorIndex]
+		if accessor.BufferView == nil || accessor.ByteOffset != 0 {
+			return
+		}
+
+		view := w.bufferViews[*accessor.BufferView]
+		stride := accessor.Type.componentCount() * accessor.ComponentType.Size()
+		if view.ByteStride != nil {
+			stride = *view.ByteStride
+		}
+
+		if stride%4 != 0 || stride > 256 || view.ByteLength != stride*accessor.Count {
+			return
+		}
+
+		layouts[*accessor.BufferView] = meshoptLayout{
+			mode:   MeshoptCompressionMode_ATTRIBUTES,
+			stride: stride,
+			count:  accessor.Count,
+		}
+	}
+
+	for _, mesh := range w.meshes {
+		for _, primitive := range mesh.Primitives {
+			for _, accessor := range primitive.Attributes {
+				attribute(accessor)
+			}
+
+			for _, target := range primitive.Targets {
+				for _, accessor := range target {
+					attribute(accessor)
+				}
+			}
+
+			if primitive.Indices == nil {
+				continue
+			}
+
+			accessor := w.accessors[*primitive.Indices]
+			if accessor.BufferView == nil || accessor.ByteOffset != 0 {
+				continue
+			}
+
+			layout := meshoptLayout{
+				mode:   MeshoptCompressionMode_INDICES,
+				stride: accessor.ComponentType.Size(),
+				count:  accessor.Count,
+			}
+
+			triangles := primitive.Mode == nil || *primitive.Mode == PrimitiveMode_TRIANGLES
+			if triangles && accessor.Count%3 == 0 {
+				layout.mode = MeshoptCompressionMode_TRIANGLES
+			}
+			layouts[*accessor.BufferView] = layout
+		}
+	}
+
+	return layouts
+}
+
+// meshoptCompress builds a copy of the writer's binary data with the vertex
+// attribute and index buffer views compressed. Compressed buffer views are
+// redirected to a fallback buffer, which is placed after the binary buffer,
+// while the remaining buffer views are packed alongside the compressed data.
+func (w Writer) meshoptCompress() ([]byte, []BufferView) {
+	raw := w.buf.Bytes()
+	layouts := w.meshoptLayouts()
+
+	out := make([]byte, 0, len(raw))
+	views := make([]BufferView, len(w.bufferViews))
+	for i, view := range w.bufferViews {
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+
+		data := raw[view.ByteOffset : view.ByteOffset+view.ByteLength]
+		layout, ok := layouts[i]
+		if !ok {
+			view.ByteOffset = len(out)
+			out = append(out, data...)
+			views[i] = view
+			continue
+		}
+
+		var compressed []byte
+		switch layout.mode {
+		case MeshoptCompressionMode_ATTRIBUTES:
+			compressed = meshoptEncodeVertexBuffer(data, layout.count, layout.stride)
+
+		case MeshoptCompressionMode_TRIANGLES:
+			compressed = meshoptEncodeIndexBuffer(readIndices(data, layout.count, layout.stride))
+
+		case MeshoptCompressionMode_INDICES:
+			compressed = meshoptEncodeIndexSequence(readIndices(data, layout.count, layout.stride))
+		}
+
+		extensions := make(Extensions)
+		for id, ext := range view.Extensions {
+			extensions[id] = ext
+		}
+		extensions[extMeshoptCompressionID] = ExtMeshoptCompression{
+			Buffer:     0,
+			ByteOffset: len(out),
+			ByteLength: len(compressed),
+			ByteStride: layout.stride,
+			Count:      layout.count,
+			Mode:       layout.mode,
+		}
+		view.Extensions = extensions
+		view.Buffer = 1
+		views[i] = view
+
+		out = append(out, compressed...)
+	}
+
+	return out, views
+}
+
+func readIndices(data []byte, count, stride int) []uint32 {
+	indices := make([]uint32, count)
+	for i := range indices {
+		switch stride {
+		case 1:
+			indices[i] = uint32(data[i])
+		case 2:
+			indices[i] = uint32(binary.LittleEndian.Uint16(data[i*2:]))
+		default:
+			indices[i] = binary.LittleEndian.Uint32(data[i*4:])
+		}
+	}
+	return indices
+}
+
+func writeIndices(dst []byte, indices []uint32, stride int) {
+	for i, index := range indices {
+		switch stride {
+		case 1:
+			dst[i] = byte(index)
+		case 2:
+			binary.LittleEndian.PutUint16(dst[i*2:], uint16(index))
+		default:
+			binary.LittleEndian.PutUint32(dst[i*4:], index)
+		}
+	}
+}
+
+// decodeMeshoptBufferViews decompresses every buffer view using the
+// EXT_meshopt_compression extension into the buffer it references, so the
+// rest of the document can be read as if it was never compressed
+func decodeMeshoptBufferViews(doc *Gltf, buffers [][]byte) error {
+	for viewIndex, view := range doc.BufferViews {
+		rawExt, ok := view.Extensions[extMeshoptCompressionID]
+		if !ok {
+			continue
+		}
+
+		// Round trip through JSON to read the extension into its structure
+		data, err := json.Marshal(rawExt)
+		if err != nil {
+			return fmt.Errorf("buffer view %d: %w", viewIndex, err)
+		}
+		ext := ExtMeshoptCompression{}
+		if err := json.Unmarshal(data, &ext); err != nil {
+			return fmt.Errorf("buffer view %d: unable to parse %s extension: %w", viewIndex, extMeshoptCompressionID, err)
+		}
+
+		if ext.Buffer < 0 || ext.Buffer >= len(buffers) || ext.ByteOffset+ext.ByteLength > len(buffers[ext.Buffer]) {
+			return fmt.Errorf("buffer view %d: compressed data out of range of buffer %d", viewIndex, ext.Buffer)
+		}
+		src := buffers[ext.Buffer][ext.ByteOffset : ext.ByteOffset+ext.ByteLength]
+
+		size := ext.Count * ext.ByteStride
+		if view.Buffer < 0 || view.Buffer >= len(buffers) || view.ByteLength < size || view.ByteOffset+size > len(buffers[view.Buffer]) {
+			return fmt.Errorf("buffer view %d: decompressed data out of range of buffer %d", viewIndex, view.Buffer)
+		}
+		dst := buffers[view.Buffer][view.ByteOffset : view.ByteOffset+size]
+
+		switch ext.Mode {
+		case MeshoptCompressionMode_ATTRIBUTES:
+			err = meshoptDecodeVertexBuffer(dst, ext.Count, ext.ByteStride, src)
+
+		case MeshoptCompressionMode_TRIANGLES, MeshoptCompressionMode_INDICES:
+			if ext.ByteStride != 2 && ext.ByteStride != 4 {
+				return fmt.Errorf("buffer view %d: invalid index stride %d", viewIndex, ext.ByteStride)
+			}
+
+			indices := make([]uint32, ext.Count)
+			if ext.Mode == MeshoptCompressionMode_TRIANGLES {
+				err = meshoptDecodeIndexBuffer(indices, src)
+			} else {
+				err = meshoptDecodeIndexSequence(indices, src)
+			}
+			writeIndices(dst, indices, ext.ByteStride)
+
+		default:
+			return fmt.Errorf("buffer view %d: unrecognized compression mode %q", viewIndex, ext.Mode)
+		}
+		if err != nil {
+			return fmt.Errorf("buffer view %d: %w", viewIndex, err)
+		}
+
+		switch ext.Filter {
+		case "", MeshoptCompressionFilter_NONE:
+
+		case MeshoptCompressionFilter_OCTAHEDRAL:
+			err = meshoptDecodeFilterOct(dst, ext.Count, ext.ByteStride)
+
+		case MeshoptCompressionFilter_QUATERNION:
+			err = meshoptDecodeFilterQuat(dst, ext.Count, ext.ByteStride)
+
+		case MeshoptCompressionFilter_EXPONENTIAL:
+			err = meshoptDecodeFilterExp(dst, ext.Count, ext.ByteStride)
+
+		default:
+			return fmt.Errorf("buffer view %d: unrecognized compression filter %q", viewIndex, ext.Filter)
+		}
+		if err != nil {
+			return fmt.Errorf("buffer view %d: %w", viewIndex, err)
+		}
+	}
+	return nil
+}
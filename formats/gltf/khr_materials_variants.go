@@ -0,0 +1,198 @@
+package gltf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const khrMaterialsVariantsID = "KHR_materials_variants"
+
+// https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Khronos/KHR_materials_variants
+type KHR_MaterialsVariants struct {
+	Variants []KHR_MaterialsVariantsVariant `json:"variants"`
+}
+
+type KHR_MaterialsVariantsVariant struct {
+	Name string `json:"name"`
+}
+
+// Material mappings of a primitive, found within the primitive's extensions
+type KHR_MaterialsVariantsPrimitive struct {
+	Mappings []KHR_MaterialsVariantsMapping `json:"mappings"`
+}
+
+type KHR_MaterialsVariantsMapping struct {
+	Variants []GltfId `json:"variants"`       // Indices of the variants within the root level extension
+	Material GltfId   `json:"material"`       // The material to render the primitive with when one of the variants is active
+	Name     string   `json:"name,omitempty"` // The user-defined name of the mapping
+}
+
+// PolyformMaterialVariant is the material a model is rendered with when the
+// named variant is active
+type PolyformMaterialVariant struct {
+	Variant  string
+	Material *PolyformMaterial
+}
+
+// addMaterialVariant registers the variant within the document, returning
+// its index
+func (w *Writer) addMaterialVariant(name string) GltfId {
+	if index, ok := w.materialVariantIndices[name]; ok {
+		return index
+	}
+
+	index := len(w.materialVariants)
+	w.materialVariants = append(w.materialVariants, name)
+	w.materialVariantIndices[name] = index
+	w.extensionsUsed[khrMaterialsVariantsID] = true
+	return index
+}
+
+// materialVariantMappings writes the materials of each variant of the model,
+// grouping the variants that share a material into a single mapping
+func (w *Writer) materialVariantMappings(model *PolyformModel) ([]KHR_MaterialsVariantsMapping, error) {
+	if len(model.MaterialVariants) == 0 {
+		return nil, nil
+	}
+
+	mappings := make([]KHR_MaterialsVariantsMapping, 0, len(model.MaterialVariants))
+	mappingIndices := make(map[GltfId]int)
+	seen := make(map[string]bool)
+
+	for _, variant := range model.MaterialVariants {
+		if variant.Variant == "" {
+			return nil, fmt.Errorf("model %q has a material variant without a name", model.Name)
+		}
+
+		if variant.Material == nil {
+			return nil, fmt.Errorf("model %q material variant %q has a nil material", model.Name, variant.Variant)
+		}
+
+		if seen[variant.Variant] {
+			return nil, fmt.Errorf("model %q defines material variant %q more than once", model.Name, variant.Variant)
+		}
+		seen[variant.Variant] = true
+
+		matIndex, err := w.AddMaterial(variant.Material)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add material %q of variant %q from model %q: %w",
+				variant.Material.Name, variant.Variant, model.Name, err)
+		}
+
+		variantIndex := w.addMaterialVariant(variant.Variant)
+		if i, ok := mappingIndices[*matIndex]; ok {
+			mappings[i].Variants = append(mappings[i].Variants, variantIndex)
+			continue
+		}
+
+		mappingIndices[*matIndex] = len(mappings)
+		mappings = append(mappings, KHR_MaterialsVariantsMapping{
+			Variants: []GltfId{variantIndex},
+			Material: *matIndex,
+		})
+	}
+
+	return mappings, nil
+}
+
+// materialVariantsKey identifies a set of mappings by its (material, variant)
+// pairs, regardless of the order they were defined in, so meshes with the
+// same mappings can be deduplicated
+func materialVariantsKey(mappings []KHR_MaterialsVariantsMapping) string {
+	pairs := make([][2]GltfId, 0, len(mappings))
+	for _, mapping := range mappings {
+		for _, variant := range mapping.Variants {
+			pairs = append(pairs, [2]GltfId{mapping.Material, variant})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	key := strings.Builder{}
+	for _, pair := range pairs {
+		fmt.Fprintf(&key, "%d:%d;", pair[0], pair[1])
+	}
+	return key.String()
+}
+
+// decodeMaterialVariantNames reads the names of every variant defined at the
+// root of the document
+func decodeMaterialVariantNames(doc *Gltf) ([]string, error) {
+	ext, ok := doc.Extensions[khrMaterialsVariantsID].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	variants, ok := ext["variants"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s extension is missing variants", khrMaterialsVariantsID)
+	}
+
+	names := make([]string, len(variants))
+	for i, rawVariant := range variants {
+		variant, ok := rawVariant.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s variant %d is not an object", khrMaterialsVariantsID, i)
+		}
+		names[i], _ = variant["name"].(string)
+	}
+	return names, nil
+}
+
+// decodeMaterialVariants builds the material of every variant mapped by the
+// primitive
+func decodeMaterialVariants(doc *Gltf, buffers [][]byte, p Primitive, opts ReaderOptions, imgCache imgReaderCache) ([]PolyformMaterialVariant, error) {
+	ext, ok := p.Extensions[khrMaterialsVariantsID].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	variantNames, err := decodeMaterialVariantNames(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, ok := ext["mappings"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s extension is missing mappings", khrMaterialsVariantsID)
+	}
+
+	variants := make([]PolyformMaterialVariant, 0, len(mappings))
+	for i, rawMapping := range mappings {
+		mapping, ok := rawMapping.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s mapping %d is not an object", khrMaterialsVariantsID, i)
+		}
+
+		materialId, ok := mapping["material"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s mapping %d is missing a material", khrMaterialsVariantsID, i)
+		}
+
+		material, err := loadMaterial(doc, int(materialId), opts, buffers, imgCache)
+		if err != nil {
+			return nil, fmt.Errorf("%s mapping %d: %w", khrMaterialsVariantsID, i, err)
+		}
+
+		variantIds, _ := mapping["variants"].([]any)
+		for _, rawId := range variantIds {
+			id, ok := rawId.(float64)
+			if !ok || id < 0 || int(id) >= len(variantNames) {
+				return nil, fmt.Errorf("%s mapping %d references invalid variant %v", khrMaterialsVariantsID, i, rawId)
+			}
+
+			variants = append(variants, PolyformMaterialVariant{
+				Variant:  variantNames[int(id)],
+				Material: material,
+			})
+		}
+	}
+
+	return variants, nil
+}
@@ -0,0 +1,201 @@
+package gltf_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func variantTestMesh() modeling.Mesh {
+	return modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+		})
+}
+
+func colourway(name string, c color.Color) *gltf.PolyformMaterial {
+	return &gltf.PolyformMaterial{
+		Name: name,
+		PbrMetallicRoughness: &gltf.PolyformPbrMetallicRoughness{
+			BaseColorFactor: c,
+		},
+	}
+}
+
+func TestMaterialVariants_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := variantTestMesh()
+	red := colourway("red", color.RGBA{R: 255, A: 255})
+	blue := colourway("blue", color.RGBA{B: 255, A: 255})
+
+	scene := gltf.PolyformScene{
+		MaterialVariants: []string{"Midnight", "Crimson", "Unused"},
+		Models: []*gltf.PolyformModel{
+			{
+				Name:     "chair",
+				Mesh:     &mesh,
+				Material: red,
+				MaterialVariants: []gltf.PolyformMaterialVariant{
+					{Variant: "Crimson", Material: red},
+					{Variant: "Midnight", Material: blue},
+					{Variant: "Navy", Material: blue},
+				},
+			},
+		},
+	}
+
+	// ACT ====================================================================
+	doc, decoded := roundTrip(t, scene, nil)
+
+	// ASSERT =================================================================
+	assert.Contains(t, doc.ExtensionsUsed, "KHR_materials_variants")
+	assert.NotContains(t, doc.ExtensionsRequired, "KHR_materials_variants")
+	assert.Len(t, doc.Materials, 2)
+	require.Len(t, doc.Meshes, 1)
+
+	// Variants sharing a material are grouped into one mapping
+	mappings := doc.Meshes[0].Primitives[0].Extensions["KHR_materials_variants"].(map[string]any)["mappings"].([]any)
+	assert.Len(t, mappings, 2)
+
+	assert.Equal(t, []string{"Midnight", "Crimson", "Unused", "Navy"}, decoded.MaterialVariants)
+
+	require.Len(t, decoded.Models, 1)
+	chair := decoded.Models[0]
+	require.NotNil(t, chair.Material)
+	assert.Equal(t, "red", chair.Material.Name)
+
+	require.Len(t, chair.MaterialVariants, 3)
+	materials := make(map[string]string)
+	for _, variant := range chair.MaterialVariants {
+		require.NotNil(t, variant.Material)
+		materials[variant.Variant] = variant.Material.Name
+	}
+	assert.Equal(t, map[string]string{
+		"Crimson":  "red",
+		"Midnight": "blue",
+		"Navy":     "blue",
+	}, materials)
+}
+
+func TestMaterialVariants_SharedMeshKeepsSeparateMappings(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := variantTestMesh()
+	red := colourway("red", color.RGBA{R: 255, A: 255})
+	blue := colourway("blue", color.RGBA{B: 255, A: 255})
+
+	scene := gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{
+			{
+				Name:             "a",
+				Mesh:             &mesh,
+				Material:         red,
+				MaterialVariants: []gltf.PolyformMaterialVariant{{Variant: "Alt", Material: blue}},
+			},
+			{
+				Name:     "b",
+				Mesh:     &mesh,
+				Material: red,
+			},
+		},
+	}
+
+	// ACT ====================================================================
+	doc, decoded := roundTrip(t, scene, nil)
+
+	// ASSERT =================================================================
+	assert.Len(t, doc.Meshes, 2)
+	assert.Len(t, doc.Accessors, 2, "geometry should still be shared")
+
+	require.Len(t, decoded.Models, 2)
+	assert.Len(t, decoded.Models[0].MaterialVariants, 1)
+	assert.Empty(t, decoded.Models[1].MaterialVariants)
+}
+
+func TestMaterialVariants_SharedMeshWithSameMappings(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := variantTestMesh()
+	red := colourway("red", color.RGBA{R: 255, A: 255})
+	blue := colourway("blue", color.RGBA{B: 255, A: 255})
+
+	scene := gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{
+			{
+				Name:     "a",
+				Mesh:     &mesh,
+				Material: red,
+				MaterialVariants: []gltf.PolyformMaterialVariant{
+					{Variant: "Alt", Material: blue},
+					{Variant: "Base", Material: red},
+				},
+			},
+			{
+				Name:     "b",
+				Mesh:     &mesh,
+				Material: red,
+				MaterialVariants: []gltf.PolyformMaterialVariant{
+					{Variant: "Base", Material: red},
+					{Variant: "Alt", Material: blue},
+				},
+			},
+		},
+	}
+
+	// ACT ====================================================================
+	doc, decoded := roundTrip(t, scene, nil)
+
+	// ASSERT =================================================================
+	assert.Len(t, doc.Meshes, 1, "mappings defined in a different order are the same")
+	require.Len(t, decoded.Models, 2)
+	assert.Len(t, decoded.Models[1].MaterialVariants, 2)
+}
+
+func TestMaterialVariants_Invalid(t *testing.T) {
+	mesh := variantTestMesh()
+	material := colourway("red", color.RGBA{R: 255, A: 255})
+
+	tests := map[string]gltf.PolyformScene{
+		"unnamed variant": {
+			Models: []*gltf.PolyformModel{{
+				Mesh:             &mesh,
+				MaterialVariants: []gltf.PolyformMaterialVariant{{Material: material}},
+			}},
+		},
+		"nil material": {
+			Models: []*gltf.PolyformModel{{
+				Mesh:             &mesh,
+				MaterialVariants: []gltf.PolyformMaterialVariant{{Variant: "A"}},
+			}},
+		},
+		"duplicate variant": {
+			Models: []*gltf.PolyformModel{{
+				Mesh: &mesh,
+				MaterialVariants: []gltf.PolyformMaterialVariant{
+					{Variant: "A", Material: material},
+					{Variant: "A", Material: material},
+				},
+			}},
+		},
+		"no mesh": {
+			Models: []*gltf.PolyformModel{{
+				MaterialVariants: []gltf.PolyformMaterialVariant{{Variant: "A", Material: material}},
+			}},
+		},
+		"unnamed scene variant": {
+			MaterialVariants: []string{""},
+		},
+	}
+
+	for name, scene := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := gltf.NewWriterFromScene(scene)
+			assert.Error(t, err)
+		})
+	}
+}
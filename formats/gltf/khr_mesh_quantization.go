@@ -0,0 +1,239 @@
+package gltf
+
+import (
+	"math"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Khronos/KHR_mesh_quantization
+const khrMeshQuantizationID = "KHR_mesh_quantization"
+
+// canQuantizePositions reports whether the positions of the mesh can be
+// quantized. Skinned meshes ignore the transform of the node referencing
+// them, and animated nodes would overwrite the folded in dequantization, so
+// both keep their positions as floats.
+func (w *Writer) canQuantizePositions(m *modeling.Mesh) bool {
+	return m.HasFloat3Attribute(modeling.PositionAttribute) &&
+		!m.HasFloat4Attribute(modeling.JointAttribute) &&
+		!w.animatedMeshes[m]
+}
+
+// positionDequantization builds the transform taking positions quantized to
+// the [-1, 1] cube back to their original bounds. The scale is kept uniform
+// so normals are unaffected.
+func positionDequantization(data *iter.ArrayIterator[vector3.Float64]) trs.TRS {
+	min := vector3.Fill(math.MaxFloat64)
+	max := vector3.Fill(-math.MaxFloat64)
+	for i := range data.Len() {
+		v := data.At(i)
+		if v.ContainsNaN() {
+			continue
+		}
+		min = vector3.Min(min, v)
+		max = vector3.Max(max, v)
+	}
+
+	if data.Len() == 0 || min.X() > max.X() {
+		return trs.Identity()
+	}
+
+	center := min.Midpoint(max)
+	extent := max.Sub(min).Scale(0.5).MaxComponent()
+	if extent <= 0 {
+		extent = 1
+	}
+
+	return trs.New(center, quaternion.Identity(), vector3.Fill(extent))
+}
+
+func quantizeSigned(v float64, bits int) int {
+	limit := float64(int(1)<<(bits-1) - 1)
+	return int(math.Round(math.Max(-1, math.Min(1, v)) * limit))
+}
+
+func quantizeUnsigned(v float64, bits int) int {
+	limit := float64(int(1)<<bits - 1)
+	return int(math.Round(math.Max(0, math.Min(1, v)) * limit))
+}
+
+// writeQuantized writes an accessor of normalized integer components. Each
+// element is padded to a multiple of 4 bytes, as required of vertex
+// attributes.
+func (w *Writer) writeQuantized(accessorType AccessorType, componentType AccessorComponentType, count int, element func(i int) []int) int {
+	w.Align(4)
+
+	components := accessorType.componentCount()
+	elementSize := components * componentType.Size()
+	stride := (elementSize + 3) &^ 3
+
+	var min, max []float64
+	for i := range count {
+		v := element(i)
+		for _, c := range v {
+			switch componentType {
+			case AccessorComponentType_BYTE, AccessorComponentType_UNSIGNED_BYTE:
+				w.bitW.Byte(byte(c))
+			default:
+				w.bitW.UInt16(uint16(c))
+			}
+		}
+		for range stride - elementSize {
+			w.bitW.Byte(0)
+		}
+
+		if min == nil {
+			min = make([]float64, components)
+			max = make([]float64, components)
+			for c := range v {
+				min[c] = float64(v[c])
+				max[c] = float64(v[c])
+			}
+			continue
+		}
+
+		for c := range v {
+			min[c] = math.Min(min[c], float64(v[c]))
+			max[c] = math.Max(max[c], float64(v[c]))
+		}
+	}
+
+	accessorIndex := len(w.accessors)
+	w.accessors = append(w.accessors, Accessor{
+		BufferView:    ptrI(len(w.bufferViews)),
+		ComponentType: componentType,
+		Normalized:    true,
+		Type:          accessorType,
+		Count:         count,
+		Min:           min,
+		Max:           max,
+	})
+
+	view := BufferView{
+		Buffer:     0,
+		ByteOffset: w.bytesWritten,
+		ByteLength: count * stride,
+		Target:     ARRAY_BUFFER,
+	}
+	if stride != elementSize {
+		view.ByteStride = ptrI(stride)
+	}
+	w.bufferViews = append(w.bufferViews, view)
+
+	w.bytesWritten += count * stride
+	w.extensionsUsed[khrMeshQuantizationID] = true
+	w.extensionsRequired[khrMeshQuantizationID] = true
+	return accessorIndex
+}
+
+// writeQuantizedPositions writes positions as normalized 16 bit integers,
+// mapped into the [-1, 1] range by the inverse of dequantize
+func (w *Writer) writeQuantizedPositions(data *iter.ArrayIterator[vector3.Float64], dequantize trs.TRS) int {
+	center := dequantize.Position()
+	scale := 1. / dequantize.Scale().X()
+	return w.writeQuantized(AccessorType_VEC3, AccessorComponentType_SHORT, data.Len(), func(i int) []int {
+		v := data.At(i).Sub(center).Scale(scale)
+		return []int{quantizeSigned(v.X(), 16), quantizeSigned(v.Y(), 16), quantizeSigned(v.Z(), 16)}
+	})
+}
+
+// writeQuantizedNormals writes unit vectors as normalized 8 bit integers
+func (w *Writer) writeQuantizedNormals(data *iter.ArrayIterator[vector3.Float64]) int {
+	return w.writeQuantized(AccessorType_VEC3, AccessorComponentType_BYTE, data.Len(), func(i int) []int {
+		v := data.At(i)
+		return []int{quantizeSigned(v.X(), 8), quantizeSigned(v.Y(), 8), quantizeSigned(v.Z(), 8)}
+	})
+}
+
+// writeQuantizedTexCoords writes texture coordinates as normalized unsigned
+// 16 bit integers. Texture coordinates outside of [0, 1] can't be
+// represented, in which case nothing is written and false is returned.
+func (w *Writer) writeQuantizedTexCoords(data *iter.ArrayIterator[vector2.Float64]) (int, bool) {
+	for i := range data.Len() {
+		v := data.At(i)
+		if v.X() < 0 || v.X() > 1 || v.Y() < 0 || v.Y() > 1 || v.ContainsNaN() {
+			return -1, false
+		}
+	}
+
+	return w.writeQuantized(AccessorType_VEC2, AccessorComponentType_UNSIGNED_SHORT, data.Len(), func(i int) []int {
+		v := data.At(i)
+		return []int{quantizeUnsigned(v.X(), 16), quantizeUnsigned(v.Y(), 16)}
+	}), true
+}
+
+// setNodeTransform replaces the transform of the node, leaving out any
+// component that's already the identity
+func setNodeTransform(node *Node, transform trs.TRS) {
+	node.Matrix = nil
+	node.Translation = nil
+	node.Rotation = nil
+	node.Scale = nil
+
+	if transform.Position() != vector3.Zero[float64]() {
+		translation := transform.Position().ToFixedArr()
+		node.Translation = &translation
+	}
+
+	if transform.Scale() != vector3.One[float64]() {
+		scale := transform.Scale().ToFixedArr()
+		node.Scale = &scale
+	}
+
+	if transform.Rotation() != quaternion.Identity() {
+		rotation := transform.Rotation().ToArr()
+		node.Rotation = &rotation
+	}
+}
+
+// dequantizeNode folds the dequantization of the node's mesh into the node's
+// transform, counteracting it on the node's children so they remain in place
+func (w *Writer) dequantizeNode(node *Node) {
+	if node.Mesh == nil {
+		return
+	}
+
+	dequantize, ok := w.meshDequantization[*node.Mesh]
+	if !ok {
+		return
+	}
+
+	setNodeTransform(node, nodeTransform(*node).Multiply(dequantize))
+
+	inverse := trs.New(
+		dequantize.Position().Scale(-1/dequantize.Scale().X()),
+		quaternion.Identity(),
+		vector3.Fill(1/dequantize.Scale().X()),
+	)
+	for _, child := range node.Children {
+		setNodeTransform(&w.nodes[child], inverse.Multiply(nodeTransform(w.nodes[child])))
+	}
+}
+
+// dequantizeInstances applies the dequantization of the mesh to each
+// instance's transform, as instances are placed relative to the node rather
+// than the mesh
+func (w *Writer) dequantizeInstances(mesh *GltfId, positions, scales []vector3.Float64, rotations []vector4.Float64) {
+	if mesh == nil {
+		return
+	}
+
+	dequantize, ok := w.meshDequantization[*mesh]
+	if !ok {
+		return
+	}
+
+	for i := range positions {
+		rotation := quaternion.New(rotations[i].XYZ(), rotations[i].W())
+		instance := trs.New(positions[i], rotation, scales[i]).Multiply(dequantize)
+		positions[i] = instance.Position()
+		rotations[i] = instance.Rotation().Vector4()
+		scales[i] = instance.Scale()
+	}
+}
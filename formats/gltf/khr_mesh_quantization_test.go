@@ -0,0 +1,145 @@
+package gltf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func quantizationTestMesh(offset vector3.Float64) modeling.Mesh {
+	return modeling.NewTriangleMesh([]int{0, 1, 2, 2, 1, 3}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.).Add(offset),
+			vector3.New(0., 2., 0.).Add(offset),
+			vector3.New(4., 0., 1.).Add(offset),
+			vector3.New(4., 2., 1.).Add(offset),
+		}).
+		SetFloat3Attribute(modeling.NormalAttribute, []vector3.Float64{
+			vector3.New(0., 0., 1.),
+			vector3.New(0., 0., 1.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+		}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.),
+			vector2.New(0., 1.),
+			vector2.New(1., 0.),
+			vector2.New(0.25, 0.75),
+		})
+}
+
+func TestWriteBinary_MeshQuantizationAndMeshoptCompression(t *testing.T) {
+	// ARRANGE ================================================================
+	parentMesh := quantizationTestMesh(vector3.New(10., -5., 3.))
+	childMesh := quantizationTestMesh(vector3.New(-1., 1., -1.))
+
+	parentTRS := trs.New(vector3.New(1., 2., 3.), quaternion.FromTheta(0.5, vector3.Up[float64]()), vector3.Fill(2.))
+	childTRS := trs.Position(vector3.New(0., 3., 0.))
+
+	scene := gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{{
+			Name: "parent",
+			Mesh: &parentMesh,
+			TRS:  &parentTRS,
+			Children: []*gltf.PolyformModel{{
+				Name: "child",
+				Mesh: &childMesh,
+				TRS:  &childTRS,
+			}},
+		}},
+	}
+
+	// ACT ====================================================================
+	buf := bytes.Buffer{}
+	err := gltf.WriteBinary(scene, &buf, &gltf.WriterOptions{
+		MeshQuantization:   true,
+		MeshoptCompression: true,
+	})
+	require.NoError(t, err)
+
+	doc, buffers, err := gltf.LoadGLB(bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+
+	decoded, err := gltf.DecodeScene(doc, buffers, nil)
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	assert.Contains(t, doc.ExtensionsUsed, "KHR_mesh_quantization")
+	assert.Contains(t, doc.ExtensionsUsed, "EXT_meshopt_compression")
+	assert.Contains(t, doc.ExtensionsRequired, "KHR_mesh_quantization")
+	assert.Contains(t, doc.ExtensionsRequired, "EXT_meshopt_compression")
+
+	positions := doc.Accessors[doc.Meshes[0].Primitives[0].Attributes[gltf.POSITION]]
+	assert.Equal(t, gltf.AccessorComponentType_SHORT, positions.ComponentType)
+	assert.True(t, positions.Normalized)
+
+	normals := doc.Accessors[doc.Meshes[0].Primitives[0].Attributes[gltf.NORMAL]]
+	assert.Equal(t, gltf.AccessorComponentType_BYTE, normals.ComponentType)
+
+	texCoords := doc.Accessors[doc.Meshes[0].Primitives[0].Attributes[gltf.TEXCOORD_0]]
+	assert.Equal(t, gltf.AccessorComponentType_UNSIGNED_SHORT, texCoords.ComponentType)
+
+	require.Len(t, decoded.Models, 1)
+	parent := decoded.Models[0]
+	require.Len(t, parent.Children, 1)
+	child := parent.Children[0]
+
+	assertWorldPositions := func(expected modeling.Mesh, actual modeling.Mesh, world trs.TRS, original trs.TRS) {
+		expectedPositions := expected.Float3Attribute(modeling.PositionAttribute)
+		actualPositions := actual.Float3Attribute(modeling.PositionAttribute)
+		require.Equal(t, expectedPositions.Len(), actualPositions.Len())
+		for i := range expectedPositions.Len() {
+			e := original.Transform(expectedPositions.At(i))
+			a := world.Transform(actualPositions.At(i))
+			assert.InDelta(t, e.X(), a.X(), 1e-3)
+			assert.InDelta(t, e.Y(), a.Y(), 1e-3)
+			assert.InDelta(t, e.Z(), a.Z(), 1e-3)
+		}
+
+		expectedUVs := expected.Float2Attribute(modeling.TexCoordAttribute)
+		actualUVs := actual.Float2Attribute(modeling.TexCoordAttribute)
+		for i := range expectedUVs.Len() {
+			assert.InDelta(t, expectedUVs.At(i).X(), actualUVs.At(i).X(), 1e-4)
+			assert.InDelta(t, expectedUVs.At(i).Y(), actualUVs.At(i).Y(), 1e-4)
+		}
+	}
+
+	assertWorldPositions(parentMesh, *parent.Mesh, *parent.TRS, parentTRS)
+	assertWorldPositions(childMesh, *child.Mesh, parent.TRS.Multiply(*child.TRS), parentTRS.Multiply(childTRS))
+}
+
+func TestWriteBinary_MeshQuantization_TexCoordsOutOfRange(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := quantizationTestMesh(vector3.Zero[float64]()).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.),
+			vector2.New(0., 2.),
+			vector2.New(2., 0.),
+			vector2.New(2., 2.),
+		})
+
+	// ACT ====================================================================
+	buf := bytes.Buffer{}
+	err := gltf.WriteBinary(
+		gltf.PolyformScene{Models: []*gltf.PolyformModel{{Mesh: &mesh}}},
+		&buf,
+		&gltf.WriterOptions{MeshQuantization: true},
+	)
+	require.NoError(t, err)
+
+	doc, _, err := gltf.LoadGLB(bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	texCoords := doc.Accessors[doc.Meshes[0].Primitives[0].Attributes[gltf.TEXCOORD_0]]
+	assert.Equal(t, gltf.AccessorComponentType_FLOAT, texCoords.ComponentType)
+	assert.NotContains(t, doc.ExtensionsUsed, "EXT_meshopt_compression")
+}
@@ -0,0 +1,921 @@
+package gltf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Pure Go implementation of the meshoptimizer bitstream formats used by the
+// EXT_meshopt_compression extension.
+// https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Vendor/EXT_meshopt_compression
+
+const (
+	meshoptVertexHeader   = 0xa0
+	meshoptIndexHeader    = 0xe0
+	meshoptSequenceHeader = 0xd0
+
+	meshoptVertexBlockSizeBytes = 8192
+	meshoptVertexBlockMaxSize   = 256
+	meshoptByteGroupSize        = 16
+	meshoptByteGroupDecodeLimit = 24
+	meshoptTailMaxSize          = 32
+)
+
+var errMeshoptTruncated = errors.New("meshopt: unexpected end of compressed data")
+
+// ============================================================================
+// Vertex codec
+// ============================================================================
+
+func meshoptVertexBlockSize(vertexSize int) int {
+	result := meshoptVertexBlockSizeBytes / vertexSize
+	result &= ^(meshoptByteGroupSize - 1)
+	return min(result, meshoptVertexBlockMaxSize)
+}
+
+func zigzag8(v byte) byte {
+	return byte(int8(v)>>7) ^ (v << 1)
+}
+
+func unzigzag8(v byte) byte {
+	return -(v & 1) ^ (v >> 1)
+}
+
+func meshoptEncodeBytesGroupMeasure(buffer []byte, bits int) int {
+	switch bits {
+	case 0:
+		for _, v := range buffer[:meshoptByteGroupSize] {
+			if v != 0 {
+				return math.MaxInt
+			}
+		}
+		return 0
+
+	case 8:
+		return meshoptByteGroupSize
+	}
+
+	sentinel := byte(1<<bits) - 1
+	result := meshoptByteGroupSize * bits / 8
+	for _, v := range buffer[:meshoptByteGroupSize] {
+		if v >= sentinel {
+			result++
+		}
+	}
+	return result
+}
+
+func meshoptEncodeBytesGroup(out []byte, buffer []byte, bits int) []byte {
+	switch bits {
+	case 0:
+		return out
+
+	case 8:
+		return append(out, buffer[:meshoptByteGroupSize]...)
+	}
+
+	// Values are packed most significant bits first, with any value that
+	// doesn't fit in the packed bits escaped by the sentinel and stored as a
+	// full byte after the group
+	sentinel := byte(1<<bits) - 1
+	perByte := 8 / bits
+	for i := 0; i < meshoptByteGroupSize; i += perByte {
+		var packed byte
+		for k := range perByte {
+			packed = (packed << bits) | min(buffer[i+k], sentinel)
+		}
+		out = append(out, packed)
+	}
+
+	for _, v := range buffer[:meshoptByteGroupSize] {
+		if v >= sentinel {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func meshoptEncodeBytes(out []byte, buffer []byte) []byte {
+	groups := len(buffer) / meshoptByteGroupSize
+
+	headerStart := len(out)
+	out = append(out, make([]byte, (groups+3)/4)...)
+
+	for i := 0; i < len(buffer); i += meshoptByteGroupSize {
+		group := buffer[i:]
+
+		// Pick whichever of 0, 2, 4 or 8 bits per value is smallest
+		var bitslog2 byte
+		bestSize := math.MaxInt
+		for candidate := range byte(4) {
+			bits := 0
+			if candidate > 0 {
+				bits = 1 << candidate
+			}
+			if size := meshoptEncodeBytesGroupMeasure(group, bits); size < bestSize {
+				bitslog2 = candidate
+				bestSize = size
+			}
+		}
+
+		headerOffset := i / meshoptByteGroupSize
+		out[headerStart+headerOffset/4] |= bitslog2 << ((headerOffset % 4) * 2)
+
+		bits := 0
+		if bitslog2 > 0 {
+			bits = 1 << bitslog2
+		}
+		out = meshoptEncodeBytesGroup(out, group, bits)
+	}
+
+	return out
+}
+
+// meshoptEncodeVertexBuffer compresses count elements of stride bytes using
+// the meshoptimizer vertex codec. The stride must be a multiple of 4 and no
+// larger than 256.
+func meshoptEncodeVertexBuffer(data []byte, count, stride int) []byte {
+	out := []byte{meshoptVertexHeader}
+
+	firstVertex := make([]byte, stride)
+	if count > 0 {
+		copy(firstVertex, data[:stride])
+	}
+	lastVertex := append([]byte{}, firstVertex...)
+
+	blockSize := meshoptVertexBlockSize(stride)
+	buffer := make([]byte, meshoptVertexBlockMaxSize)
+
+	for offset := 0; offset < count; offset += blockSize {
+		blockCount := min(blockSize, count-offset)
+		alignedCount := (blockCount + meshoptByteGroupSize - 1) &^ (meshoptByteGroupSize - 1)
+		block := data[offset*stride:]
+
+		for k := range stride {
+			clear(buffer)
+			p := lastVertex[k]
+			for i := range blockCount {
+				v := block[i*stride+k]
+				buffer[i] = zigzag8(v - p)
+				p = v
+			}
+			out = meshoptEncodeBytes(out, buffer[:alignedCount])
+		}
+
+		copy(lastVertex, block[(blockCount-1)*stride:blockCount*stride])
+	}
+
+	// The first vertex is written to the end of the stream, padded to 32
+	// bytes, which keeps the decoder from needing to bounds check every group
+	if stride < meshoptTailMaxSize {
+		out = append(out, make([]byte, meshoptTailMaxSize-stride)...)
+	}
+	return append(out, firstVertex...)
+}
+
+func meshoptDecodeBytesGroup(data []byte, buffer []byte, bitslog2 byte) []byte {
+	switch bitslog2 {
+	case 0:
+		clear(buffer[:meshoptByteGroupSize])
+		return data
+
+	case 3:
+		copy(buffer, data[:meshoptByteGroupSize])
+		return data[meshoptByteGroupSize:]
+	}
+
+	bits := 2
+	if bitslog2 == 2 {
+		bits = 4
+	}
+	sentinel := byte(1<<bits) - 1
+	perByte := 8 / bits
+	packedSize := meshoptByteGroupSize / perByte
+
+	escaped := data[packedSize:]
+	for i := range meshoptByteGroupSize {
+		packed := data[i/perByte]
+		shift := 8 - bits*(i%perByte+1)
+		v := (packed >> shift) & sentinel
+		if v == sentinel {
+			v = escaped[0]
+			escaped = escaped[1:]
+		}
+		buffer[i] = v
+	}
+	return escaped
+}
+
+func meshoptDecodeBytes(data []byte, buffer []byte) ([]byte, error) {
+	groups := len(buffer) / meshoptByteGroupSize
+	headerSize := (groups + 3) / 4
+	if len(data) < headerSize {
+		return nil, errMeshoptTruncated
+	}
+
+	header := data[:headerSize]
+	data = data[headerSize:]
+
+	for i := 0; i < len(buffer); i += meshoptByteGroupSize {
+		if len(data) < meshoptByteGroupDecodeLimit {
+			return nil, errMeshoptTruncated
+		}
+
+		headerOffset := i / meshoptByteGroupSize
+		bitslog2 := (header[headerOffset/4] >> ((headerOffset % 4) * 2)) & 3
+		data = meshoptDecodeBytesGroup(data, buffer[i:], bitslog2)
+	}
+	return data, nil
+}
+
+// meshoptDecodeVertexBuffer decompresses count elements of stride bytes,
+// written with the meshoptimizer vertex codec, into dst
+func meshoptDecodeVertexBuffer(dst []byte, count, stride int, src []byte) error {
+	if stride <= 0 || stride > 256 || stride%4 != 0 {
+		return fmt.Errorf("meshopt: invalid vertex stride %d", stride)
+	}
+
+	if len(dst) < count*stride {
+		return fmt.Errorf("meshopt: destination too small for %d vertices", count)
+	}
+
+	if len(src) < 1+stride {
+		return errMeshoptTruncated
+	}
+
+	if src[0]&0xf0 != meshoptVertexHeader {
+		return fmt.Errorf("meshopt: unrecognized vertex header 0x%x", src[0])
+	}
+
+	if version := src[0] & 0x0f; version > 0 {
+		return fmt.Errorf("meshopt: unsupported vertex codec version %d", version)
+	}
+
+	lastVertex := append([]byte{}, src[len(src)-stride:]...)
+	data := src[1:]
+
+	blockSize := meshoptVertexBlockSize(stride)
+	buffer := make([]byte, meshoptVertexBlockMaxSize)
+
+	for offset := 0; offset < count; offset += blockSize {
+		blockCount := min(blockSize, count-offset)
+		alignedCount := (blockCount + meshoptByteGroupSize - 1) &^ (meshoptByteGroupSize - 1)
+		block := dst[offset*stride:]
+
+		for k := range stride {
+			var err error
+			data, err = meshoptDecodeBytes(data, buffer[:alignedCount])
+			if err != nil {
+				return err
+			}
+
+			p := lastVertex[k]
+			for i := range blockCount {
+				v := unzigzag8(buffer[i]) + p
+				block[i*stride+k] = v
+				p = v
+			}
+		}
+
+		copy(lastVertex, block[(blockCount-1)*stride:blockCount*stride])
+	}
+
+	tailSize := max(stride, meshoptTailMaxSize)
+	if len(data) != tailSize {
+		return errors.New("meshopt: unexpected data after compressed vertices")
+	}
+	return nil
+}
+
+// ============================================================================
+// Index codecs
+// ============================================================================
+
+func meshoptEncodeVByte(out []byte, v uint32) []byte {
+	for v >= 128 {
+		out = append(out, byte(v&127)|128)
+		v >>= 7
+	}
+	return append(out, byte(v))
+}
+
+func meshoptDecodeVByte(data []byte) (uint32, []byte) {
+	lead := data[0]
+	if lead < 128 {
+		return uint32(lead), data[1:]
+	}
+
+	result := uint32(lead & 127)
+	shift := 7
+	i := 1
+	for ; i < 5; i++ {
+		group := data[i]
+		result |= uint32(group&127) << shift
+		shift += 7
+		if group < 128 {
+			i++
+			break
+		}
+	}
+	return result, data[i:]
+}
+
+func meshoptEncodeIndex(out []byte, index, last uint32) []byte {
+	d := index - last
+	v := (d << 1) ^ uint32(int32(d)>>31)
+	return meshoptEncodeVByte(out, v)
+}
+
+func meshoptDecodeIndex(data []byte, last uint32) (uint32, []byte) {
+	v, data := meshoptDecodeVByte(data)
+	d := (v >> 1) ^ -(v & 1)
+	return last + d, data
+}
+
+type meshoptVertexFifo [16]uint32
+
+func newMeshoptVertexFifo() meshoptVertexFifo {
+	var fifo meshoptVertexFifo
+	for i := range fifo {
+		fifo[i] = math.MaxUint32
+	}
+	return fifo
+}
+
+func (fifo *meshoptVertexFifo) push(v uint32, offset *int, cond bool) {
+	fifo[*offset] = v
+	if cond {
+		*offset = (*offset + 1) & 15
+	}
+}
+
+func (fifo meshoptVertexFifo) find(v uint32, offset int) int {
+	for i := range 16 {
+		if fifo[(offset-1-i)&15] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+type meshoptEdgeFifo [16][2]uint32
+
+func newMeshoptEdgeFifo() meshoptEdgeFifo {
+	var fifo meshoptEdgeFifo
+	for i := range fifo {
+		fifo[i] = [2]uint32{math.MaxUint32, math.MaxUint32}
+	}
+	return fifo
+}
+
+func (fifo *meshoptEdgeFifo) push(a, b uint32, offset *int) {
+	fifo[*offset] = [2]uint32{a, b}
+	*offset = (*offset + 1) & 15
+}
+
+// find looks for an edge of the triangle, returning the position of the edge
+// in the fifo shifted left by 2, combined with which edge of the triangle
+// matched
+func (fifo meshoptEdgeFifo) find(a, b, c uint32, offset int) int {
+	for i := range 16 {
+		e := fifo[(offset-1-i)&15]
+		switch {
+		case e[0] == a && e[1] == b:
+			return i << 2
+		case e[0] == b && e[1] == c:
+			return i<<2 | 1
+		case e[0] == c && e[1] == a:
+			return i<<2 | 2
+		}
+	}
+	return -1
+}
+
+var meshoptTriangleIndexOrder = [3][3]int{
+	{0, 1, 2},
+	{1, 2, 0},
+	{2, 0, 1},
+}
+
+// Table of the most common vertex fifo index pairs, derived by meshoptimizer
+// from symbol frequency on a training set of meshes
+var meshoptCodeAuxEncodingTable = [16]byte{
+	0x00, 0x76, 0x87, 0x56, 0x67, 0x78, 0xa9, 0x86, 0x65, 0x89, 0x68, 0x98, 0x01, 0x69,
+	0, 0, // last two entries aren't used for encoding
+}
+
+// meshoptEncodeIndexBuffer compresses a triangle list using version 1 of the
+// meshoptimizer index codec
+func meshoptEncodeIndexBuffer(indices []uint32) []byte {
+	const version = 1
+	const fecmax = 13
+
+	triangles := len(indices) / 3
+	code := make([]byte, triangles)
+	data := make([]byte, 0)
+
+	edgeFifo := newMeshoptEdgeFifo()
+	vertexFifo := newMeshoptVertexFifo()
+	edgeOffset, vertexOffset := 0, 0
+	var next, last uint32
+
+	for t := range triangles {
+		tri := indices[t*3 : t*3+3]
+
+		if fer := edgeFifo.find(tri[0], tri[1], tri[2], edgeOffset); fer >= 0 && fer>>2 < 15 {
+			// The matched edge determines the triangle's rotation
+			order := meshoptTriangleIndexOrder[fer&3]
+			a, b, c := tri[order[0]], tri[order[1]], tri[order[2]]
+
+			fe := fer >> 2
+			fc := vertexFifo.find(c, vertexOffset)
+
+			fec := 15
+			if fc >= 1 && fc < fecmax {
+				fec = fc
+			} else if c == next {
+				next++
+				fec = 0
+			}
+
+			if fec == 15 {
+				// Strip like sequences reference the last free index plus or
+				// minus one
+				if c+1 == last {
+					fec = 13
+					last = c
+				}
+				if c == last+1 {
+					fec = 14
+					last = c
+				}
+			}
+
+			code[t] = byte(fe<<4 | fec)
+
+			if fec == 15 {
+				data = meshoptEncodeIndex(data, c, last)
+				last = c
+			}
+
+			if fec == 0 || fec >= fecmax {
+				vertexFifo.push(c, &vertexOffset, true)
+			}
+
+			edgeFifo.push(c, b, &edgeOffset)
+			edgeFifo.push(a, c, &edgeOffset)
+			continue
+		}
+
+		rotation := 0
+		if tri[1] == next {
+			rotation = 1
+		} else if tri[2] == next {
+			rotation = 2
+		}
+		order := meshoptTriangleIndexOrder[rotation]
+		a, b, c := tri[order[0]], tri[order[1]], tri[order[2]]
+
+		reset := false
+		if a == 0 && b == 1 && c == 2 && next > 0 {
+			reset = true
+			next = 0
+
+			// Clear the fifo so vertices from before the reset aren't
+			// referenced, keeping next incrementing
+			vertexFifo = newMeshoptVertexFifo()
+		}
+
+		fb := vertexFifo.find(b, vertexOffset)
+		fc := vertexFifo.find(c, vertexOffset)
+
+		fea := 15
+		if a == next {
+			next++
+			fea = 0
+		}
+
+		feb := 15
+		if fb >= 0 && fb < 14 {
+			feb = fb + 1
+		} else if b == next {
+			next++
+			feb = 0
+		}
+
+		fec := 15
+		if fc >= 0 && fc < 14 {
+			fec = fc + 1
+		} else if c == next {
+			next++
+			fec = 0
+		}
+
+		codeaux := byte(feb<<4 | fec)
+		codeauxIndex := -1
+		for i, entry := range meshoptCodeAuxEncodingTable {
+			if entry == codeaux {
+				codeauxIndex = i
+				break
+			}
+		}
+
+		if fea == 0 && codeauxIndex >= 0 && codeauxIndex < 14 && !reset {
+			code[t] = byte(15<<4 | codeauxIndex)
+		} else {
+			code[t] = byte(15<<4 | 14 | fea)
+			data = append(data, codeaux)
+		}
+
+		if fea == 15 {
+			data = meshoptEncodeIndex(data, a, last)
+			last = a
+		}
+		if feb == 15 {
+			data = meshoptEncodeIndex(data, b, last)
+			last = b
+		}
+		if fec == 15 {
+			data = meshoptEncodeIndex(data, c, last)
+			last = c
+		}
+
+		vertexFifo.push(a, &vertexOffset, true)
+		vertexFifo.push(b, &vertexOffset, feb == 0 || feb == 15)
+		vertexFifo.push(c, &vertexOffset, fec == 0 || fec == 15)
+
+		edgeFifo.push(b, a, &edgeOffset)
+		edgeFifo.push(c, b, &edgeOffset)
+		edgeFifo.push(a, c, &edgeOffset)
+	}
+
+	// The table doubles as padding, guaranteeing every triangle can be read
+	// without bounds checks
+	out := make([]byte, 0, 1+len(code)+len(data)+len(meshoptCodeAuxEncodingTable))
+	out = append(out, meshoptIndexHeader|version)
+	out = append(out, code...)
+	out = append(out, data...)
+	return append(out, meshoptCodeAuxEncodingTable[:]...)
+}
+
+// meshoptDecodeIndexBuffer decompresses a triangle list written with the
+// meshoptimizer index codec
+func meshoptDecodeIndexBuffer(dst []uint32, src []byte) error {
+	count := len(dst)
+	if count%3 != 0 {
+		return fmt.Errorf("meshopt: triangle index count %d is not a multiple of 3", count)
+	}
+
+	triangles := count / 3
+	if len(src) < 1+triangles+16 {
+		return errMeshoptTruncated
+	}
+
+	if src[0]&0xf0 != meshoptIndexHeader {
+		return fmt.Errorf("meshopt: unrecognized index header 0x%x", src[0])
+	}
+
+	version := src[0] & 0x0f
+	if version > 1 {
+		return fmt.Errorf("meshopt: unsupported index codec version %d", version)
+	}
+
+	fecmax := 15
+	if version >= 1 {
+		fecmax = 13
+	}
+
+	edgeFifo := newMeshoptEdgeFifo()
+	vertexFifo := newMeshoptVertexFifo()
+	edgeOffset, vertexOffset := 0, 0
+	var next, last uint32
+
+	code := src[1 : 1+triangles]
+	codeauxTable := src[len(src)-16:]
+
+	// Every triangle reads at most 16 bytes of data, which the codeaux table
+	// at the end of the stream pads for
+	data := src[1+triangles:]
+
+	for t := range triangles {
+		if len(data) < 16 {
+			return errMeshoptTruncated
+		}
+
+		codetri := code[t]
+
+		var a, b, c uint32
+		switch {
+		case codetri < 0xf0:
+			fe := int(codetri >> 4)
+			edge := edgeFifo[(edgeOffset-1-fe)&15]
+			a, b = edge[0], edge[1]
+
+			fec := int(codetri & 15)
+			if fec < fecmax {
+				if fec == 0 {
+					c = next
+					next++
+				} else {
+					c = vertexFifo[(vertexOffset-1-fec)&15]
+				}
+				vertexFifo.push(c, &vertexOffset, fec == 0)
+			} else {
+				switch fec {
+				case 13:
+					c = last - 1
+				case 14:
+					c = last + 1
+				default:
+					c, data = meshoptDecodeIndex(data, last)
+				}
+				last = c
+				vertexFifo.push(c, &vertexOffset, true)
+			}
+
+			edgeFifo.push(c, b, &edgeOffset)
+			edgeFifo.push(a, c, &edgeOffset)
+
+		case codetri < 0xfe:
+			codeaux := codeauxTable[codetri&15]
+			feb := int(codeaux >> 4)
+			fec := int(codeaux & 15)
+
+			a = next
+			next++
+
+			if feb == 0 {
+				b = next
+				next++
+			} else {
+				b = vertexFifo[(vertexOffset-feb)&15]
+			}
+
+			if fec == 0 {
+				c = next
+				next++
+			} else {
+				c = vertexFifo[(vertexOffset-fec)&15]
+			}
+
+			vertexFifo.push(a, &vertexOffset, true)
+			vertexFifo.push(b, &vertexOffset, feb == 0)
+			vertexFifo.push(c, &vertexOffset, fec == 0)
+
+			edgeFifo.push(b, a, &edgeOffset)
+			edgeFifo.push(c, b, &edgeOffset)
+			edgeFifo.push(a, c, &edgeOffset)
+
+		default:
+			codeaux := data[0]
+			data = data[1:]
+
+			fea := 0
+			if codetri != 0xfe {
+				fea = 15
+			}
+			feb := int(codeaux >> 4)
+			fec := int(codeaux & 15)
+
+			// A codeaux of 0 that isn't read from the table signals a reset
+			if codeaux == 0 {
+				next = 0
+			}
+
+			if fea == 0 {
+				a = next
+				next++
+			}
+
+			if feb == 0 {
+				b = next
+				next++
+			} else {
+				b = vertexFifo[(vertexOffset-feb)&15]
+			}
+
+			if fec == 0 {
+				c = next
+				next++
+			} else {
+				c = vertexFifo[(vertexOffset-fec)&15]
+			}
+
+			if fea == 15 {
+				a, data = meshoptDecodeIndex(data, last)
+				last = a
+			}
+			if feb == 15 {
+				b, data = meshoptDecodeIndex(data, last)
+				last = b
+			}
+			if fec == 15 {
+				c, data = meshoptDecodeIndex(data, last)
+				last = c
+			}
+
+			vertexFifo.push(a, &vertexOffset, true)
+			vertexFifo.push(b, &vertexOffset, feb == 0 || feb == 15)
+			vertexFifo.push(c, &vertexOffset, fec == 0 || fec == 15)
+
+			edgeFifo.push(b, a, &edgeOffset)
+			edgeFifo.push(c, b, &edgeOffset)
+			edgeFifo.push(a, c, &edgeOffset)
+		}
+
+		dst[t*3+0] = a
+		dst[t*3+1] = b
+		dst[t*3+2] = c
+	}
+
+	if len(data) != 16 {
+		return errors.New("meshopt: unexpected data after compressed triangles")
+	}
+	return nil
+}
+
+// meshoptEncodeIndexSequence compresses an arbitrary sequence of indices
+// using version 1 of the meshoptimizer index sequence codec
+func meshoptEncodeIndexSequence(indices []uint32) []byte {
+	const version = 1
+	out := []byte{meshoptSequenceHeader | version}
+
+	var last [2]uint32
+	current := 0
+	for _, index := range indices {
+		// Switch baselines when the delta grows too large to fit in a byte
+		cd := int32(index - last[current])
+		if cd >= 30 || cd <= -30 {
+			current ^= 1
+		}
+
+		d := index - last[current]
+		v := (d << 1) ^ uint32(int32(d)>>31)
+		out = meshoptEncodeVByte(out, v<<1|uint32(current))
+		last[current] = index
+	}
+
+	return append(out, 0, 0, 0, 0)
+}
+
+// meshoptDecodeIndexSequence decompresses indices written with the
+// meshoptimizer index sequence codec
+func meshoptDecodeIndexSequence(dst []uint32, src []byte) error {
+	if len(src) < 1+len(dst)+4 {
+		return errMeshoptTruncated
+	}
+
+	if src[0]&0xf0 != meshoptSequenceHeader {
+		return fmt.Errorf("meshopt: unrecognized index sequence header 0x%x", src[0])
+	}
+
+	if version := src[0] & 0x0f; version > 1 {
+		return fmt.Errorf("meshopt: unsupported index sequence codec version %d", version)
+	}
+
+	data := src[1:]
+
+	// Each index reads at most 5 bytes, which the 4 byte tail pads for
+	var last [2]uint32
+	for i := range dst {
+		if len(data) <= 4 {
+			return errMeshoptTruncated
+		}
+
+		var v uint32
+		v, data = meshoptDecodeVByte(data)
+
+		current := v & 1
+		v >>= 1
+		d := (v >> 1) ^ -(v & 1)
+
+		index := last[current] + d
+		last[current] = index
+		dst[i] = index
+	}
+
+	if len(data) != 4 {
+		return errors.New("meshopt: unexpected data after compressed index sequence")
+	}
+	return nil
+}
+
+// ============================================================================
+// Filters
+// ============================================================================
+
+func roundToInt(v float64) int {
+	if v >= 0 {
+		return int(v + 0.5)
+	}
+	return int(v - 0.5)
+}
+
+// meshoptDecodeFilterOct reconstructs unit vectors stored with octahedral
+// encoding in 4 component signed 8 or 16 bit elements
+func meshoptDecodeFilterOct(data []byte, count, stride int) error {
+	switch stride {
+	case 4:
+		for i := range count {
+			v := data[i*4:]
+			x, y, z := octDecode(float64(int8(v[0])), float64(int8(v[1])), float64(int8(v[2])), math.MaxInt8)
+			v[0], v[1], v[2] = byte(int8(x)), byte(int8(y)), byte(int8(z))
+		}
+
+	case 8:
+		for i := range count {
+			v := data[i*8:]
+			x, y, z := octDecode(
+				float64(int16(binary.LittleEndian.Uint16(v[0:]))),
+				float64(int16(binary.LittleEndian.Uint16(v[2:]))),
+				float64(int16(binary.LittleEndian.Uint16(v[4:]))),
+				math.MaxInt16,
+			)
+			binary.LittleEndian.PutUint16(v[0:], uint16(int16(x)))
+			binary.LittleEndian.PutUint16(v[2:], uint16(int16(y)))
+			binary.LittleEndian.PutUint16(v[4:], uint16(int16(z)))
+		}
+
+	default:
+		return fmt.Errorf("meshopt: octahedral filter requires a stride of 4 or 8, received %d", stride)
+	}
+	return nil
+}
+
+func octDecode(x, y, z, maxValue float64) (int, int, int) {
+	// z encodes 1 at the same scale as x and y
+	z = z - math.Abs(x) - math.Abs(y)
+
+	t := math.Min(z, 0)
+	if x >= 0 {
+		x += t
+	} else {
+		x -= t
+	}
+	if y >= 0 {
+		y += t
+	} else {
+		y -= t
+	}
+
+	s := maxValue / math.Sqrt(x*x+y*y+z*z)
+	return roundToInt(x * s), roundToInt(y * s), roundToInt(z * s)
+}
+
+// meshoptDecodeFilterQuat reconstructs unit quaternions stored as their three
+// smallest components in 4 component signed 16 bit elements
+func meshoptDecodeFilterQuat(data []byte, count, stride int) error {
+	if stride != 8 {
+		return fmt.Errorf("meshopt: quaternion filter requires a stride of 8, received %d", stride)
+	}
+
+	scale := 1 / math.Sqrt2
+	for i := range count {
+		v := data[i*8:]
+		var q [4]int16
+		for c := range q {
+			q[c] = int16(binary.LittleEndian.Uint16(v[c*2:]))
+		}
+
+		// The scale is stored in the high bits of the last component, and the
+		// index of the largest component in the 2 lowest bits
+		sf := int(q[3]) | 3
+		ss := scale / float64(sf)
+
+		x := float64(q[0]) * ss
+		y := float64(q[1]) * ss
+		z := float64(q[2]) * ss
+		w := math.Sqrt(math.Max(1-x*x-y*y-z*z, 0))
+
+		qc := int(q[3] & 3)
+		out := [4]int16{}
+		out[(qc+1)&3] = int16(roundToInt(x * math.MaxInt16))
+		out[(qc+2)&3] = int16(roundToInt(y * math.MaxInt16))
+		out[(qc+3)&3] = int16(roundToInt(z * math.MaxInt16))
+		out[(qc+0)&3] = int16(roundToInt(w * math.MaxInt16))
+
+		for c := range out {
+			binary.LittleEndian.PutUint16(v[c*2:], uint16(out[c]))
+		}
+	}
+	return nil
+}
+
+// meshoptDecodeFilterExp reconstructs 32 bit floats stored as a 24 bit
+// mantissa and 8 bit exponent
+func meshoptDecodeFilterExp(data []byte, count, stride int) error {
+	if stride%4 != 0 {
+		return fmt.Errorf("meshopt: exponential filter requires a stride divisible by 4, received %d", stride)
+	}
+
+	for i := range count * stride / 4 {
+		v := binary.LittleEndian.Uint32(data[i*4:])
+		m := int32(v<<8) >> 8
+		e := int32(v) >> 24
+		f := float32(math.Ldexp(float64(m), int(e)))
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(f))
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+package gltf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeshoptVertexBuffer_RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		count  int
+		stride int
+	}{
+		"single vertex":     {count: 1, stride: 12},
+		"partial block":     {count: 17, stride: 8},
+		"multiple blocks":   {count: 1000, stride: 12},
+		"wide stride":       {count: 300, stride: 64},
+		"small stride":      {count: 513, stride: 4},
+		"empty":             {count: 0, stride: 12},
+		"interleaved float": {count: 257, stride: 32},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			data := make([]byte, tc.count*tc.stride)
+			for i := 0; i+4 <= len(data); i += 4 {
+				v := float32(math.Sin(float64(i)*0.01)) * 100
+				binary.LittleEndian.PutUint32(data[i:], math.Float32bits(v))
+			}
+
+			// ACT ============================================================
+			encoded := meshoptEncodeVertexBuffer(data, tc.count, tc.stride)
+			decoded := make([]byte, len(data))
+			err := meshoptDecodeVertexBuffer(decoded, tc.count, tc.stride, encoded)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			assert.Equal(t, data, decoded)
+		})
+	}
+}
+
+func TestMeshoptVertexBuffer_Truncated(t *testing.T) {
+	data := make([]byte, 64*12)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	encoded := meshoptEncodeVertexBuffer(data, 64, 12)
+
+	err := meshoptDecodeVertexBuffer(make([]byte, len(data)), 64, 12, encoded[:len(encoded)/2])
+	assert.Error(t, err)
+}
+
+func TestMeshoptIndexBuffer_Decode(t *testing.T) {
+	// Reference encoding of a small triangle list produced by meshoptimizer
+	encoded := []byte{
+		0xe0, 0xf0, 0x10, 0xfe, 0xff, 0xf0, 0x0c, 0xff, 0x02, 0x02, 0x02, 0x00,
+		0x76, 0x87, 0x56, 0x67, 0x78, 0xa9, 0x86, 0x65, 0x89, 0x68, 0x98, 0x01,
+		0x69, 0x00, 0x00,
+	}
+
+	decoded := make([]uint32, 12)
+	require.NoError(t, meshoptDecodeIndexBuffer(decoded, encoded))
+	assert.Equal(t, []uint32{0, 1, 2, 2, 1, 3, 4, 6, 5, 7, 8, 9}, decoded)
+}
+
+func TestMeshoptIndexBuffer_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	// Triangulated grid, so edges and vertices are frequently shared
+	const size = 20
+	indices := make([]uint32, 0)
+	for y := range uint32(size) {
+		for x := range uint32(size) {
+			a := y*(size+1) + x
+			b := a + 1
+			c := a + size + 1
+			d := c + 1
+			indices = append(indices, a, c, b, b, c, d)
+		}
+	}
+	indices = append(indices, 5000, 3, 70000)
+
+	// ACT ====================================================================
+	encoded := meshoptEncodeIndexBuffer(indices)
+	decoded := make([]uint32, len(indices))
+	err := meshoptDecodeIndexBuffer(decoded, encoded)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, indices, decoded)
+	assert.Less(t, len(encoded), len(indices)*2)
+}
+
+func TestMeshoptIndexSequence_RoundTrip(t *testing.T) {
+	indices := []uint32{0, 1, 2, 3, 100, 99, 98, 1 << 20, 0, 5, 5, 7}
+
+	encoded := meshoptEncodeIndexSequence(indices)
+	decoded := make([]uint32, len(indices))
+	require.NoError(t, meshoptDecodeIndexSequence(decoded, encoded))
+	assert.Equal(t, indices, decoded)
+}
+
+func TestMeshoptDecodeFilterOct(t *testing.T) {
+	// ARRANGE ================================================================
+	// Octahedral encoding of +Z, with the 4th component left untouched
+	data := []byte{0, 0, 127, 42}
+
+	// ACT ====================================================================
+	err := meshoptDecodeFilterOct(data, 1, 4)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 127, 42}, data)
+}
+
+func TestMeshoptDecodeFilterExp(t *testing.T) {
+	// ARRANGE ================================================================
+	// 3 * 2^-1
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, uint32(0xff000003))
+
+	// ACT ====================================================================
+	err := meshoptDecodeFilterExp(data, 1, 4)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, float32(1.5), math.Float32frombits(binary.LittleEndian.Uint32(data)))
+}
+
+// The fixed vectors below are written out by hand following the bitstream
+// described by the EXT_meshopt_compression specification, rather than being
+// produced by the encoder under test, so the decoder can't agree with a
+// mistake made on both sides of a round trip.
+
+// Four vertices of 4 bytes each: an unsigned short ranging between 0 and 300,
+// followed by two bytes picked to exercise the 4 bit and 8 bit byte groups
+var meshoptReferenceVertices = []byte{
+	0x00, 0x00, 0x00, 0x10,
+	0x2c, 0x01, 0x03, 0x20,
+	0x00, 0x00, 0x01, 0x30,
+	0x2c, 0x01, 0x06, 0x40,
+}
+
+var meshoptReferenceVertexData = []byte{
+	0xa0, // Header, version 0
+
+	// Low byte of the short, 2 bit group with 3 escaped deltas
+	0x01, 0x3f, 0x00, 0x00, 0x00, 0x58, 0x57, 0x58,
+
+	// High byte of the short, 2 bit group
+	0x01, 0x26, 0x00, 0x00, 0x00,
+
+	// 4 bit group
+	0x02, 0x06, 0x3a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+
+	// 8 bit group, relative to the first vertex
+	0x03, 0x00, 0x20, 0x20, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+
+	// Tail, padded out to 32 bytes and ending with the first vertex
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x10,
+}
+
+func TestMeshoptVertexBuffer_Decode(t *testing.T) {
+	decoded := make([]byte, len(meshoptReferenceVertices))
+	require.NoError(t, meshoptDecodeVertexBuffer(decoded, 4, 4, meshoptReferenceVertexData))
+	assert.Equal(t, meshoptReferenceVertices, decoded)
+}
+
+func TestMeshoptVertexBuffer_DecodeTrailingData(t *testing.T) {
+	data := append(append([]byte{}, meshoptReferenceVertexData...), 0x00)
+	err := meshoptDecodeVertexBuffer(make([]byte, len(meshoptReferenceVertices)), 4, 4, data)
+	assert.Error(t, err)
+}
+
+func TestMeshoptIndexSequence_Decode(t *testing.T) {
+	// Deltas of 0, +1 and -2 against the first baseline, followed by 70000
+	// against the second
+	encoded := []byte{0xd1, 0x00, 0x04, 0x06, 0xc1, 0x8b, 0x11, 0x00, 0x00, 0x00, 0x00}
+
+	decoded := make([]uint32, 4)
+	require.NoError(t, meshoptDecodeIndexSequence(decoded, encoded))
+	assert.Equal(t, []uint32{0, 1, 0xffffffff, 70000}, decoded)
+}
+
+func TestMeshoptDecodeFilterOct_Reference(t *testing.T) {
+	tests := map[string]struct {
+		stride int
+		input  []int16
+		want   []int16
+	}{
+		"8 bit +X":        {stride: 4, input: []int16{127, 0, 127, 5}, want: []int16{127, 0, 0, 5}},
+		"8 bit XY":        {stride: 4, input: []int16{-64, 64, 127, 0}, want: []int16{-90, 90, -1, 0}},
+		"8 bit folded -Z": {stride: 4, input: []int16{127, 127, 127, 0}, want: []int16{0, 0, -127, 0}},
+		"8 bit oblique":   {stride: 4, input: []int16{64, 32, 127, 0}, want: []int16{104, 52, 50, 0}},
+		"16 bit XY":       {stride: 8, input: []int16{-16384, 16384, 32767, 7}, want: []int16{-23170, 23170, -1, 7}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			data := make([]byte, tc.stride)
+			put := func(data []byte, values []int16) {
+				for i, v := range values {
+					if tc.stride == 4 {
+						data[i] = byte(int8(v))
+					} else {
+						binary.LittleEndian.PutUint16(data[i*2:], uint16(v))
+					}
+				}
+			}
+			put(data, tc.input)
+			want := make([]byte, tc.stride)
+			put(want, tc.want)
+
+			// ACT ============================================================
+			err := meshoptDecodeFilterOct(data, 1, tc.stride)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			assert.Equal(t, want, data)
+		})
+	}
+}
+
+func TestMeshoptDecodeFilterQuat(t *testing.T) {
+	// ARRANGE ================================================================
+	// A quarter turn around Z stored with W as the largest component,
+	// followed by a quarter turn around X stored with X as the largest
+	input := []int16{
+		0, 0, 16383, 16383,
+		0, 0, 16383, 16380,
+	}
+	data := make([]byte, len(input)*2)
+	for i, v := range input {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(v))
+	}
+
+	// ACT ====================================================================
+	err := meshoptDecodeFilterQuat(data, 2, 8)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	decoded := make([]int16, len(input))
+	for i := range decoded {
+		decoded[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+	assert.Equal(t, []int16{
+		0, 0, 23170, 23170,
+		23170, 0, 0, 23170,
+	}, decoded)
+}
+
+func TestMeshoptDecodeFilterExp_Reference(t *testing.T) {
+	// ARRANGE ================================================================
+	// -5 * 2^3, 6 * 2^-2, and 0 * 2^10
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[0:], 0x03fffffb)
+	binary.LittleEndian.PutUint32(data[4:], 0xfe000006)
+	binary.LittleEndian.PutUint32(data[8:], 0x0a000000)
+
+	// ACT ====================================================================
+	err := meshoptDecodeFilterExp(data, 1, 12)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, float32(-40), math.Float32frombits(binary.LittleEndian.Uint32(data[0:])))
+	assert.Equal(t, float32(1.5), math.Float32frombits(binary.LittleEndian.Uint32(data[4:])))
+	assert.Equal(t, float32(0), math.Float32frombits(binary.LittleEndian.Uint32(data[8:])))
+}
+
+func TestLoadGLB_MeshoptCompression(t *testing.T) {
+	// ARRANGE ================================================================
+	// Float positions of the triangle (0, 0, 0), (1, 0, 0), (0, 1, 0)
+	positions := []byte{
+		0xa0, // Header, version 0
+
+		// X, where only the upper two bytes of 1.0 are non-zero
+		0x00,
+		0x00,
+		0x01, 0x3c, 0x00, 0x00, 0x00, 0xff, 0xff,
+		0x01, 0x3c, 0x00, 0x00, 0x00, 0x7e, 0x7d,
+
+		// Y
+		0x00,
+		0x00,
+		0x01, 0x0c, 0x00, 0x00, 0x00, 0xff,
+		0x01, 0x0c, 0x00, 0x00, 0x00, 0x7e,
+
+		// Z
+		0x00, 0x00, 0x00, 0x00,
+
+		// Tail, padded out to 32 bytes and ending with the first vertex
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	// Index sequence 0, 2, 1
+	indices := []byte{0xd1, 0x00, 0x08, 0x02, 0x00, 0x00, 0x00, 0x00}
+
+	bin := append(append(append([]byte{}, positions...), 0x00), indices...)
+	doc := fmt.Sprintf(`{
+		"asset": {"version": "2.0"},
+		"extensionsUsed": ["EXT_meshopt_compression"],
+		"extensionsRequired": ["EXT_meshopt_compression"],
+		"buffers": [
+			{"byteLength": %d},
+			{"byteLength": 44, "extensions": {"EXT_meshopt_compression": {"fallback": true}}}
+		],
+		"bufferViews": [
+			{
+				"buffer": 1, "byteLength": 36, "byteStride": 12,
+				"extensions": {"EXT_meshopt_compression": {"buffer": 0, "byteLength": %d, "byteStride": 12, "count": 3, "mode": "ATTRIBUTES"}}
+			},
+			{
+				"buffer": 1, "byteOffset": 36, "byteLength": 6,
+				"extensions": {"EXT_meshopt_compression": {"buffer": 0, "byteOffset": %d, "byteLength": %d, "byteStride": 2, "count": 3, "mode": "INDICES"}}
+			}
+		],
+		"accessors": [
+			{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3", "min": [0, 0, 0], "max": [1, 1, 0]},
+			{"bufferView": 1, "componentType": 5123, "count": 3, "type": "SCALAR"}
+		],
+		"meshes": [{"primitives": [{"attributes": {"POSITION": 0}, "indices": 1}]}],
+		"nodes": [{"mesh": 0}],
+		"scenes": [{"nodes": [0]}],
+		"scene": 0
+	}`, len(bin), len(positions), len(positions)+1, len(indices))
+
+	jsonChunk := []byte(doc)
+	for len(jsonChunk)%4 != 0 {
+		jsonChunk = append(jsonChunk, ' ')
+	}
+	for len(bin)%4 != 0 {
+		bin = append(bin, 0)
+	}
+
+	glb := binary.LittleEndian.AppendUint32(nil, magicNumber)
+	glb = binary.LittleEndian.AppendUint32(glb, 2)
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(12+8+len(jsonChunk)+8+len(bin)))
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(len(jsonChunk)))
+	glb = binary.LittleEndian.AppendUint32(glb, jsonChunkIdentifier)
+	glb = append(glb, jsonChunk...)
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(len(bin)))
+	glb = binary.LittleEndian.AppendUint32(glb, binChunkIdentifier)
+	glb = append(glb, bin...)
+
+	// ACT ====================================================================
+	loaded, buffers, err := LoadGLB(bytes.NewReader(glb), nil)
+	require.NoError(t, err)
+	scene, err := DecodeScene(loaded, buffers, nil)
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	require.Len(t, scene.Models, 1)
+	mesh := scene.Models[0].Mesh
+	require.NotNil(t, mesh)
+
+	indexView := mesh.Indices()
+	require.Equal(t, 3, indexView.Len())
+	assert.Equal(t, []int{0, 2, 1}, []int{indexView.At(0), indexView.At(1), indexView.At(2)})
+
+	meshPositions := mesh.Float3Attribute(modeling.PositionAttribute)
+	require.Equal(t, 3, meshPositions.Len())
+	assert.Equal(t, vector3.New(0., 0., 0.), meshPositions.At(0))
+	assert.Equal(t, vector3.New(1., 0., 0.), meshPositions.At(1))
+	assert.Equal(t, vector3.New(0., 1., 0.), meshPositions.At(2))
+}
@@ -37,7 +37,14 @@ const (
 type PolyformScene struct {
 	Models     []*PolyformModel
 	Lights     []KHR_LightsPunctual
+	Cameras    []PolyformCamera
 	Animations []PolyformAnimation
+
+	// Names of the material variants available within the scene, written
+	// using the KHR_materials_variants extension. Variants referenced by
+	// models are added automatically, this only needs to be populated to
+	// control their ordering or to include variants no model references.
+	MaterialVariants []string
 }
 
 // PolyformModel is a utility structure for reading/writing to GLTF format within
@@ -47,6 +54,10 @@ type PolyformModel struct {
 	Mesh     *modeling.Mesh
 	Material *PolyformMaterial
 
+	// Alternative materials the model is rendered with when a variant is
+	// active, written using the KHR_materials_variants extension
+	MaterialVariants []PolyformMaterialVariant
+
 	// TRS contains the transformation (translation, rotation, scale) for this model
 	// This is optional and it will be used if the models are deduplicated and collapsed into a list of instances.
 	TRS *trs.TRS
@@ -58,6 +69,15 @@ type PolyformModel struct {
 	// If flag is not set, populating this list will cause the scene writing to fail.
 	GpuInstances []trs.TRS
 
+	// Lower detail alternatives to Mesh, ordered from highest to lowest
+	// detail, written using the MSFT_lod extension. Only the full detail
+	// model displays its children.
+	LODs []PolyformLOD
+
+	// Default weights of each morph target stored within the mesh. See
+	// MorphTargetAttribute.
+	MorphTargetWeights []float64
+
 	// Animation is a list of animations that are applied to this model.
 	// Models with animations defined will never be deduplicated into a single list.
 	// However, these models can utilize GpuInstances and in that case the same animation will be applied to all of them.
@@ -99,6 +119,14 @@ func (v RotationAnimationSamplerData) Write(w *Writer) int {
 	return w.WriteVector4(AccessorComponentType_FLOAT, iter.Array(v4), 0)
 }
 
+// WeightsAnimationSamplerData contains the weight of every morph target of
+// the mesh for each keyframe, one keyframe after another
+type WeightsAnimationSamplerData []float64
+
+func (v WeightsAnimationSamplerData) Write(w *Writer) int {
+	return w.WriteScalar(AccessorComponentType_FLOAT, iter.Array(v), 0)
+}
+
 type PolyformAnimationChannel struct {
 	TargetPath AnimationChannelTargetPath
 	Target     *PolyformModel
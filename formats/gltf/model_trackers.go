@@ -30,7 +30,8 @@ func (mt materialIndices) findExistingMaterialID(mat *PolyformMaterial) (*int, b
 // meshEntry tracks a unique material and its corresponding GLTF material index
 type meshEntry struct {
 	polyMesh      *modeling.Mesh
-	materialIndex int // -1 is a valid value for absence of material
+	materialIndex int    // -1 is a valid value for absence of material
+	variants      string // Key of the primitive's material variant mappings, if any
 }
 
 // meshIndices handle deduplication of GLTF meshes
@@ -40,8 +41,10 @@ type meshIndices map[meshEntry]int
 type textureIndices map[*PolyformTexture]int
 
 type writtenMeshData struct {
-	attribute map[string]GltfId
-	indices   *GltfId
+	attribute      map[string]GltfId
+	targets        []map[string]GltfId
+	indices        *GltfId
+	dequantization *trs.TRS // Transform restoring quantized positions, if they were quantized
 }
 
 type attributeIndices map[*modeling.Mesh]writtenMeshData
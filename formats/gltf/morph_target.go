@@ -0,0 +1,39 @@
+package gltf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const morphTargetPrefix = "MorphTarget"
+
+// MorphTargetAttribute is the name of the mesh attribute that stores the
+// per vertex displacements applied to attribute by the morph target at the
+// provided index. Meshes containing these attributes have them written out as
+// the morph targets of the primitive, rather than as regular attributes.
+func MorphTargetAttribute(attribute string, target int) string {
+	return fmt.Sprintf("%s%d_%s", morphTargetPrefix, target, attribute)
+}
+
+// parseMorphTargetAttribute splits a mesh attribute built with
+// MorphTargetAttribute back into the target index and the attribute being
+// displaced
+func parseMorphTargetAttribute(name string) (attribute string, target int, ok bool) {
+	rest, found := strings.CutPrefix(name, morphTargetPrefix)
+	if !found {
+		return "", -1, false
+	}
+
+	index, attribute, found := strings.Cut(rest, "_")
+	if !found || attribute == "" {
+		return "", -1, false
+	}
+
+	target, err := strconv.Atoi(index)
+	if err != nil || target < 0 {
+		return "", -1, false
+	}
+
+	return attribute, target, true
+}
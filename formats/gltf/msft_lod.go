@@ -0,0 +1,125 @@
+package gltf
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/simplify"
+)
+
+const (
+	msftLodID = "MSFT_lod"
+
+	// Key within a node's extras listing the screen coverage each LOD is
+	// displayed at
+	msftScreenCoverageKey = "MSFT_screencoverage"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Vendor/MSFT_lod
+type MsftLod struct {
+	// Nodes making up each lower level of detail, ordered from highest to
+	// lowest detail
+	Ids []GltfId `json:"ids"`
+}
+
+// PolyformLOD is a lower detail alternative to a model's mesh
+type PolyformLOD struct {
+	Mesh *modeling.Mesh
+
+	// Material the LOD is rendered with. The model's material is used when
+	// left nil
+	Material *PolyformMaterial
+
+	// Fraction of the screen, [0, 1], the model's bounds must shrink below
+	// before this LOD replaces the level of detail before it. When every LOD
+	// of a model has a coverage of 0, no screen coverage hints are written.
+	ScreenCoverage float64
+}
+
+// GenerateLODs builds a chain of progressively simplified versions of the
+// mesh using quadric decimation, where each level keeps ratio of the
+// triangles of the level before it. The first LOD is displayed once the model
+// covers less than screenCoverage of the screen, and each subsequent LOD's
+// coverage is scaled by ratio, keeping the number of triangles per pixel
+// roughly constant across levels.
+func GenerateLODs(mesh modeling.Mesh, levels int, ratio, screenCoverage float64) []PolyformLOD {
+	chain := simplify.LODChain(mesh, levels, ratio, false)
+	lods := make([]PolyformLOD, len(chain))
+	coverage := screenCoverage
+	for i := range chain {
+		lods[i] = PolyformLOD{
+			Mesh:           &chain[i],
+			ScreenCoverage: coverage,
+		}
+		coverage *= ratio
+	}
+	return lods
+}
+
+func lodScreenCoverage(lods []PolyformLOD) []float64 {
+	hasCoverage := false
+	for _, lod := range lods {
+		if lod.ScreenCoverage > 0 {
+			hasCoverage = true
+			break
+		}
+	}
+
+	if !hasCoverage {
+		return nil
+	}
+
+	// Each entry is the minimum coverage the node (or LOD) at that index is
+	// displayed at. The lowest LOD is never culled.
+	coverage := make([]float64, len(lods)+1)
+	for i, lod := range lods {
+		coverage[i] = lod.ScreenCoverage
+	}
+	return coverage
+}
+
+// decodeLODs reads the levels of detail a node references through the
+// MSFT_lod extension. Decoding stops at the first LOD that can't be
+// represented as a single mesh.
+func decodeLODs(doc *Gltf, buffers [][]byte, n Node, opts ReaderOptions, imgCache imgReaderCache) ([]PolyformLOD, error) {
+	ext, ok := n.Extensions[msftLodID].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	ids, ok := ext["ids"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s extension on node %q is missing ids", msftLodID, n.Name)
+	}
+
+	coverage, _ := n.Extras[msftScreenCoverageKey].([]any)
+
+	lods := make([]PolyformLOD, 0, len(ids))
+	for i, rawId := range ids {
+		id, ok := rawId.(float64)
+		if !ok || id < 0 || int(id) >= len(doc.Nodes) {
+			return nil, fmt.Errorf("%s extension on node %q references invalid node %v", msftLodID, n.Name, rawId)
+		}
+
+		lodNode := doc.Nodes[int(id)]
+		if lodNode.Mesh == nil || len(doc.Meshes[*lodNode.Mesh].Primitives) != 1 {
+			break
+		}
+
+		mesh, mat, err := decodePrimitive(doc, buffers, doc.Meshes[*lodNode.Mesh].Primitives[0], opts, imgCache)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode node %q LOD %d: %w", n.Name, i, err)
+		}
+
+		lod := PolyformLOD{
+			Mesh:     mesh,
+			Material: mat,
+		}
+		if i < len(coverage) {
+			lod.ScreenCoverage, _ = coverage[i].(float64)
+		}
+		lods = append(lods, lod)
+	}
+
+	return lods, nil
+}
@@ -0,0 +1,103 @@
+package gltf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateLODs(t *testing.T) {
+	sphere := primitives.UVSphere(1, 16, 16)
+
+	lods := gltf.GenerateLODs(sphere, 3, 0.5, 0.4)
+
+	require.Len(t, lods, 3)
+	previous := sphere.PrimitiveCount()
+	coverage := []float64{0.4, 0.2, 0.1}
+	for i, lod := range lods {
+		require.NotNil(t, lod.Mesh)
+		assert.Less(t, lod.Mesh.PrimitiveCount(), previous)
+		assert.InDelta(t, coverage[i], lod.ScreenCoverage, 1e-9)
+		previous = lod.Mesh.PrimitiveCount()
+	}
+}
+
+func TestWrite_MsftLod(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 16, 16)
+	transform := trs.Position(vector3.New(1., 2., 3.))
+	material := &gltf.PolyformMaterial{Name: "base"}
+	lowMaterial := &gltf.PolyformMaterial{Name: "low"}
+	lods := gltf.GenerateLODs(sphere, 2, 0.25, 0.5)
+	lods[1].Material = lowMaterial
+
+	buf := bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := gltf.WriteText(gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{
+			{
+				Name:     "sphere",
+				Mesh:     &sphere,
+				Material: material,
+				TRS:      &transform,
+				LODs:     lods,
+			},
+		},
+	}, &buf, nil)
+	require.NoError(t, err)
+
+	doc, buffers, err := gltf.LoadGLTF(bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+
+	scene, err := gltf.DecodeScene(doc, buffers, nil)
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	assert.Equal(t, []string{"MSFT_lod"}, doc.ExtensionsUsed)
+	assert.Empty(t, doc.ExtensionsRequired)
+	require.Len(t, doc.Nodes, 3)
+	require.Len(t, doc.Meshes, 3)
+	require.Len(t, doc.Materials, 2)
+	require.Len(t, doc.Scenes, 1)
+	assert.Equal(t, []int{2}, doc.Scenes[0].Nodes)
+
+	root := doc.Nodes[2]
+	assert.Equal(t, map[string]any{"ids": []any{0., 1.}}, root.Extensions["MSFT_lod"])
+	assert.Equal(t, []any{0.5, 0.125, 0.}, root.Extras["MSFT_screencoverage"])
+	for _, lodNode := range doc.Nodes[:2] {
+		assert.Equal(t, root.Translation, lodNode.Translation)
+		assert.Empty(t, lodNode.Children)
+	}
+	assert.Equal(t, "sphere_LOD1", doc.Nodes[0].Name)
+	assert.Equal(t, "sphere_LOD2", doc.Nodes[1].Name)
+
+	require.Len(t, scene.Models, 1)
+	model := scene.Models[0]
+	require.Len(t, model.LODs, 2)
+	for i, lod := range model.LODs {
+		assert.Equal(t, lods[i].Mesh.PrimitiveCount(), lod.Mesh.PrimitiveCount())
+		assert.Equal(t, lods[i].ScreenCoverage, lod.ScreenCoverage)
+	}
+	assert.Equal(t, "base", model.LODs[0].Material.Name)
+	assert.Equal(t, "low", model.LODs[1].Material.Name)
+}
+
+func TestWrite_MsftLodRequiresMesh(t *testing.T) {
+	sphere := primitives.UVSphere(1, 8, 8)
+	err := gltf.WriteText(gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{
+			{
+				Name: "empty",
+				LODs: []gltf.PolyformLOD{{Mesh: &sphere}},
+			},
+		},
+	}, &bytes.Buffer{}, nil)
+	assert.Error(t, err)
+}
@@ -1,9 +1,12 @@
 package gltf
 
 import (
+	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"io"
+	"math"
 
 	"github.com/EliCDavis/polyform/drawing/coloring"
 	"github.com/EliCDavis/polyform/generator"
@@ -24,9 +27,14 @@ func init() {
 	refutil.RegisterType[nodes.Struct[MaterialAnisotropyExtensionNode]](factory)
 	refutil.RegisterType[nodes.Struct[MaterialClearcoatExtensionNode]](factory)
 	refutil.RegisterType[nodes.Struct[MaterialNode]](factory)
+	refutil.RegisterType[nodes.Struct[MaterialVariantNode]](factory)
 	refutil.RegisterType[nodes.Struct[MaterialTransmissionExtensionNode]](factory)
 	refutil.RegisterType[nodes.Struct[MaterialVolumeExtensionNode]](factory)
 	refutil.RegisterType[nodes.Struct[ModelNode]](factory)
+	refutil.RegisterType[nodes.Struct[LODNode]](factory)
+	refutil.RegisterType[nodes.Struct[ConvexCollisionNode]](factory)
+	refutil.RegisterType[nodes.Struct[PerspectiveCameraNode]](factory)
+	refutil.RegisterType[nodes.Struct[OrthographicCameraNode]](factory)
 	refutil.RegisterType[nodes.Struct[TextureReferenceNode]](factory)
 	refutil.RegisterType[nodes.Struct[TextureNode]](factory)
 	refutil.RegisterType[nodes.Struct[NormalTextureNode]](factory)
@@ -54,12 +62,16 @@ func (Artifact) Mime() string {
 }
 
 func (ga Artifact) Write(w io.Writer) error {
-	return WriteBinary(ga.Scene, w, nil)
+	return WriteBinary(ga.Scene, w, &ga.Options)
 }
 
 type ManifestNode struct {
 	Models     []nodes.Output[*PolyformModel]
 	Animations []nodes.Output[PolyformAnimation]
+	Cameras    []nodes.Output[PolyformCamera]
+
+	MeshQuantization   nodes.Output[bool] `description:"Store mesh attributes as normalized integers using KHR_mesh_quantization"`
+	MeshoptCompression nodes.Output[bool] `description:"Compress mesh data using EXT_meshopt_compression"`
 }
 
 func (gad ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
@@ -85,9 +97,12 @@ func (gad ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
 			Scene: PolyformScene{
 				Models:     models,
 				Animations: nodes.GetOutputValues(out, gad.Animations),
+				Cameras:    nodes.GetOutputValues(out, gad.Cameras),
 			},
 			Options: WriterOptions{
 				GpuInstancingStrategy: WriterInstancingStrategy_Default,
+				MeshQuantization:      nodes.TryGetOutputValue(out, gad.MeshQuantization, false),
+				MeshoptCompression:    nodes.TryGetOutputValue(out, gad.MeshoptCompression, false),
 			},
 		},
 	}
@@ -101,11 +116,14 @@ type ModelNode struct {
 	Material nodes.Output[PolyformMaterial]
 	Children []nodes.Output[*PolyformModel]
 
+	MaterialVariants []nodes.Output[PolyformMaterialVariant] `description:"Alternative materials the model can be rendered with"`
+
 	Translation nodes.Output[vector3.Float64]
 	Rotation    nodes.Output[quaternion.Quaternion]
 	Scale       nodes.Output[vector3.Float64]
 
 	GpuInstances nodes.Output[[]trs.TRS]
+	LODs         nodes.Output[[]PolyformLOD]
 }
 
 func (gmnd ModelNode) Out(out *nodes.StructOutput[*PolyformModel]) {
@@ -122,9 +140,195 @@ func (gmnd ModelNode) Out(out *nodes.StructOutput[*PolyformModel]) {
 		Mesh:         nodes.TryGetOutputReference(out, gmnd.Mesh, nil),
 		TRS:          &transform,
 		Children:     nodes.GetOutputValues(out, gmnd.Children),
+		LODs:         nodes.TryGetOutputValue(out, gmnd.LODs, nil),
+
+		MaterialVariants: nodes.GetOutputValues(out, gmnd.MaterialVariants),
+	})
+}
+
+type ConvexCollisionNode struct {
+	Name       nodes.Output[string]          `description:"Name of the model the hulls provide collision for"`
+	Hulls      nodes.Output[[]modeling.Mesh] `description:"Convex hulls making up the collision shape"`
+	Convention nodes.Output[string]          `description:"Engine naming convention the hulls follow, either unreal or godot. Defaults to unreal"`
+}
+
+func (ConvexCollisionNode) Description() string {
+	return "Groups convex hulls under a single model, naming each one so game engines import it as collision"
+}
+
+func (n ConvexCollisionNode) Out(out *nodes.StructOutput[*PolyformModel]) {
+	name := nodes.TryGetOutputValue(out, n.Name, "Mesh")
+	convention := CollisionNamingConvention(nodes.TryGetOutputValue(out, n.Convention, string(CollisionNamingUnreal)))
+
+	children, err := ConvexCollisionModels(name, nodes.TryGetOutputValue(out, n.Hulls, nil), convention)
+	if err != nil {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Convention, Message: err.Error()})
+	}
+
+	out.Set(&PolyformModel{
+		Name:     name + "_collision",
+		Children: children,
+	})
+}
+
+type MaterialVariantNode struct {
+	Name     nodes.Output[string]           `description:"Name of the variant, shared by every model with a material for it"`
+	Material nodes.Output[PolyformMaterial] `description:"Material to render the model with when the variant is active"`
+}
+
+func (n MaterialVariantNode) Description() string {
+	return "A named alternative material for a model, exported using the KHR_materials_variants extension"
+}
+
+func (n MaterialVariantNode) Out(out *nodes.StructOutput[PolyformMaterialVariant]) {
+	if n.Name == nil {
+		out.CaptureError(errors.New("material variants must be named"))
+		return
+	}
+
+	name := nodes.GetOutputValue(out, n.Name)
+	if name == "" {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Name, Message: "material variants must be named"})
+		return
+	}
+
+	material := nodes.TryGetOutputReference(out, n.Material, nil)
+	if material == nil {
+		material = &PolyformMaterial{}
+	}
+
+	out.Set(PolyformMaterialVariant{
+		Variant:  name,
+		Material: material,
 	})
 }
 
+type LODNode struct {
+	Mesh           nodes.Output[modeling.Mesh]    `description:"The full detail triangle mesh to build levels of detail from"`
+	Levels         nodes.Output[int]              `description:"Number of levels of detail to generate. Defaults to 3"`
+	Ratio          nodes.Output[float64]          `description:"Fraction of triangles each level keeps from the level before it. Defaults to 0.5"`
+	ScreenCoverage nodes.Output[float64]          `description:"Fraction of the screen the model must shrink below before the first level of detail is displayed. Defaults to 0.5"`
+	Material       nodes.Output[PolyformMaterial] `description:"Material to render the levels of detail with, instead of the model's material"`
+}
+
+func (n LODNode) Description() string {
+	return "Generates progressively simplified versions of a mesh, to be exported as levels of detail of a model"
+}
+
+func (n LODNode) Out(out *nodes.StructOutput[[]PolyformLOD]) {
+	if n.Mesh == nil {
+		return
+	}
+
+	mesh := nodes.GetOutputValue(out, n.Mesh)
+	if mesh.Topology() != modeling.TriangleTopology {
+		out.CaptureError(fmt.Errorf("can only generate levels of detail for triangle meshes, received %s topology", mesh.Topology()))
+		return
+	}
+
+	ratio := nodes.TryGetOutputValue(out, n.Ratio, 0.5)
+	if ratio <= 0 || ratio >= 1 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Ratio, Message: "ratio must be between 0 and 1"})
+		return
+	}
+
+	lods := GenerateLODs(
+		mesh,
+		max(0, nodes.TryGetOutputValue(out, n.Levels, 3)),
+		ratio,
+		max(0, nodes.TryGetOutputValue(out, n.ScreenCoverage, 0.5)),
+	)
+
+	material := nodes.TryGetOutputReference(out, n.Material, nil)
+	for i := range lods {
+		lods[i].Material = material
+	}
+	out.Set(lods)
+}
+
+type PerspectiveCameraNode struct {
+	Name        nodes.Output[string]          `description:"Name of the camera"`
+	Position    nodes.Output[vector3.Float64] `description:"Position of the camera. Defaults to (0, 0, 5)"`
+	Target      nodes.Output[vector3.Float64] `description:"Position the camera looks at. Defaults to (0, 0, 0)"`
+	FieldOfView nodes.Output[float64]         `description:"Vertical field of view in degrees. Defaults to 60"`
+	AspectRatio nodes.Output[float64]         `description:"Aspect ratio of the field of view. The aspect ratio of the viewport is used when left unset"`
+	Near        nodes.Output[float64]         `description:"Distance to the near clipping plane. Defaults to 0.01"`
+	Far         nodes.Output[float64]         `description:"Distance to the far clipping plane. The projection is infinite when left unset"`
+}
+
+func (n PerspectiveCameraNode) Description() string {
+	return "A camera with a perspective projection, to be added to a scene"
+}
+
+func (n PerspectiveCameraNode) Out(out *nodes.StructOutput[PolyformCamera]) {
+	fov := nodes.TryGetOutputValue(out, n.FieldOfView, 60.)
+	if fov <= 0 || fov >= 180 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.FieldOfView, Message: "field of view must be between 0 and 180 degrees"})
+		return
+	}
+
+	transform := CameraLookAt(
+		nodes.TryGetOutputValue(out, n.Position, vector3.New(0., 0., 5.)),
+		nodes.TryGetOutputValue(out, n.Target, vector3.Zero[float64]()),
+	)
+
+	camera := PolyformCamera{
+		Name: nodes.TryGetOutputValue(out, n.Name, "Camera"),
+		TRS:  &transform,
+		Perspective: &CameraPerspective{
+			YFov:        fov * (math.Pi / 180),
+			AspectRatio: nodes.TryGetOutputReference(out, n.AspectRatio, nil),
+			ZNear:       nodes.TryGetOutputValue(out, n.Near, 0.01),
+			ZFar:        nodes.TryGetOutputReference(out, n.Far, nil),
+		},
+	}
+
+	if _, err := camera.toGltf(); err != nil {
+		out.CaptureError(err)
+		return
+	}
+	out.Set(camera)
+}
+
+type OrthographicCameraNode struct {
+	Name     nodes.Output[string]          `description:"Name of the camera"`
+	Position nodes.Output[vector3.Float64] `description:"Position of the camera. Defaults to (0, 0, 5)"`
+	Target   nodes.Output[vector3.Float64] `description:"Position the camera looks at. Defaults to (0, 0, 0)"`
+	Width    nodes.Output[float64]         `description:"Width of the region of the scene in view. Defaults to 2"`
+	Height   nodes.Output[float64]         `description:"Height of the region of the scene in view. Defaults to 2"`
+	Near     nodes.Output[float64]         `description:"Distance to the near clipping plane. Defaults to 0.01"`
+	Far      nodes.Output[float64]         `description:"Distance to the far clipping plane. Defaults to 100"`
+}
+
+func (n OrthographicCameraNode) Description() string {
+	return "A camera with an orthographic projection, to be added to a scene"
+}
+
+func (n OrthographicCameraNode) Out(out *nodes.StructOutput[PolyformCamera]) {
+	transform := CameraLookAt(
+		nodes.TryGetOutputValue(out, n.Position, vector3.New(0., 0., 5.)),
+		nodes.TryGetOutputValue(out, n.Target, vector3.Zero[float64]()),
+	)
+
+	camera := PolyformCamera{
+		Name: nodes.TryGetOutputValue(out, n.Name, "Camera"),
+		TRS:  &transform,
+		Orthographic: &CameraOrthographic{
+			// Magnification is half the extent of the view
+			XMag:  nodes.TryGetOutputValue(out, n.Width, 2.) / 2,
+			YMag:  nodes.TryGetOutputValue(out, n.Height, 2.) / 2,
+			ZNear: nodes.TryGetOutputValue(out, n.Near, 0.01),
+			ZFar:  nodes.TryGetOutputValue(out, n.Far, 100.),
+		},
+	}
+
+	if _, err := camera.toGltf(); err != nil {
+		out.CaptureError(err)
+		return
+	}
+	out.Set(camera)
+}
+
 type TextureReferenceNode struct {
 	URI     nodes.Output[string]
 	Sampler nodes.Output[Sampler]
@@ -189,6 +189,9 @@ func decodePrimitiveAttributeName(name string) string {
 	case NORMAL:
 		return modeling.NormalAttribute
 
+	case TANGENT:
+		return modeling.TangentAttribute
+
 	default:
 		return name
 	}
@@ -200,65 +203,71 @@ func decodeScalarAccessor(doc *Gltf, id GltfId, buffers [][]byte) ([]float64, er
 		return nil, fmt.Errorf("unexpected accessor type for scalar: %s", accessor.Type)
 	}
 
-	if accessor.BufferView == nil {
+	if accessor.BufferView == nil && accessor.Sparse == nil {
 		return nil, fmt.Errorf("scalar accessor %d missing buffer view", id)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
-
-	stride := accessor.ComponentType.Size()
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
-	}
-
 	values := make([]float64, accessor.Count)
+	if accessor.BufferView != nil {
+		bufferView := doc.BufferViews[*accessor.BufferView]
+		buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
 
-	switch accessor.ComponentType {
-	case AccessorComponentType_FLOAT:
-		for i := range accessor.Count {
-			offset := i * stride
-			values[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
+		stride := accessor.ComponentType.Size()
+		if bufferView.ByteStride != nil {
+			stride = *bufferView.ByteStride
 		}
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			values[i] = float64(buffer[offset]) / div
-		}
+		switch accessor.ComponentType {
+		case AccessorComponentType_FLOAT:
+			for i := range accessor.Count {
+				offset := i * stride
+				values[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
+			}
 
-	case AccessorComponentType_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			values[i] = float64(int8(buffer[offset])) / div
-		}
+		case AccessorComponentType_UNSIGNED_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				values[i] = float64(buffer[offset]) / div
+			}
 
-	case AccessorComponentType_UNSIGNED_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			values[i] = float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
-		}
+		case AccessorComponentType_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				values[i] = float64(int8(buffer[offset])) / div
+			}
 
-	case AccessorComponentType_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			values[i] = float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
-		}
+		case AccessorComponentType_UNSIGNED_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				values[i] = float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
+			}
 
-	case AccessorComponentType_UNSIGNED_INT:
-		for i := range accessor.Count {
-			offset := i * stride
-			values[i] = float64(binary.LittleEndian.Uint32(buffer[offset:]))
-		}
+		case AccessorComponentType_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				values[i] = float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
+			}
 
-	default:
-		return nil, fmt.Errorf("unsupported accessor component type for scalar: %d", accessor.ComponentType)
+		case AccessorComponentType_UNSIGNED_INT:
+			for i := range accessor.Count {
+				offset := i * stride
+				values[i] = float64(binary.LittleEndian.Uint32(buffer[offset:]))
+			}
+
+		default:
+			return nil, fmt.Errorf("unsupported accessor component type for scalar: %d", accessor.ComponentType)
+		}
 	}
 
+	if err := applySparse(doc, accessor, buffers, 1, func(i int, v []float64) {
+		values[i] = v[0]
+	}); err != nil {
+		return nil, err
+	}
 	return values, nil
 }
 
@@ -268,70 +277,76 @@ func decodeVector2Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector2.Fl
 		return nil, fmt.Errorf("unexpected accessor type for vec2: %s", accessor.Type)
 	}
 
-	if accessor.BufferView == nil {
+	if accessor.BufferView == nil && accessor.Sparse == nil {
 		return nil, fmt.Errorf("vec2 accessor %d missing buffer view", id)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
+	vectors := make([]vector2.Float64, accessor.Count)
+	if accessor.BufferView != nil {
+		bufferView := doc.BufferViews[*accessor.BufferView]
+		buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
 
-	stride := accessor.ComponentType.Size() * 2
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
-	}
+		stride := accessor.ComponentType.Size() * 2
+		if bufferView.ByteStride != nil {
+			stride = *bufferView.ByteStride
+		}
 
-	vectors := make([]vector2.Float64, accessor.Count)
+		switch accessor.ComponentType {
 
-	switch accessor.ComponentType {
+		case AccessorComponentType_FLOAT:
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
+				y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
+				vectors[i] = vector2.New(x, y)
+			}
 
-	case AccessorComponentType_FLOAT:
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
-			y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
-			vectors[i] = vector2.New(x, y)
-		}
+		case AccessorComponentType_UNSIGNED_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(buffer[offset]) / div
+				y := float64(buffer[offset+1]) / div
+				vectors[i] = vector2.New(x, y)
+			}
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(buffer[offset]) / div
-			y := float64(buffer[offset+1]) / div
-			vectors[i] = vector2.New(x, y)
-		}
+		case AccessorComponentType_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(int8(buffer[offset])) / div
+				y := float64(int8(buffer[offset+1])) / div
+				vectors[i] = vector2.New(x, y)
+			}
 
-	case AccessorComponentType_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(int8(buffer[offset])) / div
-			y := float64(int8(buffer[offset+1])) / div
-			vectors[i] = vector2.New(x, y)
-		}
+		case AccessorComponentType_UNSIGNED_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
+				y := float64(binary.LittleEndian.Uint16(buffer[offset+2:])) / div
+				vectors[i] = vector2.New(x, y)
+			}
 
-	case AccessorComponentType_UNSIGNED_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
-			y := float64(binary.LittleEndian.Uint16(buffer[offset+2:])) / div
-			vectors[i] = vector2.New(x, y)
-		}
+		case AccessorComponentType_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
+				y := float64(int16(binary.LittleEndian.Uint16(buffer[offset+2:]))) / div
+				vectors[i] = vector2.New(x, y)
+			}
 
-	case AccessorComponentType_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
-			y := float64(int16(binary.LittleEndian.Uint16(buffer[offset+2:]))) / div
-			vectors[i] = vector2.New(x, y)
+		default:
+			return nil, fmt.Errorf("unsupported accessor component type for vec2: %d", accessor.ComponentType)
 		}
-
-	default:
-		return nil, fmt.Errorf("unsupported accessor component type for vec2: %d", accessor.ComponentType)
 	}
 
+	if err := applySparse(doc, accessor, buffers, 2, func(i int, v []float64) {
+		vectors[i] = vector2.New(v[0], v[1])
+	}); err != nil {
+		return nil, err
+	}
 	return vectors, nil
 }
 
@@ -364,75 +379,81 @@ func decodeVector3Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector3.Fl
 		return nil, fmt.Errorf("unexpected accessor type for vec3: %s", accessor.Type)
 	}
 
-	if accessor.BufferView == nil {
+	if accessor.BufferView == nil && accessor.Sparse == nil {
 		return nil, fmt.Errorf("vec3 accessor %d missing buffer view", id)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
+	vectors := make([]vector3.Float64, accessor.Count)
+	if accessor.BufferView != nil {
+		bufferView := doc.BufferViews[*accessor.BufferView]
+		buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
 
-	stride := accessor.ComponentType.Size() * 3
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
-	}
+		stride := accessor.ComponentType.Size() * 3
+		if bufferView.ByteStride != nil {
+			stride = *bufferView.ByteStride
+		}
 
-	vectors := make([]vector3.Float64, accessor.Count)
+		switch accessor.ComponentType {
 
-	switch accessor.ComponentType {
+		case AccessorComponentType_FLOAT:
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
+				y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
+				z := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+8:])))
+				vectors[i] = vector3.New(x, y, z)
+			}
 
-	case AccessorComponentType_FLOAT:
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
-			y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
-			z := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+8:])))
-			vectors[i] = vector3.New(x, y, z)
-		}
+		case AccessorComponentType_UNSIGNED_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(buffer[offset]) / div
+				y := float64(buffer[offset+1]) / div
+				z := float64(buffer[offset+2]) / div
+				vectors[i] = vector3.New(x, y, z)
+			}
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(buffer[offset]) / div
-			y := float64(buffer[offset+1]) / div
-			z := float64(buffer[offset+2]) / div
-			vectors[i] = vector3.New(x, y, z)
-		}
+		case AccessorComponentType_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(int8(buffer[offset])) / div
+				y := float64(int8(buffer[offset+1])) / div
+				z := float64(int8(buffer[offset+2])) / div
+				vectors[i] = vector3.New(x, y, z)
+			}
 
-	case AccessorComponentType_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(int8(buffer[offset])) / div
-			y := float64(int8(buffer[offset+1])) / div
-			z := float64(int8(buffer[offset+2])) / div
-			vectors[i] = vector3.New(x, y, z)
-		}
+		case AccessorComponentType_UNSIGNED_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
+				y := float64(binary.LittleEndian.Uint16(buffer[offset+2:])) / div
+				z := float64(binary.LittleEndian.Uint16(buffer[offset+4:])) / div
+				vectors[i] = vector3.New(x, y, z)
+			}
 
-	case AccessorComponentType_UNSIGNED_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
-			y := float64(binary.LittleEndian.Uint16(buffer[offset+2:])) / div
-			z := float64(binary.LittleEndian.Uint16(buffer[offset+4:])) / div
-			vectors[i] = vector3.New(x, y, z)
-		}
+		case AccessorComponentType_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range accessor.Count {
+				offset := i * stride
+				x := float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
+				y := float64(int16(binary.LittleEndian.Uint16(buffer[offset+2:]))) / div
+				z := float64(int16(binary.LittleEndian.Uint16(buffer[offset+4:]))) / div
+				vectors[i] = vector3.New(x, y, z)
+			}
 
-	case AccessorComponentType_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range accessor.Count {
-			offset := i * stride
-			x := float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
-			y := float64(int16(binary.LittleEndian.Uint16(buffer[offset+2:]))) / div
-			z := float64(int16(binary.LittleEndian.Uint16(buffer[offset+4:]))) / div
-			vectors[i] = vector3.New(x, y, z)
+		default:
+			return nil, fmt.Errorf("unsupported accessor component type for vec3: %d", accessor.ComponentType)
 		}
-
-	default:
-		return nil, fmt.Errorf("unsupported accessor component type for vec3: %d", accessor.ComponentType)
 	}
 
+	if err := applySparse(doc, accessor, buffers, 3, func(i int, v []float64) {
+		vectors[i] = vector3.New(v[0], v[1], v[2])
+	}); err != nil {
+		return nil, err
+	}
 	return vectors, nil
 }
 
@@ -442,78 +463,85 @@ func decodeVector4Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector4.Fl
 		return nil, fmt.Errorf("unexpected accessor type for vec4: %s", accessor.Type)
 	}
 
-	if accessor.BufferView == nil {
+	if accessor.BufferView == nil && accessor.Sparse == nil {
 		return nil, fmt.Errorf("vec4 accessor %d missing buffer view", id)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
-
-	stride := accessor.ComponentType.Size() * 4
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
-	}
-
 	vectors := make([]vector4.Float64, accessor.Count)
-	switch accessor.ComponentType {
-	case AccessorComponentType_FLOAT:
-		for i := range vectors {
-			offset := i * stride
-			x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
-			y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
-			z := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+8:])))
-			w := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+12:])))
-			vectors[i] = vector4.New(x, y, z, w)
-		}
+	if accessor.BufferView != nil {
+		bufferView := doc.BufferViews[*accessor.BufferView]
+		buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range vectors {
-			offset := i * stride
-			x := float64(buffer[offset]) / div
-			y := float64(buffer[offset+1]) / div
-			z := float64(buffer[offset+2]) / div
-			w := float64(buffer[offset+3]) / div
-			vectors[i] = vector4.New(x, y, z, w)
+		stride := accessor.ComponentType.Size() * 4
+		if bufferView.ByteStride != nil {
+			stride = *bufferView.ByteStride
 		}
 
-	case AccessorComponentType_BYTE:
-		div := accessorDivisor(accessor)
-		for i := range vectors {
-			offset := i * stride
-			x := float64(int8(buffer[offset])) / div
-			y := float64(int8(buffer[offset+1])) / div
-			z := float64(int8(buffer[offset+2])) / div
-			w := float64(int8(buffer[offset+3])) / div
-			vectors[i] = vector4.New(x, y, z, w)
-		}
+		switch accessor.ComponentType {
+		case AccessorComponentType_FLOAT:
+			for i := range vectors {
+				offset := i * stride
+				x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
+				y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
+				z := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+8:])))
+				w := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+12:])))
+				vectors[i] = vector4.New(x, y, z, w)
+			}
 
-	case AccessorComponentType_UNSIGNED_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range vectors {
-			offset := i * stride
-			x := float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
-			y := float64(binary.LittleEndian.Uint16(buffer[offset+2:])) / div
-			z := float64(binary.LittleEndian.Uint16(buffer[offset+4:])) / div
-			w := float64(binary.LittleEndian.Uint16(buffer[offset+6:])) / div
-			vectors[i] = vector4.New(x, y, z, w)
-		}
+		case AccessorComponentType_UNSIGNED_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range vectors {
+				offset := i * stride
+				x := float64(buffer[offset]) / div
+				y := float64(buffer[offset+1]) / div
+				z := float64(buffer[offset+2]) / div
+				w := float64(buffer[offset+3]) / div
+				vectors[i] = vector4.New(x, y, z, w)
+			}
 
-	case AccessorComponentType_SHORT:
-		div := accessorDivisor(accessor)
-		for i := range vectors {
-			offset := i * stride
-			x := float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
-			y := float64(int16(binary.LittleEndian.Uint16(buffer[offset+2:]))) / div
-			z := float64(int16(binary.LittleEndian.Uint16(buffer[offset+4:]))) / div
-			w := float64(int16(binary.LittleEndian.Uint16(buffer[offset+6:]))) / div
-			vectors[i] = vector4.New(x, y, z, w)
-		}
+		case AccessorComponentType_BYTE:
+			div := accessorDivisor(accessor)
+			for i := range vectors {
+				offset := i * stride
+				x := float64(int8(buffer[offset])) / div
+				y := float64(int8(buffer[offset+1])) / div
+				z := float64(int8(buffer[offset+2])) / div
+				w := float64(int8(buffer[offset+3])) / div
+				vectors[i] = vector4.New(x, y, z, w)
+			}
 
-	default:
-		return nil, fmt.Errorf("unsupported accessor component type for vec4: %d", accessor.ComponentType)
+		case AccessorComponentType_UNSIGNED_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range vectors {
+				offset := i * stride
+				x := float64(binary.LittleEndian.Uint16(buffer[offset:])) / div
+				y := float64(binary.LittleEndian.Uint16(buffer[offset+2:])) / div
+				z := float64(binary.LittleEndian.Uint16(buffer[offset+4:])) / div
+				w := float64(binary.LittleEndian.Uint16(buffer[offset+6:])) / div
+				vectors[i] = vector4.New(x, y, z, w)
+			}
+
+		case AccessorComponentType_SHORT:
+			div := accessorDivisor(accessor)
+			for i := range vectors {
+				offset := i * stride
+				x := float64(int16(binary.LittleEndian.Uint16(buffer[offset:]))) / div
+				y := float64(int16(binary.LittleEndian.Uint16(buffer[offset+2:]))) / div
+				z := float64(int16(binary.LittleEndian.Uint16(buffer[offset+4:]))) / div
+				w := float64(int16(binary.LittleEndian.Uint16(buffer[offset+6:]))) / div
+				vectors[i] = vector4.New(x, y, z, w)
+			}
+
+		default:
+			return nil, fmt.Errorf("unsupported accessor component type for vec4: %d", accessor.ComponentType)
+		}
 	}
 
+	if err := applySparse(doc, accessor, buffers, 4, func(i int, v []float64) {
+		vectors[i] = vector4.New(v[0], v[1], v[2], v[3])
+	}); err != nil {
+		return nil, err
+	}
 	return vectors, nil
 }
 
@@ -695,6 +723,46 @@ func loadMaterial(doc *Gltf, materialId GltfId, opts ReaderOptions, buffers [][]
 	return material, nil
 }
 
+func decodeAttribute(doc *Gltf, buffers [][]byte, mesh modeling.Mesh, attr, attributeName string, gltfId GltfId) (modeling.Mesh, error) {
+	if gltfId < 0 || gltfId >= len(doc.Accessors) {
+		return mesh, fmt.Errorf("attribute %s references invalid accessor %d", attr, gltfId)
+	}
+
+	accessor := doc.Accessors[gltfId]
+	switch accessor.Type {
+	case AccessorType_SCALAR:
+		values, err := decodeScalarAccessor(doc, gltfId, buffers)
+		if err != nil {
+			return mesh, fmt.Errorf("failed to decode scalar attribute %s: %w", attr, err)
+		}
+		return mesh.SetFloat1Attribute(attributeName, values), nil
+
+	case AccessorType_VEC2:
+		v2, err := decodeVector2Accessor(doc, gltfId, buffers)
+		if err != nil {
+			return mesh, fmt.Errorf("failed to decode vec2 attribute %s: %w", attr, err)
+		}
+		return mesh.SetFloat2Attribute(attributeName, v2), nil
+
+	case AccessorType_VEC3:
+		v3, err := decodeVector3Accessor(doc, gltfId, buffers)
+		if err != nil {
+			return mesh, fmt.Errorf("failed to decode vec3 attribute %s: %w", attr, err)
+		}
+		return mesh.SetFloat3Attribute(attributeName, v3), nil
+
+	case AccessorType_VEC4:
+		v4, err := decodeVector4Accessor(doc, gltfId, buffers)
+		if err != nil {
+			return mesh, fmt.Errorf("failed to decode vec4 attribute %s: %w", attr, err)
+		}
+		return mesh.SetFloat4Attribute(attributeName, v4), nil
+
+	default:
+		return mesh, fmt.Errorf("unsupported accessor type %s for attribute %s", accessor.Type, attr)
+	}
+}
+
 func decodePrimitive(doc *Gltf, buffers [][]byte, p Primitive, opts ReaderOptions, imgCache imgReaderCache) (*modeling.Mesh, *PolyformMaterial, error) {
 	var indices []int
 	var err error
@@ -709,44 +777,20 @@ func decodePrimitive(doc *Gltf, buffers [][]byte, p Primitive, opts ReaderOption
 
 	// Process all attributes
 	for attr, gltfId := range p.Attributes {
-		if gltfId >= len(doc.Accessors) {
-			return nil, nil, fmt.Errorf("attribute %s references invalid accessor %d", attr, gltfId)
+		mesh, err = decodeAttribute(doc, buffers, mesh, attr, decodePrimitiveAttributeName(attr), gltfId)
+		if err != nil {
+			return nil, nil, err
 		}
+	}
 
-		accessor := doc.Accessors[gltfId]
-		attributeName := decodePrimitiveAttributeName(attr)
-
-		switch accessor.Type {
-		case AccessorType_SCALAR:
-			values, err := decodeScalarAccessor(doc, gltfId, buffers)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to decode scalar attribute %s: %w", attr, err)
-			}
-			mesh = mesh.SetFloat1Attribute(attributeName, values)
-
-		case AccessorType_VEC2:
-			v2, err := decodeVector2Accessor(doc, gltfId, buffers)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to decode vec2 attribute %s: %w", attr, err)
-			}
-			mesh = mesh.SetFloat2Attribute(attributeName, v2)
-
-		case AccessorType_VEC3:
-			v3, err := decodeVector3Accessor(doc, gltfId, buffers)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to decode vec3 attribute %s: %w", attr, err)
-			}
-			mesh = mesh.SetFloat3Attribute(attributeName, v3)
-
-		case AccessorType_VEC4:
-			v4, err := decodeVector4Accessor(doc, gltfId, buffers)
+	// Morph target displacements are stored as their own attributes
+	for target, attributes := range p.Targets {
+		for attr, gltfId := range attributes {
+			name := MorphTargetAttribute(decodePrimitiveAttributeName(attr), target)
+			mesh, err = decodeAttribute(doc, buffers, mesh, attr, name, gltfId)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to decode vec4 attribute %s: %w", attr, err)
+				return nil, nil, fmt.Errorf("morph target %d: %w", target, err)
 			}
-			mesh = mesh.SetFloat4Attribute(attributeName, v4)
-
-		default:
-			return nil, nil, fmt.Errorf("unsupported accessor type %s for attribute %s", accessor.Type, attr)
 		}
 	}
 
@@ -775,7 +819,13 @@ func decodeNode(doc *Gltf, buffers [][]byte, n Node, opts ReaderOptions, imgCach
 				return nil, fmt.Errorf("unable to decode node %s mesh %d primitive 0: %w", n.Name, *n.Mesh, err)
 			}
 
+			variants, err := decodeMaterialVariants(doc, buffers, mesh.Primitives[0], opts, imgCache)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode node %s mesh %d primitive 0: %w", n.Name, *n.Mesh, err)
+			}
+
 			model.Material = decodedMat
+			model.MaterialVariants = variants
 			model.Mesh = decodedMesh
 		} else if len(mesh.Primitives) > 1 {
 			geometryNode := &PolyformModel{}
@@ -785,13 +835,51 @@ func decodeNode(doc *Gltf, buffers [][]byte, n Node, opts ReaderOptions, imgCach
 					return nil, fmt.Errorf("unable to decode node %s mesh %d primitive %d: %w", n.Name, *n.Mesh, primIndex, err)
 				}
 
+				variants, err := decodeMaterialVariants(doc, buffers, prim, opts, imgCache)
+				if err != nil {
+					return nil, fmt.Errorf("unable to decode node %s mesh %d primitive %d: %w", n.Name, *n.Mesh, primIndex, err)
+				}
+
 				geometryNode.Material = decodedMat
+				geometryNode.MaterialVariants = variants
 				geometryNode.Mesh = decodedMesh
 			}
 			model.Children = append(model.Children, geometryNode)
 		}
+
+		lods, err := decodeLODs(doc, buffers, n, opts, imgCache)
+		if err != nil {
+			return nil, err
+		}
+		model.LODs = lods
+
+		model.MorphTargetWeights = n.Weights
+		if model.MorphTargetWeights == nil {
+			model.MorphTargetWeights = mesh.Weights
+		}
+
+		if n.Skin != nil {
+			skin, err := decodeSkin(doc, buffers, *n.Skin)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode node %s skin %d: %w", n.Name, *n.Skin, err)
+			}
+
+			model.Skeleton = &skin.skeleton
+			model.Mesh = skin.remapJoints(model.Mesh)
+			for _, geometry := range model.Children {
+				geometry.Mesh = skin.remapJoints(geometry.Mesh)
+			}
+		}
 	}
 
+	transform := nodeTransform(n)
+	model.TRS = &transform
+
+	return model, nil
+}
+
+// nodeTransform builds the local transform of the node relative to its parent
+func nodeTransform(n Node) trs.TRS {
 	transform := trs.Identity()
 	if n.Matrix != nil {
 		transform = trs.FromMatrix(mat.FromColArray(*n.Matrix))
@@ -814,13 +902,11 @@ func decodeNode(doc *Gltf, buffers [][]byte, n Node, opts ReaderOptions, imgCach
 			transform = transform.SetRotation(p)
 		}
 	}
-	model.TRS = &transform
-
-	return model, nil
+	return transform
 }
 
 // processNodeHierarchy recursively processes a node and its children, accumulating transformations
-func processNodeHierarchy(doc *Gltf, buffers [][]byte, nodeIndex int, opts ReaderOptions, imgCache imgReaderCache) (*PolyformModel, error) {
+func processNodeHierarchy(doc *Gltf, buffers [][]byte, nodeIndex int, opts ReaderOptions, imgCache imgReaderCache, decoded map[GltfId]*PolyformModel) (*PolyformModel, error) {
 	if nodeIndex >= len(doc.Nodes) {
 		return nil, fmt.Errorf("invalid node index: %d", nodeIndex)
 	}
@@ -831,10 +917,11 @@ func processNodeHierarchy(doc *Gltf, buffers [][]byte, nodeIndex int, opts Reade
 	if err != nil {
 		return nil, err
 	}
+	decoded[nodeIndex] = model
 
 	// Process children recursively
 	for _, childIndex := range node.Children {
-		childModel, err := processNodeHierarchy(doc, buffers, childIndex, opts, imgCache)
+		childModel, err := processNodeHierarchy(doc, buffers, childIndex, opts, imgCache, decoded)
 		if err != nil {
 			return nil, fmt.Errorf("failed to process child node %d of node %d: %w", childIndex, nodeIndex, err)
 		}
@@ -935,6 +1022,11 @@ func LoadGLTF(r io.Reader, options *ReaderOptions) (*Gltf, [][]byte, error) {
 			return nil, nil, fmt.Errorf("buffer %d has invalid byte length: %d", bufIndex, buf.ByteLength)
 		}
 
+		if meshoptFallbackBuffer(buf) {
+			allBuffers = append(allBuffers, make([]byte, buf.ByteLength))
+			continue
+		}
+
 		bufferData, err := opts.BufferLoader.LoadBuffer(buf.URI)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to load buffer %d: %w", bufIndex, err)
@@ -947,6 +1039,10 @@ func LoadGLTF(r io.Reader, options *ReaderOptions) (*Gltf, [][]byte, error) {
 		allBuffers = append(allBuffers, bufferData)
 	}
 
+	if err := decodeMeshoptBufferViews(g, allBuffers); err != nil {
+		return nil, nil, fmt.Errorf("failed to decompress buffer views: %w", err)
+	}
+
 	return g, allBuffers, nil
 }
 
@@ -1000,6 +1096,11 @@ func LoadGLB(r io.Reader, options *ReaderOptions) (*Gltf, [][]byte, error) {
 			return nil, nil, fmt.Errorf("buffer %d has invalid byte length: %d", bufIndex, buf.ByteLength)
 		}
 
+		if meshoptFallbackBuffer(buf) {
+			allBuffers = append(allBuffers, make([]byte, buf.ByteLength))
+			continue
+		}
+
 		if buf.URI == "" {
 			allBuffers = append(allBuffers, binPayload)
 			continue
@@ -1017,7 +1118,15 @@ func LoadGLB(r io.Reader, options *ReaderOptions) (*Gltf, [][]byte, error) {
 		allBuffers = append(allBuffers, bufferData)
 	}
 
-	return doc, allBuffers, reader.Error()
+	if err := reader.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := decodeMeshoptBufferViews(doc, allBuffers); err != nil {
+		return nil, nil, fmt.Errorf("failed to decompress buffer views: %w", err)
+	}
+
+	return doc, allBuffers, nil
 }
 
 func initializeDefaultReaderOptions(opts *ReaderOptions) {
@@ -1110,11 +1219,12 @@ func DecodeModels(doc *Gltf, buffers [][]byte, options *ReaderOptions) ([]*Polyf
 	imgCache := make(imgReaderCache)
 
 	models := make([]*PolyformModel, 0)
+	decoded := make(map[GltfId]*PolyformModel)
 
 	if doc.Scene != nil {
 		scene := doc.Scenes[*doc.Scene]
 		for _, nodeIndex := range scene.Nodes {
-			model, err := processNodeHierarchy(doc, buffers, nodeIndex, opts, imgCache)
+			model, err := processNodeHierarchy(doc, buffers, nodeIndex, opts, imgCache, decoded)
 			if err != nil {
 				return nil, fmt.Errorf("unable to decode node %d: %w", nodeIndex, err)
 			}
@@ -1123,7 +1233,7 @@ func DecodeModels(doc *Gltf, buffers [][]byte, options *ReaderOptions) ([]*Polyf
 	} else {
 		for _, scene := range doc.Scenes {
 			for _, nodeIndex := range scene.Nodes {
-				model, err := processNodeHierarchy(doc, buffers, nodeIndex, opts, imgCache)
+				model, err := processNodeHierarchy(doc, buffers, nodeIndex, opts, imgCache, decoded)
 				if err != nil {
 					return nil, fmt.Errorf("unable to decode node %d: %w", nodeIndex, err)
 				}
@@ -1132,6 +1242,12 @@ func DecodeModels(doc *Gltf, buffers [][]byte, options *ReaderOptions) ([]*Polyf
 		}
 	}
 
+	// Scene level animations have nowhere to live on a flat list of models,
+	// so only the joint sequences of skinned models are decoded
+	if _, err := decodeAnimations(doc, buffers, decoded, false); err != nil {
+		return nil, err
+	}
+
 	return models, nil
 }
 
@@ -1169,6 +1285,7 @@ func DecodeScene(doc *Gltf, buffers [][]byte, options *ReaderOptions) (*Polyform
 	}
 
 	imgCache := make(imgReaderCache)
+	decoded := make(map[GltfId]*PolyformModel)
 
 	// Get the main scene or use the first one
 	var sceneIndex int
@@ -1187,14 +1304,41 @@ func DecodeScene(doc *Gltf, buffers [][]byte, options *ReaderOptions) (*Polyform
 	gltfScene := doc.Scenes[sceneIndex]
 
 	// Process root nodes and their children recursively
+	cameraNodes := make([]GltfId, 0)
 	for _, rootNodeIndex := range gltfScene.Nodes {
-		model, err := processNodeHierarchy(doc, buffers, rootNodeIndex, opts, imgCache)
+		if rootNodeIndex >= 0 && rootNodeIndex < len(doc.Nodes) && cameraOnlyNode(doc.Nodes[rootNodeIndex]) {
+			cameraNodes = append(cameraNodes, rootNodeIndex)
+			continue
+		}
+
+		model, err := processNodeHierarchy(doc, buffers, rootNodeIndex, opts, imgCache, decoded)
 		if err != nil {
 			return nil, fmt.Errorf("failed to process root node %d: %w", rootNodeIndex, err)
 		}
 		scene.Models = append(scene.Models, model)
 	}
 
+	animations, err := decodeAnimations(doc, buffers, decoded, true)
+	if err != nil {
+		return nil, err
+	}
+	scene.Animations = animations
+
+	for nodeIndex := range decoded {
+		cameraNodes = append(cameraNodes, nodeIndex)
+	}
+	cameras, err := decodeCameras(doc, cameraNodes)
+	if err != nil {
+		return nil, err
+	}
+	scene.Cameras = cameras
+
+	variants, err := decodeMaterialVariantNames(doc)
+	if err != nil {
+		return nil, err
+	}
+	scene.MaterialVariants = variants
+
 	return scene, nil
 }
 
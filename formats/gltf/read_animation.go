@@ -0,0 +1,406 @@
+package gltf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/EliCDavis/polyform/math/mat"
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/animation"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+func decodeMatrix4Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]mat.Matrix4x4, error) {
+	accessor := doc.Accessors[id]
+	if accessor.Type != AccessorType_MAT4 {
+		return nil, fmt.Errorf("unexpected accessor type for mat4: %s", accessor.Type)
+	}
+
+	if accessor.ComponentType != AccessorComponentType_FLOAT {
+		return nil, fmt.Errorf("unsupported accessor component type for mat4: %d", accessor.ComponentType)
+	}
+
+	if accessor.BufferView == nil {
+		return nil, fmt.Errorf("mat4 accessor %d missing buffer view", id)
+	}
+
+	bufferView := doc.BufferViews[*accessor.BufferView]
+	buffer := resolveBufferview(bufferView, buffers)[accessor.ByteOffset:]
+
+	stride := 16 * 4
+	if bufferView.ByteStride != nil {
+		stride = *bufferView.ByteStride
+	}
+
+	matrices := make([]mat.Matrix4x4, accessor.Count)
+	for i := range matrices {
+		var data [16]float64
+		for c := range data {
+			offset := i*stride + c*4
+			data[c] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
+		}
+		matrices[i] = mat.FromColArray(data)
+	}
+	return matrices, nil
+}
+
+// decodedSkin is a GLTF skin converted into a polyform skeleton
+type decodedSkin struct {
+	skeleton animation.Skeleton
+
+	// Skeleton path of every node used as a joint within the skin
+	jointPaths map[GltfId]string
+
+	// Skeleton joint index for each joint index of the skin
+	remap []int
+}
+
+// remapJoints converts the joint attribute of the mesh from indexing the
+// skin's joints to indexing the joints of the decoded skeleton
+func (ds decodedSkin) remapJoints(m *modeling.Mesh) *modeling.Mesh {
+	if m == nil || !m.HasFloat4Attribute(modeling.JointAttribute) {
+		return m
+	}
+
+	remap := func(v float64) float64 {
+		i := int(v)
+		if i < 0 || i >= len(ds.remap) {
+			return v
+		}
+		return float64(ds.remap[i])
+	}
+
+	joints := m.Float4Attribute(modeling.JointAttribute)
+	remapped := make([]vector4.Float64, joints.Len())
+	for i := range remapped {
+		j := joints.At(i)
+		remapped[i] = vector4.New(remap(j.X()), remap(j.Y()), remap(j.Z()), remap(j.W()))
+	}
+
+	result := m.SetFloat4Attribute(modeling.JointAttribute, remapped)
+	return &result
+}
+
+// nodeWorldMatrix computes the transform of the node relative to the root of
+// the document
+func nodeWorldMatrix(doc *Gltf, parents []GltfId, node GltfId) mat.Matrix4x4 {
+	world := mat.Identity()
+	for n := node; n != -1; n = parents[n] {
+		world = nodeTransform(doc.Nodes[n]).Matrix().Multiply(world)
+	}
+	return world
+}
+
+func nodeParents(doc *Gltf) []GltfId {
+	parents := make([]GltfId, len(doc.Nodes))
+	for i := range parents {
+		parents[i] = -1
+	}
+	for i, n := range doc.Nodes {
+		for _, child := range n.Children {
+			if child >= 0 && child < len(parents) {
+				parents[child] = i
+			}
+		}
+	}
+	return parents
+}
+
+// decodeSkin converts a GLTF skin into a skeleton. The bind pose of each joint
+// comes from its inverse bind matrix when available, and the joint node's
+// transform otherwise. Skins with multiple root joints are placed under a
+// shared root joint.
+func decodeSkin(doc *Gltf, buffers [][]byte, skinIndex GltfId) (*decodedSkin, error) {
+	if skinIndex < 0 || skinIndex >= len(doc.Skins) {
+		return nil, fmt.Errorf("invalid skin index: %d", skinIndex)
+	}
+
+	skin := doc.Skins[skinIndex]
+	if len(skin.Joints) == 0 {
+		return nil, fmt.Errorf("skin %d contains no joints", skinIndex)
+	}
+
+	var inverseBindMatrices []mat.Matrix4x4
+	if skin.InverseBindMatrices != nil {
+		if *skin.InverseBindMatrices < 0 || *skin.InverseBindMatrices >= len(doc.Accessors) {
+			return nil, fmt.Errorf("skin %d references invalid accessor %d", skinIndex, *skin.InverseBindMatrices)
+		}
+
+		var err error
+		inverseBindMatrices, err = decodeMatrix4Accessor(doc, *skin.InverseBindMatrices, buffers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode inverse bind matrices: %w", err)
+		}
+	}
+
+	parents := nodeParents(doc)
+	jointIndex := make(map[GltfId]int, len(skin.Joints))
+	for i, node := range skin.Joints {
+		if node < 0 || node >= len(doc.Nodes) {
+			return nil, fmt.Errorf("skin %d references invalid node %d", skinIndex, node)
+		}
+		jointIndex[node] = i
+	}
+
+	// Joints are parented to their closest ancestor that's also a joint
+	roots := make([]GltfId, 0)
+	children := make(map[GltfId][]GltfId)
+	for _, node := range skin.Joints {
+		parent := parents[node]
+		for parent != -1 {
+			if _, ok := jointIndex[parent]; ok {
+				break
+			}
+			parent = parents[parent]
+		}
+
+		if parent == -1 {
+			roots = append(roots, node)
+		} else {
+			children[parent] = append(children[parent], node)
+		}
+	}
+
+	jointPaths := make(map[GltfId]string, len(skin.Joints))
+	var buildJoint func(node GltfId, path string, siblingNames map[string]struct{}) animation.Joint
+	buildJoint = func(node GltfId, path string, siblingNames map[string]struct{}) animation.Joint {
+		name := strings.ReplaceAll(doc.Nodes[node].Name, "/", "_")
+		if name == "" {
+			name = fmt.Sprintf("joint%d", node)
+		}
+		if _, taken := siblingNames[name]; taken {
+			name = fmt.Sprintf("%s_%d", name, node)
+		}
+		siblingNames[name] = struct{}{}
+
+		if path != "" {
+			path += "/"
+		}
+		path += name
+		jointPaths[node] = path
+
+		var bind mat.Matrix4x4
+		if i := jointIndex[node]; i < len(inverseBindMatrices) {
+			bind = inverseBindMatrices[i].Inverse()
+		} else {
+			bind = nodeWorldMatrix(doc, parents, node)
+		}
+
+		childNames := make(map[string]struct{})
+		childJoints := make([]animation.Joint, 0, len(children[node]))
+		for _, child := range children[node] {
+			childJoints = append(childJoints, buildJoint(child, path, childNames))
+		}
+
+		return animation.NewJoint(
+			name,
+			1,
+			vector3.New(bind.X03, bind.X13, bind.X23),
+			vector3.New(bind.X01, bind.X11, bind.X21).Normalized(),
+			vector3.New(bind.X02, bind.X12, bind.X22).Normalized(),
+			childJoints...,
+		)
+	}
+
+	var root animation.Joint
+	if len(roots) == 1 {
+		root = buildJoint(roots[0], "", make(map[string]struct{}))
+	} else {
+		rootName := "root"
+		rootNames := make(map[string]struct{})
+		rootJoints := make([]animation.Joint, len(roots))
+		for i, node := range roots {
+			rootJoints[i] = buildJoint(node, rootName, rootNames)
+		}
+		root = animation.NewJoint(
+			rootName,
+			1,
+			vector3.Zero[float64](),
+			vector3.Up[float64](),
+			vector3.Forward[float64](),
+			rootJoints...,
+		)
+	}
+
+	skeleton := animation.NewSkeleton(root)
+	remap := make([]int, len(skin.Joints))
+	for i, node := range skin.Joints {
+		remap[i] = skeleton.Lookup(jointPaths[node])
+	}
+
+	return &decodedSkin{
+		skeleton:   skeleton,
+		jointPaths: jointPaths,
+		remap:      remap,
+	}, nil
+}
+
+func decodeAnimationSampler(doc *Gltf, buffers [][]byte, sampler AnimationSampler, path AnimationChannelTargetPath) (*PolyformAnimationSampler, error) {
+	if sampler.Input < 0 || sampler.Input >= len(doc.Accessors) {
+		return nil, fmt.Errorf("sampler references invalid input accessor %d", sampler.Input)
+	}
+
+	if sampler.Output < 0 || sampler.Output >= len(doc.Accessors) {
+		return nil, fmt.Errorf("sampler references invalid output accessor %d", sampler.Output)
+	}
+
+	times, err := decodeScalarAccessor(doc, sampler.Input, buffers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sampler input: %w", err)
+	}
+
+	interpolation := sampler.Interpolation
+	if interpolation == "" {
+		interpolation = AnimationSamplerInterpolation_LINEAR
+	}
+
+	decoded := &PolyformAnimationSampler{
+		Interpolation: interpolation,
+		Times:         times,
+	}
+
+	switch path {
+	case AnimationChannelTargetPath_TRANSLATION, AnimationChannelTargetPath_SCALE:
+		v3, err := decodeVector3Accessor(doc, sampler.Output, buffers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sampler output: %w", err)
+		}
+		decoded.Data = Vector3AnimationSamplerData(v3)
+
+	case AnimationChannelTargetPath_ROTATION:
+		v4, err := decodeVector4Accessor(doc, sampler.Output, buffers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sampler output: %w", err)
+		}
+		rotations := make(RotationAnimationSamplerData, len(v4))
+		for i, v := range v4 {
+			rotations[i] = quaternion.New(vector3.New(v.X(), v.Y(), v.Z()), v.W())
+		}
+		decoded.Data = rotations
+
+	case AnimationChannelTargetPath_WEIGHTS:
+		weights, err := decodeScalarAccessor(doc, sampler.Output, buffers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sampler output: %w", err)
+		}
+		decoded.Data = WeightsAnimationSamplerData(weights)
+
+	default:
+		return nil, fmt.Errorf("unsupported animation channel path %q", path)
+	}
+
+	return decoded, nil
+}
+
+// jointSequence converts a translation sampler into the sequence of a single
+// skeleton joint. Cubic spline samplers only contribute their keyframe
+// values, dropping the tangents.
+func jointSequence(joint string, sampler *PolyformAnimationSampler) animation.Sequence {
+	values := sampler.Data.(Vector3AnimationSamplerData)
+	step, offset := 1, 0
+	if sampler.Interpolation == AnimationSamplerInterpolation_CUBICSPLINE {
+		step, offset = 3, 1
+	}
+
+	frames := make([]animation.Frame[vector3.Float64], 0, len(sampler.Times))
+	for i, t := range sampler.Times {
+		index := i*step + offset
+		if index >= len(values) {
+			break
+		}
+		frames = append(frames, animation.NewFrame(t, values[index]))
+	}
+	return animation.NewSequence(joint, frames)
+}
+
+// decodeAnimations converts every animation within the document that targets
+// a decoded node. Translations of skeleton joints are additionally attached
+// as sequences to every skinned model whose skin makes use of the joint.
+// Without sceneAnimations only the joint sequences are decoded, and no
+// animations are returned.
+func decodeAnimations(doc *Gltf, buffers [][]byte, decoded map[GltfId]*PolyformModel, sceneAnimations bool) ([]PolyformAnimation, error) {
+	if len(doc.Animations) == 0 {
+		return nil, nil
+	}
+
+	type skinnedJoint struct {
+		model *PolyformModel
+		path  string
+	}
+	joints := make(map[GltfId][]skinnedJoint)
+	for nodeIndex, model := range decoded {
+		skinIndex := doc.Nodes[nodeIndex].Skin
+		if skinIndex == nil || model.Skeleton == nil {
+			continue
+		}
+
+		skin, err := decodeSkin(doc, buffers, *skinIndex)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode skin %d: %w", *skinIndex, err)
+		}
+
+		for joint, path := range skin.jointPaths {
+			joints[joint] = append(joints[joint], skinnedJoint{model: model, path: path})
+		}
+	}
+
+	if !sceneAnimations && len(joints) == 0 {
+		return nil, nil
+	}
+
+	animations := make([]PolyformAnimation, 0, len(doc.Animations))
+	for animationIndex, anim := range doc.Animations {
+		decodedAnimation := PolyformAnimation{
+			Name:     anim.Name,
+			Channels: make([]PolyformAnimationChannel, 0, len(anim.Channels)),
+		}
+
+		for channelIndex, channel := range anim.Channels {
+			if channel.Target.Node == nil {
+				continue
+			}
+
+			target, ok := decoded[*channel.Target.Node]
+			if !ok {
+				continue
+			}
+
+			if channel.Sampler < 0 || channel.Sampler >= len(anim.Samplers) {
+				return nil, fmt.Errorf("animation %d channel %d references invalid sampler %d", animationIndex, channelIndex, channel.Sampler)
+			}
+
+			var jointTargets []skinnedJoint
+			if channel.Target.Path == AnimationChannelTargetPath_TRANSLATION {
+				jointTargets = joints[*channel.Target.Node]
+			}
+			if !sceneAnimations && len(jointTargets) == 0 {
+				continue
+			}
+
+			sampler, err := decodeAnimationSampler(doc, buffers, anim.Samplers[channel.Sampler], channel.Target.Path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode animation %d channel %d: %w", animationIndex, channelIndex, err)
+			}
+
+			decodedAnimation.Channels = append(decodedAnimation.Channels, PolyformAnimationChannel{
+				TargetPath: channel.Target.Path,
+				Target:     target,
+				Sampler:    *sampler,
+			})
+
+			for _, joint := range jointTargets {
+				joint.model.Animations = append(joint.model.Animations, jointSequence(joint.path, sampler))
+			}
+		}
+
+		if sceneAnimations {
+			animations = append(animations, decodedAnimation)
+		}
+	}
+
+	return animations, nil
+}
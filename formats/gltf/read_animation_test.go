@@ -0,0 +1,205 @@
+package gltf_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func roundTrip(t *testing.T, scene gltf.PolyformScene, modify func(doc *gltf.Gltf)) (*gltf.Gltf, *gltf.PolyformScene) {
+	t.Helper()
+
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteText(scene, &buf, nil))
+
+	if modify != nil {
+		doc := gltf.Gltf{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		modify(&doc)
+
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		buf = *bytes.NewBuffer(data)
+	}
+
+	doc, buffers, err := gltf.LoadGLTF(bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+
+	decoded, err := gltf.DecodeScene(doc, buffers, nil)
+	require.NoError(t, err)
+	return doc, decoded
+}
+
+func TestDecodeScene_MorphTargets(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+		}).
+		SetFloat3Attribute(gltf.MorphTargetAttribute(modeling.PositionAttribute, 0), []vector3.Float64{
+			vector3.New(0., 0., 1.),
+			vector3.New(0., 0., 2.),
+			vector3.New(0., 0., 3.),
+		})
+
+	model := &gltf.PolyformModel{
+		Name:               "morph",
+		Mesh:               &tri,
+		MorphTargetWeights: []float64{0.5},
+	}
+
+	// ACT ====================================================================
+	doc, scene := roundTrip(t, gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{model},
+		Animations: []gltf.PolyformAnimation{
+			{
+				Name: "wiggle",
+				Channels: []gltf.PolyformAnimationChannel{
+					{
+						TargetPath: gltf.AnimationChannelTargetPath_WEIGHTS,
+						Target:     model,
+						Sampler: gltf.PolyformAnimationSampler{
+							Interpolation: gltf.AnimationSamplerInterpolation_STEP,
+							Times:         []float64{0, 1},
+							Data:          gltf.WeightsAnimationSamplerData{0, 1},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	// ASSERT =================================================================
+	require.Len(t, doc.Meshes, 1)
+	primitive := doc.Meshes[0].Primitives[0]
+	assert.Len(t, primitive.Attributes, 1)
+	require.Len(t, primitive.Targets, 1)
+	assert.Contains(t, primitive.Targets[0], gltf.POSITION)
+	assert.Equal(t, []float64{0.5}, doc.Nodes[0].Weights)
+
+	require.Len(t, scene.Models, 1)
+	decoded := scene.Models[0]
+	assert.Equal(t, []float64{0.5}, decoded.MorphTargetWeights)
+	assert.Equal(t, tri.Float3Attribute(gltf.MorphTargetAttribute(modeling.PositionAttribute, 0)), decoded.Mesh.Float3Attribute("MorphTarget0_Position"))
+
+	require.Len(t, scene.Animations, 1)
+	animation := scene.Animations[0]
+	assert.Equal(t, "wiggle", animation.Name)
+	require.Len(t, animation.Channels, 1)
+	assert.Equal(t, decoded, animation.Channels[0].Target)
+	assert.Equal(t, gltf.AnimationChannelTargetPath_WEIGHTS, animation.Channels[0].TargetPath)
+	assert.Equal(t, gltf.AnimationSamplerInterpolation_STEP, animation.Channels[0].Sampler.Interpolation)
+	assert.Equal(t, []float64{0, 1}, animation.Channels[0].Sampler.Times)
+	assert.Equal(t, gltf.WeightsAnimationSamplerData{0, 1}, animation.Channels[0].Sampler.Data)
+}
+
+func TestDecodeScene_SkinnedAnimation(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+		}).
+		SetFloat4Attribute(modeling.JointAttribute, []vector4.Float64{
+			vector4.New(1., 0., 0., 0.),
+			vector4.New(0., 0., 0., 0.),
+			vector4.New(1., 0., 0., 0.),
+		}).
+		SetFloat4Attribute(modeling.WeightAttribute, []vector4.Float64{
+			vector4.New(1., 0., 0., 0.),
+			vector4.New(1., 0., 0., 0.),
+			vector4.New(1., 0., 0., 0.),
+		})
+
+	hipTRS := trs.Position(vector3.New(0., 1., 0.))
+	kneeTRS := trs.Position(vector3.New(0., -0.5, 0.))
+	knee := &gltf.PolyformModel{Name: "knee", TRS: &kneeTRS}
+	hip := &gltf.PolyformModel{Name: "hip", TRS: &hipTRS, Children: []*gltf.PolyformModel{knee}}
+	skinned := &gltf.PolyformModel{Name: "leg", Mesh: &tri}
+
+	scene := gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{skinned, hip},
+		Animations: []gltf.PolyformAnimation{
+			{
+				Name: "kick",
+				Channels: []gltf.PolyformAnimationChannel{
+					{
+						TargetPath: gltf.AnimationChannelTargetPath_TRANSLATION,
+						Target:     knee,
+						Sampler: gltf.PolyformAnimationSampler{
+							Interpolation: gltf.AnimationSamplerInterpolation_LINEAR,
+							Times:         []float64{0, 0.5},
+							Data: gltf.Vector3AnimationSamplerData{
+								vector3.New(0., -0.5, 0.),
+								vector3.New(0., -0.5, 0.5),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// ACT ====================================================================
+	doc, decoded := roundTrip(t, scene, func(doc *gltf.Gltf) {
+		nodes := make(map[string]int)
+		for i, n := range doc.Nodes {
+			nodes[n.Name] = i
+		}
+
+		// Joints listed in the reverse of their hierarchy order
+		skin := 0
+		doc.Skins = []gltf.Skin{{Joints: []int{nodes["knee"], nodes["hip"]}}}
+		doc.Nodes[nodes["leg"]].Skin = &skin
+	})
+
+	// ASSERT =================================================================
+	require.Len(t, decoded.Models, 2)
+	leg := decoded.Models[0]
+	require.NotNil(t, leg.Skeleton)
+
+	skeleton := *leg.Skeleton
+	assert.Equal(t, 2, skeleton.JointCount())
+	assert.Equal(t, 0, skeleton.Lookup("hip"))
+	assert.Equal(t, 1, skeleton.Lookup("hip/knee"))
+	assert.Equal(t, vector3.New(0., 1., 0.), skeleton.WorldPosition(0))
+	assert.Equal(t, vector3.New(0., 0.5, 0.), skeleton.WorldPosition(1))
+
+	// Joint indices now reference the skeleton rather than the skin
+	joints := leg.Mesh.Float4Attribute(modeling.JointAttribute)
+	assert.Equal(t, vector4.New(0., 1., 1., 1.), joints.At(0))
+	assert.Equal(t, vector4.New(1., 1., 1., 1.), joints.At(1))
+
+	require.Len(t, leg.Animations, 1)
+	sequence := leg.Animations[0]
+	assert.Equal(t, "hip/knee", sequence.Joint())
+	require.Len(t, sequence.Frames(), 2)
+	assert.Equal(t, 0.5, sequence.Frames()[1].Time())
+	assert.Equal(t, vector3.New(0., -0.5, 0.5), sequence.Frames()[1].Val())
+
+	require.Len(t, decoded.Animations, 1)
+	require.Len(t, decoded.Animations[0].Channels, 1)
+	assert.Equal(t, decoded.Models[1].Children[0], decoded.Animations[0].Channels[0].Target)
+
+	// Decoding a flat list of models still keeps the joint sequences
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	reloaded, buffers, err := gltf.LoadGLTF(bytes.NewReader(data), nil)
+	require.NoError(t, err)
+	models, err := gltf.DecodeModels(reloaded, buffers, nil)
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+	require.Len(t, models[0].Animations, 1)
+	assert.Equal(t, "hip/knee", models[0].Animations[0].Joint())
+}
@@ -0,0 +1,306 @@
+package gltf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// decodeComponent reads a single component of an accessor element from the
+// start of the buffer
+func decodeComponent(buffer []byte, componentType AccessorComponentType, div float64) (float64, error) {
+	switch componentType {
+	case AccessorComponentType_FLOAT:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer))), nil
+
+	case AccessorComponentType_UNSIGNED_BYTE:
+		return float64(buffer[0]) / div, nil
+
+	case AccessorComponentType_BYTE:
+		return float64(int8(buffer[0])) / div, nil
+
+	case AccessorComponentType_UNSIGNED_SHORT:
+		return float64(binary.LittleEndian.Uint16(buffer)) / div, nil
+
+	case AccessorComponentType_SHORT:
+		return float64(int16(binary.LittleEndian.Uint16(buffer))) / div, nil
+
+	case AccessorComponentType_UNSIGNED_INT:
+		return float64(binary.LittleEndian.Uint32(buffer)), nil
+
+	default:
+		return 0, fmt.Errorf("unsupported accessor component type: %d", componentType)
+	}
+}
+
+// applySparse substitutes the sparse values of the accessor, if it has any,
+// calling set with the index and components of each substituted element
+func applySparse(doc *Gltf, accessor Accessor, buffers [][]byte, components int, set func(i int, v []float64)) error {
+	sparse := accessor.Sparse
+	if sparse == nil {
+		return nil
+	}
+
+	if sparse.Indices.BufferView < 0 || sparse.Indices.BufferView >= len(doc.BufferViews) {
+		return fmt.Errorf("sparse indices reference invalid buffer view %d", sparse.Indices.BufferView)
+	}
+
+	if sparse.Values.BufferView < 0 || sparse.Values.BufferView >= len(doc.BufferViews) {
+		return fmt.Errorf("sparse values reference invalid buffer view %d", sparse.Values.BufferView)
+	}
+
+	indexBuffer := resolveBufferview(doc.BufferViews[sparse.Indices.BufferView], buffers)[sparse.Indices.ByteOffset:]
+	valueBuffer := resolveBufferview(doc.BufferViews[sparse.Values.BufferView], buffers)[sparse.Values.ByteOffset:]
+
+	indexSize := sparse.Indices.ComponentType.Size()
+	componentSize := accessor.ComponentType.Size()
+	if len(indexBuffer) < sparse.Count*indexSize {
+		return fmt.Errorf("sparse indices buffer too small for %d elements", sparse.Count)
+	}
+	if len(valueBuffer) < sparse.Count*components*componentSize {
+		return fmt.Errorf("sparse values buffer too small for %d elements", sparse.Count)
+	}
+
+	div := 1.
+	if accessor.ComponentType != AccessorComponentType_FLOAT && accessor.ComponentType != AccessorComponentType_UNSIGNED_INT {
+		div = accessorDivisor(accessor)
+	}
+
+	v := make([]float64, components)
+	for i := range sparse.Count {
+		var index int
+		switch sparse.Indices.ComponentType {
+		case AccessorComponentType_UNSIGNED_BYTE:
+			index = int(indexBuffer[i])
+
+		case AccessorComponentType_UNSIGNED_SHORT:
+			index = int(binary.LittleEndian.Uint16(indexBuffer[i*2:]))
+
+		case AccessorComponentType_UNSIGNED_INT:
+			index = int(binary.LittleEndian.Uint32(indexBuffer[i*4:]))
+
+		default:
+			return fmt.Errorf("unsupported sparse index component type: %d", sparse.Indices.ComponentType)
+		}
+
+		if index < 0 || index >= accessor.Count {
+			return fmt.Errorf("sparse index %d out of range of accessor with %d elements", index, accessor.Count)
+		}
+
+		for c := range components {
+			offset := (i*components + c) * componentSize
+			value, err := decodeComponent(valueBuffer[offset:], accessor.ComponentType, div)
+			if err != nil {
+				return err
+			}
+			v[c] = value
+		}
+		set(index, v)
+	}
+
+	return nil
+}
+
+// sparseIndexComponentType picks the smallest component type capable of
+// indexing every element of an accessor
+func sparseIndexComponentType(count int) AccessorComponentType {
+	if count <= math.MaxUint8+1 {
+		return AccessorComponentType_UNSIGNED_BYTE
+	}
+	if count <= math.MaxUint16+1 {
+		return AccessorComponentType_UNSIGNED_SHORT
+	}
+	return AccessorComponentType_UNSIGNED_INT
+}
+
+// writeSparse writes a float accessor that only stores the elements listed in
+// changed. Accessors without a base are initialized to zero, otherwise they
+// share the buffer view of the base accessor.
+func (w *Writer) writeSparse(accessorType AccessorType, base *GltfId, count int, changed []int, element func(i int) []float64) int {
+	accessor := Accessor{
+		ComponentType: AccessorComponentType_FLOAT,
+		Type:          accessorType,
+		Count:         count,
+	}
+
+	if base != nil {
+		baseAccessor := w.accessors[*base]
+		accessor.BufferView = baseAccessor.BufferView
+		accessor.ByteOffset = baseAccessor.ByteOffset
+	}
+
+	// The sparse array can't be empty, so an accessor identical to its base
+	// still stores its first element
+	if len(changed) == 0 && count > 0 {
+		changed = []int{0}
+	}
+
+	// Min and max must account for the substituted values
+	var min, max []float64
+	for i := range count {
+		v := element(i)
+		if min == nil {
+			min = append([]float64{}, v...)
+			max = append([]float64{}, v...)
+			continue
+		}
+		for c, component := range v {
+			if math.IsNaN(component) {
+				continue
+			}
+			min[c] = math.Min(min[c], component)
+			max[c] = math.Max(max[c], component)
+		}
+	}
+	accessor.Min = min
+	accessor.Max = max
+
+	indexType := sparseIndexComponentType(count)
+	w.Align(indexType.Size())
+	indicesView := len(w.bufferViews)
+	for _, i := range changed {
+		switch indexType {
+		case AccessorComponentType_UNSIGNED_BYTE:
+			w.bitW.Byte(uint8(i))
+		case AccessorComponentType_UNSIGNED_SHORT:
+			w.bitW.UInt16(uint16(i))
+		default:
+			w.bitW.UInt32(uint32(i))
+		}
+	}
+	indicesSize := len(changed) * indexType.Size()
+	w.bufferViews = append(w.bufferViews, BufferView{
+		Buffer:     0,
+		ByteOffset: w.bytesWritten,
+		ByteLength: indicesSize,
+	})
+	w.bytesWritten += indicesSize
+
+	w.Align(AccessorComponentType_FLOAT.Size())
+	valuesView := len(w.bufferViews)
+	valuesSize := 0
+	for _, i := range changed {
+		for _, component := range element(i) {
+			w.bitW.Float32(float32(component))
+			valuesSize += 4
+		}
+	}
+	w.bufferViews = append(w.bufferViews, BufferView{
+		Buffer:     0,
+		ByteOffset: w.bytesWritten,
+		ByteLength: valuesSize,
+	})
+	w.bytesWritten += valuesSize
+
+	accessor.Sparse = &AccessorSparse{
+		Count: len(changed),
+		Indices: AccessorSparseIndices{
+			BufferView:    indicesView,
+			ComponentType: indexType,
+		},
+		Values: AccessorSparseValues{
+			BufferView: valuesView,
+		},
+	}
+
+	accessorIndex := len(w.accessors)
+	w.accessors = append(w.accessors, accessor)
+	return accessorIndex
+}
+
+// WriteSparseScalar writes data as a sparse float accessor, only storing the
+// elements that differ from base. When baseAccessor is nil, base is treated as
+// all zeros and the accessor references no buffer view. Otherwise the accessor
+// shares the buffer view of baseAccessor, which must contain the values of
+// base.
+func (w *Writer) WriteSparseScalar(baseAccessor *GltfId, base, data []float64) int {
+	changed := make([]int, 0)
+	for i, v := range data {
+		if v != sparseBase(base, i, 0.) {
+			changed = append(changed, i)
+		}
+	}
+	return w.writeSparse(AccessorType_SCALAR, baseAccessor, len(data), changed, func(i int) []float64 {
+		return []float64{data[i]}
+	})
+}
+
+// WriteSparseVector2 writes data as a sparse float accessor, only storing the
+// elements that differ from base. See WriteSparseScalar.
+func (w *Writer) WriteSparseVector2(baseAccessor *GltfId, base, data []vector2.Float64) int {
+	changed := make([]int, 0)
+	for i, v := range data {
+		if v != sparseBase(base, i, vector2.Zero[float64]()) {
+			changed = append(changed, i)
+		}
+	}
+	return w.writeSparse(AccessorType_VEC2, baseAccessor, len(data), changed, func(i int) []float64 {
+		return []float64{data[i].X(), data[i].Y()}
+	})
+}
+
+// WriteSparseVector3 writes data as a sparse float accessor, only storing the
+// elements that differ from base. See WriteSparseScalar.
+func (w *Writer) WriteSparseVector3(baseAccessor *GltfId, base, data []vector3.Float64) int {
+	changed := make([]int, 0)
+	for i, v := range data {
+		if v != sparseBase(base, i, vector3.Zero[float64]()) {
+			changed = append(changed, i)
+		}
+	}
+	return w.writeSparse(AccessorType_VEC3, baseAccessor, len(data), changed, func(i int) []float64 {
+		return []float64{data[i].X(), data[i].Y(), data[i].Z()}
+	})
+}
+
+// WriteSparseVector4 writes data as a sparse float accessor, only storing the
+// elements that differ from base. See WriteSparseScalar.
+func (w *Writer) WriteSparseVector4(baseAccessor *GltfId, base, data []vector4.Float64) int {
+	changed := make([]int, 0)
+	for i, v := range data {
+		if v != sparseBase(base, i, vector4.Zero[float64]()) {
+			changed = append(changed, i)
+		}
+	}
+	return w.writeSparse(AccessorType_VEC4, baseAccessor, len(data), changed, func(i int) []float64 {
+		return []float64{data[i].X(), data[i].Y(), data[i].Z(), data[i].W()}
+	})
+}
+
+func sparseBase[T any](base []T, i int, zero T) T {
+	if i < len(base) {
+		return base[i]
+	}
+	return zero
+}
+
+// Morph targets where fewer than this fraction of vertices are displaced are
+// written as sparse accessors
+const sparseMorphTargetThreshold = 0.5
+
+// writeMorphTargetVector3 writes the displacements of a morph target scaled
+// by scale, using a sparse accessor when most of the vertices are left
+// untouched
+func (w *Writer) writeMorphTargetVector3(m modeling.Mesh, attribute string, scale float64) int {
+	data := m.Float3Attribute(attribute)
+	values := make([]vector3.Float64, data.Len())
+	displaced := 0
+	for i := range values {
+		values[i] = data.At(i).Scale(scale)
+		if values[i] != vector3.Zero[float64]() {
+			displaced++
+		}
+	}
+
+	if float64(displaced) >= float64(len(values))*sparseMorphTargetThreshold {
+		return w.WriteVector3(AccessorComponentType_FLOAT, iter.Array(values), ARRAY_BUFFER)
+	}
+
+	return w.WriteSparseVector3(nil, nil, values)
+}
@@ -0,0 +1,111 @@
+package gltf_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSparseVector3_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	base := []vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 0., 0.),
+		vector3.New(0., 1., 0.),
+		vector3.New(1., 1., 0.),
+	}
+	patched := []vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 0., 0.),
+		vector3.New(0., 1., 5.),
+		vector3.New(1., 1., 0.),
+	}
+
+	writer := gltf.NewWriter()
+	baseAccessor := writer.WriteVector3(gltf.AccessorComponentType_FLOAT, iter.Array(base), gltf.ARRAY_BUFFER)
+
+	// ACT ====================================================================
+	sparseAccessor := writer.WriteSparseVector3(&baseAccessor, base, patched)
+	zeroedAccessor := writer.WriteSparseVector3(nil, nil, patched)
+
+	doc := writer.ToGLTF(gltf.BufferEmbeddingStrategy_Base64Encode)
+	doc.Meshes = []gltf.Mesh{
+		{Primitives: []gltf.Primitive{{Attributes: map[string]gltf.GltfId{gltf.POSITION: sparseAccessor}}}},
+		{Primitives: []gltf.Primitive{{Attributes: map[string]gltf.GltfId{gltf.POSITION: zeroedAccessor}}}},
+	}
+	doc.Nodes = []gltf.Node{{Mesh: ptr(0)}, {Mesh: ptr(1)}}
+	doc.Scenes = []gltf.Scene{{Nodes: []gltf.GltfId{0, 1}}}
+	doc.Scene = ptr(0)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	loaded, buffers, err := gltf.LoadGLTF(bytes.NewReader(data), nil)
+	require.NoError(t, err)
+	models, err := gltf.DecodeModels(loaded, buffers, nil)
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	sparse := doc.Accessors[sparseAccessor]
+	require.NotNil(t, sparse.Sparse)
+	assert.Equal(t, 1, sparse.Sparse.Count)
+	assert.Equal(t, gltf.AccessorComponentType_UNSIGNED_BYTE, sparse.Sparse.Indices.ComponentType)
+	assert.Equal(t, doc.Accessors[baseAccessor].BufferView, sparse.BufferView)
+	assert.Equal(t, []float64{0, 0, 0}, sparse.Min)
+	assert.Equal(t, []float64{1, 1, 5}, sparse.Max)
+
+	zeroed := doc.Accessors[zeroedAccessor]
+	assert.Nil(t, zeroed.BufferView)
+	require.NotNil(t, zeroed.Sparse)
+	assert.Equal(t, 3, zeroed.Sparse.Count)
+
+	require.Len(t, models, 2)
+	for _, model := range models {
+		positions := model.Mesh.Float3Attribute(modeling.PositionAttribute)
+		require.Equal(t, len(patched), positions.Len())
+		for i, v := range patched {
+			assert.Equal(t, v, positions.At(i))
+		}
+	}
+}
+
+func TestWrite_SparseMorphTarget(t *testing.T) {
+	// ARRANGE ================================================================
+	positions := make([]vector3.Float64, 0)
+	displacements := make([]vector3.Float64, 0)
+	indices := make([]int, 0)
+	for i := range 10 {
+		x := float64(i)
+		positions = append(positions, vector3.New(x, 0, 0), vector3.New(x, 1, 0), vector3.New(x+1, 0, 0))
+		displacements = append(displacements, vector3.Zero[float64](), vector3.Zero[float64](), vector3.Zero[float64]())
+		indices = append(indices, i*3, i*3+1, i*3+2)
+	}
+	displacements[4] = vector3.New(0., 2., 0.)
+
+	mesh := modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat3Attribute(gltf.MorphTargetAttribute(modeling.PositionAttribute, 0), displacements)
+
+	// ACT ====================================================================
+	doc, scene := roundTrip(t, gltf.PolyformScene{
+		Models: []*gltf.PolyformModel{{Name: "sparse", Mesh: &mesh}},
+	}, nil)
+
+	// ASSERT =================================================================
+	target := doc.Meshes[0].Primitives[0].Targets[0][gltf.POSITION]
+	require.NotNil(t, doc.Accessors[target].Sparse)
+	assert.Nil(t, doc.Accessors[target].BufferView)
+	assert.Equal(t, 1, doc.Accessors[target].Sparse.Count)
+
+	decoded := scene.Models[0].Mesh.Float3Attribute(gltf.MorphTargetAttribute(modeling.PositionAttribute, 0))
+	require.Equal(t, len(displacements), decoded.Len())
+	for i, v := range displacements {
+		assert.Equal(t, v, decoded.At(i))
+	}
+}
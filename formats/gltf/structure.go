@@ -135,12 +135,48 @@ type Texture struct {
 // https://github.com/KhronosGroup/glTF/blob/main/specification/2.0/schema/skin.schema.json
 type Skin struct {
 	ChildOfRootProperty
-	InverseBindMatrices GltfId   `json:"inverseBindMatrices,omitempty"` // The index of the accessor containing the floating-point 4x4 inverse-bind matrices. Its `accessor.count` property **MUST** be greater than or equal to the number of elements of the `joints` array. When undefined, each matrix is a 4x4 identity matrix.
-	Skeleton            GltfId   `json:"skeleton,omitempty"`            // The index of the node used as a skeleton root. The node **MUST** be the closest common root of the joints hierarchy or a direct or indirect parent node of the closest common root.
+	InverseBindMatrices *GltfId  `json:"inverseBindMatrices,omitempty"` // The index of the accessor containing the floating-point 4x4 inverse-bind matrices. Its `accessor.count` property **MUST** be greater than or equal to the number of elements of the `joints` array. When undefined, each matrix is a 4x4 identity matrix.
+	Skeleton            *GltfId  `json:"skeleton,omitempty"`            // The index of the node used as a skeleton root. The node **MUST** be the closest common root of the joints hierarchy or a direct or indirect parent node of the closest common root.
 	Joints              []GltfId `json:"joints"`                        // Indices of skeleton nodes, used as joints in this skin.
 }
 
-type Camera struct{}
+type CameraType string
+
+const (
+	CameraType_Perspective  CameraType = "perspective"
+	CameraType_Orthographic CameraType = "orthographic"
+)
+
+// A camera's projection. A node **MAY** reference a camera to apply a
+// transform to place the camera in the scene. The camera looks down the
+// node's local -Z axis, with +Y up.
+// https://github.com/KhronosGroup/glTF/blob/main/specification/2.0/schema/camera.schema.json
+type Camera struct {
+	ChildOfRootProperty
+	Type         CameraType          `json:"type"`                   // Specifies if the camera uses a perspective or orthographic projection.
+	Perspective  *CameraPerspective  `json:"perspective,omitempty"`  // A perspective camera containing properties to create a perspective projection matrix. This property **MUST NOT** be defined when `orthographic` is defined.
+	Orthographic *CameraOrthographic `json:"orthographic,omitempty"` // An orthographic camera containing properties to create an orthographic projection matrix. This property **MUST NOT** be defined when `perspective` is defined.
+}
+
+// A perspective camera containing properties to create a perspective projection matrix.
+// https://github.com/KhronosGroup/glTF/blob/main/specification/2.0/schema/camera.perspective.schema.json
+type CameraPerspective struct {
+	Property
+	AspectRatio *float64 `json:"aspectRatio,omitempty"` // The floating-point aspect ratio of the field of view. When undefined, the aspect ratio of the rendering viewport **MUST** be used.
+	YFov        float64  `json:"yfov"`                  // The floating-point vertical field of view in radians. This value **SHOULD** be less than π.
+	ZFar        *float64 `json:"zfar,omitempty"`        // The floating-point distance to the far clipping plane. When defined, `zfar` **MUST** be greater than `znear`. If `zfar` is undefined, client implementations **SHOULD** use infinite projection matrix.
+	ZNear       float64  `json:"znear"`                 // The floating-point distance to the near clipping plane.
+}
+
+// An orthographic camera containing properties to create an orthographic projection matrix.
+// https://github.com/KhronosGroup/glTF/blob/main/specification/2.0/schema/camera.orthographic.schema.json
+type CameraOrthographic struct {
+	Property
+	XMag  float64 `json:"xmag"`  // The floating-point horizontal magnification of the view. This value **MUST NOT** be equal to zero. This value **SHOULD NOT** be negative.
+	YMag  float64 `json:"ymag"`  // The floating-point vertical magnification of the view. This value **MUST NOT** be equal to zero. This value **SHOULD NOT** be negative.
+	ZFar  float64 `json:"zfar"`  // The floating-point distance to the far clipping plane. This value **MUST NOT** be equal to zero. `zfar` **MUST** be greater than `znear`.
+	ZNear float64 `json:"znear"` // The floating-point distance to the near clipping plane.
+}
 
 // The root object for a glTF asset.
 // https://github.com/KhronosGroup/glTF/blob/main/specification/2.0/schema/glTF.schema.json
@@ -0,0 +1,112 @@
+package gltf_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func normalMappedMesh() modeling.Mesh {
+	forward := vector3.Forward[float64]()
+	return modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(0., 1., 0.),
+		}).
+		SetFloat3Attribute(modeling.NormalAttribute, []vector3.Float64{forward, forward, forward}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.),
+			vector2.New(1., 0.),
+			vector2.New(0., 1.),
+		})
+}
+
+func normalMapped() *gltf.PolyformMaterial {
+	return &gltf.PolyformMaterial{
+		NormalTexture: &gltf.PolyformNormal{
+			PolyformTexture: &gltf.PolyformTexture{Image: image.NewRGBA(image.Rect(0, 0, 2, 2))},
+		},
+	}
+}
+
+func TestWrite_Tangents(t *testing.T) {
+	withTangents := normalMappedMesh().SetFloat4Attribute(modeling.TangentAttribute, []vector4.Float64{
+		vector4.New(0., 1., 0., -1.),
+		vector4.New(0., 1., 0., -1.),
+		vector4.New(0., 1., 0., -1.),
+	})
+
+	tests := map[string]struct {
+		mesh     modeling.Mesh
+		material *gltf.PolyformMaterial
+		variants []gltf.PolyformMaterialVariant
+		tangents bool
+		tangent  vector4.Float64
+	}{
+		"generated for normal texture": {
+			mesh:     normalMappedMesh(),
+			material: normalMapped(),
+			tangents: true,
+			tangent:  vector4.New(1., 0., 0., 1.),
+		},
+		"generated for variant normal texture": {
+			mesh:     normalMappedMesh(),
+			material: &gltf.PolyformMaterial{},
+			variants: []gltf.PolyformMaterialVariant{{Variant: "bumpy", Material: normalMapped()}},
+			tangents: true,
+			tangent:  vector4.New(1., 0., 0., 1.),
+		},
+		"not generated without normal texture": {
+			mesh:     normalMappedMesh(),
+			material: &gltf.PolyformMaterial{},
+		},
+		"existing tangents kept": {
+			mesh:     withTangents,
+			material: normalMapped(),
+			tangents: true,
+			tangent:  vector4.New(0., 1., 0., -1.),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			scene := gltf.PolyformScene{
+				Models: []*gltf.PolyformModel{{
+					Name:             "model",
+					Mesh:             &tc.mesh,
+					Material:         tc.material,
+					MaterialVariants: tc.variants,
+				}},
+			}
+
+			// ACT ============================================================
+			doc, decoded := roundTrip(t, scene, nil)
+
+			// ASSERT =========================================================
+			require.Len(t, doc.Meshes, 1)
+			_, written := doc.Meshes[0].Primitives[0].Attributes[gltf.TANGENT]
+			assert.Equal(t, tc.tangents, written)
+
+			require.Len(t, decoded.Models, 1)
+			mesh := decoded.Models[0].Mesh
+			require.Equal(t, tc.tangents, mesh.HasFloat4Attribute(modeling.TangentAttribute))
+			if !tc.tangents {
+				return
+			}
+
+			tangents := mesh.Float4Attribute(modeling.TangentAttribute)
+			for i := range tangents.Len() {
+				assert.Equal(t, tc.tangent, tangents.At(i))
+			}
+		})
+	}
+}
@@ -38,6 +38,18 @@ type WriterOptions struct {
 
 	// JsonFormat specifies the JSON output format (default is pretty-printed with indentation)
 	JsonFormat JsonFormat
+
+	// MeshQuantization stores vertex positions as normalized 16 bit integers,
+	// normals as normalized 8 bit integers and texture coordinates as
+	// normalized unsigned 16 bit integers through the KHR_mesh_quantization
+	// extension. The transform mapping the quantized positions back to their
+	// original range is folded into the transforms of the nodes referencing
+	// the mesh.
+	MeshQuantization bool
+
+	// MeshoptCompression compresses vertex attribute and index buffer views
+	// through the EXT_meshopt_compression extension
+	MeshoptCompression bool
 }
 
 func defaultAsset() Asset {
@@ -76,6 +88,9 @@ func polyformToGLTFAttribute(key string) string {
 
 	case modeling.NormalAttribute:
 		return NORMAL
+
+	case modeling.TangentAttribute:
+		return TANGENT
 	}
 
 	if strings.HasPrefix(key, modeling.TexCoordAttribute) {
@@ -1303,6 +1303,24 @@ func TestWrite_TexturedTriWithMaterialWithColor_NormalOcclusion(t *testing.T) {
         {
             "bufferView": 0,
             "componentType": 5126,
+            "type": "VEC4",
+            "count": 3,
+            "max": [
+                1,
+                1,
+                0,
+                -1
+            ],
+            "min": [
+                0,
+                0,
+                0,
+                -1
+            ]
+        },
+        {
+            "bufferView": 1,
+            "componentType": 5126,
             "type": "VEC3",
             "count": 3,
             "max": [
@@ -1317,7 +1335,7 @@ func TestWrite_TexturedTriWithMaterialWithColor_NormalOcclusion(t *testing.T) {
             ]
         },
         {
-            "bufferView": 1,
+            "bufferView": 2,
             "componentType": 5126,
             "type": "VEC3",
             "count": 3,
@@ -1333,7 +1351,7 @@ func TestWrite_TexturedTriWithMaterialWithColor_NormalOcclusion(t *testing.T) {
             ]
         },
         {
-            "bufferView": 2,
+            "bufferView": 3,
             "componentType": 5126,
             "type": "VEC2",
             "count": 3,
@@ -1347,7 +1365,7 @@ func TestWrite_TexturedTriWithMaterialWithColor_NormalOcclusion(t *testing.T) {
             ]
         },
         {
-            "bufferView": 3,
+            "bufferView": 4,
             "componentType": 5123,
             "type": "SCALAR",
             "count": 3
@@ -1359,31 +1377,37 @@ func TestWrite_TexturedTriWithMaterialWithColor_NormalOcclusion(t *testing.T) {
     },
     "buffers": [
         {
-            "byteLength": 102,
-            "uri": "data:application/octet-stream;base64,AACAPwAAAAAAAAAAAAAAAAAAgD8AAAAAAAAAAAAAAAAAAIA/AAAAAAAAAAAAAAAAAAAAAAAAgD8AAAAAAACAPwAAAAAAAAAAAAAAAAAAAAAAAAAAAACAPwAAgD8AAAAAAAABAAIA"
+            "byteLength": 150,
+            "uri": "data:application/octet-stream;base64,AAAAAAAAgD8AAAAAAACAvwAAgD8AAAAAAAAAAAAAgL8AAIA/AAAAAAAAAAAAAIC/AACAPwAAAAAAAAAAAAAAAAAAgD8AAAAAAAAAAAAAAAAAAIA/AAAAAAAAAAAAAAAAAAAAAAAAgD8AAAAAAACAPwAAAAAAAAAAAAAAAAAAAAAAAAAAAACAPwAAgD8AAAAAAAABAAIA"
         }
     ],
     "bufferViews": [
         {
             "buffer": 0,
+            "byteLength": 48,
+            "target": 34962
+        },
+        {
+            "buffer": 0,
+            "byteOffset": 48,
             "byteLength": 36,
             "target": 34962
         },
         {
             "buffer": 0,
-            "byteOffset": 36,
+            "byteOffset": 84,
             "byteLength": 36,
             "target": 34962
         },
         {
             "buffer": 0,
-            "byteOffset": 72,
+            "byteOffset": 120,
             "byteLength": 24,
             "target": 34962
         },
         {
             "buffer": 0,
-            "byteOffset": 96,
+            "byteOffset": 144,
             "byteLength": 6,
             "target": 34963
         }
@@ -1430,11 +1454,12 @@ func TestWrite_TexturedTriWithMaterialWithColor_NormalOcclusion(t *testing.T) {
             "primitives": [
                 {
                     "attributes": {
-                        "NORMAL": 0,
-                        "POSITION": 1,
-                        "TEXCOORD_0": 2
+                        "NORMAL": 1,
+                        "POSITION": 2,
+                        "TANGENT": 0,
+                        "TEXCOORD_0": 3
                     },
-                    "indices": 3,
+                    "indices": 4,
                     "material": 0
                 }
             ]
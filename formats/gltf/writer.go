@@ -11,7 +11,10 @@ import (
 	"image/color"
 	"image/png"
 	"io"
+	"maps"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/EliCDavis/bitlib"
 	"github.com/EliCDavis/iter"
@@ -19,6 +22,7 @@ import (
 	"github.com/EliCDavis/polyform/math/trs"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/polyform/modeling/animation"
+	"github.com/EliCDavis/polyform/modeling/meshops"
 	"github.com/EliCDavis/vector/vector2"
 	"github.com/EliCDavis/vector/vector3"
 	"github.com/EliCDavis/vector/vector4"
@@ -50,9 +54,13 @@ type Writer struct {
 	textureIndices      textureIndices      // Tracks and deduplicates unique textures
 	embededImageIndices map[image.Image]int // Tracks and deduplicates unique written images to our buffer
 	modelIndices        map[*PolyformModel]int
+	meshDequantization  map[GltfId]trs.TRS                // Transform restoring the quantized positions of each mesh
+	animatedMeshes      map[*modeling.Mesh]bool           // Meshes referenced by animated models, which aren't quantized
+	tangentMeshes       map[*modeling.Mesh]*modeling.Mesh // Meshes with tangents generated for them, by the mesh they were generated from
 
 	skins      []Skin
 	animations []Animation
+	cameras    []Camera
 
 	textures []Texture
 	images   []Image
@@ -60,7 +68,9 @@ type Writer struct {
 	scenes   []Scene
 
 	// Extension Stuff
-	lights []KHR_LightsPunctual
+	lights                 []KHR_LightsPunctual
+	materialVariants       []string
+	materialVariantIndices map[string]GltfId
 
 	extensionsUsed     map[string]bool
 	extensionsRequired map[string]bool
@@ -80,15 +90,21 @@ func NewWriter() *Writer {
 		materials:   make([]Material, 0),
 		skins:       make([]Skin, 0),
 		animations:  make([]Animation, 0),
+		cameras:     make([]Camera, 0),
 
 		meshIndices:         make(meshIndices),
 		writtenMeshData:     make(attributeIndices),
 		textureIndices:      make(textureIndices),
 		embededImageIndices: make(map[image.Image]int),
 		modelIndices:        make(map[*PolyformModel]int),
+		meshDequantization:  make(map[GltfId]trs.TRS),
+		animatedMeshes:      make(map[*modeling.Mesh]bool),
+		tangentMeshes:       make(map[*modeling.Mesh]*modeling.Mesh),
 
 		// Extensions
-		lights: make([]KHR_LightsPunctual, 0),
+		lights:                 make([]KHR_LightsPunctual, 0),
+		materialVariants:       make([]string, 0),
+		materialVariantIndices: make(map[string]GltfId),
 
 		extensionsUsed:     make(map[string]bool),
 		extensionsRequired: make(map[string]bool),
@@ -387,7 +403,13 @@ func (w *Writer) WriteIndices(indices *iter.ArrayIterator[int], attributeSize in
 	indiceSize := indices.Len()
 
 	componentType := AccessorComponentType_UNSIGNED_INT
-	if attributeSize > math.MaxUint16 {
+	if attributeSize <= math.MaxUint16 {
+		componentType = AccessorComponentType_UNSIGNED_SHORT
+	}
+
+	w.Align(componentType.Size())
+
+	if componentType == AccessorComponentType_UNSIGNED_INT {
 		for i := range indices.Len() {
 			w.bitW.UInt32(uint32(indices.At(i)))
 		}
@@ -397,11 +419,8 @@ func (w *Writer) WriteIndices(indices *iter.ArrayIterator[int], attributeSize in
 			w.bitW.UInt16(uint16(indices.At(i)))
 		}
 		indiceSize *= 2
-		componentType = AccessorComponentType_UNSIGNED_SHORT
 	}
 
-	w.Align(componentType.Size())
-
 	w.accessors = append(w.accessors, Accessor{
 		BufferView:    ptrI(len(w.bufferViews)),
 		ComponentType: componentType,
@@ -508,6 +527,21 @@ func (w *Writer) AddScene(scene PolyformScene) error {
 
 	sceneNodes := make([]GltfId, 0)
 
+	for _, variant := range scene.MaterialVariants {
+		if variant == "" {
+			return errors.New("scene material variants must be named")
+		}
+		w.addMaterialVariant(variant)
+	}
+
+	for _, animation := range scene.Animations {
+		for _, channel := range animation.Channels {
+			if channel.Target != nil && channel.Target.Mesh != nil {
+				w.animatedMeshes[channel.Target.Mesh] = true
+			}
+		}
+	}
+
 	childInstanceGroups := make(instancesCachce)
 
 	for i, child := range scene.Models {
@@ -588,6 +622,7 @@ func (w *Writer) AddScene(scene PolyformScene) error {
 
 			childNode.Extensions = make(Extensions)
 			childNode.Extensions[extGpuInstancingID] = w.addExtGpuInstancing(
+				childNode.Mesh,
 				trs.Positions(instances),
 				trs.Scales(instances),
 				childRotations,
@@ -602,6 +637,14 @@ func (w *Writer) AddScene(scene PolyformScene) error {
 		sceneNodes = append(sceneNodes, w.AddLight(light))
 	}
 
+	for i, camera := range scene.Cameras {
+		id, err := w.AddCamera(camera)
+		if err != nil {
+			return fmt.Errorf("unable to add camera[%d] %q to scene: %w", i, camera.Name, err)
+		}
+		sceneNodes = append(sceneNodes, id)
+	}
+
 	if len(sceneNodes) == 0 {
 		return nil
 	}
@@ -629,10 +672,24 @@ func (w *Writer) getOrAddMeshIndex(model *PolyformModel) (meshIndex int, err err
 		}
 	}
 
-	uniqueMesh := meshEntry{model.Mesh, -1}
+	variantMappings, err := w.materialVariantMappings(model)
+	if err != nil {
+		return -1, err
+	}
+
+	// Normal textures need tangents to be shaded consistently across viewers
+	mesh := model.Mesh
+	if modelHasNormalTexture(model) {
+		mesh = w.meshWithTangents(model.Mesh)
+	}
+
+	uniqueMesh := meshEntry{polyMesh: mesh, materialIndex: -1}
 	if matIndex != nil {
 		uniqueMesh.materialIndex = *matIndex
 	}
+	if len(variantMappings) > 0 {
+		uniqueMesh.variants = materialVariantsKey(variantMappings)
+	}
 
 	// Check if mesh already exists
 	if existingIndex, exists := w.meshIndices[uniqueMesh]; exists {
@@ -646,69 +703,185 @@ func (w *Writer) getOrAddMeshIndex(model *PolyformModel) (meshIndex int, err err
 	// Create the mesh - process geometry, materials etc
 
 	var primitiveAttributes map[string]int
+	var primitiveTargets []map[string]int
 	var indicesIndex int
 
-	writtenData, alreadyWrittenMesh := w.writtenMeshData[model.Mesh]
+	writtenData, alreadyWrittenMesh := w.writtenMeshData[mesh]
+
+	var dequantize *trs.TRS
 
 	if alreadyWrittenMesh {
 		primitiveAttributes = writtenData.attribute
+		primitiveTargets = writtenData.targets
 		indicesIndex = *writtenData.indices
+		dequantize = writtenData.dequantization
 	} else {
 		primitiveAttributes = make(map[string]int)
-		for _, val := range model.Mesh.Float4Attributes() {
-			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
-			w.WriteVector4(attributeType(val), model.Mesh.Float4Attribute(val), ARRAY_BUFFER)
+
+		// Positions are quantized relative to the bounds of the mesh
+		quantize := w.Options.MeshQuantization
+		if quantize && w.canQuantizePositions(mesh) {
+			transform := positionDequantization(mesh.Float3Attribute(modeling.PositionAttribute))
+			dequantize = &transform
 		}
 
-		for _, val := range model.Mesh.Float3Attributes() {
-			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
-			w.WriteVector3(attributeType(val), model.Mesh.Float3Attribute(val), ARRAY_BUFFER)
+		// Morph target displacements are written to the primitive's targets
+		// rather than alongside the rest of the attributes
+		attributes := func(target int) map[string]int {
+			if target < 0 {
+				return primitiveAttributes
+			}
+			for len(primitiveTargets) <= target {
+				primitiveTargets = append(primitiveTargets, make(map[string]int))
+			}
+			return primitiveTargets[target]
+		}
+		split := func(val string) (string, int) {
+			if attr, target, ok := parseMorphTargetAttribute(val); ok {
+				return attr, target
+			}
+			return val, -1
 		}
 
-		for _, val := range model.Mesh.Float2Attributes() {
-			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
-			w.WriteVector2(attributeType(val), model.Mesh.Float2Attribute(val))
+		for _, val := range mesh.Float4Attributes() {
+			attr, target := split(val)
+			attributes(target)[polyformToGLTFAttribute(attr)] = len(w.accessors)
+			w.WriteVector4(attributeType(attr), mesh.Float4Attribute(val), ARRAY_BUFFER)
+		}
+
+		for _, val := range mesh.Float3Attributes() {
+			attr, target := split(val)
+			gltfAttr := polyformToGLTFAttribute(attr)
+			if target >= 0 {
+				// Position displacements need to be in the same space as
+				// the quantized positions
+				scale := 1.
+				if dequantize != nil && gltfAttr == POSITION {
+					scale = 1 / dequantize.Scale().X()
+				}
+				attributes(target)[gltfAttr] = w.writeMorphTargetVector3(*mesh, val, scale)
+				continue
+			}
+
+			switch {
+			case gltfAttr == POSITION && dequantize != nil:
+				attributes(target)[gltfAttr] = w.writeQuantizedPositions(mesh.Float3Attribute(val), *dequantize)
+
+			case gltfAttr == NORMAL && quantize:
+				attributes(target)[gltfAttr] = w.writeQuantizedNormals(mesh.Float3Attribute(val))
+
+			default:
+				attributes(target)[gltfAttr] = len(w.accessors)
+				w.WriteVector3(attributeType(attr), mesh.Float3Attribute(val), ARRAY_BUFFER)
+			}
+		}
+
+		for _, val := range mesh.Float2Attributes() {
+			attr, target := split(val)
+			gltfAttr := polyformToGLTFAttribute(attr)
+			if quantize && target < 0 && strings.HasPrefix(gltfAttr, "TEXCOORD_") {
+				if accessor, ok := w.writeQuantizedTexCoords(mesh.Float2Attribute(val)); ok {
+					attributes(target)[gltfAttr] = accessor
+					continue
+				}
+			}
+			attributes(target)[gltfAttr] = len(w.accessors)
+			w.WriteVector2(attributeType(attr), mesh.Float2Attribute(val))
 		}
 
 		indicesIndex = len(w.accessors)
-		w.WriteIndices(model.Mesh.Indices(), model.Mesh.AttributeLength())
+		w.WriteIndices(mesh.Indices(), mesh.AttributeLength())
 
-		w.writtenMeshData[model.Mesh] = writtenMeshData{
-			attribute: primitiveAttributes,
-			indices:   &indicesIndex,
+		w.writtenMeshData[mesh] = writtenMeshData{
+			attribute:      primitiveAttributes,
+			targets:        primitiveTargets,
+			indices:        &indicesIndex,
+			dequantization: dequantize,
 		}
 	}
 
+	if dequantize != nil {
+		w.meshDequantization[meshIndex] = *dequantize
+	}
+
 	var mode *PrimitiveMode = nil
-	if model.Mesh.Topology() == modeling.PointTopology {
+	if mesh.Topology() == modeling.PointTopology {
 		p := PrimitiveMode_POINTS
 		mode = &p
 	}
 
+	primitive := Primitive{
+		Indices:    &indicesIndex,
+		Attributes: primitiveAttributes,
+		Targets:    primitiveTargets,
+		Material:   matIndex,
+		Mode:       mode,
+	}
+
+	if len(variantMappings) > 0 {
+		primitive.Extensions = Extensions{
+			khrMaterialsVariantsID: KHR_MaterialsVariantsPrimitive{
+				Mappings: variantMappings,
+			},
+		}
+	}
+
 	w.meshes = append(w.meshes, Mesh{
 		ChildOfRootProperty: ChildOfRootProperty{Name: model.Name},
-		Primitives: []Primitive{
-			{
-				Indices:    &indicesIndex,
-				Attributes: primitiveAttributes,
-				Material:   matIndex,
-				Mode:       mode,
-			},
-		},
+		Primitives:          []Primitive{primitive},
 	})
 
 	return meshIndex, nil
 }
 
+func modelHasNormalTexture(model *PolyformModel) bool {
+	if model.Material != nil && model.Material.NormalTexture != nil {
+		return true
+	}
+	for _, variant := range model.MaterialVariants {
+		if variant.Material != nil && variant.Material.NormalTexture != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// meshWithTangents generates MikkTSpace tangents for meshes that don't
+// already have them. Meshes missing the normals or texture coordinates
+// tangents are derived from are left as is.
+func (w *Writer) meshWithTangents(m *modeling.Mesh) *modeling.Mesh {
+	if generated, ok := w.tangentMeshes[m]; ok {
+		return generated
+	}
+
+	if m.HasFloat4Attribute(modeling.TangentAttribute) {
+		return m
+	}
+
+	tangents, err := meshops.TangentsTransformer{}.Transform(*m)
+	if err != nil {
+		return m
+	}
+
+	generated := &tangents
+	w.tangentMeshes[m] = generated
+	if w.animatedMeshes[m] {
+		w.animatedMeshes[generated] = true
+	}
+	return generated
+}
+
 func canCollapseChildIntoParentAsInstance(parent, child *PolyformModel) bool {
-	return len(child.Children) == 0 && child.Mesh == parent.Mesh && child.Material == parent.Material
+	return len(child.Children) == 0 && len(child.LODs) == 0 && len(parent.LODs) == 0 && child.Mesh == parent.Mesh && child.Material == parent.Material && slices.Equal(child.MaterialVariants, parent.MaterialVariants)
 }
 
 func canCollapseIntoInstance(model *PolyformModel) bool {
-	return len(model.Children) == 0 && model.Mesh != nil && model.Mesh.PrimitiveCount() > 0
+	return len(model.Children) == 0 && len(model.LODs) == 0 && model.Mesh != nil && model.Mesh.PrimitiveCount() > 0
 }
 
-func (w *Writer) addExtGpuInstancing(positions, scales []vector3.Float64, rotations []vector4.Float64) ExtGpuInstancing {
+func (w *Writer) addExtGpuInstancing(mesh *GltfId, positions, scales []vector3.Float64, rotations []vector4.Float64) ExtGpuInstancing {
+	w.dequantizeInstances(mesh, positions, scales, rotations)
+
 	w.extensionsRequired[extGpuInstancingID] = true
 	w.extensionsUsed[extGpuInstancingID] = true
 	instances := ExtGpuInstancing{
@@ -741,6 +914,10 @@ func (w *Writer) addModel(model *PolyformModel, parentTransformOverride *trs.TRS
 		return nil, errors.New("model can not reference a material without also referencing a mesh")
 	}
 
+	if len(model.MaterialVariants) > 0 && model.Mesh == nil {
+		return nil, errors.New("model can not reference material variants without also referencing a mesh")
+	}
+
 	if model.TRS != nil || parentTransformOverride != nil {
 		trs := trs.Identity()
 		if model.TRS != nil {
@@ -773,6 +950,13 @@ func (w *Writer) addModel(model *PolyformModel, parentTransformOverride *trs.TRS
 			return nil, err
 		}
 		node.Mesh = &meshIndex
+		node.Weights = model.MorphTargetWeights
+	}
+
+	if len(model.LODs) > 0 {
+		if err := w.addLODs(model, &node); err != nil {
+			return nil, err
+		}
 	}
 
 	positions := make([]vector3.Float64, 0, len(model.GpuInstances))
@@ -841,13 +1025,15 @@ func (w *Writer) addModel(model *PolyformModel, parentTransformOverride *trs.TRS
 		node.Children = append(node.Children, *childeNodeIndex)
 	}
 
+	instanced := false
 	if len(positions) > 0 {
 		switch w.Options.GpuInstancingStrategy {
 		case WriterInstancingStrategy_Default, WriterInstancingStrategy_Collapse:
 			if node.Extensions == nil {
 				node.Extensions = make(Extensions)
 			}
-			node.Extensions[extGpuInstancingID] = w.addExtGpuInstancing(positions, scales, rotations)
+			node.Extensions[extGpuInstancingID] = w.addExtGpuInstancing(node.Mesh, positions, scales, rotations)
+			instanced = true
 		}
 	}
 
@@ -886,6 +1072,7 @@ func (w *Writer) addModel(model *PolyformModel, parentTransformOverride *trs.TRS
 			}
 			childNode.Extensions = make(Extensions)
 			childNode.Extensions[extGpuInstancingID] = w.addExtGpuInstancing(
+				childNode.Mesh,
 				trs.Positions(instances),
 				trs.Scales(instances),
 				childRotations,
@@ -895,12 +1082,77 @@ func (w *Writer) addModel(model *PolyformModel, parentTransformOverride *trs.TRS
 		}
 	}
 
+	// Instances already account for the dequantization of the mesh
+	if !instanced {
+		w.dequantizeNode(&node)
+	}
+
 	index := len(w.nodes)
 	w.modelIndices[model] = index
 	w.nodes = append(w.nodes, node)
 	return &index, nil
 }
 
+// addLODs writes a node for each of the model's LODs, mirroring the
+// transform of the full detail node, and references them from the full detail
+// node using the MSFT_lod extension
+func (w *Writer) addLODs(model *PolyformModel, node *Node) error {
+	if model.Mesh == nil {
+		return fmt.Errorf("model %q can not define LODs without also defining a mesh", model.Name)
+	}
+
+	ids := make([]GltfId, len(model.LODs))
+	for i, lod := range model.LODs {
+		if lod.Mesh == nil {
+			return fmt.Errorf("model %q LOD %d has a nil mesh", model.Name, i)
+		}
+
+		lodModel := &PolyformModel{
+			Name:     fmt.Sprintf("%s_LOD%d", model.Name, i+1),
+			Mesh:     lod.Mesh,
+			Material: lod.Material,
+		}
+		if lodModel.Material == nil {
+			lodModel.Material = model.Material
+			lodModel.MaterialVariants = model.MaterialVariants
+		}
+
+		lodNode := Node{
+			Name:        lodModel.Name,
+			Translation: node.Translation,
+			Rotation:    node.Rotation,
+			Scale:       node.Scale,
+		}
+
+		if lod.Mesh.PrimitiveCount() > 0 {
+			meshIndex, err := w.getOrAddMeshIndex(lodModel)
+			if err != nil {
+				return fmt.Errorf("failed to add model %q LOD %d: %w", model.Name, i, err)
+			}
+			lodNode.Mesh = &meshIndex
+			w.dequantizeNode(&lodNode)
+		}
+
+		ids[i] = len(w.nodes)
+		w.nodes = append(w.nodes, lodNode)
+	}
+
+	if node.Extensions == nil {
+		node.Extensions = make(Extensions)
+	}
+	node.Extensions[msftLodID] = MsftLod{Ids: ids}
+	w.extensionsUsed[msftLodID] = true
+
+	if coverage := lodScreenCoverage(model.LODs); coverage != nil {
+		if node.Extras == nil {
+			node.Extras = make(Extra)
+		}
+		node.Extras[msftScreenCoverageKey] = coverage
+	}
+
+	return nil
+}
+
 func (w *Writer) AddTexture(polyTex *PolyformTexture) *TextureInfo {
 	texIndex := -1
 	var texFound bool
@@ -1134,7 +1386,7 @@ func (w *Writer) AddSkin(skeleton animation.Skeleton) Node {
 
 	w.skins = append(w.skins, Skin{
 		Joints:              jointIndices,
-		InverseBindMatrices: len(w.accessors) - 1,
+		InverseBindMatrices: ptrI(len(w.accessors) - 1),
 	})
 
 	return Node{
@@ -1264,6 +1516,44 @@ func (w *Writer) AddLight(light KHR_LightsPunctual) GltfId {
 	return nodeIndex
 }
 
+// AddCamera writes the camera along with a node placing it within the scene,
+// returning the index of the node
+func (w *Writer) AddCamera(camera PolyformCamera) (GltfId, error) {
+	gltfCamera, err := camera.toGltf()
+	if err != nil {
+		return -1, err
+	}
+
+	cameraIndex := len(w.cameras)
+	w.cameras = append(w.cameras, gltfCamera)
+
+	node := Node{
+		Name:   camera.Name,
+		Camera: &cameraIndex,
+	}
+
+	if camera.TRS != nil {
+		if camera.TRS.Position() != vector3.Zero[float64]() {
+			translation := camera.TRS.Position().ToFixedArr()
+			node.Translation = &translation
+		}
+
+		if camera.TRS.Scale() != vector3.One[float64]() {
+			scale := camera.TRS.Scale().ToFixedArr()
+			node.Scale = &scale
+		}
+
+		if camera.TRS.Rotation() != quaternion.Identity() {
+			rotation := camera.TRS.Rotation().ToArr()
+			node.Rotation = &rotation
+		}
+	}
+
+	nodeIndex := len(w.nodes)
+	w.nodes = append(w.nodes, node)
+	return nodeIndex, nil
+}
+
 type BufferEmbeddingStrategy int
 
 const (
@@ -1272,26 +1562,72 @@ const (
 )
 
 func (w Writer) ToGLTF(embeddingStrategy BufferEmbeddingStrategy) Gltf {
+	doc, _ := w.toGLTF(embeddingStrategy)
+	return doc
+}
+
+// toGLTF builds the GLTF document along with the binary data of its first
+// buffer
+func (w Writer) toGLTF(embeddingStrategy BufferEmbeddingStrategy) (Gltf, []byte) {
+	bin := w.buf.Bytes()
+	bufferViews := w.bufferViews
+	extensionsUsed := w.extensionsUsed
+	extensionsRequired := w.extensionsRequired
+
+	compressed := false
+	if w.Options.MeshoptCompression && w.bytesWritten > 0 {
+		bin, bufferViews = w.meshoptCompress()
+
+		for _, view := range bufferViews {
+			if _, ok := view.Extensions[extMeshoptCompressionID]; ok {
+				compressed = true
+				break
+			}
+		}
+
+		if compressed {
+			extensionsUsed = maps.Clone(extensionsUsed)
+			extensionsRequired = maps.Clone(extensionsRequired)
+			extensionsUsed[extMeshoptCompressionID] = true
+			extensionsRequired[extMeshoptCompressionID] = true
+		}
+	}
+
 	buffers := []Buffer{}
-	if w.bytesWritten > 0 {
+	if len(bin) > 0 {
 		buffer := Buffer{
-			ByteLength: w.bytesWritten,
+			ByteLength: len(bin),
 		}
 
 		if embeddingStrategy == BufferEmbeddingStrategy_Base64Encode {
-			buffer.URI = "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(w.buf.Bytes())
+			buffer.URI = "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(bin)
 		}
 
 		buffers = append(buffers, buffer)
 	}
 
-	extensionsUsedArr := make([]string, 0, len(w.extensionsUsed))
-	for ext := range w.extensionsUsed {
+	if compressed {
+		// Compressed buffer views decompress into this buffer, which has no
+		// data of its own
+		buffers = append(buffers, Buffer{
+			ChildOfRootProperty: ChildOfRootProperty{
+				Property: Property{
+					Extensions: Extensions{
+						extMeshoptCompressionID: map[string]any{"fallback": true},
+					},
+				},
+			},
+			ByteLength: w.bytesWritten,
+		})
+	}
+
+	extensionsUsedArr := make([]string, 0, len(extensionsUsed))
+	for ext := range extensionsUsed {
 		extensionsUsedArr = append(extensionsUsedArr, ext)
 	}
 
-	extensionsRequiredArr := make([]string, 0, len(w.extensionsRequired))
-	for ext := range w.extensionsRequired {
+	extensionsRequiredArr := make([]string, 0, len(extensionsRequired))
+	for ext := range extensionsRequired {
 		extensionsRequiredArr = append(extensionsRequiredArr, ext)
 	}
 
@@ -1308,6 +1644,16 @@ func (w Writer) ToGLTF(embeddingStrategy BufferEmbeddingStrategy) Gltf {
 		}
 	}
 
+	if len(w.materialVariants) > 0 {
+		variants := make([]KHR_MaterialsVariantsVariant, len(w.materialVariants))
+		for i, name := range w.materialVariants {
+			variants[i] = KHR_MaterialsVariantsVariant{Name: name}
+		}
+		extensions[khrMaterialsVariantsID] = KHR_MaterialsVariants{
+			Variants: variants,
+		}
+	}
+
 	var scene *int
 	if len(w.scenes) > 0 {
 		zero := 0
@@ -1317,7 +1663,7 @@ func (w Writer) ToGLTF(embeddingStrategy BufferEmbeddingStrategy) Gltf {
 	return Gltf{
 		Asset:       defaultAsset(),
 		Buffers:     buffers,
-		BufferViews: w.bufferViews,
+		BufferViews: bufferViews,
 		Accessors:   w.accessors,
 
 		// Skins: skins,
@@ -1326,6 +1672,7 @@ func (w Writer) ToGLTF(embeddingStrategy BufferEmbeddingStrategy) Gltf {
 
 		Skins:      w.skins,
 		Animations: w.animations,
+		Cameras:    w.cameras,
 
 		Nodes:     w.nodes,
 		Meshes:    w.meshes,
@@ -1339,19 +1686,21 @@ func (w Writer) ToGLTF(embeddingStrategy BufferEmbeddingStrategy) Gltf {
 		Property: Property{
 			Extensions: extensions,
 		},
-	}
+	}, bin
 }
 
 func (w Writer) WriteGLB(out io.Writer, opts WriterOptions) error {
+	doc, binBytes := w.toGLTF(BufferEmbeddingStrategy_GLB)
+
 	var jsonBytes []byte
 	var err error
 	switch opts.JsonFormat {
 	case PrettyJsonFormat:
-		jsonBytes, err = json.MarshalIndent(w.ToGLTF(BufferEmbeddingStrategy_GLB), "", "    ")
+		jsonBytes, err = json.MarshalIndent(doc, "", "    ")
 	case DefaultJsonFormat, MinifyJsonFormat:
 		fallthrough
 	default:
-		jsonBytes, err = json.Marshal(w.ToGLTF(BufferEmbeddingStrategy_GLB))
+		jsonBytes, err = json.Marshal(doc)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -1361,7 +1710,6 @@ func (w Writer) WriteGLB(out io.Writer, opts WriterOptions) error {
 	jsonPadding := (4 - (jsonByteLen % 4)) % 4
 	jsonByteLen += jsonPadding
 
-	binBytes := w.buf.Bytes()
 	binByteLen := len(binBytes)
 	binPadding := (4 - (binByteLen % 4)) % 4
 	binByteLen += binPadding
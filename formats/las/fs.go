@@ -0,0 +1,31 @@
+package las
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+// Load opens the LAS file located at the filePath and deserializes it
+func Load(filePath string) (*Cloud, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(bufio.NewReader(f))
+}
+
+// Save writes the mesh to the filePath as a LAS file using the default
+// writer options
+func Save(filePath string, m modeling.Mesh) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return Write(f, m, WriterOptions{})
+}
@@ -0,0 +1,201 @@
+package las
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	headerSizeV12 = 227
+	headerSizeV13 = 235
+	headerSizeV14 = 375
+)
+
+// Header is the public header block found at the start of every LAS file.
+// Fields introduced in later versions of the format are left zero when
+// reading older files.
+type Header struct {
+	FileSourceID       uint16
+	GlobalEncoding     uint16
+	ProjectID          [16]byte
+	VersionMajor       uint8
+	VersionMinor       uint8
+	SystemIdentifier   string
+	GeneratingSoftware string
+	CreationDayOfYear  uint16
+	CreationYear       uint16
+	HeaderSize         uint16
+	OffsetToPointData  uint32
+	NumberOfVLRs       uint32
+
+	// PointDataFormat is the format of each point record, 0 through 10
+	PointDataFormat       uint8
+	PointDataRecordLength uint16
+
+	// Compressed is set when the point data is LAZ compressed
+	Compressed bool
+
+	NumberOfPoints         uint64
+	NumberOfPointsByReturn [15]uint64
+
+	Scale  vector3.Float64
+	Offset vector3.Float64
+	Min    vector3.Float64
+	Max    vector3.Float64
+
+	// LAS 1.3+
+	StartOfWaveformData uint64
+
+	// LAS 1.4+
+	StartOfFirstEVLR uint64
+	NumberOfEVLRs    uint32
+}
+
+// rawHeader mirrors the on disk layout of the LAS 1.2 header, which every
+// later version extends
+type rawHeader struct {
+	Signature                    [4]byte
+	FileSourceID                 uint16
+	GlobalEncoding               uint16
+	ProjectID                    [16]byte
+	VersionMajor                 uint8
+	VersionMinor                 uint8
+	SystemIdentifier             [32]byte
+	GeneratingSoftware           [32]byte
+	CreationDayOfYear            uint16
+	CreationYear                 uint16
+	HeaderSize                   uint16
+	OffsetToPointData            uint32
+	NumberOfVLRs                 uint32
+	PointDataFormat              uint8
+	PointDataRecordLength        uint16
+	LegacyNumberOfPoints         uint32
+	LegacyNumberOfPointsByReturn [5]uint32
+	Scale                        [3]float64
+	Offset                       [3]float64
+	MaxX, MinX                   float64
+	MaxY, MinY                   float64
+	MaxZ, MinZ                   float64
+}
+
+type rawHeaderV13 struct {
+	StartOfWaveformData uint64
+}
+
+type rawHeaderV14 struct {
+	StartOfFirstEVLR       uint64
+	NumberOfEVLRs          uint32
+	NumberOfPoints         uint64
+	NumberOfPointsByReturn [15]uint64
+}
+
+func trimString(b []byte) string {
+	return string(bytes.TrimRight(b, "\x00 "))
+}
+
+// ReadHeader reads the public header block, consuming exactly HeaderSize
+// bytes from the reader
+func ReadHeader(in io.Reader) (*Header, error) {
+	raw := rawHeader{}
+	if err := binary.Read(in, binary.LittleEndian, &raw); err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+
+	if string(raw.Signature[:]) != "LASF" {
+		return nil, fmt.Errorf("invalid file signature %q", string(raw.Signature[:]))
+	}
+
+	if raw.VersionMajor != 1 || raw.VersionMinor > 4 {
+		return nil, fmt.Errorf("unsupported LAS version %d.%d", raw.VersionMajor, raw.VersionMinor)
+	}
+
+	if raw.HeaderSize < headerSizeV12 {
+		return nil, fmt.Errorf("header size %d is smaller than the minimum of %d", raw.HeaderSize, headerSizeV12)
+	}
+
+	header := &Header{
+		FileSourceID:          raw.FileSourceID,
+		GlobalEncoding:        raw.GlobalEncoding,
+		ProjectID:             raw.ProjectID,
+		VersionMajor:          raw.VersionMajor,
+		VersionMinor:          raw.VersionMinor,
+		SystemIdentifier:      trimString(raw.SystemIdentifier[:]),
+		GeneratingSoftware:    trimString(raw.GeneratingSoftware[:]),
+		CreationDayOfYear:     raw.CreationDayOfYear,
+		CreationYear:          raw.CreationYear,
+		HeaderSize:            raw.HeaderSize,
+		OffsetToPointData:     raw.OffsetToPointData,
+		NumberOfVLRs:          raw.NumberOfVLRs,
+		PointDataFormat:       raw.PointDataFormat & 0x3F,
+		PointDataRecordLength: raw.PointDataRecordLength,
+		// LAZ marks compressed point data by setting the upper bits of the
+		// point format
+		Compressed:     raw.PointDataFormat&0xC0 != 0,
+		NumberOfPoints: uint64(raw.LegacyNumberOfPoints),
+		Scale:          vector3.New(raw.Scale[0], raw.Scale[1], raw.Scale[2]),
+		Offset:         vector3.New(raw.Offset[0], raw.Offset[1], raw.Offset[2]),
+		Min:            vector3.New(raw.MinX, raw.MinY, raw.MinZ),
+		Max:            vector3.New(raw.MaxX, raw.MaxY, raw.MaxZ),
+	}
+	for i, count := range raw.LegacyNumberOfPointsByReturn {
+		header.NumberOfPointsByReturn[i] = uint64(count)
+	}
+
+	read := headerSizeV12
+	if raw.VersionMinor >= 3 && raw.HeaderSize >= headerSizeV13 {
+		v13 := rawHeaderV13{}
+		if err := binary.Read(in, binary.LittleEndian, &v13); err != nil {
+			return nil, fmt.Errorf("unable to read 1.3 header: %w", err)
+		}
+		header.StartOfWaveformData = v13.StartOfWaveformData
+		read = headerSizeV13
+	}
+
+	if raw.VersionMinor >= 4 && raw.HeaderSize >= headerSizeV14 {
+		v14 := rawHeaderV14{}
+		if err := binary.Read(in, binary.LittleEndian, &v14); err != nil {
+			return nil, fmt.Errorf("unable to read 1.4 header: %w", err)
+		}
+		header.StartOfFirstEVLR = v14.StartOfFirstEVLR
+		header.NumberOfEVLRs = v14.NumberOfEVLRs
+
+		// The legacy counts are zero whenever the file contains more points
+		// than they can represent, or uses a point format they don't cover
+		if header.NumberOfPoints == 0 {
+			header.NumberOfPoints = v14.NumberOfPoints
+			header.NumberOfPointsByReturn = v14.NumberOfPointsByReturn
+		}
+		read = headerSizeV14
+	}
+
+	// Skip any data appended to the header by the generating software
+	if _, err := io.CopyN(io.Discard, in, int64(raw.HeaderSize)-int64(read)); err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+
+	return header, nil
+}
+
+func (h Header) Validate() error {
+	if h.Compressed {
+		return ErrCompressed
+	}
+
+	if h.PointDataFormat > 10 {
+		return fmt.Errorf("unsupported point data format %d", h.PointDataFormat)
+	}
+
+	if required := pointRecordSizes[h.PointDataFormat]; int(h.PointDataRecordLength) < required {
+		return fmt.Errorf("point record length %d is smaller than the %d bytes required by format %d", h.PointDataRecordLength, required, h.PointDataFormat)
+	}
+
+	if h.OffsetToPointData < uint32(h.HeaderSize) {
+		return fmt.Errorf("point data offset %d lies within the header", h.OffsetToPointData)
+	}
+
+	return nil
+}
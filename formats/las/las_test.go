@@ -0,0 +1,239 @@
+package las_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/las"
+	"github.com/EliCDavis/polyform/generator/manifest"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCloud(color, gpsTime bool) modeling.Mesh {
+	cloud := modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {
+				vector3.New(1., 2., 3.),
+				vector3.New(-4.5, 100.25, 0.001),
+			},
+		},
+		nil,
+		map[string][]float64{
+			modeling.IntensityAttribute: {0, 1},
+			modeling.ClassAttribute:     {2, 6},
+		},
+	)
+	if color {
+		cloud = cloud.SetFloat3Attribute(modeling.ColorAttribute, []vector3.Float64{
+			vector3.New(1., 0., 0.),
+			vector3.New(0., 0.5, 1.),
+		})
+	}
+	if gpsTime {
+		cloud = cloud.SetFloat1Attribute(modeling.GPSTimeAttribute, []float64{12345.678, 12345.679})
+	}
+	return cloud
+}
+
+func TestWriteRead(t *testing.T) {
+	tests := map[string]struct {
+		color, gpsTime bool
+		format         uint8
+	}{
+		"format 0": {format: 0},
+		"format 1": {gpsTime: true, format: 1},
+		"format 2": {color: true, format: 2},
+		"format 3": {color: true, gpsTime: true, format: 3},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			cloud := testCloud(tc.color, tc.gpsTime)
+			buf := &bytes.Buffer{}
+
+			// ACT ============================================================
+			err := las.Write(buf, cloud, las.WriterOptions{})
+			require.NoError(t, err)
+			back, err := las.Read(buf)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			assert.Equal(t, tc.format, back.Header.PointDataFormat)
+			assert.Equal(t, uint64(2), back.Header.NumberOfPoints)
+			assert.Equal(t, "polyform", back.Header.GeneratingSoftware)
+			assert.Equal(t, modeling.PointTopology, back.Mesh.Topology())
+
+			positions := back.Mesh.Float3Attribute(modeling.PositionAttribute)
+			require.Equal(t, 2, positions.Len())
+			assert.InDelta(t, 0, positions.At(0).Distance(vector3.New(1., 2., 3.)), 1e-9)
+			assert.InDelta(t, 0, positions.At(1).Distance(vector3.New(-4.5, 100.25, 0.001)), 1e-9)
+
+			assert.Equal(t, []float64{0, 1}, readFloat1(back.Mesh, modeling.IntensityAttribute))
+			assert.Equal(t, []float64{2, 6}, readFloat1(back.Mesh, modeling.ClassAttribute))
+
+			assert.Equal(t, tc.gpsTime, back.Mesh.HasFloat1Attribute(modeling.GPSTimeAttribute))
+			if tc.gpsTime {
+				assert.Equal(t, []float64{12345.678, 12345.679}, readFloat1(back.Mesh, modeling.GPSTimeAttribute))
+			}
+
+			assert.Equal(t, tc.color, back.Mesh.HasFloat3Attribute(modeling.ColorAttribute))
+			if tc.color {
+				colors := back.Mesh.Float3Attribute(modeling.ColorAttribute)
+				assert.Equal(t, vector3.New(1., 0., 0.), colors.At(0))
+				assert.InDelta(t, 0.5, colors.At(1).Y(), 1e-4)
+			}
+		})
+	}
+}
+
+func readFloat1(m modeling.Mesh, attribute string) []float64 {
+	data := m.Float1Attribute(attribute)
+	out := make([]float64, data.Len())
+	for i := range out {
+		out[i] = data.At(i)
+	}
+	return out
+}
+
+// buildV14 constructs a LAS 1.4 file containing a single point of format 7,
+// with extra bytes appended to the header and each point record
+func buildV14(t *testing.T, pointFormat uint8) []byte {
+	t.Helper()
+	const headerSize = 375 + 5
+	const recordLength = 36 + 4
+
+	buf := &bytes.Buffer{}
+	write := func(v any) {
+		require.NoError(t, binary.Write(buf, binary.LittleEndian, v))
+	}
+
+	buf.WriteString("LASF")
+	write(uint16(0))                                // File source ID
+	write(uint16(0))                                // Global encoding
+	write([16]byte{})                               // Project ID
+	write([2]uint8{1, 4})                           // Version
+	write([32]byte{})                               // System identifier
+	write([32]byte{'t', 'e', 's', 't'})             // Generating software
+	write([2]uint16{1, 2024})                       // Creation date
+	write(uint16(headerSize))                       // Header size
+	write(uint32(headerSize))                       // Offset to point data
+	write(uint32(0))                                // Number of VLRs
+	write(pointFormat)                              // Point data format
+	write(uint16(recordLength))                     // Point data record length
+	write(uint32(0))                                // Legacy number of points
+	write([5]uint32{})                              // Legacy number of points by return
+	write([3]float64{0.01, 0.01, 0.01})             // Scale
+	write([3]float64{1000, 2000, 0})                // Offset
+	write([6]float64{1000, 1000, 2000, 2000, 0, 0}) // Bounds
+	write(uint64(0))                                // Start of waveform data
+	write(uint64(0))                                // Start of first EVLR
+	write(uint32(0))                                // Number of EVLRs
+	write(uint64(1))                                // Number of points
+	write([15]uint64{1})                            // Number of points by return
+	buf.Write(make([]byte, headerSize-buf.Len()))
+
+	write([3]int32{150, -250, 1000}) // Position
+	write(uint16(math.MaxUint16))    // Intensity
+	write(uint8(0x11))               // Returns
+	write(uint8(0))                  // Flags
+	write(uint8(65))                 // Classification, only valid in 1.4
+	write(uint8(0))                  // User data
+	write(int16(0))                  // Scan angle
+	write(uint16(0))                 // Point source ID
+	write(float64(42.5))             // GPS time
+	write([3]uint16{0, 255, 0})      // RGB, 8 bit
+	buf.Write(make([]byte, 4))       // Extra bytes
+
+	return buf.Bytes()
+}
+
+func TestRead_V14Format7(t *testing.T) {
+	// ACT ====================================================================
+	cloud, err := las.Read(bytes.NewReader(buildV14(t, 7)))
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, uint8(4), cloud.Header.VersionMinor)
+	assert.Equal(t, uint64(1), cloud.Header.NumberOfPoints)
+	assert.Equal(t, "test", cloud.Header.GeneratingSoftware)
+
+	require.Equal(t, 1, cloud.Mesh.AttributeLength())
+	assert.Equal(t, vector3.New(1001.5, 1997.5, 10.), cloud.Mesh.Float3Attribute(modeling.PositionAttribute).At(0))
+	assert.Equal(t, 1., cloud.Mesh.Float1Attribute(modeling.IntensityAttribute).At(0))
+	assert.Equal(t, 65., cloud.Mesh.Float1Attribute(modeling.ClassAttribute).At(0))
+	assert.Equal(t, 42.5, cloud.Mesh.Float1Attribute(modeling.GPSTimeAttribute).At(0))
+	assert.Equal(t, vector3.New(0., 1., 0.), cloud.Mesh.Float3Attribute(modeling.ColorAttribute).At(0))
+}
+
+func TestRead_Errors(t *testing.T) {
+	compressed := buildV14(t, 7|0x80)
+	badSignature := buildV14(t, 7)
+	copy(badSignature, "LASX")
+	badFormat := buildV14(t, 11)
+	truncated := buildV14(t, 7)
+	truncated = truncated[:len(truncated)-10]
+	overstated := buildV14(t, 7)
+	binary.LittleEndian.PutUint64(overstated[247:], math.MaxInt32)
+
+	tests := map[string]struct {
+		data []byte
+		err  string
+	}{
+		"compressed":    {data: compressed, err: las.ErrCompressed.Error()},
+		"bad signature": {data: badSignature, err: `invalid file signature "LASX"`},
+		"bad format":    {data: badFormat, err: "unsupported point data format 11"},
+		"truncated":     {data: truncated, err: "unable to read point 0: unexpected EOF"},
+		"overstated":    {data: overstated, err: "unable to read point 1: EOF"},
+		"empty":         {data: nil, err: "unable to read header: EOF"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := las.Read(bytes.NewReader(tc.data))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestWrite_Errors(t *testing.T) {
+	t.Run("missing positions", func(t *testing.T) {
+		err := las.Write(&bytes.Buffer{}, modeling.EmptyMesh(modeling.PointTopology), las.WriterOptions{})
+		assert.EqualError(t, err, "mesh is missing position data")
+	})
+
+	t.Run("too large for scale", func(t *testing.T) {
+		cloud := modeling.NewPointCloud(nil, map[string][]vector3.Float64{
+			modeling.PositionAttribute: {vector3.New(0., 0., 0.), vector3.New(1e9, 0., 0.)},
+		}, nil, nil)
+		err := las.Write(&bytes.Buffer{}, cloud, las.WriterOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestManifestNode(t *testing.T) {
+	// ARRANGE ================================================================
+	node := &nodes.Struct[las.ManifestNode]{
+		Data: las.ManifestNode{
+			Mesh: nodes.ConstOutput[modeling.Mesh]{Val: testCloud(true, false)},
+		},
+	}
+	result := nodes.GetNodeOutputPort[manifest.Manifest](node, "Out").Value()
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := result.Entries["points.las"].Artifact.Write(buf)
+	require.NoError(t, err)
+	cloud, err := las.Read(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 2, cloud.Mesh.AttributeLength())
+}
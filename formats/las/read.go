@@ -0,0 +1,222 @@
+package las
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// ErrCompressed is returned when attempting to read LAZ compressed point data
+var ErrCompressed = errors.New("LAZ compressed point data is not supported, decompress with laszip first")
+
+// pointRecordSizes is the minimum size in bytes of each point data record
+// format. Records may be longer when carrying extra bytes.
+var pointRecordSizes = [11]int{20, 28, 26, 34, 57, 63, 30, 36, 38, 59, 67}
+
+// maxPreallocatedPoints caps the space reserved for point records up front,
+// as a corrupt header's point count can far exceed the records that follow
+const maxPreallocatedPoints = 1 << 20
+
+// VariableLengthRecord carries metadata such as the coordinate reference
+// system of the point cloud
+type VariableLengthRecord struct {
+	UserID      string
+	RecordID    uint16
+	Description string
+	Data        []byte
+}
+
+type rawVLRHeader struct {
+	Reserved                uint16
+	UserID                  [16]byte
+	RecordID                uint16
+	RecordLengthAfterHeader uint16
+	Description             [32]byte
+}
+
+type Cloud struct {
+	Header Header
+	VLRs   []VariableLengthRecord
+	Mesh   modeling.Mesh
+}
+
+func (h Header) extendedFormat() bool {
+	return h.PointDataFormat >= 6
+}
+
+func (h Header) hasGPSTime() bool {
+	return h.PointDataFormat != 0 && h.PointDataFormat != 2
+}
+
+// colorOffset is where the RGB values start within a point record, or -1 if
+// the format doesn't contain color
+func (h Header) colorOffset() int {
+	switch h.PointDataFormat {
+	case 2:
+		return 20
+	case 3, 5:
+		return 28
+	case 7, 8, 10:
+		return 30
+	}
+	return -1
+}
+
+func readVLRs(in io.Reader, count uint32) ([]VariableLengthRecord, int64, error) {
+	vlrs := make([]VariableLengthRecord, count)
+	read := int64(0)
+	for i := range vlrs {
+		raw := rawVLRHeader{}
+		if err := binary.Read(in, binary.LittleEndian, &raw); err != nil {
+			return nil, 0, fmt.Errorf("unable to read variable length record %d: %w", i, err)
+		}
+
+		data := make([]byte, raw.RecordLengthAfterHeader)
+		if _, err := io.ReadFull(in, data); err != nil {
+			return nil, 0, fmt.Errorf("unable to read variable length record %d: %w", i, err)
+		}
+
+		vlrs[i] = VariableLengthRecord{
+			UserID:      trimString(raw.UserID[:]),
+			RecordID:    raw.RecordID,
+			Description: trimString(raw.Description[:]),
+			Data:        data,
+		}
+		read += int64(binary.Size(raw)) + int64(len(data))
+	}
+	return vlrs, read, nil
+}
+
+// Read deserializes a LAS file into a point cloud with position, intensity,
+// classification, color and GPS time attributes, depending on which the
+// file's point format contains. Intensity and color are normalized to [0, 1].
+func Read(in io.Reader) (*Cloud, error) {
+	header, err := ReadHeader(in)
+	if err != nil {
+		return nil, err
+	}
+
+	vlrs, vlrSize, err := readVLRs(in, header.NumberOfVLRs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vlr := range vlrs {
+		if vlr.UserID == "laszip encoded" {
+			return nil, ErrCompressed
+		}
+	}
+
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Skip anything between the records and the start of the point data
+	padding := int64(header.OffsetToPointData) - int64(header.HeaderSize) - vlrSize
+	if padding < 0 {
+		return nil, fmt.Errorf("point data offset %d lies within the variable length records", header.OffsetToPointData)
+	}
+	if _, err := io.CopyN(io.Discard, in, padding); err != nil {
+		return nil, fmt.Errorf("unable to seek to point data: %w", err)
+	}
+
+	const maxPointsToRead = math.MaxInt32
+	if header.NumberOfPoints > maxPointsToRead {
+		return nil, fmt.Errorf("header defines too many points: %d", header.NumberOfPoints)
+	}
+	count := int(header.NumberOfPoints)
+
+	capacity := min(count, maxPreallocatedPoints)
+	positions := make([]vector3.Float64, 0, capacity)
+	intensities := make([]float64, 0, capacity)
+	classes := make([]float64, 0, capacity)
+
+	var gpsTimes []float64
+	if header.hasGPSTime() {
+		gpsTimes = make([]float64, 0, capacity)
+	}
+
+	colorOffset := header.colorOffset()
+	var colors []vector3.Float64
+	if colorOffset >= 0 {
+		colors = make([]vector3.Float64, 0, capacity)
+	}
+
+	classOffset := 15
+	gpsOffset := 20
+	if header.extendedFormat() {
+		classOffset = 16
+		gpsOffset = 22
+	}
+
+	reader := bufio.NewReader(in)
+	record := make([]byte, header.PointDataRecordLength)
+	maxColor := uint16(0)
+	for i := 0; i < count; i++ {
+		if _, err := io.ReadFull(reader, record); err != nil {
+			return nil, fmt.Errorf("unable to read point %d: %w", i, err)
+		}
+
+		positions = append(positions, vector3.New(
+			float64(int32(binary.LittleEndian.Uint32(record[0:])))*header.Scale.X()+header.Offset.X(),
+			float64(int32(binary.LittleEndian.Uint32(record[4:])))*header.Scale.Y()+header.Offset.Y(),
+			float64(int32(binary.LittleEndian.Uint32(record[8:])))*header.Scale.Z()+header.Offset.Z(),
+		))
+		intensities = append(intensities, float64(binary.LittleEndian.Uint16(record[12:]))/math.MaxUint16)
+
+		class := record[classOffset]
+		if !header.extendedFormat() {
+			// The upper bits are synthetic, key-point and withheld flags
+			class &= 0x1F
+		}
+		classes = append(classes, float64(class))
+
+		if gpsTimes != nil {
+			gpsTimes = append(gpsTimes, math.Float64frombits(binary.LittleEndian.Uint64(record[gpsOffset:])))
+		}
+
+		if colors != nil {
+			r := binary.LittleEndian.Uint16(record[colorOffset:])
+			g := binary.LittleEndian.Uint16(record[colorOffset+2:])
+			b := binary.LittleEndian.Uint16(record[colorOffset+4:])
+			maxColor = max(maxColor, r, g, b)
+			colors = append(colors, vector3.New(float64(r), float64(g), float64(b)))
+		}
+	}
+
+	v3Data := map[string][]vector3.Float64{
+		modeling.PositionAttribute: positions,
+	}
+	if colors != nil {
+		// The spec calls for 16 bit color, but plenty of software writes 8
+		// bit values instead
+		scale := float64(math.MaxUint16)
+		if maxColor <= math.MaxUint8 {
+			scale = math.MaxUint8
+		}
+		for i, c := range colors {
+			colors[i] = c.DivByConstant(scale)
+		}
+		v3Data[modeling.ColorAttribute] = colors
+	}
+
+	v1Data := map[string][]float64{
+		modeling.IntensityAttribute: intensities,
+		modeling.ClassAttribute:     classes,
+	}
+	if gpsTimes != nil {
+		v1Data[modeling.GPSTimeAttribute] = gpsTimes
+	}
+
+	return &Cloud{
+		Header: *header,
+		VLRs:   vlrs,
+		Mesh:   modeling.NewPointCloud(nil, v3Data, nil, v1Data),
+	}, nil
+}
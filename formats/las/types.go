@@ -0,0 +1,84 @@
+package las
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/generator/manifest"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+	refutil.RegisterType[nodes.Struct[ReadNode]](factory)
+	refutil.RegisterType[nodes.Struct[ManifestNode]](factory)
+	generator.RegisterTypes(factory)
+}
+
+type ReadNode struct {
+	Data nodes.Output[[]byte]
+}
+
+func (rn ReadNode) CacheVersion() int {
+	return 1
+}
+
+func (rn ReadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	out.Set(modeling.EmptyMesh(modeling.PointTopology))
+	if rn.Data == nil {
+		return
+	}
+
+	data := nodes.GetOutputValue(out, rn.Data)
+	if len(data) == 0 {
+		return
+	}
+
+	cloud, err := Read(bytes.NewReader(data))
+	if err != nil {
+		out.CaptureError(err)
+		return
+	}
+
+	out.Set(cloud.Mesh)
+}
+
+// ============================================================================
+
+type Artifact struct {
+	Mesh    modeling.Mesh
+	Options WriterOptions
+}
+
+func (a Artifact) Write(w io.Writer) error {
+	return Write(w, a.Mesh, a.Options)
+}
+
+func (Artifact) Mime() string {
+	return "application/vnd.las"
+}
+
+type ManifestNode struct {
+	Mesh  nodes.Output[modeling.Mesh]
+	Scale nodes.Output[float64] `description:"Precision positions are stored with. Defaults to 0.001"`
+}
+
+func (mn ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	scale := nodes.TryGetOutputValue(out, mn.Scale, 0.001)
+	if scale <= 0 {
+		out.CaptureError(nodes.InvalidInputError{Input: mn.Scale, Message: "scale must be positive"})
+		scale = 0.001
+	}
+
+	entry := manifest.Entry{
+		Artifact: Artifact{
+			Mesh:    nodes.TryGetOutputValue(out, mn.Mesh, modeling.EmptyMesh(modeling.PointTopology)),
+			Options: WriterOptions{Scale: vector3.Fill(scale)},
+		},
+	}
+	out.Set(manifest.SingleEntryManifest("points.las", entry))
+}
@@ -0,0 +1,143 @@
+package las
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// WriterOptions controls how points are quantized when written
+type WriterOptions struct {
+	// Scale is the precision positions are stored with along each axis.
+	// Defaults to a millimeter.
+	Scale vector3.Float64
+}
+
+func clampUnit(v float64) uint16 {
+	return uint16(math.Round(math.Max(0, math.Min(1, v)) * math.MaxUint16))
+}
+
+// Write serializes the vertices of the mesh as a LAS 1.2 file. The point
+// format is picked based on whether the mesh has color and GPS time data.
+// Intensity and color are expected to be within [0, 1].
+func Write(out io.Writer, m modeling.Mesh, options WriterOptions) error {
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return errors.New("mesh is missing position data")
+	}
+
+	scale := options.Scale
+	if scale == (vector3.Float64{}) {
+		scale = vector3.Fill(0.001)
+	}
+	if scale.MinComponent() <= 0 {
+		return fmt.Errorf("invalid scale %v, all components must be positive", scale)
+	}
+
+	count := m.AttributeLength()
+	if count > math.MaxUint32 {
+		return fmt.Errorf("LAS 1.2 can not store %d points", count)
+	}
+
+	hasColor := m.HasFloat3Attribute(modeling.ColorAttribute)
+	hasGPSTime := m.HasFloat1Attribute(modeling.GPSTimeAttribute)
+	hasIntensity := m.HasFloat1Attribute(modeling.IntensityAttribute)
+	hasClass := m.HasFloat1Attribute(modeling.ClassAttribute)
+
+	format := uint8(0)
+	if hasGPSTime {
+		format |= 1
+	}
+	if hasColor {
+		format |= 2
+	}
+
+	bounds := geometry.NewEmptyAABB()
+	if count > 0 {
+		bounds = m.BoundingBox(modeling.PositionAttribute)
+	}
+	offset := bounds.Min()
+
+	extents := bounds.Max().Sub(offset).DivByVector(scale)
+	if extents.MaxComponent() > math.MaxInt32 {
+		return fmt.Errorf("point cloud is too large to be stored at a scale of %v", scale)
+	}
+
+	header := rawHeader{
+		Signature:                    [4]byte{'L', 'A', 'S', 'F'},
+		VersionMajor:                 1,
+		VersionMinor:                 2,
+		HeaderSize:                   headerSizeV12,
+		OffsetToPointData:            headerSizeV12,
+		PointDataFormat:              format,
+		PointDataRecordLength:        uint16(pointRecordSizes[format]),
+		LegacyNumberOfPoints:         uint32(count),
+		LegacyNumberOfPointsByReturn: [5]uint32{uint32(count)},
+		Scale:                        [3]float64{scale.X(), scale.Y(), scale.Z()},
+		Offset:                       [3]float64{offset.X(), offset.Y(), offset.Z()},
+		MinX:                         bounds.Min().X(),
+		MinY:                         bounds.Min().Y(),
+		MinZ:                         bounds.Min().Z(),
+		MaxX:                         bounds.Max().X(),
+		MaxY:                         bounds.Max().Y(),
+		MaxZ:                         bounds.Max().Z(),
+	}
+	copy(header.GeneratingSoftware[:], "polyform")
+
+	writer := bufio.NewWriter(out)
+	if err := binary.Write(writer, binary.LittleEndian, header); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	record := make([]byte, header.PointDataRecordLength)
+	for i := 0; i < count; i++ {
+		clear(record)
+
+		p := positions.At(i).Sub(offset).DivByVector(scale).Round()
+		binary.LittleEndian.PutUint32(record[0:], uint32(int32(p.X())))
+		binary.LittleEndian.PutUint32(record[4:], uint32(int32(p.Y())))
+		binary.LittleEndian.PutUint32(record[8:], uint32(int32(p.Z())))
+
+		if hasIntensity {
+			intensity := m.Float1Attribute(modeling.IntensityAttribute).At(i)
+			binary.LittleEndian.PutUint16(record[12:], clampUnit(intensity))
+		}
+
+		// Every point is the first of a single return
+		record[14] = 1 | 1<<3
+
+		if hasClass {
+			class := m.Float1Attribute(modeling.ClassAttribute).At(i)
+			record[15] = uint8(math.Max(0, math.Min(31, math.Round(class))))
+		}
+
+		if hasGPSTime {
+			gpsTime := m.Float1Attribute(modeling.GPSTimeAttribute).At(i)
+			binary.LittleEndian.PutUint64(record[20:], math.Float64bits(gpsTime))
+		}
+
+		if hasColor {
+			colorOffset := 20
+			if hasGPSTime {
+				colorOffset = 28
+			}
+			c := m.Float3Attribute(modeling.ColorAttribute).At(i)
+			binary.LittleEndian.PutUint16(record[colorOffset:], clampUnit(c.X()))
+			binary.LittleEndian.PutUint16(record[colorOffset+2:], clampUnit(c.Y()))
+			binary.LittleEndian.PutUint16(record[colorOffset+4:], clampUnit(c.Z()))
+		}
+
+		if _, err := writer.Write(record); err != nil {
+			return fmt.Errorf("unable to write point %d: %w", i, err)
+		}
+	}
+
+	return writer.Flush()
+}
@@ -109,6 +109,10 @@ type ReadNode struct {
 	In nodes.Output[[]byte]
 }
 
+func (pn ReadNode) CacheVersion() int {
+	return 1
+}
+
 func (pn ReadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if pn.In == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
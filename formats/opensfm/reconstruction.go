@@ -1,28 +1,150 @@
 package opensfm
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math"
+	"sort"
+	"strconv"
 
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/sfm/opensfm"
 	"github.com/EliCDavis/vector/vector3"
 )
 
+// sortedPointIDs returns the reconstruction's point ids in a stable order,
+// along with their numeric values when every id is an integer
+func sortedPointIDs(points map[string]opensfm.PointSchema) ([]string, []float64, bool) {
+	keys := make([]string, 0, len(points))
+	for key := range points {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parsed := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		id, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return keys, nil, false
+		}
+		parsed[key] = id
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return parsed[keys[i]] < parsed[keys[j]] })
+	ids := make([]float64, len(keys))
+	for i, key := range keys {
+		ids[i] = float64(parsed[key])
+	}
+	return keys, ids, true
+}
+
+// ReconstructionToPointcloud converts the reconstruction's points into a
+// point cloud. When the point ids are integers, they're kept in an "id"
+// attribute so the points can be matched back up with their tracks.
 func ReconstructionToPointcloud(reconstruction opensfm.ReconstructionSchema) modeling.Mesh {
-	positionData := make([]vector3.Float64, len(reconstruction.Points))
-	colorData := make([]vector3.Float64, len(reconstruction.Points))
+	keys, ids, numeric := sortedPointIDs(reconstruction.Points)
 
-	i := 0
-	for _, point := range reconstruction.Points {
+	positionData := make([]vector3.Float64, len(keys))
+	colorData := make([]vector3.Float64, len(keys))
+
+	for i, key := range keys {
+		point := reconstruction.Points[key]
 		positionData[i] = vector3.New(point.Coordinates[0], point.Coordinates[1], point.Coordinates[2])
 		colorData[i] = vector3.New(point.Color[0]/255, point.Color[1]/255, point.Color[2]/255)
-		i++
+	}
+
+	var v1 map[string][]float64
+	if numeric {
+		v1 = map[string][]float64{"id": ids}
 	}
 
 	return modeling.NewPointCloud(nil, map[string][]vector3.Vector[float64]{
 		modeling.PositionAttribute: positionData,
 		modeling.ColorAttribute:    colorData,
-	}, nil, nil)
+	}, nil, v1)
+}
+
+// PointCloudToReconstruction replaces the points of the original
+// reconstruction with the contents of the point cloud, keeping its cameras,
+// shots and everything else as is. Points keep the id found in their "id"
+// attribute, and are numbered sequentially otherwise.
+func PointCloudToReconstruction(cloud modeling.Mesh, original opensfm.ReconstructionSchema) (opensfm.ReconstructionSchema, error) {
+	if !cloud.HasFloat3Attribute(modeling.PositionAttribute) {
+		return original, errors.New("point cloud requires a position attribute")
+	}
+
+	positions := cloud.Float3Attribute(modeling.PositionAttribute)
+	keys := make([]string, positions.Len())
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+
+	if cloud.HasFloat1Attribute("id") {
+		ids := cloud.Float1Attribute("id")
+		for i := range keys {
+			id := ids.At(i)
+			if id != math.Trunc(id) {
+				return original, fmt.Errorf("point %d has an invalid id %g", i, id)
+			}
+			keys[i] = strconv.FormatInt(int64(id), 10)
+		}
+	}
+
+	points := make(map[string]opensfm.PointSchema, len(keys))
+	for i, key := range keys {
+		if _, ok := points[key]; ok {
+			return original, fmt.Errorf("point %d has duplicate id %s", i, key)
+		}
+
+		p := positions.At(i)
+		c := vector3.Fill(255.)
+		if cloud.HasFloat3Attribute(modeling.ColorAttribute) {
+			c = cloud.Float3Attribute(modeling.ColorAttribute).At(i).Clamp(0, 1).Scale(255)
+		}
+
+		points[key] = opensfm.PointSchema{
+			Color:       []float64{c.X(), c.Y(), c.Z()},
+			Coordinates: []float64{p.X(), p.Y(), p.Z()},
+		}
+	}
+
+	result := original
+	result.Points = points
+	return result, nil
+}
+
+// WriteReconstruction writes the reconstructions in the layout of OpenSfM's
+// reconstruction.json
+func WriteReconstruction(out io.Writer, reconstructions opensfm.ReconstructionJsonSchema) error {
+	// OpenSfM expects objects where encoding/json would write null
+	cleaned := make(opensfm.ReconstructionJsonSchema, len(reconstructions))
+	for i, rec := range reconstructions {
+		if rec.Cameras == nil {
+			rec.Cameras = make(map[string]opensfm.CameraSchema)
+		}
+		if rec.Shots == nil {
+			rec.Shots = make(map[string]opensfm.ShotSchema)
+		}
+		if rec.Points == nil {
+			rec.Points = make(map[string]opensfm.PointSchema)
+		}
+		if rec.Biases == nil {
+			rec.Biases = make(map[string]opensfm.BiasSchema)
+		}
+		if rec.RigCameras == nil {
+			rec.RigCameras = make(map[string]opensfm.RigCamera)
+		}
+		if rec.RigInstances == nil {
+			rec.RigInstances = make(map[string]opensfm.RigInstance)
+		}
+		cleaned[i] = rec
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(cleaned)
 }
 
 // Loads the feature match point data into a Pointcloud mesh
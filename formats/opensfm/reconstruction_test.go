@@ -1,6 +1,7 @@
 package opensfm_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/EliCDavis/polyform/formats/opensfm"
@@ -8,6 +9,7 @@ import (
 	opensfmFormat "github.com/EliCDavis/sfm/opensfm"
 	"github.com/EliCDavis/vector/vector3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReconstructionToPointcloud(t *testing.T) {
@@ -44,3 +46,104 @@ func TestReconstructionToPointcloud(t *testing.T) {
 	assert.Equal(t, 0., colorData.At(0).Y())
 	assert.InDelta(t, .5, colorData.At(0).Z(), 0.01)
 }
+
+func TestReconstructionToPointcloud_IDs(t *testing.T) {
+	reconstruction := opensfmFormat.ReconstructionSchema{
+		Points: map[string]opensfmFormat.PointSchema{
+			"10": {Color: []float64{0, 0, 0}, Coordinates: []float64{10, 0, 0}},
+			"2":  {Color: []float64{0, 0, 0}, Coordinates: []float64{2, 0, 0}},
+		},
+	}
+
+	pointcloud := opensfm.ReconstructionToPointcloud(reconstruction)
+
+	require.True(t, pointcloud.HasFloat1Attribute("id"))
+	ids := pointcloud.Float1Attribute("id")
+	assert.Equal(t, 2., ids.At(0))
+	assert.Equal(t, 10., ids.At(1))
+	assert.Equal(t, vector3.New(2., 0., 0.), pointcloud.Float3Attribute(modeling.PositionAttribute).At(0))
+}
+
+func TestReconstructionToPointcloud_NonNumericIDs(t *testing.T) {
+	reconstruction := opensfmFormat.ReconstructionSchema{
+		Points: map[string]opensfmFormat.PointSchema{
+			"a": {Color: []float64{0, 0, 0}, Coordinates: []float64{1, 2, 3}},
+		},
+	}
+
+	pointcloud := opensfm.ReconstructionToPointcloud(reconstruction)
+
+	assert.False(t, pointcloud.HasFloat1Attribute("id"))
+}
+
+func TestWriteReconstruction_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	original := opensfmFormat.ReconstructionSchema{
+		Cameras: map[string]opensfmFormat.CameraSchema{
+			"v2 unknown 640 480 perspective 0": {
+				ProjectionType: "perspective",
+				Width:          640,
+				Height:         480,
+				Focal:          0.85,
+				K1:             0.01,
+			},
+		},
+		Shots: map[string]opensfmFormat.ShotSchema{
+			"frame.jpg": {
+				Rotation:    []float64{0.1, 0.2, 0.3},
+				Translation: []float64{1, 2, 3},
+				Orientation: 1,
+				Camera:      "v2 unknown 640 480 perspective 0",
+				CaptureTime: 12.5,
+			},
+		},
+		Points: map[string]opensfmFormat.PointSchema{
+			"4": {Color: []float64{255, 0, 0}, Coordinates: []float64{1, 1, 1}},
+			"7": {Color: []float64{0, 255, 0}, Coordinates: []float64{2, 2, 2}},
+		},
+		ReferenceLLA: opensfmFormat.ReferenceLLA{Latitude: 1, Longitude: 2, Altitude: 3},
+	}
+
+	// Drop point 4 and move point 7
+	cloud := opensfm.ReconstructionToPointcloud(original).
+		Translate(vector3.New(1., 0., 0.))
+	cloud = modeling.NewPointCloud(nil, map[string][]vector3.Float64{
+		modeling.PositionAttribute: {cloud.Float3Attribute(modeling.PositionAttribute).At(1)},
+		modeling.ColorAttribute:    {cloud.Float3Attribute(modeling.ColorAttribute).At(1)},
+	}, nil, map[string][]float64{
+		"id": {cloud.Float1Attribute("id").At(1)},
+	})
+
+	// ACT ====================================================================
+	reconstruction, err := opensfm.PointCloudToReconstruction(cloud, original)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = opensfm.WriteReconstruction(buf, opensfmFormat.ReconstructionJsonSchema{reconstruction})
+	require.NoError(t, err)
+
+	back, err := opensfmFormat.ReadReconstruction(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, back, 1)
+	assert.Equal(t, original.Cameras, back[0].Cameras)
+	assert.Equal(t, original.Shots, back[0].Shots)
+	assert.Equal(t, original.ReferenceLLA, back[0].ReferenceLLA)
+	assert.Equal(t, map[string]opensfmFormat.PointSchema{
+		"7": {Color: []float64{0, 255, 0}, Coordinates: []float64{3, 2, 2}},
+	}, back[0].Points)
+	assert.NotNil(t, back[0].Biases)
+}
+
+func TestPointCloudToReconstruction_DuplicateIDs(t *testing.T) {
+	cloud := modeling.NewPointCloud(nil, map[string][]vector3.Float64{
+		modeling.PositionAttribute: {vector3.Zero[float64](), vector3.Zero[float64]()},
+	}, nil, map[string][]float64{
+		"id": {1, 1},
+	})
+
+	_, err := opensfm.PointCloudToReconstruction(cloud, opensfmFormat.ReconstructionSchema{})
+
+	assert.EqualError(t, err, "point 1 has duplicate id 1")
+}
@@ -2,17 +2,22 @@ package opensfm
 
 import (
 	"bytes"
+	"errors"
+	"io"
 
 	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/generator/manifest"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/polyform/nodes"
 	"github.com/EliCDavis/polyform/refutil"
+	"github.com/EliCDavis/sfm/opensfm"
 )
 
 func init() {
 	factory := &refutil.TypeFactory{}
 
 	refutil.RegisterType[nodes.Struct[ReadReconstructionNode]](factory)
+	refutil.RegisterType[nodes.Struct[ManifestNode]](factory)
 
 	generator.RegisterTypes(factory)
 }
@@ -21,6 +26,10 @@ type ReadReconstructionNode struct {
 	In nodes.Output[[]byte]
 }
 
+func (pn ReadReconstructionNode) CacheVersion() int {
+	return 1
+}
+
 func (pn ReadReconstructionNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if pn.In == nil {
 		out.Set(modeling.EmptyMesh(modeling.PointTopology))
@@ -31,3 +40,58 @@ func (pn ReadReconstructionNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	out.Set(data)
 	out.CaptureError(err)
 }
+
+// ============================================================================
+
+type Artifact struct {
+	Reconstructions opensfm.ReconstructionJsonSchema
+}
+
+func (a Artifact) Write(w io.Writer) error {
+	return WriteReconstruction(w, a.Reconstructions)
+}
+
+func (Artifact) Mime() string {
+	return "application/json"
+}
+
+type ManifestNode struct {
+	Points   nodes.Output[modeling.Mesh]
+	Original nodes.Output[[]byte] `description:"reconstruction.json the point cloud came from, whose cameras and shots are carried over"`
+}
+
+func (mn ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	original := opensfm.ReconstructionSchema{}
+	if data := nodes.TryGetOutputValue(out, mn.Original, nil); len(data) > 0 {
+		reconstructions, err := opensfm.ReadReconstruction(bytes.NewReader(data))
+		if err != nil {
+			out.CaptureError(err)
+			return
+		}
+
+		if len(reconstructions) > 1 {
+			out.CaptureError(errors.New("point cloud can only be written back to a single reconstruction"))
+			return
+		}
+
+		if len(reconstructions) == 1 {
+			original = reconstructions[0]
+		}
+	}
+
+	reconstruction, err := PointCloudToReconstruction(
+		nodes.TryGetOutputValue(out, mn.Points, modeling.EmptyPointcloud()),
+		original,
+	)
+	if err != nil {
+		out.CaptureError(err)
+		return
+	}
+
+	entry := manifest.Entry{
+		Artifact: Artifact{
+			Reconstructions: opensfm.ReconstructionJsonSchema{reconstruction},
+		},
+	}
+	out.Set(manifest.SingleEntryManifest("reconstruction.json", entry))
+}
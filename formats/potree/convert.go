@@ -0,0 +1,404 @@
+package potree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/math/morton"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	// Bits per axis of the morton codes used to sort points, which limits
+	// how deep the octree can go
+	mortonResolution = 21
+
+	// Each node samples its points on a grid with this many cells along each
+	// axis, so the spacing between points halves with every level
+	samplingGridBits = 7
+
+	hierarchyEntrySize = 22
+
+	innerNodeType = 0
+	leafNodeType  = 1
+)
+
+// ConversionOptions controls how a point cloud is split into an octree
+type ConversionOptions struct {
+	Name string
+
+	// Precision positions are stored with. Defaults to 0.001.
+	Scale float64
+
+	// Most points a leaf node can hold before it's subdivided. Defaults to
+	// 20,000.
+	MaxPointsPerNode int
+
+	// Deepest level of the octree, beyond which leaves hold every remaining
+	// point regardless of MaxPointsPerNode. Defaults to, and can't exceed, 20.
+	MaxDepth int
+}
+
+func (co ConversionOptions) fill() (ConversionOptions, error) {
+	if co.Scale == 0 {
+		co.Scale = 0.001
+	}
+	if co.MaxPointsPerNode == 0 {
+		co.MaxPointsPerNode = 20_000
+	}
+	if co.MaxDepth == 0 {
+		co.MaxDepth = mortonResolution - 1
+	}
+
+	if co.Scale < 0 {
+		return co, fmt.Errorf("invalid scale %g, must be positive", co.Scale)
+	}
+	if co.MaxPointsPerNode < 0 {
+		return co, fmt.Errorf("invalid max points per node %d", co.MaxPointsPerNode)
+	}
+	if co.MaxDepth < 0 || co.MaxDepth >= mortonResolution {
+		return co, fmt.Errorf("invalid max depth %d, must be between 1 and %d", co.MaxDepth, mortonResolution-1)
+	}
+	return co, nil
+}
+
+// Dataset is a Potree 2.0 point cloud, ready to be served to a viewer
+type Dataset struct {
+	Metadata  Metadata
+	Root      *OctreeNode
+	Hierarchy []byte
+	Octree    []byte
+}
+
+// pointLayout determines which attributes each point is stored with
+type pointLayout struct {
+	intensity bool
+	class     bool
+	color     bool
+}
+
+func (pl pointLayout) attributes(cloud modeling.Mesh) []Attribute {
+	positionBounds := cloud.BoundingBox(modeling.PositionAttribute)
+	attributes := []Attribute{{
+		Name:        "position",
+		Size:        12,
+		NumElements: 3,
+		ElementSize: 4,
+		Type:        Int32AttributeType,
+		Min:         []float64{positionBounds.Min().X(), positionBounds.Min().Y(), positionBounds.Min().Z()},
+		Max:         []float64{positionBounds.Max().X(), positionBounds.Max().Y(), positionBounds.Max().Z()},
+	}}
+
+	if pl.intensity {
+		attributes = append(attributes, Attribute{
+			Name:        "intensity",
+			Size:        2,
+			NumElements: 1,
+			ElementSize: 2,
+			Type:        UInt16AttributeType,
+			Min:         []float64{0},
+			Max:         []float64{math.MaxUint16},
+		})
+	}
+
+	if pl.class {
+		attributes = append(attributes, Attribute{
+			Name:        "classification",
+			Size:        1,
+			NumElements: 1,
+			ElementSize: 1,
+			Type:        UInt8AttributeType,
+			Min:         []float64{0},
+			Max:         []float64{math.MaxUint8},
+		})
+	}
+
+	if pl.color {
+		attributes = append(attributes, Attribute{
+			Name:        "rgb",
+			Size:        6,
+			NumElements: 3,
+			ElementSize: 2,
+			Type:        UInt16AttributeType,
+			Min:         []float64{0, 0, 0},
+			Max:         []float64{math.MaxUint8, math.MaxUint8, math.MaxUint8},
+		})
+	}
+
+	return attributes
+}
+
+func unitToUint(v, max float64) uint64 {
+	return uint64(math.Round(math.Max(0, math.Min(1, v)) * max))
+}
+
+// octreeBuilder lays points out in Potree's own octree rather than a
+// trees.OctTree, which indexes bounding box elements, fits its bounds to
+// them, numbers its children with X as the lowest bit and keeps its nodes
+// private. Potree needs cubic nodes with a subsample of points kept at
+// every level.
+type octreeBuilder struct {
+	cloud   modeling.Mesh
+	layout  pointLayout
+	options ConversionOptions
+	offset  vector3.Float64
+	codes   []uint64
+	octree  *bytes.Buffer
+	record  []byte
+}
+
+func (ob *octreeBuilder) writePoint(i int) {
+	clear(ob.record)
+
+	p := ob.cloud.Float3Attribute(modeling.PositionAttribute).At(i).
+		Sub(ob.offset).
+		DivByConstant(ob.options.Scale).
+		Round()
+	binary.LittleEndian.PutUint32(ob.record[0:], uint32(int32(p.X())))
+	binary.LittleEndian.PutUint32(ob.record[4:], uint32(int32(p.Y())))
+	binary.LittleEndian.PutUint32(ob.record[8:], uint32(int32(p.Z())))
+	offset := 12
+
+	if ob.layout.intensity {
+		intensity := ob.cloud.Float1Attribute(modeling.IntensityAttribute).At(i)
+		binary.LittleEndian.PutUint16(ob.record[offset:], uint16(unitToUint(intensity, math.MaxUint16)))
+		offset += 2
+	}
+
+	if ob.layout.class {
+		class := ob.cloud.Float1Attribute(modeling.ClassAttribute).At(i)
+		ob.record[offset] = uint8(math.Max(0, math.Min(math.MaxUint8, math.Round(class))))
+		offset++
+	}
+
+	// Colors are stored as 8 bit values, which readers tell apart from 16
+	// bit values by every channel being at most 255
+	if ob.layout.color {
+		c := ob.cloud.Float3Attribute(modeling.ColorAttribute).At(i)
+		binary.LittleEndian.PutUint16(ob.record[offset:], uint16(unitToUint(c.X(), math.MaxUint8)))
+		binary.LittleEndian.PutUint16(ob.record[offset+2:], uint16(unitToUint(c.Y(), math.MaxUint8)))
+		binary.LittleEndian.PutUint16(ob.record[offset+4:], uint16(unitToUint(c.Z(), math.MaxUint8)))
+	}
+
+	ob.octree.Write(ob.record)
+}
+
+// build stores a subsample of the points within the node, and passes the
+// rest down to its children. Points are sorted by their morton code, so
+// points sharing a sampling cell or a child are next to one another.
+func (ob *octreeBuilder) build(node *OctreeNode, points []int) {
+	node.ByteOffset = uint64(ob.octree.Len())
+
+	if len(points) <= ob.options.MaxPointsPerNode || node.Level >= ob.options.MaxDepth {
+		for _, p := range points {
+			ob.writePoint(p)
+		}
+		node.NodeType = leafNodeType
+		node.NumPoints = uint32(len(points))
+		node.ByteSize = uint64(ob.octree.Len()) - node.ByteOffset
+		return
+	}
+
+	cellShift := 3 * max(0, mortonResolution-(node.Level+samplingGridBits))
+	childShift := 3 * (mortonResolution - node.Level - 1)
+
+	var children [8][]int
+	previousCell := uint64(math.MaxUint64)
+	for _, p := range points {
+		code := ob.codes[p]
+		if cell := code >> cellShift; cell != previousCell {
+			previousCell = cell
+			ob.writePoint(p)
+			node.NumPoints++
+			continue
+		}
+
+		// Morton codes interleave X into the lowest bit, while Potree
+		// indexes children with X as the highest bit
+		octant := (code >> childShift) & 0b111
+		child := (octant&0b001)<<2 | octant&0b010 | (octant&0b100)>>2
+		children[child] = append(children[child], p)
+	}
+
+	node.NodeType = innerNodeType
+	node.ByteSize = uint64(ob.octree.Len()) - node.ByteOffset
+
+	for i, childPoints := range children {
+		if len(childPoints) == 0 {
+			continue
+		}
+
+		child := &OctreeNode{
+			Name:        node.Name + strconv.Itoa(i),
+			BoundingBox: createChildAABB(node.BoundingBox, i),
+			Spacing:     node.Spacing / 2,
+			Level:       node.Level + 1,
+			Parent:      node,
+		}
+		node.ChildMask |= 1 << i
+		node.Children = append(node.Children, child)
+		ob.build(child, childPoints)
+	}
+}
+
+// hierarchy serializes every node breadth first, as a single chunk
+func hierarchy(root *OctreeNode) []byte {
+	buf := &bytes.Buffer{}
+	queue := []*OctreeNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		binary.Write(buf, binary.LittleEndian, HierarchyNodeEntry{
+			Type:       node.NodeType,
+			ChildMask:  node.ChildMask,
+			NumPoints:  node.NumPoints,
+			ByteOffset: node.ByteOffset,
+			ByteSize:   node.ByteSize,
+		})
+		queue = append(queue, node.Children...)
+	}
+	return buf.Bytes()
+}
+
+// Convert builds an octree out of the point cloud, where every node holds a
+// subsample of the points within it, so viewers can progressively load
+// detail. Position, color, intensity and classification are carried over,
+// with color and intensity expected to be within [0, 1].
+func Convert(cloud modeling.Mesh, options ConversionOptions) (*Dataset, error) {
+	options, err := options.fill()
+	if err != nil {
+		return nil, err
+	}
+
+	if !cloud.HasFloat3Attribute(modeling.PositionAttribute) {
+		return nil, errors.New("point cloud is missing position data")
+	}
+
+	count := cloud.AttributeLength()
+	if count > math.MaxUint32 {
+		return nil, fmt.Errorf("point cloud has too many points: %d", count)
+	}
+
+	// Potree expects the octree's bounds to be a cube. It's padded so
+	// quantizing positions can't push them outside of it.
+	bounds := cloud.BoundingBox(modeling.PositionAttribute)
+	size := bounds.Size().MaxComponent() + options.Scale
+	cube := geometry.NewAABBFromPoints(bounds.Min(), bounds.Min().Add(vector3.Fill(size)))
+	if size/options.Scale > math.MaxInt32 {
+		return nil, fmt.Errorf("point cloud is too large to be stored at a scale of %g", options.Scale)
+	}
+
+	encoder := morton.Encoder3D{Bounds: cube, Resolution: mortonResolution}
+	positions := make([]vector3.Float64, count)
+	for i := range positions {
+		positions[i] = cloud.Float3Attribute(modeling.PositionAttribute).At(i)
+	}
+	codes := encoder.EncodeArray(positions)
+
+	points := make([]int, count)
+	for i := range points {
+		points[i] = i
+	}
+	slices.SortStableFunc(points, func(a, b int) int {
+		if codes[a] < codes[b] {
+			return -1
+		}
+		if codes[a] > codes[b] {
+			return 1
+		}
+		return 0
+	})
+
+	layout := pointLayout{
+		intensity: cloud.HasFloat1Attribute(modeling.IntensityAttribute),
+		class:     cloud.HasFloat1Attribute(modeling.ClassAttribute),
+		color:     cloud.HasFloat3Attribute(modeling.ColorAttribute),
+	}
+
+	metadata := Metadata{
+		Version: "2.0",
+		Name:    options.Name,
+		Points:  int64(count),
+		Offset:  []float64{cube.Min().X(), cube.Min().Y(), cube.Min().Z()},
+		Scale:   []float64{options.Scale, options.Scale, options.Scale},
+		Spacing: size / (1 << samplingGridBits),
+		BoundingBox: MetadataBounds{
+			Min: []float64{cube.Min().X(), cube.Min().Y(), cube.Min().Z()},
+			Max: []float64{cube.Max().X(), cube.Max().Y(), cube.Max().Z()},
+		},
+		Encoding:   "DEFAULT",
+		Attributes: layout.attributes(cloud),
+	}
+
+	builder := &octreeBuilder{
+		cloud:   cloud,
+		layout:  layout,
+		options: options,
+		offset:  cube.Min(),
+		codes:   codes,
+		octree:  &bytes.Buffer{},
+		record:  make([]byte, metadata.BytesPerPoint()),
+	}
+
+	root := &OctreeNode{
+		Name:        "r",
+		BoundingBox: cube,
+		Spacing:     metadata.Spacing,
+	}
+	builder.build(root, points)
+
+	hierarchyData := hierarchy(root)
+	metadata.Hierarchy = MetadataHierarchy{
+		FirstChunkSize: uint64(len(hierarchyData)),
+		StepSize:       4,
+		Depth:          root.Height(),
+	}
+
+	return &Dataset{
+		Metadata:  metadata,
+		Root:      root,
+		Hierarchy: hierarchyData,
+		Octree:    builder.octree.Bytes(),
+	}, nil
+}
+
+func (m Metadata) Write(out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(m)
+}
+
+// Save writes the metadata.json, hierarchy.bin and octree.bin files that
+// make up the dataset to the directory
+func (d Dataset) Save(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	metadata, err := os.Create(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		return err
+	}
+	defer metadata.Close()
+	if err := d.Metadata.Write(metadata); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "hierarchy.bin"), d.Hierarchy, 0666); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "octree.bin"), d.Octree, 0666)
+}
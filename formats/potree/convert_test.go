@@ -0,0 +1,171 @@
+package potree_test
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/potree"
+	"github.com/EliCDavis/polyform/generator/manifest"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomCloud(count int) modeling.Mesh {
+	rng := rand.New(rand.NewSource(1))
+	positions := make([]vector3.Float64, count)
+	colors := make([]vector3.Float64, count)
+	for i := range positions {
+		positions[i] = vector3.New(rng.Float64()*10, rng.Float64()*5-20, rng.Float64())
+		colors[i] = vector3.New(rng.Float64(), rng.Float64(), rng.Float64())
+	}
+	return modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: positions,
+			modeling.ColorAttribute:    colors,
+		},
+		nil,
+		nil,
+	)
+}
+
+func TestConvert_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := randomCloud(5000)
+
+	// ACT ====================================================================
+	dataset, err := potree.Convert(cloud, potree.ConversionOptions{
+		Name:             "test",
+		MaxPointsPerNode: 500,
+	})
+	require.NoError(t, err)
+
+	metadataBuf := &bytes.Buffer{}
+	require.NoError(t, dataset.Metadata.Write(metadataBuf))
+	metadata, err := potree.ReadMetadata(metadataBuf)
+	require.NoError(t, err)
+	root, err := metadata.ReadHierarchy(bytes.NewReader(dataset.Hierarchy))
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	assert.Equal(t, "2.0", metadata.Version)
+	assert.Equal(t, "test", metadata.Name)
+	assert.Equal(t, int64(5000), metadata.Points)
+	assert.Equal(t, uint64(5000), root.PointCount())
+	assert.Positive(t, root.Height())
+	assert.Equal(t, root.Height(), metadata.Hierarchy.Depth)
+	assert.Equal(t, 18, metadata.BytesPerPoint())
+
+	// Positions are quantized relative to the offset
+	offset := metadata.OffsetF()
+	key := func(p vector3.Float64) vector3.Float64 {
+		return p.Sub(offset).Scale(1000).Round()
+	}
+	original := make(map[vector3.Float64]vector3.Float64)
+	for i := range cloud.AttributeLength() {
+		p := cloud.Float3Attribute(modeling.PositionAttribute).At(i)
+		original[key(p)] = cloud.Float3Attribute(modeling.ColorAttribute).At(i)
+	}
+
+	seen := 0
+	root.Walk(func(node *potree.OctreeNode) bool {
+		if len(node.Children) == 0 {
+			assert.LessOrEqual(t, int(node.NumPoints), 500)
+		}
+
+		data := dataset.Octree[node.ByteOffset : node.ByteOffset+node.ByteSize]
+		positions := make([]vector3.Float64, node.NumPoints)
+		colors := make([]vector3.Float64, node.NumPoints)
+		potree.LoadNodePositionDataIntoArray(metadata, data, positions)
+		potree.LoadNodeColorDataIntoArray(metadata, data, colors)
+
+		bounds := node.BoundingBox
+		for i, p := range positions {
+			color, ok := original[key(p)]
+			require.True(t, ok, "unknown point %v", p)
+			assert.InDelta(t, 0, color.Distance(colors[i]), 0.01)
+			assert.True(t, bounds.Contains(p), "point %v outside of node %s", p, node.Name)
+			seen++
+		}
+		return true
+	})
+	assert.Equal(t, 5000, seen)
+}
+
+func TestConvert_SmallCloudIsSingleNode(t *testing.T) {
+	dataset, err := potree.Convert(randomCloud(10), potree.ConversionOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), dataset.Root.NumPoints)
+	assert.Empty(t, dataset.Root.Children)
+	assert.Len(t, dataset.Hierarchy, 22)
+	assert.Len(t, dataset.Octree, 10*18)
+}
+
+func TestConvert_Errors(t *testing.T) {
+	tests := map[string]struct {
+		cloud   modeling.Mesh
+		options potree.ConversionOptions
+		err     string
+	}{
+		"no positions": {
+			cloud: modeling.EmptyPointcloud(),
+			err:   "point cloud is missing position data",
+		},
+		"negative scale": {
+			cloud:   randomCloud(1),
+			options: potree.ConversionOptions{Scale: -1},
+			err:     "invalid scale -1, must be positive",
+		},
+		"too deep": {
+			cloud:   randomCloud(1),
+			options: potree.ConversionOptions{MaxDepth: 21},
+			err:     "invalid max depth 21, must be between 1 and 20",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := potree.Convert(tc.cloud, tc.options)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDataset_Save(t *testing.T) {
+	dataset, err := potree.Convert(randomCloud(100), potree.ConversionOptions{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	require.NoError(t, dataset.Save(dir))
+
+	metadata, err := potree.LoadMetadata(filepath.Join(dir, "metadata.json"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), metadata.Points)
+
+	octree, err := os.ReadFile(filepath.Join(dir, "octree.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, dataset.Octree, octree)
+}
+
+func TestManifestNode(t *testing.T) {
+	node := &nodes.Struct[potree.ManifestNode]{
+		Data: potree.ManifestNode{
+			Cloud: nodes.ConstOutput[modeling.Mesh]{Val: randomCloud(100)},
+		},
+	}
+
+	result := nodes.GetNodeOutputPort[manifest.Manifest](node, "Out").Value()
+
+	assert.Equal(t, "metadata.json", result.Main)
+	assert.Len(t, result.Entries, 3)
+	buf := &bytes.Buffer{}
+	require.NoError(t, result.Entries["octree.bin"].Artifact.Write(buf))
+	assert.Equal(t, 100*18, buf.Len())
+}
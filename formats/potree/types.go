@@ -0,0 +1,80 @@
+package potree
+
+import (
+	"errors"
+	"io"
+
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/generator/manifest"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+	refutil.RegisterType[nodes.Struct[ManifestNode]](factory)
+	generator.RegisterTypes(factory)
+}
+
+type MetadataArtifact struct {
+	Metadata Metadata
+}
+
+func (ma MetadataArtifact) Write(w io.Writer) error {
+	return ma.Metadata.Write(w)
+}
+
+func (MetadataArtifact) Mime() string {
+	return "application/json"
+}
+
+type BinaryArtifact struct {
+	Data []byte
+}
+
+func (ba BinaryArtifact) Write(w io.Writer) error {
+	_, err := w.Write(ba.Data)
+	return err
+}
+
+func (BinaryArtifact) Mime() string {
+	return "application/octet-stream"
+}
+
+type ManifestNode struct {
+	Cloud            nodes.Output[modeling.Mesh] `description:"Point cloud to convert"`
+	Name             nodes.Output[string]
+	MaxPointsPerNode nodes.Output[int]     `description:"Most points a leaf of the octree can hold before it's subdivided. Defaults to 20,000"`
+	Scale            nodes.Output[float64] `description:"Precision positions are stored with. Defaults to 0.001"`
+}
+
+func (mn ManifestNode) Description() string {
+	return "Converts a point cloud into a Potree 2.0 octree for progressive loading in web viewers"
+}
+
+func (mn ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	if mn.Cloud == nil {
+		out.CaptureError(errors.New("a point cloud is required"))
+		return
+	}
+
+	dataset, err := Convert(nodes.GetOutputValue(out, mn.Cloud), ConversionOptions{
+		Name:             nodes.TryGetOutputValue(out, mn.Name, ""),
+		MaxPointsPerNode: nodes.TryGetOutputValue(out, mn.MaxPointsPerNode, 20_000),
+		Scale:            nodes.TryGetOutputValue(out, mn.Scale, 0.001),
+	})
+	if err != nil {
+		out.CaptureError(err)
+		return
+	}
+
+	out.Set(manifest.Manifest{
+		Main: "metadata.json",
+		Entries: map[string]manifest.Entry{
+			"metadata.json": {Artifact: MetadataArtifact{Metadata: dataset.Metadata}},
+			"hierarchy.bin": {Artifact: BinaryArtifact{Data: dataset.Hierarchy}},
+			"octree.bin":    {Artifact: BinaryArtifact{Data: dataset.Octree}},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package pts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func to255(v float64) string {
+	return strconv.Itoa(int(math.Round(math.Max(0, math.Min(1, v)) * 255)))
+}
+
+// WritePointCloud serializes the vertices of the mesh, along with their
+// intensity and color if present. Intensity and color are expected to be
+// within [0, 1], matching what ReadPointCloud produces.
+func WritePointCloud(out io.Writer, m modeling.Mesh) error {
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return errors.New("mesh is missing position data")
+	}
+
+	hasIntensity := m.HasFloat1Attribute(modeling.IntensityAttribute)
+	hasColor := m.HasFloat3Attribute(modeling.ColorAttribute)
+
+	writer := bufio.NewWriter(out)
+	count := m.AttributeLength()
+	fmt.Fprintf(writer, "%d\n", count)
+
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	for i := 0; i < count; i++ {
+		p := positions.At(i)
+		writer.WriteString(formatFloat(p.X()))
+		writer.WriteByte(' ')
+		writer.WriteString(formatFloat(p.Y()))
+		writer.WriteByte(' ')
+		writer.WriteString(formatFloat(p.Z()))
+
+		// Color can only be written alongside an intensity value
+		if hasIntensity || hasColor {
+			intensity := 0.
+			if hasIntensity {
+				intensity = m.Float1Attribute(modeling.IntensityAttribute).At(i)
+			}
+			writer.WriteByte(' ')
+			writer.WriteString(to255(intensity))
+		}
+
+		if hasColor {
+			c := m.Float3Attribute(modeling.ColorAttribute).At(i)
+			writer.WriteByte(' ')
+			writer.WriteString(to255(c.X()))
+			writer.WriteByte(' ')
+			writer.WriteString(to255(c.Y()))
+			writer.WriteByte(' ')
+			writer.WriteString(to255(c.Z()))
+		}
+
+		writer.WriteByte('\n')
+	}
+
+	return writer.Flush()
+}
+
+// Save writes the mesh to the filePath as a PTS file
+func Save(filePath string, m modeling.Mesh) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return WritePointCloud(f, m)
+}
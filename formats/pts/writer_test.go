@@ -0,0 +1,93 @@
+package pts_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/pts"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePointCloud(t *testing.T) {
+	positions := []vector3.Float64{
+		vector3.New(0.5, 0., -1.),
+		vector3.New(1., 2., 3.),
+	}
+
+	tests := map[string]struct {
+		cloud    modeling.Mesh
+		expected string
+	}{
+		"positions": {
+			cloud:    modeling.NewPointCloud(nil, map[string][]vector3.Float64{modeling.PositionAttribute: positions}, nil, nil),
+			expected: "2\n0.5 0 -1\n1 2 3\n",
+		},
+		"intensity": {
+			cloud: modeling.NewPointCloud(
+				nil,
+				map[string][]vector3.Float64{modeling.PositionAttribute: positions},
+				nil,
+				map[string][]float64{modeling.IntensityAttribute: {0, 1}},
+			),
+			expected: "2\n0.5 0 -1 0\n1 2 3 255\n",
+		},
+		"color without intensity": {
+			cloud: modeling.NewPointCloud(
+				nil,
+				map[string][]vector3.Float64{
+					modeling.PositionAttribute: positions,
+					modeling.ColorAttribute:    {vector3.New(1., 0., 0.), vector3.New(0., 0., 1.)},
+				},
+				nil,
+				nil,
+			),
+			expected: "2\n0.5 0 -1 0 255 0 0\n1 2 3 0 0 0 255\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			buf := &bytes.Buffer{}
+
+			// ACT ============================================================
+			err := pts.WritePointCloud(buf, tc.cloud)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestWritePointCloud_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {vector3.New(0.125, 2., 3.)},
+			modeling.ColorAttribute:    {vector3.New(1., 0., 1.)},
+		},
+		nil,
+		map[string][]float64{modeling.IntensityAttribute: {1}},
+	)
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	require.NoError(t, pts.WritePointCloud(buf, cloud))
+	back, err := pts.ReadPointCloud(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, vector3.New(0.125, 2., 3.), back.Float3Attribute(modeling.PositionAttribute).At(0))
+	assert.Equal(t, vector3.New(1., 0., 1.), back.Float3Attribute(modeling.ColorAttribute).At(0))
+	assert.Equal(t, 1., back.Float1Attribute(modeling.IntensityAttribute).At(0))
+}
+
+func TestWritePointCloud_MissingPositions(t *testing.T) {
+	err := pts.WritePointCloud(&bytes.Buffer{}, modeling.EmptyPointcloud())
+	assert.EqualError(t, err, "mesh is missing position data")
+}
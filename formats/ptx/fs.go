@@ -0,0 +1,18 @@
+package ptx
+
+import (
+	"bufio"
+	"os"
+)
+
+// Load opens the PTX file located at the filePath and deserializes every
+// scan within
+func Load(filePath string) ([]Scan, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(bufio.NewReader(f))
+}
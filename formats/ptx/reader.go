@@ -0,0 +1,249 @@
+package ptx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/EliCDavis/polyform/math/mat"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// maxPreallocatedPoints caps the space reserved for each scan up front. A
+// scan's size comes from the column and row counts in its header, which say
+// nothing of how many point lines actually follow.
+const maxPreallocatedPoints = 1 << 20
+
+// Scan is a single grid of points captured from one scanner setup
+type Scan struct {
+	Columns int
+	Rows    int
+
+	// Registered position and orientation of the scanner
+	ScannerPosition vector3.Float64
+	ScannerAxes     [3]vector3.Float64
+
+	// Transform takes points from the scanner's local space into world space
+	Transform mat.Matrix4x4
+
+	// Mesh is the point cloud of the scan, with the transform applied.
+	// Positions the scanner recorded no return for are left out.
+	Mesh modeling.Mesh
+}
+
+type lineReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (lr *lineReader) next() ([]string, error) {
+	for lr.scanner.Scan() {
+		lr.line++
+		fields := strings.Fields(lr.scanner.Text())
+		if len(fields) > 0 {
+			return fields, nil
+		}
+	}
+	if err := lr.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (lr *lineReader) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: "+format, append([]any{lr.line}, args...)...)
+}
+
+func (lr *lineReader) floats(count int) ([]float64, error) {
+	fields, err := lr.next()
+	if err != nil {
+		return nil, lr.errorf("unexpected end of header: %w", err)
+	}
+	if len(fields) != count {
+		return nil, lr.errorf("expected %d values, found %d", count, len(fields))
+	}
+	return parseFloats(lr, fields)
+}
+
+func parseFloats(lr *lineReader, fields []string) ([]float64, error) {
+	values := make([]float64, len(fields))
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, lr.errorf("unable to parse %q: %w", field, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (lr *lineReader) readInt(firstLine []string) (int, error) {
+	if len(firstLine) != 1 {
+		return 0, lr.errorf("expected a single value, found %d", len(firstLine))
+	}
+	v, err := strconv.Atoi(firstLine[0])
+	if err != nil {
+		return 0, lr.errorf("unable to parse %q: %w", firstLine[0], err)
+	}
+	if v < 0 {
+		return 0, lr.errorf("invalid dimension %d", v)
+	}
+	return v, nil
+}
+
+func (lr *lineReader) readScan(columnsLine []string) (*Scan, error) {
+	columns, err := lr.readInt(columnsLine)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsLine, err := lr.next()
+	if err != nil {
+		return nil, lr.errorf("unexpected end of header: %w", err)
+	}
+	rows, err := lr.readInt(rowsLine)
+	if err != nil {
+		return nil, err
+	}
+
+	if columns != 0 && rows > math.MaxInt/columns {
+		return nil, lr.errorf("scan dimensions %dx%d are too large", columns, rows)
+	}
+
+	scan := &Scan{Columns: columns, Rows: rows}
+
+	position, err := lr.floats(3)
+	if err != nil {
+		return nil, err
+	}
+	scan.ScannerPosition = vector3.New(position[0], position[1], position[2])
+
+	for i := range scan.ScannerAxes {
+		axis, err := lr.floats(3)
+		if err != nil {
+			return nil, err
+		}
+		scan.ScannerAxes[i] = vector3.New(axis[0], axis[1], axis[2])
+	}
+
+	// Each line of the matrix is a column, with the translation last
+	var matrix [16]float64
+	for i := 0; i < 4; i++ {
+		column, err := lr.floats(4)
+		if err != nil {
+			return nil, err
+		}
+		copy(matrix[i*4:], column)
+	}
+	scan.Transform = mat.FromColArray(matrix)
+
+	count := columns * rows
+
+	capacity := min(count, maxPreallocatedPoints)
+	positions := make([]vector3.Float64, 0, capacity)
+	intensities := make([]float64, 0, capacity)
+	var colors []vector3.Float64
+
+	for i := 0; i < count; i++ {
+		fields, err := lr.next()
+		if err != nil {
+			return nil, lr.errorf("expected %d points, found %d: %w", count, i, err)
+		}
+
+		if i == 0 && len(fields) == 7 {
+			colors = make([]vector3.Float64, 0, capacity)
+		}
+
+		expected := 4
+		if colors != nil {
+			expected = 7
+		}
+		if len(fields) != expected {
+			return nil, lr.errorf("expected %d values, found %d", expected, len(fields))
+		}
+
+		values, err := parseFloats(lr, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		p := vector3.New(values[0], values[1], values[2])
+
+		// The scanner writes points without a return as zero, so the
+		// grid stays complete
+		if p == (vector3.Float64{}) {
+			continue
+		}
+
+		positions = append(positions, scan.Transform.MulPosition(p))
+		intensities = append(intensities, values[3])
+		if colors != nil {
+			colors = append(colors, vector3.New(values[4], values[5], values[6]).DivByConstant(255))
+		}
+	}
+
+	v3Data := map[string][]vector3.Float64{
+		modeling.PositionAttribute: positions,
+	}
+	if colors != nil {
+		v3Data[modeling.ColorAttribute] = colors
+	}
+
+	scan.Mesh = modeling.NewPointCloud(
+		nil,
+		v3Data,
+		nil,
+		map[string][]float64{
+			modeling.IntensityAttribute: intensities,
+		},
+	)
+	return scan, nil
+}
+
+// Read deserializes every scan contained within the PTX data
+func Read(in io.Reader) ([]Scan, error) {
+	lr := &lineReader{scanner: bufio.NewScanner(in)}
+
+	scans := make([]Scan, 0)
+	for {
+		fields, err := lr.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scan, err := lr.readScan(fields)
+		if err != nil {
+			return nil, fmt.Errorf("scan %d: %w", len(scans), err)
+		}
+		scans = append(scans, *scan)
+	}
+
+	if len(scans) == 0 {
+		return nil, errors.New("no scans found")
+	}
+
+	return scans, nil
+}
+
+// ReadPointCloud deserializes the PTX data, combining every scan into a
+// single point cloud
+func ReadPointCloud(in io.Reader) (*modeling.Mesh, error) {
+	scans, err := Read(in)
+	if err != nil {
+		return nil, err
+	}
+
+	cloud := scans[0].Mesh
+	for _, scan := range scans[1:] {
+		cloud = cloud.Append(scan.Mesh)
+	}
+	return &cloud, nil
+}
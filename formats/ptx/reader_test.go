@@ -0,0 +1,139 @@
+package ptx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/ptx"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Two scans, the first with color and an empty position, the second
+// translated by (10, 0, 0)
+const twoScans = `2
+1
+0 0 0
+1 0 0
+0 1 0
+0 0 1
+1 0 0 0
+0 1 0 0
+0 0 1 0
+0 0 0 1
+1 2 3 0.5 255 0 0
+0 0 0 0.5 0 0 0
+1
+2
+10 0 0
+1 0 0
+0 1 0
+0 0 1
+1 0 0 0
+0 1 0 0
+0 0 1 0
+10 0 0 1
+1 0 0 0.25
+0 1 0 0.75
+`
+
+func TestRead(t *testing.T) {
+	// ACT ====================================================================
+	scans, err := ptx.Read(strings.NewReader(twoScans))
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scans, 2)
+
+	first := scans[0]
+	assert.Equal(t, 2, first.Columns)
+	assert.Equal(t, 1, first.Rows)
+	require.Equal(t, 1, first.Mesh.AttributeLength())
+	assert.Equal(t, vector3.New(1., 2., 3.), first.Mesh.Float3Attribute(modeling.PositionAttribute).At(0))
+	assert.Equal(t, vector3.New(1., 0., 0.), first.Mesh.Float3Attribute(modeling.ColorAttribute).At(0))
+	assert.Equal(t, 0.5, first.Mesh.Float1Attribute(modeling.IntensityAttribute).At(0))
+
+	second := scans[1]
+	assert.Equal(t, vector3.New(10., 0., 0.), second.ScannerPosition)
+	assert.Equal(t, vector3.New(0., 0., 1.), second.ScannerAxes[2])
+	assert.False(t, second.Mesh.HasFloat3Attribute(modeling.ColorAttribute))
+	positions := second.Mesh.Float3Attribute(modeling.PositionAttribute)
+	require.Equal(t, 2, positions.Len())
+	assert.Equal(t, vector3.New(11., 0., 0.), positions.At(0))
+	assert.Equal(t, vector3.New(10., 1., 0.), positions.At(1))
+}
+
+func TestReadPointCloud(t *testing.T) {
+	cloud, err := ptx.ReadPointCloud(strings.NewReader(twoScans))
+
+	require.NoError(t, err)
+	assert.Equal(t, modeling.PointTopology, cloud.Topology())
+	assert.Equal(t, 3, cloud.AttributeLength())
+	assert.Equal(t, []float64{0.5, 0.25, 0.75}, []float64{
+		cloud.Float1Attribute(modeling.IntensityAttribute).At(0),
+		cloud.Float1Attribute(modeling.IntensityAttribute).At(1),
+		cloud.Float1Attribute(modeling.IntensityAttribute).At(2),
+	})
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		err   string
+	}{
+		"empty": {
+			input: "",
+			err:   "no scans found",
+		},
+		"bad dimension": {
+			input: "a\n",
+			err:   `scan 0: line 1: unable to parse "a": strconv.Atoi: parsing "a": invalid syntax`,
+		},
+		"overflowing dimensions": {
+			input: "4000000000\n4000000000\n",
+			err:   "scan 0: line 2: scan dimensions 4000000000x4000000000 are too large",
+		},
+		"more points than the file holds": {
+			input: "2000000000\n2000000000\n0 0 0\n1 0 0\n0 1 0\n0 0 1\n1 0 0 0\n0 1 0 0\n0 0 1 0\n0 0 0 1\n",
+			err:   "scan 0: line 10: expected 4000000000000000000 points, found 0: EOF",
+		},
+		"short header": {
+			input: "1\n1\n0 0 0\n1 0\n",
+			err:   "scan 0: line 4: expected 3 values, found 2",
+		},
+		"missing points": {
+			input: strings.Join(strings.Split(twoScans, "\n")[:11], "\n"),
+			err:   "scan 0: line 11: expected 2 points, found 1: EOF",
+		},
+		"inconsistent color": {
+			input: strings.Replace(twoScans, "0 0 0 0.5 0 0 0", "0 0 0 0.5", 1),
+			err:   "scan 0: line 12: expected 7 values, found 4",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ptx.Read(strings.NewReader(tc.input))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestReadNode(t *testing.T) {
+	node := &nodes.Struct[ptx.ReadNode]{
+		Data: ptx.ReadNode{
+			Data: nodes.ConstOutput[[]byte]{Val: []byte(twoScans)},
+		},
+	}
+
+	combined := nodes.GetNodeOutputPort[modeling.Mesh](node, "Out").Value()
+	scans := nodes.GetNodeOutputPort[[]modeling.Mesh](node, "Scans").Value()
+
+	assert.Equal(t, 3, combined.AttributeLength())
+	require.Len(t, scans, 2)
+	assert.Equal(t, 1, scans[0].AttributeLength())
+	assert.Equal(t, 2, scans[1].AttributeLength())
+}
@@ -0,0 +1,61 @@
+package ptx
+
+import (
+	"bytes"
+
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+	refutil.RegisterType[nodes.Struct[ReadNode]](factory)
+	generator.RegisterTypes(factory)
+}
+
+type ReadNode struct {
+	Data nodes.Output[[]byte]
+}
+
+func (rn ReadNode) scans(out nodes.ExecutionRecorder) []Scan {
+	if rn.Data == nil {
+		return nil
+	}
+
+	data := nodes.GetOutputValue(out, rn.Data)
+	if len(data) == 0 {
+		return nil
+	}
+
+	scans, err := Read(bytes.NewReader(data))
+	if err != nil {
+		out.CaptureError(err)
+		return nil
+	}
+	return scans
+}
+
+func (rn ReadNode) CacheVersion() int {
+	return 1
+}
+
+// Out is every scan combined into a single point cloud
+func (rn ReadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	cloud := modeling.EmptyPointcloud()
+	for _, scan := range rn.scans(out) {
+		cloud = cloud.Append(scan.Mesh)
+	}
+	out.Set(cloud)
+}
+
+// Scans is the point cloud of each individual scan
+func (rn ReadNode) Scans(out *nodes.StructOutput[[]modeling.Mesh]) {
+	scans := rn.scans(out)
+	meshes := make([]modeling.Mesh, len(scans))
+	for i, scan := range scans {
+		meshes[i] = scan.Mesh
+	}
+	out.Set(meshes)
+}
@@ -19,6 +19,10 @@ type ReadNode struct {
 	Data nodes.Output[[]byte]
 }
 
+func (gad ReadNode) CacheVersion() int {
+	return 1
+}
+
 func (gad ReadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	out.Set(modeling.EmptyMesh(modeling.PointTopology))
 	if gad.Data == nil {
@@ -0,0 +1,225 @@
+package stl
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Solid is a single named collection of triangles within an ASCII STL file
+type Solid struct {
+	Name      string
+	Triangles []Triangle
+}
+
+// ASCII is the human readable variant of the STL format, which unlike the
+// binary variant can contain multiple solids
+type ASCII struct {
+	Solids []Solid
+}
+
+// isASCII determines whether the data is an ASCII STL file. Plenty of binary
+// files begin their header with "solid" too, so data that's exactly the size
+// a binary file with the triangle count in its header would be is treated as
+// binary regardless.
+func isASCII(data []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("solid")) {
+		return false
+	}
+
+	if len(data) >= 84 {
+		count := uint64(data[80]) | uint64(data[81])<<8 | uint64(data[82])<<16 | uint64(data[83])<<24
+		if uint64(len(data)) == 84+count*50 {
+			return false
+		}
+	}
+	return true
+}
+
+func ReadASCII(in io.Reader) (*ASCII, error) {
+	scanner := bufio.NewScanner(in)
+	lineNumber := 0
+
+	// next returns the fields of the next non-empty line, along with the line
+	// itself for recovering solid names with spaces in them
+	next := func() ([]string, string, error) {
+		for scanner.Scan() {
+			lineNumber++
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			return strings.Fields(line), line, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, "", err
+		}
+		return nil, "", io.EOF
+	}
+
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("line %d: "+format, append([]any{lineNumber}, args...)...)
+	}
+
+	keyword := func(fields []string, expected ...string) error {
+		if len(fields) < len(expected) {
+			return fail("expected %q", strings.Join(expected, " "))
+		}
+		for i, e := range expected {
+			if !strings.EqualFold(fields[i], e) {
+				return fail("expected %q, found %q", strings.Join(expected, " "), strings.Join(fields, " "))
+			}
+		}
+		return nil
+	}
+
+	parseVec := func(fields []string) (Vec, error) {
+		if len(fields) != 3 {
+			return Vec{}, fail("expected 3 components, found %d", len(fields))
+		}
+		var components [3]float32
+		for i, field := range fields {
+			v, err := strconv.ParseFloat(field, 32)
+			if err != nil {
+				return Vec{}, fail("unable to parse %q: %w", field, err)
+			}
+			components[i] = float32(v)
+		}
+		return Vec{X: components[0], Y: components[1], Z: components[2]}, nil
+	}
+
+	result := &ASCII{}
+	for {
+		fields, line, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := keyword(fields, "solid"); err != nil {
+			return nil, err
+		}
+		solid := Solid{
+			Name:      strings.TrimSpace(line[len("solid"):]),
+			Triangles: make([]Triangle, 0),
+		}
+
+		for {
+			fields, _, err = next()
+			if errors.Is(err, io.EOF) {
+				return nil, fail("solid %q missing endsolid", solid.Name)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if strings.EqualFold(fields[0], "endsolid") {
+				break
+			}
+
+			if err := keyword(fields, "facet", "normal"); err != nil {
+				return nil, err
+			}
+			normal, err := parseVec(fields[2:])
+			if err != nil {
+				return nil, err
+			}
+
+			if fields, _, err = next(); err != nil {
+				return nil, fail("unexpected end of facet: %w", err)
+			}
+			if err := keyword(fields, "outer", "loop"); err != nil {
+				return nil, err
+			}
+
+			// Only triangles are valid, but some exporters write larger
+			// polygons, which are fanned into triangles
+			vertices := make([]Vec, 0, 3)
+			for {
+				if fields, _, err = next(); err != nil {
+					return nil, fail("unexpected end of loop: %w", err)
+				}
+				if strings.EqualFold(fields[0], "endloop") {
+					break
+				}
+				if err := keyword(fields, "vertex"); err != nil {
+					return nil, err
+				}
+				v, err := parseVec(fields[1:])
+				if err != nil {
+					return nil, err
+				}
+				vertices = append(vertices, v)
+			}
+
+			if fields, _, err = next(); err != nil {
+				return nil, fail("unexpected end of facet: %w", err)
+			}
+			if err := keyword(fields, "endfacet"); err != nil {
+				return nil, err
+			}
+
+			if len(vertices) < 3 {
+				return nil, fail("facet has %d vertices, expected at least 3", len(vertices))
+			}
+			for i := 2; i < len(vertices); i++ {
+				solid.Triangles = append(solid.Triangles, Triangle{
+					Normal:  normal,
+					Vertex1: vertices[0],
+					Vertex2: vertices[i-1],
+					Vertex3: vertices[i],
+				})
+			}
+		}
+
+		result.Solids = append(result.Solids, solid)
+	}
+
+	if len(result.Solids) == 0 {
+		return nil, errors.New("no solids found")
+	}
+
+	return result, nil
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
+
+func writeVec(out *bufio.Writer, prefix string, v Vec) {
+	out.WriteString(prefix)
+	out.WriteString(formatFloat(v.X))
+	out.WriteByte(' ')
+	out.WriteString(formatFloat(v.Y))
+	out.WriteByte(' ')
+	out.WriteString(formatFloat(v.Z))
+	out.WriteByte('\n')
+}
+
+func WriteASCII(out io.Writer, ascii ASCII) error {
+	writer := bufio.NewWriter(out)
+	for _, solid := range ascii.Solids {
+		if strings.ContainsAny(solid.Name, "\r\n") {
+			return fmt.Errorf("solid name %q can not contain line breaks", solid.Name)
+		}
+
+		fmt.Fprintf(writer, "solid %s\n", solid.Name)
+		for _, tri := range solid.Triangles {
+			writeVec(writer, "  facet normal ", tri.Normal)
+			writer.WriteString("    outer loop\n")
+			writeVec(writer, "      vertex ", tri.Vertex1)
+			writeVec(writer, "      vertex ", tri.Vertex2)
+			writeVec(writer, "      vertex ", tri.Vertex3)
+			writer.WriteString("    endloop\n")
+			writer.WriteString("  endfacet\n")
+		}
+		fmt.Fprintf(writer, "endsolid %s\n", solid.Name)
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,211 @@
+package stl_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/stl"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const twoSolids = `solid first part
+  facet normal 0 0 1
+    outer loop
+      vertex 0 0 0
+      vertex 1 0 0
+      vertex 1 1 0
+    endloop
+  endfacet
+endsolid first part
+SOLID second
+  facet normal 0 0 0
+    outer loop
+      vertex 0 0 1
+      vertex 1 0 1
+      vertex 1 1 1
+      vertex 0 1 1
+    endloop
+  endfacet
+endsolid second
+`
+
+func TestReadASCII(t *testing.T) {
+	// ACT ====================================================================
+	ascii, err := stl.ReadASCII(strings.NewReader(twoSolids))
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, ascii.Solids, 2)
+	assert.Equal(t, "first part", ascii.Solids[0].Name)
+	assert.Equal(t, "second", ascii.Solids[1].Name)
+	assert.Equal(t, []stl.Triangle{{
+		Normal:  stl.Vec{Z: 1},
+		Vertex1: stl.Vec{},
+		Vertex2: stl.Vec{X: 1},
+		Vertex3: stl.Vec{X: 1, Y: 1},
+	}}, ascii.Solids[0].Triangles)
+
+	// Quads are fanned into triangles
+	assert.Len(t, ascii.Solids[1].Triangles, 2)
+}
+
+func TestReadASCII_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		err   string
+	}{
+		"empty": {
+			input: "",
+			err:   "no solids found",
+		},
+		"missing endsolid": {
+			input: "solid a\n",
+			err:   `line 1: solid "a" missing endsolid`,
+		},
+		"bad keyword": {
+			input: "solid a\nfacet norml 0 0 0\n",
+			err:   `line 2: expected "facet normal", found "facet norml 0 0 0"`,
+		},
+		"bad number": {
+			input: "solid a\nfacet normal 0 0 x\n",
+			err:   `line 2: unable to parse "x": strconv.ParseFloat: parsing "x": invalid syntax`,
+		},
+		"too few vertices": {
+			input: "solid a\nfacet normal 0 0 0\nouter loop\nvertex 0 0 0\nendloop\nendfacet\nendsolid a\n",
+			err:   "line 6: facet has 1 vertices, expected at least 3",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := stl.ReadASCII(strings.NewReader(tc.input))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestReadMeshes_MultipleSolids(t *testing.T) {
+	// ACT ====================================================================
+	meshes, err := stl.ReadMeshes(strings.NewReader(twoSolids))
+	combined, combinedErr := stl.ReadMesh(strings.NewReader(twoSolids))
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, meshes, 2)
+	assert.Equal(t, 1, meshes[0].PrimitiveCount())
+	assert.Equal(t, 2, meshes[1].PrimitiveCount())
+	assert.True(t, meshes[0].HasFloat3Attribute(modeling.NormalAttribute))
+	assert.False(t, meshes[1].HasFloat3Attribute(modeling.NormalAttribute))
+
+	require.NoError(t, combinedErr)
+	assert.Equal(t, 3, combined.PrimitiveCount())
+}
+
+func TestRead_BinaryHeaderStartingWithSolid(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := &bytes.Buffer{}
+	require.NoError(t, stl.WriteMeshWithOptions(buf, primitives.UnitCube(), stl.WriterOptions{
+		Name: "solid exported by some tool",
+	}))
+
+	// ACT ====================================================================
+	bin, err := stl.Read(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Len(t, bin.Triangles, 12)
+	assert.True(t, strings.HasPrefix(string(bin.Header[:]), "solid exported by some tool"))
+}
+
+func TestWriteMeshWithOptions_ASCIIRoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := primitives.UnitCube()
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := stl.WriteMeshWithOptions(buf, cube, stl.WriterOptions{
+		Format: stl.FormatASCII,
+		Name:   "cube",
+	})
+	written := buf.String()
+	back, readErr := stl.ReadMeshes(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(written, "solid cube\n"))
+	assert.True(t, strings.HasSuffix(written, "endsolid cube\n"))
+
+	require.NoError(t, readErr)
+	require.Len(t, back, 1)
+	require.Equal(t, cube.PrimitiveCount(), back[0].PrimitiveCount())
+	for i := range cube.PrimitiveCount() {
+		expected := cube.Tri(i)
+		actual := back[0].Tri(i)
+		assert.Equal(t, expected.P1Vec3Attr(modeling.PositionAttribute), actual.P1Vec3Attr(modeling.PositionAttribute))
+		assert.Equal(t, expected.P2Vec3Attr(modeling.PositionAttribute), actual.P2Vec3Attr(modeling.PositionAttribute))
+		assert.Equal(t, expected.P3Vec3Attr(modeling.PositionAttribute), actual.P3Vec3Attr(modeling.PositionAttribute))
+	}
+}
+
+func TestWriteMeshWithOptions_UnknownFormat(t *testing.T) {
+	err := stl.WriteMeshWithOptions(&bytes.Buffer{}, primitives.UnitCube(), stl.WriterOptions{Format: "obj"})
+	assert.EqualError(t, err, `unrecognized stl format "obj"`)
+}
+
+func TestLoad_DetectsFormat(t *testing.T) {
+	cube := primitives.UnitCube()
+	for _, format := range []stl.Format{stl.FormatBinary, stl.FormatASCII} {
+		t.Run(format.String(), func(t *testing.T) {
+			// ARRANGE ========================================================
+			fp := t.TempDir() + "/cube.stl"
+			require.NoError(t, stl.SaveWithOptions(fp, cube, stl.WriterOptions{Format: format}))
+
+			// ACT ============================================================
+			back, err := stl.Load(fp)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			assert.Equal(t, cube.PrimitiveCount(), back.PrimitiveCount())
+		})
+	}
+}
+
+func TestWriteASCII_NameWithLineBreak(t *testing.T) {
+	err := stl.WriteASCII(&bytes.Buffer{}, stl.ASCII{Solids: []stl.Solid{{Name: "a\nb"}}})
+	assert.EqualError(t, err, `solid name "a\nb" can not contain line breaks`)
+}
+
+// Binary files can legitimately have a triangle count of zero and a header
+// beginning with "solid", which is still binary
+func TestRead_EmptyBinaryWithSolidHeader(t *testing.T) {
+	data := make([]byte, 84)
+	copy(data, "solid")
+	binary.LittleEndian.PutUint32(data[80:], 0)
+
+	bin, err := stl.Read(bytes.NewReader(data))
+
+	require.NoError(t, err)
+	assert.Empty(t, bin.Triangles)
+}
+
+func TestReadNode(t *testing.T) {
+	node := &nodes.Struct[stl.ReadNode]{
+		Data: stl.ReadNode{
+			Data: nodes.ConstOutput[[]byte]{Val: []byte(twoSolids)},
+		},
+	}
+
+	combined := nodes.GetNodeOutputPort[modeling.Mesh](node, "Out").Value()
+	meshes := nodes.GetNodeOutputPort[[]modeling.Mesh](node, "Meshes").Value()
+
+	assert.Equal(t, 3, combined.PrimitiveCount())
+	require.Len(t, meshes, 2)
+	assert.Equal(t, 1, meshes[0].PrimitiveCount())
+	assert.Equal(t, 2, meshes[1].PrimitiveCount())
+}
@@ -7,7 +7,12 @@ import (
 	"github.com/EliCDavis/polyform/modeling"
 )
 
+// Save writes the mesh to the path as a binary STL file
 func Save(fp string, m modeling.Mesh) error {
+	return SaveWithOptions(fp, m, WriterOptions{})
+}
+
+func SaveWithOptions(fp string, m modeling.Mesh, options WriterOptions) error {
 	f, err := os.Create(fp)
 	if err != nil {
 		return err
@@ -15,13 +20,15 @@ func Save(fp string, m modeling.Mesh) error {
 	defer f.Close()
 
 	writer := bufio.NewWriter(f)
-	if err := WriteMesh(writer, m); err != nil {
+	if err := WriteMeshWithOptions(writer, m, options); err != nil {
 		return err
 	}
 
 	return writer.Flush()
 }
 
+// Load reads either an ASCII or binary STL file, combining every solid within
+// into a single mesh
 func Load(fp string) (*modeling.Mesh, error) {
 	f, err := os.Open(fp)
 	if err != nil {
@@ -31,3 +38,15 @@ func Load(fp string) (*modeling.Mesh, error) {
 
 	return ReadMesh(bufio.NewReader(f))
 }
+
+// LoadMeshes reads either an ASCII or binary STL file, returning a mesh per
+// solid within
+func LoadMeshes(fp string) ([]modeling.Mesh, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadMeshes(bufio.NewReader(f))
+}
@@ -1,6 +1,7 @@
 package stl
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -9,7 +10,7 @@ import (
 	"github.com/EliCDavis/vector/vector3"
 )
 
-func Read(in io.Reader) (*Binary, error) {
+func readBinary(in io.Reader) (*Binary, error) {
 
 	header := new(Header)
 	if err := binary.Read(in, binary.LittleEndian, header); err != nil {
@@ -32,23 +33,88 @@ func Read(in io.Reader) (*Binary, error) {
 	}, nil
 }
 
+// readSolids reads either variant of the format, returning each solid found
+// within. Binary files always contain a single solid, named after their
+// header.
+func readSolids(in io.Reader) ([]Solid, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if isASCII(data) {
+		ascii, err := ReadASCII(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return ascii.Solids, nil
+	}
+
+	bin, err := readBinary(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return []Solid{{
+		Name:      string(bytes.TrimRight(bin.Header[:], "\x00 ")),
+		Triangles: bin.Triangles,
+	}}, nil
+}
+
+// Read interprets the contents of either an ASCII or binary STL file. All
+// solids of an ASCII file are combined, with the first solid's name used as
+// the header.
+func Read(in io.Reader) (*Binary, error) {
+	solids, err := readSolids(in)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Binary{}
+	copy(result.Header[:], solids[0].Name)
+	for _, solid := range solids {
+		result.Triangles = append(result.Triangles, solid.Triangles...)
+	}
+	return result, nil
+}
+
+// ReadMesh reads either an ASCII or binary STL file, combining every solid
+// within into a single mesh.
 func ReadMesh(in io.Reader) (*modeling.Mesh, error) {
 	bin, err := Read(in)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(bin.Triangles) == 0 {
-		empty := modeling.EmptyMesh(modeling.TriangleTopology)
-		return &empty, nil
+	mesh := trianglesToMesh(bin.Triangles)
+	return &mesh, nil
+}
+
+// ReadMeshes reads either an ASCII or binary STL file, building a separate
+// mesh for each solid within.
+func ReadMeshes(in io.Reader) ([]modeling.Mesh, error) {
+	solids, err := readSolids(in)
+	if err != nil {
+		return nil, err
+	}
+
+	meshes := make([]modeling.Mesh, len(solids))
+	for i, solid := range solids {
+		meshes[i] = trianglesToMesh(solid.Triangles)
+	}
+	return meshes, nil
+}
+
+func trianglesToMesh(triangles []Triangle) modeling.Mesh {
+	if len(triangles) == 0 {
+		return modeling.EmptyMesh(modeling.TriangleTopology)
 	}
 
-	indices := make([]int, len(bin.Triangles)*3)
-	position := make([]vector3.Float64, len(bin.Triangles)*3)
-	normals := make([]vector3.Float64, len(bin.Triangles)*3)
+	indices := make([]int, len(triangles)*3)
+	position := make([]vector3.Float64, len(triangles)*3)
+	normals := make([]vector3.Float64, len(triangles)*3)
 	normalExists := false
 
-	for i, tri := range bin.Triangles {
+	for i, tri := range triangles {
 		start := i * 3
 		indices[start] = start
 		indices[start+1] = start + 1
@@ -81,5 +147,5 @@ func ReadMesh(in io.Reader) (*modeling.Mesh, error) {
 		mesh = mesh.SetFloat3Attribute(modeling.NormalAttribute, normals)
 	}
 
-	return &mesh, nil
+	return mesh
 }
@@ -22,36 +22,60 @@ type ReadNode struct {
 	Data nodes.Output[[]byte]
 }
 
-func (gad ReadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
-	if gad.Data == nil {
-		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
-		return
+func (rn ReadNode) data(out nodes.ExecutionRecorder) []byte {
+	if rn.Data == nil {
+		return nil
 	}
+	return nodes.GetOutputValue(out, rn.Data)
+}
+
+func (rn ReadNode) CacheVersion() int {
+	return 1
+}
 
-	data := nodes.GetOutputValue(out, gad.Data)
+// Out is every solid combined into a single mesh
+func (rn ReadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	data := rn.data(out)
 	if len(data) == 0 {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
 		return
 	}
 
-	cloud, err := ReadMesh(bytes.NewReader(data))
+	mesh, err := ReadMesh(bytes.NewReader(data))
 	if err != nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
 		out.CaptureError(err)
 		return
 	}
 
-	out.Set(*cloud)
+	out.Set(*mesh)
+}
+
+// Meshes is the mesh of each individual solid
+func (rn ReadNode) Meshes(out *nodes.StructOutput[[]modeling.Mesh]) {
+	data := rn.data(out)
+	if len(data) == 0 {
+		return
+	}
+
+	meshes, err := ReadMeshes(bytes.NewReader(data))
+	if err != nil {
+		out.CaptureError(err)
+		return
+	}
+
+	out.Set(meshes)
 }
 
 // ============================================================================
 
 type Artifact struct {
-	Mesh modeling.Mesh
+	Mesh    modeling.Mesh
+	Options WriterOptions
 }
 
 func (sa Artifact) Write(w io.Writer) error {
-	return WriteMesh(w, sa.Mesh)
+	return WriteMeshWithOptions(w, sa.Mesh, sa.Options)
 }
 
 func (Artifact) Mime() string {
@@ -59,13 +83,20 @@ func (Artifact) Mime() string {
 }
 
 type ManifestNode struct {
-	Mesh nodes.Output[modeling.Mesh]
+	Mesh  nodes.Output[modeling.Mesh]
+	ASCII nodes.Output[bool] `description:"Write the human readable variant of the format instead of binary"`
 }
 
 func (pn ManifestNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	format := FormatBinary
+	if nodes.TryGetOutputValue(out, pn.ASCII, false) {
+		format = FormatASCII
+	}
+
 	entry := manifest.Entry{
 		Artifact: Artifact{
-			Mesh: nodes.TryGetOutputValue(out, pn.Mesh, modeling.EmptyMesh(modeling.TriangleTopology)),
+			Mesh:    nodes.TryGetOutputValue(out, pn.Mesh, modeling.EmptyMesh(modeling.TriangleTopology)),
+			Options: WriterOptions{Format: format},
 		},
 	}
 	out.Set(manifest.SingleEntryManifest("model.stl", entry))
@@ -8,6 +8,26 @@ import (
 	"github.com/EliCDavis/polyform/modeling"
 )
 
+type Format string
+
+const (
+	FormatBinary Format = "binary"
+	FormatASCII  Format = "ascii"
+)
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// WriterOptions controls how meshes are serialized. The zero value writes
+// binary files.
+type WriterOptions struct {
+	Format Format
+
+	// Name of the solid when writing ASCII files
+	Name string
+}
+
 func Write(out io.Writer, bin Binary) error {
 	if _, err := out.Write(bin.Header[:]); err != nil {
 		return fmt.Errorf("unable to write header %w", err)
@@ -24,17 +44,38 @@ func Write(out io.Writer, bin Binary) error {
 	return nil
 }
 
+// WriteMesh writes the mesh as a binary STL file
 func WriteMesh(out io.Writer, m modeling.Mesh) error {
+	return WriteMeshWithOptions(out, m, WriterOptions{})
+}
+
+func WriteMeshWithOptions(out io.Writer, m modeling.Mesh, options WriterOptions) error {
 	if m.Topology() != modeling.TriangleTopology {
 		panic(fmt.Errorf("stl format does not supoprt %s topology", m.Topology()))
 	}
 
-	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
-		return Write(out, Binary{
-			Triangles: make([]Triangle, 0),
+	tris := meshTriangles(m)
+
+	switch options.Format {
+	case FormatBinary, "":
+		bin := Binary{Triangles: tris}
+		copy(bin.Header[:], options.Name)
+		return Write(out, bin)
+
+	case FormatASCII:
+		return WriteASCII(out, ASCII{
+			Solids: []Solid{{Name: options.Name, Triangles: tris}},
 		})
 	}
 
+	return fmt.Errorf("unrecognized stl format %q", options.Format)
+}
+
+func meshTriangles(m modeling.Mesh) []Triangle {
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return make([]Triangle, 0)
+	}
+
 	count := m.PrimitiveCount()
 	tris := make([]Triangle, count)
 	for i := 0; i < count; i++ {
@@ -84,7 +125,5 @@ func WriteMesh(out io.Writer, m modeling.Mesh) error {
 		}
 	}
 
-	return Write(out, Binary{
-		Triangles: tris,
-	})
+	return tris
 }
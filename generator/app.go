@@ -2,6 +2,7 @@ package generator
 
 import (
 	"archive/zip"
+	"context"
 	_ "embed"
 	"encoding/json"
 	"fmt"
@@ -11,7 +12,9 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/EliCDavis/polyform/generator/batch"
 	"github.com/EliCDavis/polyform/generator/cli"
+	"github.com/EliCDavis/polyform/generator/diskcache"
 	"github.com/EliCDavis/polyform/generator/edit"
 	"github.com/EliCDavis/polyform/generator/graph"
 	"github.com/EliCDavis/polyform/generator/manifest"
@@ -20,6 +23,7 @@ import (
 	"github.com/EliCDavis/polyform/generator/persistence"
 	"github.com/EliCDavis/polyform/generator/serialize"
 	"github.com/EliCDavis/polyform/generator/variable"
+	"github.com/EliCDavis/polyform/nodes"
 )
 
 type App struct {
@@ -141,6 +145,29 @@ func (a *App) Run(args []string) error {
 		},
 	}
 
+	diskCacheFlagName := "disk-cache"
+	diskCacheSizeFlagName := "disk-cache-size"
+	diskCacheFlag := &cli.StringFlag{
+		Name:        diskCacheFlagName,
+		Description: "directory to persist evaluated node outputs in, letting later runs skip re-evaluating anything unchanged",
+	}
+	diskCacheSizeFlag := &cli.Int64Flag{
+		Name:        diskCacheSizeFlagName,
+		Value:       1024,
+		Description: "megabytes the disk cache can grow to before the least recently used outputs are evicted. Zero or less disables eviction",
+	}
+	openDiskCache := func(appState *cli.RunState) (nodes.PersistentCache, error) {
+		dir := appState.String(diskCacheFlagName)
+		if dir == "" {
+			return nil, nil
+		}
+		cache, err := diskcache.New(dir, appState.Int64(diskCacheSizeFlagName)*1024*1024)
+		if err != nil {
+			return nil, err
+		}
+		return cache, nil
+	}
+
 	var commands []*cli.Command
 	commands = []*cli.Command{
 		{
@@ -212,11 +239,73 @@ func (a *App) Run(args []string) error {
 					Value:       ".",
 					Description: "folder to save generated contents to",
 				},
+				diskCacheFlag,
+				diskCacheSizeFlag,
 				requiredGraphFlag,
 				profileFlag,
 			},
 			Run: func(appState *cli.RunState) error {
-				return graph.WriteToFolder(a.Graph, appState.String("folder"))
+				cache, err := openDiskCache(appState)
+				if err != nil {
+					return err
+				}
+				ctx := nodes.WithPersistentCache(context.Background(), cache)
+				return graph.WriteToFolderWithContext(ctx, a.Graph, appState.String("folder"))
+			},
+		},
+		{
+			Name:        "Batch",
+			Description: "Runs all producers for every variant of a parameter sweep, saving each to its own folder or zip along with an index",
+			Aliases:     []string{"batch"},
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "sweep",
+					Required:    true,
+					Description: "JSON file defining the sweep, as either a list of profiles, a product of variable values, or a number of random samples within variable ranges",
+				},
+				&cli.StringFlag{
+					Name:        "folder",
+					Value:       ".",
+					Description: "folder to save variants and their index to",
+				},
+				&cli.StringFlag{
+					Name:        "format",
+					Value:       string(batch.FolderFormat),
+					Description: "how to write each variant, either 'folder' or 'zip'",
+				},
+				&cli.DurationFlag{
+					Name:        "timeout",
+					Description: "How long generating a single variant can take before the batch is cancelled. Zero allows generation to run indefinitely",
+				},
+				&cli.IntFlag{
+					Name:        "workers",
+					Value:       1,
+					Description: "Number of goroutines evaluation of independent graph branches can spread across",
+				},
+				diskCacheFlag,
+				diskCacheSizeFlag,
+				requiredGraphFlag,
+				profileFlag,
+			},
+			Run: func(appState *cli.RunState) error {
+				sweep, err := batch.LoadSweep(appState.String("sweep"))
+				if err != nil {
+					return err
+				}
+
+				cache, err := openDiskCache(appState)
+				if err != nil {
+					return err
+				}
+
+				ctx := nodes.WithPersistentCache(context.Background(), cache)
+				_, err = batch.Run(ctx, a.Graph, sweep, batch.Options{
+					Folder:  appState.String("folder"),
+					Format:  batch.Format(strings.ToLower(strings.TrimSpace(appState.String("format")))),
+					Timeout: appState.Duration("timeout"),
+					Workers: appState.Int("workers"),
+				})
+				return err
 			},
 		},
 		{
@@ -257,6 +346,15 @@ func (a *App) Run(args []string) error {
 					Value:       "key.pem",
 					Description: "Path to key file",
 				},
+				&cli.DurationFlag{
+					Name:        "timeout",
+					Description: "How long evaluating a manifest can take before it's cancelled. Zero allows evaluation to run indefinitely",
+				},
+				&cli.IntFlag{
+					Name:        "workers",
+					Value:       1,
+					Description: "Number of goroutines evaluation of independent graph branches can spread across",
+				},
 				&cli.DurationFlag{
 					Name:        "ping-period",
 					Value:       time.Second * 54,
@@ -277,9 +375,16 @@ func (a *App) Run(args []string) error {
 					Value:       1024 * 2,
 					Description: "Maximum message size allowed from peer over websocketed connection",
 				},
+				diskCacheFlag,
+				diskCacheSizeFlag,
 				optionalGraphFlag,
 			},
 			Run: func(appState *cli.RunState) error {
+				cache, err := openDiskCache(appState)
+				if err != nil {
+					return err
+				}
+
 				server := edit.Server{
 					Graph:                   a.Graph,
 					Host:                    appState.String("host"),
@@ -301,6 +406,10 @@ func (a *App) Run(args []string) error {
 						PongWait:       appState.Duration("pong-wait"),
 						WriteWait:      appState.Duration("write-wait"),
 					},
+
+					Timeout:         appState.Duration("timeout"),
+					Workers:         appState.Int("workers"),
+					PersistentCache: cache,
 				}
 				return server.Serve()
 			},
@@ -339,10 +448,41 @@ func (a *App) Run(args []string) error {
 					Value:       100,
 					Description: "Path to key file",
 				},
+				&cli.IntFlag{
+					Name:        "pool-size",
+					Value:       1,
+					Description: "Number of graph instances to load for evaluating requests concurrently",
+				},
+				&cli.IntFlag{
+					Name:        "queue-size",
+					Value:       100,
+					Description: "Number of requests that can wait for a free graph instance before new ones are rejected with a 429",
+				},
+				&cli.DurationFlag{
+					Name:        "timeout",
+					Description: "How long evaluating a manifest can take before it's cancelled and the request fails with a 504. Zero allows evaluation to run indefinitely",
+				},
+				&cli.IntFlag{
+					Name:        "workers",
+					Value:       1,
+					Description: "Number of goroutines each request can spread evaluation of independent graph branches across",
+				},
+				&cli.DurationFlag{
+					Name:        "queue-timeout",
+					Value:       time.Second * 30,
+					Description: "How long a request waits for a free graph instance before failing with a 503. Zero waits indefinitely",
+				},
+				diskCacheFlag,
+				diskCacheSizeFlag,
 				requiredGraphFlag,
 				profileFlag,
 			},
 			Run: func(appState *cli.RunState) error {
+				cache, err := openDiskCache(appState)
+				if err != nil {
+					return err
+				}
+
 				server := run.Server{
 					Graph: a.Graph,
 					Host:  appState.String("host"),
@@ -352,6 +492,14 @@ func (a *App) Run(args []string) error {
 					CertPath:  appState.String("ssl.cert"),
 					KeyPath:   appState.String("ssl.key"),
 					CacheSize: appState.Int("cache-size"),
+
+					PoolSize:     appState.Int("pool-size"),
+					QueueSize:    appState.Int("queue-size"),
+					QueueTimeout: appState.Duration("queue-timeout"),
+					Timeout:      appState.Duration("timeout"),
+					Workers:      appState.Int("workers"),
+
+					PersistentCache: cache,
 				}
 				return server.Serve()
 			},
@@ -365,10 +513,16 @@ func (a *App) Run(args []string) error {
 					Name:        "out",
 					Description: "file to write the contents of the zip too",
 				},
+				diskCacheFlag,
+				diskCacheSizeFlag,
 				requiredGraphFlag,
 				profileFlag,
 			},
 			Run: func(appState *cli.RunState) error {
+				cache, err := openDiskCache(appState)
+				if err != nil {
+					return err
+				}
 
 				fileFlag := appState.String("out")
 
@@ -383,7 +537,8 @@ func (a *App) Run(args []string) error {
 				}
 
 				z := zip.NewWriter(out)
-				if err := graph.WriteToZip(a.Graph, z); err != nil {
+				ctx := nodes.WithPersistentCache(context.Background(), cache)
+				if err := graph.WriteToZipWithContext(ctx, a.Graph, z); err != nil {
 					return err
 				}
 
@@ -4,6 +4,8 @@ import (
 	"archive/zip"
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/EliCDavis/polyform/generator"
@@ -345,6 +347,8 @@ COMMANDS:
         Create a new graph
     Generate: generate gen 
         Runs all producers the graph has defined and saves it to the file system
+    Batch: batch 
+        Runs all producers for every variant of a parameter sweep, saving each to its own folder or zip along with an index
     Edit: edit 
         Starts an http server and hosts a webplayer for editing the execution graph
     Serve: serve 
@@ -363,3 +367,33 @@ COMMANDS:
         
     `, string(contents))
 }
+
+func TestAppCommand_Batch(t *testing.T) {
+	// ARRANGE ================================================================
+	g := graph.New(graph.Config{Name: "Test Graph"})
+	g.AddProducer("test", buildTextArifact(&parameter.String{
+		Name:         "Welp",
+		CurrentValue: "yee",
+	}))
+
+	folder := t.TempDir()
+	sweepFile := filepath.Join(folder, "sweep.json")
+	assert.NoError(t, os.WriteFile(sweepFile, []byte(`{"profiles": [{}, {}]}`), 0644))
+
+	app := generator.App{
+		Graph: g,
+		Out:   &bytes.Buffer{},
+	}
+
+	// ACT ====================================================================
+	err := app.Run([]string{"polyform", "batch", "--sweep", sweepFile, "--folder", folder, "--format", "zip"})
+
+	// ASSERT =================================================================
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(folder, "variant-1.zip"))
+	assert.FileExists(t, filepath.Join(folder, "variant-2.zip"))
+
+	index, err := os.ReadFile(filepath.Join(folder, "index.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), `"test/text.txt"`)
+}
@@ -0,0 +1,196 @@
+package batch
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/EliCDavis/polyform/generator/graph"
+	"github.com/EliCDavis/polyform/generator/variable"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+// IndexFileName is the name of the index written alongside the variants
+const IndexFileName = "index.json"
+
+type Format string
+
+const (
+	FolderFormat Format = "folder"
+	ZipFormat    Format = "zip"
+)
+
+type Options struct {
+	// Folder the variants and index are written to
+	Folder string
+
+	// Format each variant is written in, defaulting to a folder per variant
+	Format Format
+
+	// Timeout is how long generating a single variant can take before it's
+	// cancelled and the batch fails. Zero allows generation to run
+	// indefinitely.
+	Timeout time.Duration
+
+	// Workers is how many goroutines evaluation of independent graph
+	// branches can spread across. Anything below two evaluates sequentially.
+	Workers int
+}
+
+// Variant records the complete profile a variant was generated with and
+// the artifacts it produced, relative to the variant's folder or zip
+type Variant struct {
+	Name      string           `json:"name"`
+	Path      string           `json:"path"`
+	Profile   variable.Profile `json:"profile"`
+	Artifacts []string         `json:"artifacts"`
+}
+
+type Index struct {
+	Variants []Variant `json:"variants"`
+}
+
+// LoadSweep reads a sweep definition from a JSON file
+func LoadSweep(file string) (Sweep, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Sweep{}, err
+	}
+
+	sweep := Sweep{}
+	if err := json.Unmarshal(data, &sweep); err != nil {
+		return Sweep{}, fmt.Errorf("unable to interpret sweep %q: %w", file, err)
+	}
+	return sweep, nil
+}
+
+// Run generates every variant of the sweep, writing an index of the
+// variants it completed even if a later one fails. The graph's profile is
+// restored once it's done, and failing to do so is reported alongside any
+// other error.
+func Run(ctx context.Context, instance *graph.Instance, sweep Sweep, options Options) (index Index, err error) {
+	variants, err := sweep.Variants(instance.SwaggerDefinition())
+	if err != nil {
+		return Index{}, err
+	}
+
+	if options.Format == "" {
+		options.Format = FolderFormat
+	}
+	if options.Format != FolderFormat && options.Format != ZipFormat {
+		return Index{}, fmt.Errorf("unrecognized batch format %q", options.Format)
+	}
+
+	if err := os.MkdirAll(options.Folder, os.ModePerm); err != nil {
+		return Index{}, err
+	}
+
+	ctx = nodes.WithParallelEvaluation(ctx, options.Workers)
+
+	baseline := instance.CurrentProfile()
+	defer func() {
+		if restoreErr := instance.ApplyProfile(baseline); restoreErr != nil {
+			err = errors.Join(err, fmt.Errorf("unable to restore profile: %w", restoreErr))
+		}
+	}()
+
+	index = Index{Variants: make([]Variant, 0, len(variants))}
+	digits := len(strconv.Itoa(len(variants)))
+	for i, variant := range variants {
+		profile := make(variable.Profile, len(baseline)+len(variant))
+		for key, val := range baseline {
+			profile[key] = val
+		}
+		for key, val := range variant {
+			profile[key] = val
+		}
+
+		name := fmt.Sprintf("variant-%0*d", digits, i+1)
+		generated, err := generateVariant(ctx, instance, name, profile, options)
+		if err != nil {
+			err = fmt.Errorf("variant %q: %w", name, err)
+			return index, errors.Join(err, writeIndex(options.Folder, index))
+		}
+		index.Variants = append(index.Variants, generated)
+	}
+
+	return index, writeIndex(options.Folder, index)
+}
+
+func generateVariant(ctx context.Context, instance *graph.Instance, name string, profile variable.Profile, options Options) (Variant, error) {
+	if err := instance.ApplyProfile(profile); err != nil {
+		return Variant{}, fmt.Errorf("unable to apply profile: %w", err)
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	variantPath := name
+	switch options.Format {
+	case FolderFormat:
+		if err := graph.WriteToFolderWithContext(ctx, instance, filepath.Join(options.Folder, variantPath)); err != nil {
+			return Variant{}, err
+		}
+
+	case ZipFormat:
+		variantPath += ".zip"
+		if err := writeZip(ctx, instance, filepath.Join(options.Folder, variantPath)); err != nil {
+			return Variant{}, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return Variant{}, fmt.Errorf("generation exceeded %s timeout", options.Timeout)
+		}
+		return Variant{}, err
+	}
+
+	// Every manifest was just evaluated, so this is served from the cache
+	artifacts, err := graph.ArtifactPaths(ctx, instance)
+	if err != nil {
+		return Variant{}, err
+	}
+
+	return Variant{
+		Name:      name,
+		Path:      variantPath,
+		Profile:   profile,
+		Artifacts: artifacts,
+	}, nil
+}
+
+func writeZip(ctx context.Context, instance *graph.Instance, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	z := zip.NewWriter(f)
+	if err := graph.WriteToZipWithContext(ctx, instance, z); err != nil {
+		return err
+	}
+
+	if err := z.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func writeIndex(folder string, index Index) error {
+	data, err := json.MarshalIndent(index, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(folder, IndexFileName), data, 0644)
+}
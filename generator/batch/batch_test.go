@@ -0,0 +1,161 @@
+package batch_test
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EliCDavis/polyform/generator/batch"
+	"github.com/EliCDavis/polyform/generator/graph"
+	"github.com/EliCDavis/polyform/generator/manifest/basics"
+	"github.com/EliCDavis/polyform/generator/variable"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func messageGraph(t *testing.T) *graph.Instance {
+	t.Helper()
+	tf := &refutil.TypeFactory{}
+	tf.RegisterBuilder("Text", func() any {
+		return &nodes.Struct[basics.TextNode]{}
+	})
+
+	g := graph.New(graph.Config{TypeFactory: tf})
+	message := &variable.TypeVariable[string]{}
+	message.SetValue("default")
+	g.NewVariable("Message", message)
+
+	_, variableNode, err := g.CreateNode("Message")
+	require.NoError(t, err)
+	_, textNode, err := g.CreateNode("Text")
+	require.NoError(t, err)
+	g.ConnectNodes(variableNode, "Value", textNode, "In")
+	return g
+}
+
+func messageSweep() batch.Sweep {
+	return batch.Sweep{
+		Product: map[string][]json.RawMessage{
+			"Message": {json.RawMessage(`"hello"`), json.RawMessage(`"world"`)},
+		},
+	}
+}
+
+func TestRun_Folder(t *testing.T) {
+	// ARRANGE ================================================================
+	g := messageGraph(t)
+	folder := t.TempDir()
+
+	// ACT ====================================================================
+	index, err := batch.Run(context.Background(), g, messageSweep(), batch.Options{Folder: folder})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, index.Variants, 2)
+
+	for i, expected := range []string{"hello", "world"} {
+		variant := index.Variants[i]
+		require.Len(t, variant.Artifacts, 1)
+		assert.Equal(t, `"`+expected+`"`, string(variant.Profile["Message"]))
+
+		data, err := os.ReadFile(filepath.Join(folder, variant.Path, variant.Artifacts[0]))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+	assert.Equal(t, "variant-1", index.Variants[0].Path)
+	assert.Equal(t, "variant-2", index.Variants[1].Path)
+
+	written, err := os.ReadFile(filepath.Join(folder, batch.IndexFileName))
+	require.NoError(t, err)
+	var writtenIndex batch.Index
+	require.NoError(t, json.Unmarshal(written, &writtenIndex))
+	assert.Equal(t, index, writtenIndex)
+
+	// The graph is left how we found it
+	assert.Equal(t, `"default"`, string(g.CurrentProfile()["Message"]))
+}
+
+func TestRun_Zip(t *testing.T) {
+	// ARRANGE ================================================================
+	g := messageGraph(t)
+	folder := t.TempDir()
+
+	// ACT ====================================================================
+	index, err := batch.Run(context.Background(), g, messageSweep(), batch.Options{
+		Folder: folder,
+		Format: batch.ZipFormat,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, index.Variants, 2)
+
+	variant := index.Variants[1]
+	assert.Equal(t, "variant-2.zip", variant.Path)
+
+	z, err := zip.OpenReader(filepath.Join(folder, variant.Path))
+	require.NoError(t, err)
+	defer z.Close()
+	require.Len(t, z.File, 1)
+	assert.Equal(t, variant.Artifacts[0], z.File[0].Name)
+
+	rc, err := z.File[0].Open()
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+}
+
+func TestRun_WorkersAndTimeout(t *testing.T) {
+	// ARRANGE ================================================================
+	g := messageGraph(t)
+	folder := t.TempDir()
+
+	// ACT ====================================================================
+	index, err := batch.Run(context.Background(), g, messageSweep(), batch.Options{
+		Folder:  folder,
+		Timeout: time.Minute,
+		Workers: 4,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, index.Variants, 2)
+
+	variant := index.Variants[1]
+	data, err := os.ReadFile(filepath.Join(folder, variant.Path, variant.Artifacts[0]))
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+}
+
+func TestRun_IndexesCompletedVariantsOnFailure(t *testing.T) {
+	// ARRANGE ================================================================
+	g := messageGraph(t)
+	folder := t.TempDir()
+	sweep := batch.Sweep{
+		Profiles: []variable.Profile{
+			{"Message": json.RawMessage(`"hello"`)},
+			{"Missing": json.RawMessage(`1`)},
+		},
+	}
+
+	// ACT ====================================================================
+	index, err := batch.Run(context.Background(), g, sweep, batch.Options{Folder: folder})
+
+	// ASSERT =================================================================
+	assert.EqualError(t, err, `variant "variant-2": unable to apply profile: unable to apply "Missing" from profile, variable does not exist`)
+	require.Len(t, index.Variants, 1)
+
+	written, readErr := os.ReadFile(filepath.Join(folder, batch.IndexFileName))
+	require.NoError(t, readErr)
+	var writtenIndex batch.Index
+	require.NoError(t, json.Unmarshal(written, &writtenIndex))
+	assert.Equal(t, index, writtenIndex)
+}
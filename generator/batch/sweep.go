@@ -0,0 +1,236 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/EliCDavis/polyform/formats/swagger"
+	"github.com/EliCDavis/polyform/generator/variable"
+)
+
+// Range bounds the values a variable can take on within a random sweep.
+// Min and Max must share the same shape, with every number in Min paired
+// with the number at the same place in Max, so vectors and other structured
+// values can be swept component by component.
+type Range struct {
+	Min json.RawMessage `json:"min"`
+	Max json.RawMessage `json:"max"`
+}
+
+// RandomSweep samples Count variants, drawing every ranged variable
+// uniformly between its bounds. Sweeps with the same seed produce the same
+// variants.
+type RandomSweep struct {
+	Count  int              `json:"count"`
+	Seed   uint64           `json:"seed"`
+	Ranges map[string]Range `json:"ranges"`
+}
+
+// Sweep describes a set of variants to generate. Exactly one of Profiles,
+// Product or Random must be provided. Base is applied beneath every
+// variant, and variables set by neither keep the value the graph was
+// loaded with.
+type Sweep struct {
+	Base variable.Profile `json:"base,omitempty"`
+
+	// Profiles lists every variant explicitly
+	Profiles []variable.Profile `json:"profiles,omitempty"`
+
+	// Product generates a variant for every combination of the listed
+	// values
+	Product map[string][]json.RawMessage `json:"product,omitempty"`
+
+	Random *RandomSweep `json:"random,omitempty"`
+}
+
+// Variants resolves the sweep into the profile of each variant, in order.
+// The variable definition is used to determine which variables only accept
+// whole numbers.
+func (s Sweep) Variants(variables swagger.Definition) ([]variable.Profile, error) {
+	kinds := 0
+	if s.Profiles != nil {
+		kinds++
+	}
+	if s.Product != nil {
+		kinds++
+	}
+	if s.Random != nil {
+		kinds++
+	}
+	if kinds != 1 {
+		return nil, errors.New("sweep must define exactly one of profiles, product or random")
+	}
+
+	var variants []variable.Profile
+	var err error
+	switch {
+	case s.Profiles != nil:
+		variants = s.Profiles
+	case s.Product != nil:
+		variants, err = productVariants(s.Product)
+	case s.Random != nil:
+		variants, err = randomVariants(*s.Random, variables)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]variable.Profile, len(variants))
+	for i, variant := range variants {
+		profile := make(variable.Profile, len(s.Base)+len(variant))
+		for key, val := range s.Base {
+			profile[key] = val
+		}
+		for key, val := range variant {
+			profile[key] = val
+		}
+		results[i] = profile
+	}
+	return results, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// productVariants walks every combination of values, with variables
+// ordered by name and the last variable changing fastest
+func productVariants(product map[string][]json.RawMessage) ([]variable.Profile, error) {
+	names := sortedKeys(product)
+	total := 1
+	for _, name := range names {
+		if len(product[name]) == 0 {
+			return nil, fmt.Errorf("product variable %q has no values", name)
+		}
+		total *= len(product[name])
+	}
+
+	variants := make([]variable.Profile, total)
+	for i := range variants {
+		profile := make(variable.Profile, len(names))
+		remainder := i
+		for n := len(names) - 1; n >= 0; n-- {
+			values := product[names[n]]
+			profile[names[n]] = values[remainder%len(values)]
+			remainder /= len(values)
+		}
+		variants[i] = profile
+	}
+	return variants, nil
+}
+
+func randomVariants(sweep RandomSweep, variables swagger.Definition) ([]variable.Profile, error) {
+	if sweep.Count < 1 {
+		return nil, fmt.Errorf("random sweep count must be at least 1, found %d", sweep.Count)
+	}
+
+	type bounds struct {
+		min, max any
+		integer  bool
+	}
+
+	names := sortedKeys(sweep.Ranges)
+	ranges := make([]bounds, len(names))
+	for i, name := range names {
+		r := sweep.Ranges[name]
+		if err := json.Unmarshal(r.Min, &ranges[i].min); err != nil {
+			return nil, fmt.Errorf("range %q: unable to interpret min: %w", name, err)
+		}
+		if err := json.Unmarshal(r.Max, &ranges[i].max); err != nil {
+			return nil, fmt.Errorf("range %q: unable to interpret max: %w", name, err)
+		}
+		ranges[i].integer = variables.Properties[name].Type == swagger.IntegerPropertyType
+	}
+
+	rng := rand.New(rand.NewPCG(sweep.Seed, sweep.Seed))
+	variants := make([]variable.Profile, sweep.Count)
+	for i := range variants {
+		profile := make(variable.Profile, len(names))
+		for r, name := range names {
+			val, err := sample(rng, ranges[r].min, ranges[r].max, ranges[r].integer)
+			if err != nil {
+				return nil, fmt.Errorf("range %q: %w", name, err)
+			}
+
+			data, err := json.Marshal(val)
+			if err != nil {
+				return nil, fmt.Errorf("range %q: %w", name, err)
+			}
+			profile[name] = data
+		}
+		variants[i] = profile
+	}
+	return variants, nil
+}
+
+// sample draws a value between min and max, recursing into objects and
+// arrays so each number within them is drawn independently
+func sample(rng *rand.Rand, min, max any, integer bool) (any, error) {
+	switch minV := min.(type) {
+	case float64:
+		maxV, ok := max.(float64)
+		if !ok {
+			return nil, errors.New("min and max must have the same shape")
+		}
+		if maxV < minV {
+			return nil, fmt.Errorf("max %g is less than min %g", maxV, minV)
+		}
+
+		if integer {
+			low, high := math.Ceil(minV), math.Floor(maxV)
+			if high < low {
+				return nil, fmt.Errorf("no whole numbers between %g and %g", minV, maxV)
+			}
+			return int64(low) + rng.Int64N(int64(high-low)+1), nil
+		}
+		return minV + rng.Float64()*(maxV-minV), nil
+
+	case []any:
+		maxV, ok := max.([]any)
+		if !ok || len(maxV) != len(minV) {
+			return nil, errors.New("min and max must have the same shape")
+		}
+
+		result := make([]any, len(minV))
+		for i := range minV {
+			v, err := sample(rng, minV[i], maxV[i], integer)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+		return result, nil
+
+	case map[string]any:
+		maxV, ok := max.(map[string]any)
+		if !ok || len(maxV) != len(minV) {
+			return nil, errors.New("min and max must have the same shape")
+		}
+
+		result := make(map[string]any, len(minV))
+		for _, key := range sortedKeys(minV) {
+			maxField, ok := maxV[key]
+			if !ok {
+				return nil, errors.New("min and max must have the same shape")
+			}
+
+			v, err := sample(rng, minV[key], maxField, integer)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = v
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unable to sample between %v and %v, only numbers, arrays and objects can be ranged", min, max)
+}
@@ -0,0 +1,157 @@
+package batch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/swagger"
+	"github.com/EliCDavis/polyform/generator/batch"
+	"github.com/EliCDavis/polyform/generator/variable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func profileJSON(t *testing.T, profiles []variable.Profile) string {
+	t.Helper()
+	data, err := json.Marshal(profiles)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestSweep_Profiles(t *testing.T) {
+	sweep := batch.Sweep{
+		Base: variable.Profile{"A": json.RawMessage(`1`), "B": json.RawMessage(`2`)},
+		Profiles: []variable.Profile{
+			{"A": json.RawMessage(`3`)},
+			{"C": json.RawMessage(`"c"`)},
+		},
+	}
+
+	variants, err := sweep.Variants(swagger.Definition{})
+
+	require.NoError(t, err)
+	assert.Equal(t, `[{"A":3,"B":2},{"A":1,"B":2,"C":"c"}]`, profileJSON(t, variants))
+}
+
+func TestSweep_Product(t *testing.T) {
+	sweep := batch.Sweep{
+		Product: map[string][]json.RawMessage{
+			"Size":  {json.RawMessage(`1`), json.RawMessage(`2`)},
+			"Color": {json.RawMessage(`"red"`), json.RawMessage(`"green"`), json.RawMessage(`"blue"`)},
+		},
+	}
+
+	variants, err := sweep.Variants(swagger.Definition{})
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`[{"Color":"red","Size":1},{"Color":"red","Size":2},{"Color":"green","Size":1},{"Color":"green","Size":2},{"Color":"blue","Size":1},{"Color":"blue","Size":2}]`,
+		profileJSON(t, variants),
+	)
+}
+
+func TestSweep_Random(t *testing.T) {
+	// ARRANGE ================================================================
+	sweep := batch.Sweep{
+		Random: &batch.RandomSweep{
+			Count: 50,
+			Seed:  7,
+			Ranges: map[string]batch.Range{
+				"Seed":   {Min: json.RawMessage(`0`), Max: json.RawMessage(`3`)},
+				"Scale":  {Min: json.RawMessage(`0.5`), Max: json.RawMessage(`1.5`)},
+				"Offset": {Min: json.RawMessage(`{"x":0,"y":10}`), Max: json.RawMessage(`{"x":1,"y":20}`)},
+			},
+		},
+	}
+	variables := swagger.Definition{
+		Properties: map[string]swagger.Property{
+			"Seed":  {Type: swagger.IntegerPropertyType},
+			"Scale": {Type: swagger.NumberPropertyType},
+		},
+	}
+
+	// ACT ====================================================================
+	variants, err := sweep.Variants(variables)
+	again, againErr := sweep.Variants(variables)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.NoError(t, againErr)
+	require.Len(t, variants, 50)
+	assert.Equal(t, profileJSON(t, variants), profileJSON(t, again))
+
+	for _, variant := range variants {
+		var seed int
+		require.NoError(t, json.Unmarshal(variant["Seed"], &seed))
+		assert.GreaterOrEqual(t, seed, 0)
+		assert.LessOrEqual(t, seed, 3)
+
+		var scale float64
+		require.NoError(t, json.Unmarshal(variant["Scale"], &scale))
+		assert.GreaterOrEqual(t, scale, 0.5)
+		assert.LessOrEqual(t, scale, 1.5)
+
+		var offset struct{ X, Y float64 }
+		require.NoError(t, json.Unmarshal(variant["Offset"], &offset))
+		assert.GreaterOrEqual(t, offset.X, 0.)
+		assert.LessOrEqual(t, offset.X, 1.)
+		assert.GreaterOrEqual(t, offset.Y, 10.)
+		assert.LessOrEqual(t, offset.Y, 20.)
+	}
+}
+
+func TestSweep_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		sweep batch.Sweep
+		err   string
+	}{
+		"nothing defined": {
+			sweep: batch.Sweep{},
+			err:   "sweep must define exactly one of profiles, product or random",
+		},
+		"multiple defined": {
+			sweep: batch.Sweep{
+				Profiles: []variable.Profile{{}},
+				Product:  map[string][]json.RawMessage{"A": {json.RawMessage(`1`)}},
+			},
+			err: "sweep must define exactly one of profiles, product or random",
+		},
+		"empty product values": {
+			sweep: batch.Sweep{Product: map[string][]json.RawMessage{"A": {}}},
+			err:   `product variable "A" has no values`,
+		},
+		"no random count": {
+			sweep: batch.Sweep{Random: &batch.RandomSweep{}},
+			err:   "random sweep count must be at least 1, found 0",
+		},
+		"mismatched shapes": {
+			sweep: batch.Sweep{Random: &batch.RandomSweep{
+				Count:  1,
+				Ranges: map[string]batch.Range{"A": {Min: json.RawMessage(`[0, 1]`), Max: json.RawMessage(`[1]`)}},
+			}},
+			err: `range "A": min and max must have the same shape`,
+		},
+		"inverted range": {
+			sweep: batch.Sweep{Random: &batch.RandomSweep{
+				Count:  1,
+				Ranges: map[string]batch.Range{"A": {Min: json.RawMessage(`2`), Max: json.RawMessage(`1`)}},
+			}},
+			err: `range "A": max 1 is less than min 2`,
+		},
+		"unrangeable": {
+			sweep: batch.Sweep{Random: &batch.RandomSweep{
+				Count:  1,
+				Ranges: map[string]batch.Range{"A": {Min: json.RawMessage(`"a"`), Max: json.RawMessage(`"b"`)}},
+			}},
+			err: `range "A": unable to sample between a and b, only numbers, arrays and objects can be ranged`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.sweep.Variants(swagger.Definition{})
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
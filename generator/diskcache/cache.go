@@ -0,0 +1,235 @@
+package diskcache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const tempSuffix = ".tmp"
+
+type entry struct {
+	size     int64
+	lastUsed time.Time
+}
+
+// Cache is a nodes.PersistentCache backed by a directory on disk. Entries
+// are sharded into sub directories by the first two characters of their
+// key, and once the cache grows past its max size the least recently used
+// entries are deleted until it fits again.
+type Cache struct {
+	dir     string
+	maxSize int64
+
+	lock    sync.Mutex
+	entries map[string]*entry
+	size    int64
+}
+
+// New opens the cache stored in dir, creating the directory if it doesn't
+// exist yet. A maxSize of zero or less lets the cache grow without bound.
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory %q: %w", dir, err)
+	}
+
+	cache := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*entry),
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || strings.HasSuffix(d.Name(), tempSuffix) {
+			return nil
+		}
+
+		// Leave anything that isn't laid out like an entry alone
+		if entryPath, err := cache.path(d.Name()); err != nil || entryPath != path {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		cache.entries[d.Name()] = &entry{
+			size:     info.Size(),
+			lastUsed: info.ModTime(),
+		}
+		cache.size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to index cache directory %q: %w", dir, err)
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.evict()
+
+	return cache, nil
+}
+
+// Size is the number of bytes currently stored in the cache
+func (c *Cache) Size() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.size
+}
+
+func (c *Cache) path(key string) (string, error) {
+	if len(key) < 3 || strings.ContainsAny(key, `/\.`) {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	return filepath.Join(c.dir, key[:2], key), nil
+}
+
+func (c *Cache) Load(key string, read func(io.Reader) error) (bool, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if err := read(f); err != nil {
+		// Whatever's there isn't any use to anyone
+		f.Close()
+		c.remove(key)
+		return false, err
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.lastUsed = now
+	}
+	return true, nil
+}
+
+// Store writes the entry to a temporary file before moving it into place,
+// so a crash mid write never leaves a partial entry behind. Entries are
+// addressed by their content, so storing a key that already exists is a
+// no-op.
+func (c *Cache) Store(key string, write func(io.Writer) error) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	_, exists := c.entries[key]
+	c.lock.Unlock()
+	if exists {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), key+"-*"+tempSuffix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if c.maxSize > 0 && info.Size() > c.maxSize {
+		return fmt.Errorf("entry of %d bytes exceeds the cache's %d byte limit", info.Size(), c.maxSize)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = &entry{size: info.Size(), lastUsed: time.Now()}
+		c.size += info.Size()
+	}
+	c.evict()
+	return nil
+}
+
+func (c *Cache) remove(key string) {
+	path, err := c.path(key)
+	if err != nil {
+		return
+	}
+	os.Remove(path)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.size -= e.size
+		delete(c.entries, key)
+	}
+}
+
+// evict deletes the least recently used entries until the cache fits within
+// its max size. Callers must hold the lock.
+func (c *Cache) evict() {
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].lastUsed.Before(c.entries[keys[j]].lastUsed)
+	})
+
+	for _, key := range keys {
+		if c.size <= c.maxSize {
+			return
+		}
+
+		path, err := c.path(key)
+		if err == nil {
+			os.Remove(path)
+		}
+		c.size -= c.entries[key].size
+		delete(c.entries, key)
+	}
+}
@@ -0,0 +1,136 @@
+package diskcache_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EliCDavis/polyform/generator/diskcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func store(t *testing.T, cache *diskcache.Cache, key string, data []byte) {
+	t.Helper()
+	require.NoError(t, cache.Store(key, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}))
+}
+
+func load(t *testing.T, cache *diskcache.Cache, key string) ([]byte, bool) {
+	t.Helper()
+	var data []byte
+	found, err := cache.Load(key, func(r io.Reader) (err error) {
+		data, err = io.ReadAll(r)
+		return
+	})
+	require.NoError(t, err)
+	return data, found
+}
+
+func TestCache_StoreAndLoad(t *testing.T) {
+	// ARRANGE ================================================================
+	cache, err := diskcache.New(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	// ACT ====================================================================
+	store(t, cache, "abc123", []byte("hello world"))
+	data, found := load(t, cache, "abc123")
+	_, missingFound := load(t, cache, "def456")
+
+	// ASSERT =================================================================
+	assert.True(t, found)
+	assert.Equal(t, []byte("hello world"), data)
+	assert.False(t, missingFound)
+	assert.Equal(t, int64(11), cache.Size())
+}
+
+func TestCache_ReopenIndexesExistingEntries(t *testing.T) {
+	// ARRANGE ================================================================
+	dir := t.TempDir()
+	cache, err := diskcache.New(dir, 0)
+	require.NoError(t, err)
+	store(t, cache, "abc123", []byte("hello"))
+
+	// Files that aren't laid out like entries are left alone
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not an entry"), 0644))
+
+	// ACT ====================================================================
+	reopened, err := diskcache.New(dir, 0)
+	require.NoError(t, err)
+	data, found := load(t, reopened, "abc123")
+
+	// ASSERT =================================================================
+	assert.True(t, found)
+	assert.Equal(t, []byte("hello"), data)
+	assert.Equal(t, int64(5), reopened.Size())
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	// ARRANGE ================================================================
+	cache, err := diskcache.New(t.TempDir(), 10)
+	require.NoError(t, err)
+	store(t, cache, "aaa", bytes.Repeat([]byte{1}, 4))
+	store(t, cache, "bbb", bytes.Repeat([]byte{2}, 4))
+
+	// Touch the older entry so the newer one is least recently used
+	_, found := load(t, cache, "aaa")
+	require.True(t, found)
+
+	// ACT ====================================================================
+	store(t, cache, "ccc", bytes.Repeat([]byte{3}, 4))
+
+	// ASSERT =================================================================
+	_, aFound := load(t, cache, "aaa")
+	_, bFound := load(t, cache, "bbb")
+	_, cFound := load(t, cache, "ccc")
+	assert.True(t, aFound)
+	assert.False(t, bFound)
+	assert.True(t, cFound)
+	assert.Equal(t, int64(8), cache.Size())
+}
+
+func TestCache_RejectsEntriesLargerThanCache(t *testing.T) {
+	cache, err := diskcache.New(t.TempDir(), 4)
+	require.NoError(t, err)
+
+	err = cache.Store("abc", func(w io.Writer) error {
+		_, err := w.Write([]byte("too big"))
+		return err
+	})
+
+	assert.EqualError(t, err, "entry of 7 bytes exceeds the cache's 4 byte limit")
+	_, found := load(t, cache, "abc")
+	assert.False(t, found)
+	assert.Equal(t, int64(0), cache.Size())
+}
+
+func TestCache_CorruptEntriesAreRemoved(t *testing.T) {
+	// ARRANGE ================================================================
+	cache, err := diskcache.New(t.TempDir(), 0)
+	require.NoError(t, err)
+	store(t, cache, "abc", []byte("garbage"))
+	corrupt := errors.New("corrupt")
+
+	// ACT ====================================================================
+	found, err := cache.Load("abc", func(r io.Reader) error { return corrupt })
+
+	// ASSERT =================================================================
+	assert.False(t, found)
+	assert.ErrorIs(t, err, corrupt)
+	_, found = load(t, cache, "abc")
+	assert.False(t, found)
+	assert.Equal(t, int64(0), cache.Size())
+}
+
+func TestCache_InvalidKey(t *testing.T) {
+	cache, err := diskcache.New(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	_, err = cache.Load("../escape", func(r io.Reader) error { return nil })
+	assert.EqualError(t, err, `invalid cache key "../escape"`)
+}
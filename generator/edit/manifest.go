@@ -1,6 +1,7 @@
 package edit
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -74,13 +75,30 @@ func getNodeOutputFromURLPath(r *http.Request, base string, graph *graph.Instanc
 	}, nil
 }
 
+// evaluationContext layers the server's evaluation options onto the context
+func (as *Server) evaluationContext(ctx context.Context) context.Context {
+	ctx = nodes.WithParallelEvaluation(ctx, as.Workers)
+	return nodes.WithPersistentCache(ctx, as.PersistentCache)
+}
+
 func (as *Server) writeManifest(w http.ResponseWriter, r *http.Request) error {
 	resolvedNode, err := getTypedNodeOutputFromURLPath[manifest.Manifest](r, "/manifest/", as.Graph)
 	if err != nil {
 		return err
 	}
 
-	manifest := resolvedNode.output.Value()
+	ctx := r.Context()
+	if as.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, as.Timeout)
+		defer cancel()
+	}
+	ctx = as.evaluationContext(ctx)
+
+	manifest := nodes.ValueWithContext(ctx, resolvedNode.output)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("evaluating node %q output %q: %w", resolvedNode.nodeID, resolvedNode.outputName, err)
+	}
 
 	// We're just trying to get the manifest of the node's output manifest
 	if resolvedNode.remainingUrl == "" {
@@ -21,6 +21,7 @@ import (
 	"github.com/EliCDavis/polyform/generator/room"
 	"github.com/EliCDavis/polyform/generator/serialize"
 	"github.com/EliCDavis/polyform/generator/variable"
+	"github.com/EliCDavis/polyform/nodes"
 )
 
 func writeJSONError(out io.Writer, err error) error {
@@ -70,6 +71,18 @@ type Server struct {
 
 	ClientConfig *room.ClientConfig
 
+	// Timeout is how long evaluating a manifest can take before it's
+	// cancelled. Zero allows evaluation to run indefinitely.
+	Timeout time.Duration
+
+	// Workers is how many goroutines evaluation of independent graph branches
+	// can spread across. Anything below two evaluates sequentially.
+	Workers int
+
+	// PersistentCache, if set, stores evaluated outputs across runs of the
+	// editor
+	PersistentCache nodes.PersistentCache
+
 	serverStarted     time.Time
 	showNewGraphPopup bool
 }
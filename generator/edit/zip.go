@@ -2,6 +2,7 @@ package edit
 
 import (
 	"archive/zip"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -12,10 +13,10 @@ import (
 	"github.com/EliCDavis/polyform/generator/manifest"
 )
 
-func writeZip(out io.Writer, g *graph.Instance) error {
+func writeZip(ctx context.Context, out io.Writer, g *graph.Instance) error {
 	z := zip.NewWriter(out)
 
-	if err := graph.WriteToZip(g, z); err != nil {
+	if err := graph.WriteToZipWithContext(ctx, g, z); err != nil {
 		return err
 	}
 
@@ -26,7 +27,7 @@ func (as *Server) zipEndpoint(w http.ResponseWriter, r *http.Request) error {
 
 	// Their requesting a zip of the entire graph, just zip the entire thing
 	if r.URL.Path == "/zip/" {
-		return writeZip(w, as.Graph)
+		return writeZip(as.evaluationContext(r.Context()), w, as.Graph)
 	}
 
 	resolvedNode, err := getTypedNodeOutputFromURLPath[manifest.Manifest](r, "/zip/", as.Graph)
@@ -39,7 +40,7 @@ func (as *Server) zipEndpoint(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	z := zip.NewWriter(w)
-	err = graph.WriteManifestToZip(as.Graph, z, as.Graph.NodeId(resolvedNode.node), resolvedNode.node, resolvedNode.output)
+	err = graph.WriteManifestToZipWithContext(as.evaluationContext(r.Context()), as.Graph, z, as.Graph.NodeId(resolvedNode.node), resolvedNode.node, resolvedNode.output)
 	if err != nil {
 		return err
 	}
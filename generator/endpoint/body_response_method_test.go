@@ -2,6 +2,7 @@ package endpoint_test
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -112,3 +113,34 @@ func TestBodyResponseMethod_ErrorPanicRecovery(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 	assert.Equal(t, `{"error":"panic recover: yee haw"}`, string(body))
 }
+
+func TestBodyResponseMethod_StatusError(t *testing.T) {
+	// ARRANGE ================================================================
+	type Body struct{}
+	type Response struct{}
+	handler := endpoint.Handler{
+		Methods: map[string]endpoint.Method{
+			http.MethodGet: endpoint.BodyResponseMethod[Body, Response]{
+				Request:        endpoint.JsonRequestReader[Body]{},
+				ResponseWriter: endpoint.JsonResponseWriter[Response]{},
+				Handler: func(request endpoint.Request[Body]) (Response, error) {
+					return Response{}, endpoint.StatusError{
+						Status: http.StatusTooManyRequests,
+						Err:    errors.New("slow down"),
+					}
+				},
+			},
+		},
+	}
+	req := httptest.NewRequest("GET", "http://example.com/foo", bytes.NewReader([]byte(`{}`)))
+	w := httptest.NewRecorder()
+
+	// ACT ====================================================================
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 429, resp.StatusCode)
+	assert.Equal(t, `{"error":"slow down"}`, string(body))
+}
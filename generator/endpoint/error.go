@@ -0,0 +1,29 @@
+package endpoint
+
+import (
+	"errors"
+	"net/http"
+)
+
+// StatusError lets handlers respond with a status code other than the
+// default 500 when they fail
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (se StatusError) Error() string {
+	return se.Err.Error()
+}
+
+func (se StatusError) Unwrap() error {
+	return se.Err
+}
+
+func errorStatus(err error) int {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status
+	}
+	return http.StatusInternalServerError
+}
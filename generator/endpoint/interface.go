@@ -1,10 +1,17 @@
 package endpoint
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 type Request[Body any] struct {
 	Body Body
 	Url  string
+
+	// Context of the underlying HTTP request, cancelled once the client
+	// disconnects
+	Context context.Context
 }
 
 type Method interface {
@@ -21,7 +21,7 @@ func readJSON[T any](body io.Reader) (T, error) {
 
 func writeJSONError(w http.ResponseWriter, err error) error {
 	w.Header().Set("Content-Type", string(JsonContentType))
-	w.WriteHeader(http.StatusInternalServerError)
+	w.WriteHeader(errorStatus(err))
 
 	data, err := json.Marshal(errorResponse{
 		Error: err.Error(),
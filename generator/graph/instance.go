@@ -324,6 +324,13 @@ func (a *Instance) ApplyProfile(profile variable.Profile) error {
 	return a.variables.ApplyProfile(profile)
 }
 
+// CurrentProfile captures the current value of every variable in the graph
+func (a *Instance) CurrentProfile() variable.Profile {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.variables.GetProfile()
+}
+
 // <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
 
 func (a *Instance) IsPortNamed(node nodes.Node, portName string) (string, bool) {
@@ -851,6 +858,29 @@ func (a *Instance) EncodeToAppSchema() ([]byte, error) {
 	return encoder.ToPgtf(appSchema)
 }
 
+// Clone builds an independent copy of the graph by round tripping it through
+// its app schema. Nothing is shared between the two, so each can have
+// profiles applied and be evaluated without coordinating with the other.
+func (a *Instance) Clone() (*Instance, error) {
+	if err := a.assertRootGraph("clone graph"); err != nil {
+		return nil, err
+	}
+
+	payload, err := a.EncodeToAppSchema()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode graph for clone: %w", err)
+	}
+
+	clone := New(Config{
+		TypeFactory:     a.typeFactory,
+		VariableFactory: a.variableFactory,
+	})
+	if err := clone.ApplyAppSchema(payload); err != nil {
+		return nil, fmt.Errorf("unable to load graph clone: %w", err)
+	}
+	return clone, nil
+}
+
 func (a *Instance) buildNodeGraphInstanceSchema(node nodes.Node, encoder *jbtf.Encoder) persistence.Node {
 
 	resolver := refutil.TypeResolution{
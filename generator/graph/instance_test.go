@@ -234,3 +234,27 @@ func TestInstance_ApplyAppSchema_invalidPayload(t *testing.T) {
 	err := instance.ApplyAppSchema([]byte(`not valid jbtf`))
 	assert.Error(t, err)
 }
+
+func TestInstance_Clone(t *testing.T) {
+	source, _ := testInstanceWithTextProducer(t)
+
+	clone, err := source.Clone()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Test App", clone.GetName())
+	assert.Equal(t, []string{"test.txt"}, clone.ProducerNames())
+
+	// Updating the clone's parameter leaves the source untouched
+	_, err = clone.UpdateParameter("Node-0", []byte(`"changed"`))
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	cloneManifest := clone.Manifest("test.txt")
+	assert.NoError(t, cloneManifest.Entries[cloneManifest.Main].Artifact.Write(buf))
+	assert.Equal(t, "changed", buf.String())
+
+	buf.Reset()
+	sourceManifest := source.Manifest("test.txt")
+	assert.NoError(t, sourceManifest.Entries[sourceManifest.Main].Artifact.Write(buf))
+	assert.Equal(t, "bruh", buf.String())
+}
@@ -2,9 +2,11 @@ package graph
 
 import (
 	"archive/zip"
+	"context"
 	"fmt"
 	"os"
 	"path"
+	"sort"
 
 	"github.com/EliCDavis/polyform/generator/manifest"
 	"github.com/EliCDavis/polyform/nodes"
@@ -25,7 +27,16 @@ func manifestFileName(i *Instance, nodeId string, node nodes.Node, out nodes.Out
 }
 
 func WriteManifestToZip(i *Instance, zw *zip.Writer, nodeId string, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
-	manifest := out.Value()
+	return WriteManifestToZipWithContext(context.Background(), i, zw, nodeId, node, out)
+}
+
+// WriteManifestToZipWithContext evaluates the manifest with the provided
+// context, failing if the context is done before evaluation finishes
+func WriteManifestToZipWithContext(ctx context.Context, i *Instance, zw *zip.Writer, nodeId string, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
+	manifest := nodes.ValueWithContext(ctx, out)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	manifestName := manifestFileName(i, nodeId, node, out)
 
 	entries := manifest.Entries
@@ -44,17 +55,24 @@ func WriteManifestToZip(i *Instance, zw *zip.Writer, nodeId string, node nodes.N
 }
 
 func WriteToZip(i *Instance, zw *zip.Writer) error {
+	return WriteToZipWithContext(context.Background(), i, zw)
+}
+
+func WriteToZipWithContext(ctx context.Context, i *Instance, zw *zip.Writer) error {
 	if zw == nil {
 		panic("can't write to nil zip writer")
 	}
 
 	return ForeachManifestNodeOutput(i, func(s string, n nodes.Node, o nodes.Output[manifest.Manifest]) error {
-		return WriteManifestToZip(i, zw, s, n, o)
+		return WriteManifestToZipWithContext(ctx, i, zw, s, n, o)
 	})
 }
 
-func writeManifestToFolder(i *Instance, folder string, nodeId string, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
-	manifest := out.Value()
+func writeManifestToFolder(ctx context.Context, i *Instance, folder string, nodeId string, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
+	manifest := nodes.ValueWithContext(ctx, out)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	manifestName := manifestFileName(i, nodeId, node, out)
 
 	manifestFolder := path.Join(folder, manifestName)
@@ -82,7 +100,31 @@ func writeManifestToFolder(i *Instance, folder string, nodeId string, node nodes
 }
 
 func WriteToFolder(i *Instance, folder string) error {
+	return WriteToFolderWithContext(context.Background(), i, folder)
+}
+
+func WriteToFolderWithContext(ctx context.Context, i *Instance, folder string) error {
 	return ForeachManifestNodeOutput(i, func(s string, n nodes.Node, o nodes.Output[manifest.Manifest]) error {
-		return writeManifestToFolder(i, folder, s, n, o)
+		return writeManifestToFolder(ctx, i, folder, s, n, o)
+	})
+}
+
+// ArtifactPaths lists where every artifact the graph produces ends up,
+// relative to the root of the folder or zip it's written to
+func ArtifactPaths(ctx context.Context, i *Instance) ([]string, error) {
+	var paths []string
+	err := ForeachManifestNodeOutput(i, func(nodeId string, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
+		manifest := nodes.ValueWithContext(ctx, out)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		manifestName := manifestFileName(i, nodeId, node, out)
+		for artifactName := range manifest.Entries {
+			paths = append(paths, path.Join(manifestName, artifactName))
+		}
+		return nil
 	})
+	sort.Strings(paths)
+	return paths, err
 }
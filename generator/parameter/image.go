@@ -35,6 +35,10 @@ func (sno imageNodeOutput) Version() int {
 	return sno.Val.version
 }
 
+func (sno imageNodeOutput) ContentHash() (string, bool) {
+	return nodes.HashContent("image.Image", sno.Val.ToMessage())
+}
+
 func (sno imageNodeOutput) BuildProxyOutput(source nodes.ProxySource) nodes.OutputPort {
 	return nodes.NewProxyOutput[image.Image](source)
 }
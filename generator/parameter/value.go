@@ -38,6 +38,10 @@ func (sno parameterNodeOutput[T]) Version() int {
 	return sno.Val.version
 }
 
+func (sno parameterNodeOutput[T]) ContentHash() (string, bool) {
+	return nodes.HashContent(sno.Type(), sno.Val.Value())
+}
+
 func (sno parameterNodeOutput[T]) BuildProxyOutput(source nodes.ProxySource) nodes.OutputPort {
 	return nodes.NewProxyOutput[T](source)
 }
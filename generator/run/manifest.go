@@ -2,10 +2,12 @@ package run
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strings"
 
@@ -61,32 +63,68 @@ func getNodeOutputFromURLPath[T any](requestUrl string, base string, graph []nod
 
 func (s *Server) manifestEndpoint() http.Handler {
 	post := func(request endpoint.Request[*variable.Profile]) (CreateManifestResponse, error) {
-		s.lock.Lock()
-		defer s.lock.Unlock()
-
 		response := CreateManifestResponse{}
+
+		instance, err := s.pool.acquire(request.Context)
+		if err != nil {
+			return response, err
+		}
+		defer func() {
+			if request.Body == nil {
+				s.pool.release(instance)
+				return
+			}
+
+			// An instance that can't be restored would serve later requests
+			// with this request's profile
+			if err := instance.graph.ApplyProfile(instance.baseline); err != nil {
+				log.Printf("discarding graph instance, unable to restore its profile: %s", err.Error())
+				s.pool.discard(instance)
+				return
+			}
+			s.pool.release(instance)
+		}()
+
 		if request.Body != nil {
-			err := s.Graph.ApplyProfile(*request.Body)
+			err := instance.graph.ApplyProfile(*request.Body)
 			if err != nil {
 				return response, fmt.Errorf("unable to apply profile: %w", err)
 			}
 		}
 
-		resolvedNode, err := getNodeOutputFromURLPath(request.Url, "/manifest/", s.manifestNodes)
+		resolvedNode, err := getNodeOutputFromURLPath(request.Url, "/manifest/", instance.manifestNodes)
 		if err != nil {
 			return response, err
 		}
 
-		response.Manifest = resolvedNode.output.Value()
+		ctx := request.Context
+		if s.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+			defer cancel()
+		}
+		ctx = nodes.WithParallelEvaluation(ctx, s.Workers)
+		ctx = nodes.WithPersistentCache(ctx, s.PersistentCache)
+
+		response.Manifest = nodes.ValueWithContext(ctx, resolvedNode.output)
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return response, endpoint.StatusError{
+					Status: http.StatusGatewayTimeout,
+					Err:    fmt.Errorf("evaluation exceeded %s timeout", s.Timeout),
+				}
+			}
+			return response, err
+		}
+
+		s.cacheLock.Lock()
 		response.Id = s.cache.Add(response.Manifest)
+		s.cacheLock.Unlock()
 
 		return response, nil
 	}
 
 	get := func(r *http.Request) ([]byte, error) {
-		s.lock.RLock()
-		defer s.lock.RUnlock()
-
 		components := urlComponents(r.URL.Path, "/manifest/")
 		if len(components) != 2 {
 			return nil, fmt.Errorf("invalid url: %q", r.URL.Path)
@@ -94,7 +132,9 @@ func (s *Server) manifestEndpoint() http.Handler {
 		id := components[0]
 		entryPath := components[1]
 
+		s.cacheLock.Lock()
 		m, ok := s.cache.Get(id)
+		s.cacheLock.Unlock()
 		if !ok {
 			return nil, fmt.Errorf("no manifest exists with id %q", id)
 		}
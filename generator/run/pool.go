@@ -0,0 +1,114 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/EliCDavis/polyform/generator/endpoint"
+	"github.com/EliCDavis/polyform/generator/graph"
+	"github.com/EliCDavis/polyform/generator/manifest"
+	"github.com/EliCDavis/polyform/generator/variable"
+)
+
+var (
+	ErrPoolSaturated = errors.New("all graph instances are busy and the request queue is full")
+	ErrPoolTimeout   = errors.New("timed out waiting for a free graph instance")
+	ErrPoolExhausted = errors.New("every graph instance has been discarded")
+)
+
+// pooledInstance is a graph loaded independently of every other instance in
+// the pool, along with the manifest outputs resolved against it. The
+// baseline profile is restored after every request that applies its own,
+// so results never depend on which instance served a previous request.
+type pooledInstance struct {
+	graph         *graph.Instance
+	manifestNodes []nodeAndOutput[manifest.Manifest]
+	baseline      variable.Profile
+}
+
+// instancePool hands out graph instances to requests one at a time. Requests
+// that arrive while every instance is busy wait in a bounded queue for up to
+// timeout before giving up.
+type instancePool struct {
+	free    chan *pooledInstance
+	queue   chan struct{}
+	timeout time.Duration
+
+	// Instances left in an unknown state are discarded rather than
+	// released, and once none remain exhausted is closed so nothing waits
+	// on an instance that will never come
+	lock      sync.Mutex
+	live      int
+	exhausted chan struct{}
+}
+
+func newInstancePool(instances []*pooledInstance, queueSize int, timeout time.Duration) *instancePool {
+	pool := &instancePool{
+		free:      make(chan *pooledInstance, len(instances)),
+		queue:     make(chan struct{}, max(queueSize, 0)),
+		timeout:   timeout,
+		live:      len(instances),
+		exhausted: make(chan struct{}),
+	}
+	for _, instance := range instances {
+		pool.free <- instance
+	}
+	return pool
+}
+
+// acquire blocks until an instance frees up, the request's place in the
+// queue times out, or the context is cancelled. Callers must release the
+// instance once they're done with it.
+func (p *instancePool) acquire(ctx context.Context) (*pooledInstance, error) {
+	select {
+	case instance := <-p.free:
+		return instance, nil
+	case <-p.exhausted:
+		return nil, endpoint.StatusError{Status: http.StatusServiceUnavailable, Err: ErrPoolExhausted}
+	default:
+	}
+
+	select {
+	case p.queue <- struct{}{}:
+		defer func() { <-p.queue }()
+	default:
+		return nil, endpoint.StatusError{Status: http.StatusTooManyRequests, Err: ErrPoolSaturated}
+	}
+
+	var timeout <-chan time.Time
+	if p.timeout > 0 {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case instance := <-p.free:
+		return instance, nil
+	case <-p.exhausted:
+		return nil, endpoint.StatusError{Status: http.StatusServiceUnavailable, Err: ErrPoolExhausted}
+	case <-timeout:
+		return nil, endpoint.StatusError{Status: http.StatusServiceUnavailable, Err: ErrPoolTimeout}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *instancePool) release(instance *pooledInstance) {
+	p.free <- instance
+}
+
+// discard permanently removes an instance from the pool instead of
+// releasing it, for instances that can no longer be trusted to serve
+// requests the same as every other instance
+func (p *instancePool) discard(instance *pooledInstance) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.live--
+	if p.live == 0 {
+		close(p.exhausted)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/EliCDavis/polyform/generator/endpoint"
 	"github.com/EliCDavis/polyform/generator/graph"
@@ -54,9 +55,62 @@ type Server struct {
 
 	CacheSize int
 
-	cache         *lruCache[manifest.Manifest]
-	lock          sync.RWMutex
-	manifestNodes []nodeAndOutput[manifest.Manifest]
+	// PoolSize is the number of independently loaded graph instances
+	// available for evaluating requests concurrently. Defaults to 1.
+	PoolSize int
+
+	// QueueSize is how many requests can wait for a graph instance to free
+	// up before the server starts responding with 429s
+	QueueSize int
+
+	// QueueTimeout is how long a request waits for a graph instance before
+	// the server gives up with a 503. Zero waits indefinitely.
+	QueueTimeout time.Duration
+
+	// Timeout is how long evaluating a manifest can take before it's
+	// cancelled and the request fails with a 504. Zero allows evaluation to
+	// run indefinitely.
+	Timeout time.Duration
+
+	// Workers is how many goroutines a single request can spread evaluation
+	// of independent graph branches across. Anything below two evaluates
+	// sequentially.
+	Workers int
+
+	// PersistentCache, if set, stores evaluated outputs across restarts of
+	// the server and is shared by every graph instance in the pool
+	PersistentCache nodes.PersistentCache
+
+	cache     *lruCache[manifest.Manifest]
+	cacheLock sync.Mutex
+	pool      *instancePool
+}
+
+func (s *Server) buildPool() (*instancePool, error) {
+	size := max(s.PoolSize, 1)
+	instances := make([]*pooledInstance, size)
+	for i := range instances {
+		g := s.Graph
+		if i > 0 {
+			var err error
+			g, err = s.Graph.Clone()
+			if err != nil {
+				return nil, fmt.Errorf("unable to load graph instance %d: %w", i, err)
+			}
+		}
+
+		manifestNodes, err := getAvailableManifests(g)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute graph manifests: %w", err)
+		}
+
+		instances[i] = &pooledInstance{
+			graph:         g,
+			manifestNodes: manifestNodes,
+			baseline:      g.CurrentProfile(),
+		}
+	}
+	return newInstancePool(instances, s.QueueSize, s.QueueTimeout), nil
 }
 
 func (s *Server) Handler() (*http.ServeMux, error) {
@@ -75,7 +129,11 @@ func (s *Server) Handler() (*http.ServeMux, error) {
 		return nil, fmt.Errorf("unable to compute static /profile response: %w", err)
 	}
 
-	s.manifestNodes = availableManifests
+	s.pool, err = s.buildPool()
+	if err != nil {
+		return nil, err
+	}
+
 	s.cache = &lruCache[manifest.Manifest]{
 		max:  s.CacheSize,
 		data: make(map[string]*lruCacheEntry[manifest.Manifest]),
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/EliCDavis/polyform/generator/graph"
+	"github.com/EliCDavis/polyform/generator/manifest"
 	"github.com/EliCDavis/polyform/generator/manifest/basics"
 	"github.com/EliCDavis/polyform/generator/parameter"
 	"github.com/EliCDavis/polyform/generator/run"
@@ -286,3 +289,202 @@ func TestServer_VariableProfile(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, `{"Test Variable":{"type":"number","format":"double"}}`, string(rawResponse))
 }
+
+// Evaluation of BlockingNode signals on blockingEntered and then waits on
+// blockingRelease, letting tests hold graph instances busy
+var (
+	blockingEntered chan struct{}
+	blockingRelease chan struct{}
+)
+
+type BlockingNode struct{}
+
+func (BlockingNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	blockingEntered <- struct{}{}
+	<-blockingRelease
+	out.Set(manifest.SingleEntryManifest("text.txt", manifest.Entry{Artifact: basics.TextArtifact{Data: "done"}}))
+}
+
+func blockingServer(t *testing.T, server *run.Server) http.Handler {
+	t.Helper()
+	blockingEntered = make(chan struct{})
+	blockingRelease = make(chan struct{})
+
+	tf := &refutil.TypeFactory{}
+	tf.RegisterBuilder("Blocking", func() any {
+		return &nodes.Struct[BlockingNode]{}
+	})
+
+	g := graph.New(graph.Config{TypeFactory: tf})
+	_, _, err := g.CreateNode("Blocking")
+	require.NoError(t, err)
+
+	server.Graph = g
+	server.CacheSize = 10
+	handler, err := server.Handler()
+	require.NoError(t, err)
+	return handler
+}
+
+func postManifest(handler http.Handler, url string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, url, nil))
+	return rr
+}
+
+func TestServer_PoolSaturated(t *testing.T) {
+	// ARRANGE ================================================================
+	handler := blockingServer(t, &run.Server{PoolSize: 1, QueueSize: 0})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	var first *httptest.ResponseRecorder
+	go func() {
+		defer wg.Done()
+		first = postManifest(handler, "/manifest/Node-0/Out")
+	}()
+	<-blockingEntered
+
+	// ACT ====================================================================
+	second := postManifest(handler, "/manifest/Node-0/Out")
+	close(blockingRelease)
+	wg.Wait()
+
+	// ASSERT =================================================================
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, `{"error":"all graph instances are busy and the request queue is full"}`, second.Body.String())
+	assert.Equal(t, http.StatusOK, first.Code)
+}
+
+func TestServer_PoolTimeout(t *testing.T) {
+	// ARRANGE ================================================================
+	handler := blockingServer(t, &run.Server{
+		PoolSize:     1,
+		QueueSize:    1,
+		QueueTimeout: 10 * time.Millisecond,
+	})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		postManifest(handler, "/manifest/Node-0/Out")
+	}()
+	<-blockingEntered
+
+	// ACT ====================================================================
+	second := postManifest(handler, "/manifest/Node-0/Out")
+	close(blockingRelease)
+	wg.Wait()
+
+	// ASSERT =================================================================
+	assert.Equal(t, http.StatusServiceUnavailable, second.Code)
+	assert.Equal(t, `{"error":"timed out waiting for a free graph instance"}`, second.Body.String())
+}
+
+func TestServer_PoolEvaluatesConcurrently(t *testing.T) {
+	// ARRANGE ================================================================
+	handler := blockingServer(t, &run.Server{PoolSize: 2})
+
+	results := make([]*httptest.ResponseRecorder, 2)
+	wg := sync.WaitGroup{}
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = postManifest(handler, "/manifest/Node-0/Out")
+		}()
+	}
+
+	// ACT ====================================================================
+	// Both requests have to be evaluating at the same time for this to
+	// return, as neither is released until after
+	<-blockingEntered
+	<-blockingEntered
+	close(blockingRelease)
+	wg.Wait()
+
+	// ASSERT =================================================================
+	for _, result := range results {
+		assert.Equal(t, http.StatusOK, result.Code)
+	}
+}
+
+func TestServer_ProfileDoesNotLeakBetweenRequests(t *testing.T) {
+	// ARRANGE ================================================================
+	g := graph.New(graph.Config{
+		TypeFactory: typeFactory(),
+	})
+	message := &variable.TypeVariable[string]{}
+	message.SetValue("default")
+	g.NewVariable("Message", message)
+
+	_, variableNode, err := g.CreateNode("Message")
+	require.NoError(t, err)
+	_, textNode, err := g.CreateNode("Text")
+	require.NoError(t, err)
+	g.ConnectNodes(variableNode, "Value", textNode, "In")
+
+	server := run.Server{
+		Graph:     g,
+		CacheSize: 10,
+	}
+	handler, err := server.Handler()
+	require.NoError(t, err)
+
+	read := func(body string) string {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/manifest/%s/Out", textNode), strings.NewReader(body))
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, 200, rr.Code, rr.Body.String())
+
+		var createResponse run.CreateManifestResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &createResponse))
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/manifest/%s/text.txt", createResponse.Id), nil))
+		require.Equal(t, 200, rr.Code)
+		return rr.Body.String()
+	}
+
+	// ACT ====================================================================
+	custom := read(`{"Message":"custom"}`)
+	afterwards := read(``)
+
+	// ASSERT =================================================================
+	assert.Equal(t, "custom", custom)
+	assert.Equal(t, "default", afterwards)
+}
+
+type WaitForCancellationNode struct{}
+
+func (WaitForCancellationNode) Out(out *nodes.StructOutput[manifest.Manifest]) {
+	<-out.Context().Done()
+}
+
+func TestServer_EvaluationTimeout(t *testing.T) {
+	// ARRANGE ================================================================
+	tf := &refutil.TypeFactory{}
+	tf.RegisterBuilder("Wait", func() any {
+		return &nodes.Struct[WaitForCancellationNode]{}
+	})
+
+	g := graph.New(graph.Config{TypeFactory: tf})
+	_, _, err := g.CreateNode("Wait")
+	require.NoError(t, err)
+
+	server := &run.Server{
+		Graph:     g,
+		CacheSize: 10,
+		Timeout:   10 * time.Millisecond,
+	}
+	handler, err := server.Handler()
+	require.NoError(t, err)
+
+	// ACT ====================================================================
+	rr := postManifest(handler, "/manifest/Node-0/Out")
+
+	// ASSERT =================================================================
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	assert.Equal(t, `{"error":"evaluation exceeded 10ms timeout"}`, rr.Body.String())
+}
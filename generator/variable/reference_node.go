@@ -55,6 +55,10 @@ func (vrn *variableReferenceNodePort[T]) Value() T {
 	return vrn.node.variable.currentValue().(T)
 }
 
+func (vrn *variableReferenceNodePort[T]) ContentHash() (string, bool) {
+	return nodes.HashContent(vrn.Type(), vrn.Value())
+}
+
 func (vrn *variableReferenceNodePort[T]) BuildProxyOutput(source nodes.ProxySource) nodes.OutputPort {
 	return nodes.NewProxyOutput[T](source)
 }
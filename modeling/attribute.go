@@ -3,10 +3,12 @@ package modeling
 const (
 	PositionAttribute  = "Position"
 	NormalAttribute    = "Normal"
+	TangentAttribute   = "Tangent"
 	ColorAttribute     = "Color"
 	TexCoordAttribute  = "TexCoord"
 	ClassAttribute     = "Class"
 	IntensityAttribute = "Intensity"
+	GPSTimeAttribute   = "GPSTime"
 	JointAttribute     = "Joint"
 	WeightAttribute    = "Weight"
 	ScaleAttribute     = "Scale"
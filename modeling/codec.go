@@ -0,0 +1,189 @@
+package modeling
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/EliCDavis/bitlib"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+func init() {
+	nodes.RegisterOutputCodec[Mesh](meshCodec{})
+}
+
+// Bumped whenever the layout written by meshCodec changes, so entries
+// written by older versions are rejected rather than misread
+const meshCodecVersion = 1
+
+// meshCodec losslessly serializes meshes, including every attribute, for
+// the persistent node output cache
+type meshCodec struct{}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeAttributes[T any](writer *bitlib.Writer, data map[string][]T, write func(T)) {
+	writer.UInt64(uint64(len(data)))
+	for _, name := range sortedKeys(data) {
+		writer.UInt64(uint64(len(name)))
+		writer.Write([]byte(name))
+
+		values := data[name]
+		writer.UInt64(uint64(len(values)))
+		for _, v := range values {
+			write(v)
+		}
+	}
+}
+
+// readLength reads a length prefix, rejecting lengths that claim more
+// elements of the given size than the entry has bytes left to hold, so a
+// corrupt entry can't request an arbitrarily large allocation
+func readLength(reader *bitlib.Reader, remaining *bytes.Reader, elementSize int) (int, error) {
+	length := reader.UInt64()
+	if err := reader.Error(); err != nil {
+		return 0, err
+	}
+
+	if length > uint64(remaining.Len()/elementSize) {
+		return 0, fmt.Errorf("length %d exceeds the %d bytes remaining in the entry", length, remaining.Len())
+	}
+	return int(length), nil
+}
+
+func readAttributes[T any](reader *bitlib.Reader, remaining *bytes.Reader, elementSize int, read func() T) (map[string][]T, error) {
+	// Every attribute takes at least the 16 bytes of its two length prefixes
+	count, err := readLength(reader, remaining, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string][]T, count)
+	for range count {
+		nameLength, err := readLength(reader, remaining, 1)
+		if err != nil {
+			return nil, err
+		}
+		name := reader.String(nameLength)
+
+		valueCount, err := readLength(reader, remaining, elementSize)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]T, valueCount)
+		for i := range values {
+			values[i] = read()
+		}
+		data[name] = values
+	}
+	return data, reader.Error()
+}
+
+func (meshCodec) Encode(out io.Writer, m Mesh) error {
+	buf := bufio.NewWriter(out)
+	writer := bitlib.NewWriter(buf, binary.LittleEndian)
+
+	writer.Int32(meshCodecVersion)
+	writer.Int32(int32(m.topology))
+
+	writer.UInt64(uint64(len(m.indices)))
+	for _, index := range m.indices {
+		writer.Int64(int64(index))
+	}
+
+	writeAttributes(writer, m.v1Data, func(v float64) {
+		writer.Float64(v)
+	})
+	writeAttributes(writer, m.v2Data, func(v vector2.Float64) {
+		writer.Float64(v.X())
+		writer.Float64(v.Y())
+	})
+	writeAttributes(writer, m.v3Data, func(v vector3.Float64) {
+		writer.Float64(v.X())
+		writer.Float64(v.Y())
+		writer.Float64(v.Z())
+	})
+	writeAttributes(writer, m.v4Data, func(v vector4.Float64) {
+		writer.Float64(v.X())
+		writer.Float64(v.Y())
+		writer.Float64(v.Z())
+		writer.Float64(v.W())
+	})
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// Decode reads the entire entry up front, which lets every length within it
+// be checked against the bytes actually available before allocating
+func (meshCodec) Decode(in io.Reader) (Mesh, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+	remaining := bytes.NewReader(data)
+	reader := bitlib.NewReader(remaining, binary.LittleEndian)
+
+	if version := reader.Int32(); reader.Error() == nil && version != meshCodecVersion {
+		return EmptyMesh(TriangleTopology), fmt.Errorf("unsupported mesh codec version %d", version)
+	}
+
+	topology := Topology(reader.Int32())
+
+	indexCount, err := readLength(reader, remaining, 8)
+	if err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+	indices := make([]int, indexCount)
+	for i := range indices {
+		indices[i] = int(reader.Int64())
+	}
+
+	m := Mesh{
+		topology: topology,
+		indices:  indices,
+	}
+	if m.v1Data, err = readAttributes(reader, remaining, 8, reader.Float64); err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+	m.v2Data, err = readAttributes(reader, remaining, 16, func() vector2.Float64 {
+		return vector2.New(reader.Float64(), reader.Float64())
+	})
+	if err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+	m.v3Data, err = readAttributes(reader, remaining, 24, func() vector3.Float64 {
+		return vector3.New(reader.Float64(), reader.Float64(), reader.Float64())
+	})
+	if err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+	m.v4Data, err = readAttributes(reader, remaining, 32, func() vector4.Float64 {
+		return vector4.New(reader.Float64(), reader.Float64(), reader.Float64(), reader.Float64())
+	})
+	if err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+
+	if err := reader.Error(); err != nil {
+		return EmptyMesh(TriangleTopology), err
+	}
+	return m, nil
+}
@@ -0,0 +1,68 @@
+package modeling_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeshCodec_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	codec, ok := nodes.LookupOutputCodec[modeling.Mesh]()
+	require.True(t, ok)
+
+	m := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat1Attribute("weight", []float64{0.1, 0.2, 0.3}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.), vector2.New(1., 0.), vector2.New(0., 1.),
+		}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.), vector3.New(1., 0., 0.), vector3.New(0., 1., 0.),
+		}).
+		SetFloat4Attribute(modeling.ColorAttribute, []vector4.Float64{
+			vector4.New(1., 0., 0., 1.), vector4.New(0., 1., 0., 1.), vector4.New(0., 0., 1., 1.),
+		})
+
+	// ACT ====================================================================
+	buf := &bytes.Buffer{}
+	require.NoError(t, codec.Encode(buf, m))
+	decoded, err := codec.Decode(buf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, m, decoded)
+}
+
+func TestMeshCodec_Truncated(t *testing.T) {
+	codec, ok := nodes.LookupOutputCodec[modeling.Mesh]()
+	require.True(t, ok)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, codec.Encode(buf, modeling.NewTriangleMesh([]int{0, 1, 2})))
+
+	_, err := codec.Decode(bytes.NewReader(buf.Bytes()[:10]))
+	assert.Error(t, err)
+}
+
+func TestMeshCodec_CorruptLength(t *testing.T) {
+	codec, ok := nodes.LookupOutputCodec[modeling.Mesh]()
+	require.True(t, ok)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, codec.Encode(buf, modeling.NewTriangleMesh([]int{0, 1, 2})))
+
+	// Claim far more indices than the entry could ever hold
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint64(data[8:], 1<<62)
+
+	_, err := codec.Decode(bytes.NewReader(data))
+	assert.EqualError(t, err, "length 4611686018427387904 exceeds the 56 bytes remaining in the entry")
+}
@@ -270,6 +270,10 @@ type CircleNode struct {
 	Path       nodes.Output[[]vector3.Float64]
 }
 
+func (pnd CircleNode) CacheVersion() int {
+	return 1
+}
+
 func (pnd CircleNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if pnd.Path == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -296,6 +300,10 @@ type CircleAlongSplineNode struct {
 	UVs              nodes.Output[primitives.StripUVs]
 }
 
+func (pnd CircleAlongSplineNode) CacheVersion() int {
+	return 1
+}
+
 func (pnd CircleAlongSplineNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if pnd.Spline == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
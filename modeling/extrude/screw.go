@@ -19,6 +19,10 @@ type ScrewNode struct {
 	UVs         nodes.Output[primitives.StripUVs]
 }
 
+func (snd ScrewNode) CacheVersion() int {
+	return 1
+}
+
 func (snd ScrewNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
 	if snd.Line == nil {
@@ -0,0 +1,31 @@
+package halfedge
+
+// NonManifoldReport lists the parts of the mesh that prevent it from being a
+// 2-manifold surface
+type NonManifoldReport struct {
+	// Edges shared by more than two faces, shared by faces with inconsistent
+	// winding, or collapsed down to a single vertex
+	Edges [][2]int
+
+	// Vertices where multiple fans of faces touch at a single point
+	Vertices []int
+}
+
+// IsManifold determines whether or not the report found no issues
+func (r NonManifoldReport) IsManifold() bool {
+	return len(r.Edges) == 0 && len(r.Vertices) == 0
+}
+
+// NonManifold reports all non-manifold edges and vertices within the mesh
+func (m Mesh) NonManifold() NonManifoldReport {
+	return NonManifoldReport{
+		Edges:    append([][2]int(nil), m.nonManifoldEdges...),
+		Vertices: append([]int(nil), m.nonManifoldVertices...),
+	}
+}
+
+// IsManifold determines whether or not every edge and vertex of the mesh is
+// manifold
+func (m Mesh) IsManifold() bool {
+	return len(m.nonManifoldEdges) == 0 && len(m.nonManifoldVertices) == 0
+}
@@ -0,0 +1,248 @@
+package halfedge
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+var (
+	ErrUnsupportedTopology = errors.New("half edge meshes can only be built from triangle or quad topology")
+	ErrMissingPositions    = fmt.Errorf("half edge meshes require the vector3 attribute: '%s'", modeling.PositionAttribute)
+)
+
+// HalfEdge is one directed side of a face, running from its Origin vertex to
+// the Origin of the Next half edge within the same face.
+type HalfEdge struct {
+	// Topological vertex the half edge starts at
+	Origin int
+
+	// Index into the original mesh's attribute data used by the face at this
+	// half edge's origin. Vertices split along UV or normal seams share a
+	// topological vertex while keeping their own attributes.
+	Attribute int
+
+	// Face the half edge belongs to
+	Face int
+
+	// Next and Prev half edges walking around the face
+	Next int
+	Prev int
+
+	// Half edge running in the opposite direction along the same edge on the
+	// neighboring face. -1 if the edge lies on a boundary.
+	Twin int
+}
+
+// Face references one of the half edges that make up its loop
+type Face struct {
+	HalfEdge int
+}
+
+// Vertex is a unique position shared by one or more faces
+type Vertex struct {
+	Position vector3.Float64
+
+	// One of the half edges starting from this vertex. For boundary vertices
+	// this is always the outgoing half edge that lies on the boundary, so
+	// walking the one-ring from it visits every face of the fan. -1 if the
+	// vertex isn't used by any face.
+	HalfEdge int
+}
+
+// Mesh is a half edge representation of a triangle or quad modeling.Mesh,
+// providing adjacency queries that an indexed mesh can't answer directly.
+// Vertices are welded by position, so faces on opposite sides of an
+// attribute seam are still considered neighbors.
+type Mesh struct {
+	source    modeling.Mesh
+	vertices  []Vertex
+	faces     []Face
+	halfEdges []HalfEdge
+
+	nonManifoldEdges    [][2]int
+	nonManifoldVertices []int
+}
+
+// FromMesh builds the half edge structure of a triangle or quad mesh
+func FromMesh(m modeling.Mesh) (*Mesh, error) {
+	topo := m.Topology()
+	if topo != modeling.TriangleTopology && topo != modeling.QuadTopology {
+		return nil, ErrUnsupportedTopology
+	}
+
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return nil, ErrMissingPositions
+	}
+
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	attributeToVertex := make([]int, positions.Len())
+	vertexLookup := make(map[vector3.Float64]int)
+	vertices := make([]Vertex, 0)
+	for i := range positions.Len() {
+		p := positions.At(i)
+		v, ok := vertexLookup[p]
+		if !ok {
+			v = len(vertices)
+			vertexLookup[p] = v
+			vertices = append(vertices, Vertex{Position: p, HalfEdge: -1})
+		}
+		attributeToVertex[i] = v
+	}
+
+	mesh := &Mesh{
+		source:    m,
+		vertices:  vertices,
+		faces:     make([]Face, 0),
+		halfEdges: make([]HalfEdge, 0),
+	}
+
+	type directedEdge struct{ from, to int }
+	directed := make(map[directedEdge]int)
+	nonManifold := make(map[[2]int]struct{})
+	flagEdge := func(a, b int) {
+		if a > b {
+			a, b = b, a
+		}
+		nonManifold[[2]int{a, b}] = struct{}{}
+	}
+
+	indices := m.Indices()
+	size := topo.IndexSize()
+	for start := 0; start+size <= indices.Len(); start += size {
+		faceIndex := len(mesh.faces)
+		firstEdge := len(mesh.halfEdges)
+		mesh.faces = append(mesh.faces, Face{HalfEdge: firstEdge})
+
+		for c := range size {
+			attr := indices.At(start + c)
+			mesh.halfEdges = append(mesh.halfEdges, HalfEdge{
+				Origin:    attributeToVertex[attr],
+				Attribute: attr,
+				Face:      faceIndex,
+				Next:      firstEdge + ((c + 1) % size),
+				Prev:      firstEdge + ((c + size - 1) % size),
+				Twin:      -1,
+			})
+		}
+
+		for c := range size {
+			he := firstEdge + c
+			from := mesh.halfEdges[he].Origin
+			to := mesh.halfEdges[mesh.halfEdges[he].Next].Origin
+
+			if mesh.vertices[from].HalfEdge == -1 {
+				mesh.vertices[from].HalfEdge = he
+			}
+
+			if from == to {
+				flagEdge(from, to)
+				continue
+			}
+
+			key := directedEdge{from, to}
+			if _, ok := directed[key]; ok {
+				// Two faces running the same direction along an edge means
+				// either more than two faces share it or the winding flips
+				flagEdge(from, to)
+				continue
+			}
+			directed[key] = he
+
+			twin, ok := directed[directedEdge{to, from}]
+			if !ok {
+				continue
+			}
+
+			if mesh.halfEdges[twin].Twin != -1 {
+				flagEdge(from, to)
+				continue
+			}
+
+			mesh.halfEdges[twin].Twin = he
+			mesh.halfEdges[he].Twin = twin
+		}
+	}
+
+	for e := range nonManifold {
+		mesh.nonManifoldEdges = append(mesh.nonManifoldEdges, e)
+	}
+	sort.Slice(mesh.nonManifoldEdges, func(i, j int) bool {
+		a, b := mesh.nonManifoldEdges[i], mesh.nonManifoldEdges[j]
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] < b[1]
+	})
+
+	// Point boundary vertices at their boundary half edge so one-ring walks
+	// start at the beginning of the fan
+	for he, e := range mesh.halfEdges {
+		if e.Twin == -1 {
+			mesh.vertices[e.Origin].HalfEdge = he
+		}
+	}
+
+	// A vertex whose faces can't all be reached walking its one-ring is
+	// pinched between multiple fans
+	outgoing := make([]int, len(mesh.vertices))
+	for _, e := range mesh.halfEdges {
+		outgoing[e.Origin]++
+	}
+	for v := range mesh.vertices {
+		if outgoing[v] != len(mesh.VertexOutgoing(v)) {
+			mesh.nonManifoldVertices = append(mesh.nonManifoldVertices, v)
+		}
+	}
+
+	return mesh, nil
+}
+
+// Topology of the faces within the mesh
+func (m Mesh) Topology() modeling.Topology {
+	return m.source.Topology()
+}
+
+func (m Mesh) VertexCount() int {
+	return len(m.vertices)
+}
+
+func (m Mesh) FaceCount() int {
+	return len(m.faces)
+}
+
+func (m Mesh) HalfEdgeCount() int {
+	return len(m.halfEdges)
+}
+
+func (m Mesh) Vertex(i int) Vertex {
+	return m.vertices[i]
+}
+
+func (m Mesh) Face(i int) Face {
+	return m.faces[i]
+}
+
+func (m Mesh) HalfEdge(i int) HalfEdge {
+	return m.halfEdges[i]
+}
+
+// Destination returns the vertex the half edge points to
+func (m Mesh) Destination(he int) int {
+	return m.halfEdges[m.halfEdges[he].Next].Origin
+}
+
+// ToMesh converts the half edge structure back into an indexed mesh, retaining
+// all attribute data of the mesh it was built from
+func (m Mesh) ToMesh() modeling.Mesh {
+	indices := make([]int, 0, len(m.halfEdges))
+	for f := range m.faces {
+		for _, he := range m.FaceHalfEdges(f) {
+			indices = append(indices, m.halfEdges[he].Attribute)
+		}
+	}
+	return m.source.SetIndices(indices)
+}
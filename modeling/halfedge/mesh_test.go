@@ -0,0 +1,186 @@
+package halfedge_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/halfedge"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 3x3 grid of quads on the XY plane
+func quadGrid() modeling.Mesh {
+	positions := make([]vector3.Float64, 0)
+	for y := 0; y <= 3; y++ {
+		for x := 0; x <= 3; x++ {
+			positions = append(positions, vector3.New(float64(x), float64(y), 0))
+		}
+	}
+
+	indices := make([]int, 0)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			bl := y*4 + x
+			indices = append(indices, bl, bl+1, bl+5, bl+4)
+		}
+	}
+
+	return modeling.NewMesh(modeling.QuadTopology, indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions)
+}
+
+func TestFromMesh_RequiresTopology(t *testing.T) {
+	_, err := halfedge.FromMesh(modeling.EmptyPointcloud())
+	assert.ErrorIs(t, err, halfedge.ErrUnsupportedTopology)
+
+	_, err = halfedge.FromMesh(modeling.NewTriangleMesh([]int{0, 1, 2}))
+	assert.ErrorIs(t, err, halfedge.ErrMissingPositions)
+}
+
+func TestFromMesh_ClosedCube(t *testing.T) {
+	mesh, err := halfedge.FromMesh(primitives.UnitCube())
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, mesh.VertexCount())
+	assert.Equal(t, 12, mesh.FaceCount())
+	assert.Len(t, mesh.Edges(), 18)
+	assert.True(t, mesh.IsClosed())
+	assert.True(t, mesh.IsManifold())
+	assert.Empty(t, mesh.BoundaryLoops())
+
+	for f := range mesh.FaceCount() {
+		assert.Len(t, mesh.FaceNeighbors(f), 3)
+		assert.False(t, mesh.IsBoundaryFace(f))
+	}
+
+	totalValence := 0
+	for v := range mesh.VertexCount() {
+		assert.False(t, mesh.IsBoundaryVertex(v))
+		assert.Len(t, mesh.VertexFaces(v), mesh.Valence(v))
+		totalValence += mesh.Valence(v)
+	}
+	assert.Equal(t, 36, totalValence)
+}
+
+func TestFromMesh_QuadGrid(t *testing.T) {
+	mesh, err := halfedge.FromMesh(quadGrid())
+	require.NoError(t, err)
+
+	assert.Equal(t, 16, mesh.VertexCount())
+	assert.Equal(t, 9, mesh.FaceCount())
+	assert.Len(t, mesh.Edges(), 24)
+	assert.False(t, mesh.IsClosed())
+	assert.True(t, mesh.IsManifold())
+
+	// Center face is surrounded on all sides
+	assert.Len(t, mesh.FaceNeighbors(4), 4)
+	assert.False(t, mesh.IsBoundaryFace(4))
+	assert.Len(t, mesh.FaceNeighbors(0), 2)
+
+	// Corner, edge, and interior vertices
+	assert.Equal(t, 2, mesh.Valence(0))
+	assert.Equal(t, 3, mesh.Valence(1))
+	assert.Equal(t, 4, mesh.Valence(5))
+	assert.ElementsMatch(t, []int{1, 4, 6, 9}, mesh.VertexOneRing(5))
+	assert.ElementsMatch(t, []int{0, 2, 5}, mesh.VertexOneRing(1))
+	assert.True(t, mesh.IsBoundaryVertex(1))
+	assert.False(t, mesh.IsBoundaryVertex(5))
+
+	loops := mesh.BoundaryLoops()
+	require.Len(t, loops, 1)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 7, 11, 15, 14, 13, 12, 8, 4}, loops[0])
+
+	// Edge running from vertex 4 to 5 continues straight across the grid
+	var start int
+	for _, he := range mesh.VertexOutgoing(4) {
+		if mesh.Destination(he) == 5 {
+			start = he
+		}
+	}
+	loop := mesh.EdgeLoop(start)
+	require.Len(t, loop, 3)
+	assert.Equal(t, 6, mesh.Destination(loop[1]))
+	assert.Equal(t, 7, mesh.Destination(loop[2]))
+}
+
+func TestFromMesh_NonManifoldEdge(t *testing.T) {
+	// Three triangles sharing the edge between vertices 0 and 1
+	m := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		1, 0, 3,
+		0, 1, 4,
+	}).SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 0., 0.),
+		vector3.New(0., 1., 0.),
+		vector3.New(0., -1., 0.),
+		vector3.New(0., 0., 1.),
+	})
+
+	mesh, err := halfedge.FromMesh(m)
+	require.NoError(t, err)
+
+	report := mesh.NonManifold()
+	assert.False(t, report.IsManifold())
+	assert.Equal(t, [][2]int{{0, 1}}, report.Edges)
+}
+
+func TestFromMesh_NonManifoldVertex(t *testing.T) {
+	// Two triangles touching at the tip, forming a bow tie
+	m := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		0, 3, 4,
+	}).SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 1., 0.),
+		vector3.New(-1., 1., 0.),
+		vector3.New(-1., -1., 0.),
+		vector3.New(1., -1., 0.),
+	})
+
+	mesh, err := halfedge.FromMesh(m)
+	require.NoError(t, err)
+
+	report := mesh.NonManifold()
+	assert.Empty(t, report.Edges)
+	assert.Equal(t, []int{0}, report.Vertices)
+	assert.False(t, mesh.IsManifold())
+}
+
+func TestToMesh_RetainsAttributes(t *testing.T) {
+	// Two triangles sharing an edge, with a UV seam running along it
+	m := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		3, 4, 5,
+	}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(0., 1., 0.),
+		}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.),
+			vector2.New(1., 0.),
+			vector2.New(0., 1.),
+			vector2.New(0.5, 0.),
+			vector2.New(1., 1.),
+			vector2.New(0.5, 1.),
+		})
+
+	mesh, err := halfedge.FromMesh(m)
+	require.NoError(t, err)
+
+	// Welded across the seam
+	assert.Equal(t, 4, mesh.VertexCount())
+	assert.Equal(t, []int{0}, mesh.FaceNeighbors(1))
+	assert.Equal(t, []int{1}, mesh.FaceNeighbors(0))
+
+	assert.Equal(t, m, mesh.ToMesh())
+}
@@ -0,0 +1,220 @@
+package halfedge
+
+// Edge is an undirected edge of the mesh, referenced by one of its half edges
+type Edge struct {
+	HalfEdge int
+	A, B     int
+}
+
+// FaceHalfEdges returns the half edges making up the face, in winding order
+func (m Mesh) FaceHalfEdges(f int) []int {
+	start := m.faces[f].HalfEdge
+	out := []int{start}
+	for he := m.halfEdges[start].Next; he != start; he = m.halfEdges[he].Next {
+		out = append(out, he)
+	}
+	return out
+}
+
+// FaceVertices returns the topological vertices of the face, in winding order
+func (m Mesh) FaceVertices(f int) []int {
+	halfEdges := m.FaceHalfEdges(f)
+	out := make([]int, len(halfEdges))
+	for i, he := range halfEdges {
+		out[i] = m.halfEdges[he].Origin
+	}
+	return out
+}
+
+// FaceNeighbors returns the faces sharing an edge with the face
+func (m Mesh) FaceNeighbors(f int) []int {
+	out := make([]int, 0)
+	for _, he := range m.FaceHalfEdges(f) {
+		twin := m.halfEdges[he].Twin
+		if twin == -1 {
+			continue
+		}
+		out = append(out, m.halfEdges[twin].Face)
+	}
+	return out
+}
+
+// VertexOutgoing returns the half edges starting at the vertex, in the order
+// encountered walking around its one-ring. For non-manifold vertices only
+// the half edges of a single fan are returned.
+func (m Mesh) VertexOutgoing(v int) []int {
+	start := m.vertices[v].HalfEdge
+	if start == -1 {
+		return nil
+	}
+
+	out := make([]int, 0)
+	he := start
+	for range len(m.halfEdges) {
+		out = append(out, he)
+		he = m.halfEdges[m.halfEdges[he].Prev].Twin
+		if he == -1 || he == start {
+			break
+		}
+	}
+	return out
+}
+
+// VertexOneRing returns the vertices directly connected to the vertex by an
+// edge, in the order encountered walking around it
+func (m Mesh) VertexOneRing(v int) []int {
+	outgoing := m.VertexOutgoing(v)
+	out := make([]int, 0, len(outgoing)+1)
+	for _, he := range outgoing {
+		out = append(out, m.Destination(he))
+	}
+
+	// Boundary vertices have one more neighbor than outgoing half edges,
+	// found across the incoming boundary edge of the final face
+	if len(outgoing) > 0 {
+		last := m.halfEdges[outgoing[len(outgoing)-1]].Prev
+		if m.halfEdges[last].Twin == -1 {
+			out = append(out, m.halfEdges[last].Origin)
+		}
+	}
+	return out
+}
+
+// VertexFaces returns the faces that make use of the vertex
+func (m Mesh) VertexFaces(v int) []int {
+	outgoing := m.VertexOutgoing(v)
+	out := make([]int, len(outgoing))
+	for i, he := range outgoing {
+		out[i] = m.halfEdges[he].Face
+	}
+	return out
+}
+
+// Valence is the number of edges connected to the vertex
+func (m Mesh) Valence(v int) int {
+	return len(m.VertexOneRing(v))
+}
+
+// Edges returns every unique undirected edge within the mesh
+func (m Mesh) Edges() []Edge {
+	out := make([]Edge, 0, len(m.halfEdges)/2)
+	for he, e := range m.halfEdges {
+		if e.Twin != -1 && e.Twin < he {
+			continue
+		}
+		out = append(out, Edge{
+			HalfEdge: he,
+			A:        e.Origin,
+			B:        m.Destination(he),
+		})
+	}
+	return out
+}
+
+// IsBoundaryEdge determines whether or not the half edge has no face on its
+// opposite side
+func (m Mesh) IsBoundaryEdge(he int) bool {
+	return m.halfEdges[he].Twin == -1
+}
+
+// IsBoundaryVertex determines whether or not the vertex lies on a boundary
+// edge
+func (m Mesh) IsBoundaryVertex(v int) bool {
+	he := m.vertices[v].HalfEdge
+	return he != -1 && m.halfEdges[he].Twin == -1
+}
+
+// IsBoundaryFace determines whether or not any of the face's edges lie on a
+// boundary
+func (m Mesh) IsBoundaryFace(f int) bool {
+	for _, he := range m.FaceHalfEdges(f) {
+		if m.halfEdges[he].Twin == -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClosed determines whether or not the mesh has no boundaries
+func (m Mesh) IsClosed() bool {
+	for _, e := range m.halfEdges {
+		if e.Twin == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// BoundaryLoops returns the vertices of every boundary within the mesh, in
+// order. Loops follow the winding of the faces along them.
+func (m Mesh) BoundaryLoops() [][]int {
+	visited := make([]bool, len(m.halfEdges))
+	loops := make([][]int, 0)
+
+	for start, e := range m.halfEdges {
+		if e.Twin != -1 || visited[start] {
+			continue
+		}
+
+		loop := make([]int, 0)
+		he := start
+		for range len(m.halfEdges) {
+			visited[he] = true
+			loop = append(loop, m.Destination(he))
+
+			next := m.nextBoundaryHalfEdge(he)
+			if next == -1 || next == start || visited[next] {
+				break
+			}
+			he = next
+		}
+		loops = append(loops, loop)
+	}
+
+	return loops
+}
+
+// nextBoundaryHalfEdge finds the boundary half edge leaving from the
+// destination of the provided boundary half edge. -1 if the destination is
+// pinched between multiple boundaries and the walk can't continue
+func (m Mesh) nextBoundaryHalfEdge(he int) int {
+	dest := m.Destination(he)
+	candidate := m.halfEdges[he].Next
+	for range len(m.halfEdges) {
+		if m.halfEdges[candidate].Twin == -1 {
+			return candidate
+		}
+		candidate = m.halfEdges[m.halfEdges[candidate].Twin].Next
+		if m.halfEdges[candidate].Origin != dest {
+			return -1
+		}
+	}
+	return -1
+}
+
+// EdgeLoop walks straight across a quad mesh starting at the half edge,
+// leaving each vertex through the edge opposite of the one it arrived from.
+// The walk stops at boundaries, at vertices that don't have exactly four
+// edges, or once it arrives back where it started. Returns the half edges
+// traversed.
+func (m Mesh) EdgeLoop(start int) []int {
+	loop := []int{start}
+
+	for he := start; ; {
+		dest := m.Destination(he)
+		if m.IsBoundaryVertex(dest) || m.Valence(dest) != 4 {
+			return loop
+		}
+
+		twin := m.halfEdges[m.halfEdges[he].Next].Twin
+		if twin == -1 {
+			return loop
+		}
+
+		he = m.halfEdges[twin].Next
+		if he == start || len(loop) > len(m.halfEdges) {
+			return loop
+		}
+		loop = append(loop, he)
+	}
+}
@@ -0,0 +1,431 @@
+package hull
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+type DecompositionTransformer struct {
+	// Number of voxels along the longest side of the mesh.
+	Resolution int
+
+	// Most hulls to produce.
+	MaxHulls int
+
+	// How much empty space a hull may enclose beyond the part of the mesh
+	// it's wrapping before being split further, as a fraction of the mesh's
+	// volume. Zero splits parts until they're entirely convex.
+	MaxConcavity float64
+
+	// Most times the mesh can be recursively split in two. Zero keeps the
+	// mesh whole.
+	MaxDepth int
+}
+
+// DefaultDecompositionTransformer voxelizes the mesh at a resolution of 32,
+// splitting it at most 6 times into no more than 16 hulls, each enclosing up
+// to 1% of the mesh's volume in empty space.
+func DefaultDecompositionTransformer() DecompositionTransformer {
+	return DecompositionTransformer{
+		Resolution:   32,
+		MaxHulls:     16,
+		MaxConcavity: 0.01,
+		MaxDepth:     6,
+	}
+}
+
+func (dt DecompositionTransformer) validate() error {
+	if dt.Resolution < 2 {
+		return fmt.Errorf("resolution must be at least 2, received %d", dt.Resolution)
+	}
+	if dt.MaxHulls < 1 {
+		return fmt.Errorf("max hulls must be at least 1, received %d", dt.MaxHulls)
+	}
+	if dt.MaxConcavity < 0 {
+		return fmt.Errorf("max concavity can not be negative, received %g", dt.MaxConcavity)
+	}
+	if dt.MaxDepth < 0 {
+		return fmt.Errorf("max depth can not be negative, received %d", dt.MaxDepth)
+	}
+	return nil
+}
+
+// Decompose approximates the closed triangle mesh with a set of convex hulls
+// in the spirit of V-HACD. The inside of the mesh is voxelized, then split
+// recursively along whichever axis aligned plane leaves the least empty
+// space within the hulls of the two halves, until every part is close enough
+// to convex. Parts are then merged back together, cheapest first, until no
+// more than MaxHulls remain.
+//
+// Hulls are built from the voxels, so they can extend past the mesh by up to
+// a voxel. Meshes that enclose no voxels, such as open or flat meshes, are
+// approximated with a single convex hull of all of their positions.
+func (dt DecompositionTransformer) Decompose(m modeling.Mesh) ([]modeling.Mesh, error) {
+	if err := meshops.RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return nil, err
+	}
+
+	if err := meshops.RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return nil, err
+	}
+
+	if err := dt.validate(); err != nil {
+		return nil, err
+	}
+
+	if m.PrimitiveCount() == 0 {
+		return nil, nil
+	}
+
+	grid := voxelizeSolid(m, dt.Resolution)
+	if len(grid.voxels) == 0 {
+		whole := ConvexHull(m)
+		if whole.PrimitiveCount() == 0 {
+			return nil, nil
+		}
+		return []modeling.Mesh{whole}, nil
+	}
+
+	totalVolume := float64(len(grid.voxels)) * grid.voxelVolume()
+	maxConcavity := dt.MaxConcavity * totalVolume
+
+	parts := make([]*part, 0)
+	var split func(voxels []vector3.Int, depth int)
+	split = func(voxels []vector3.Int, depth int) {
+		p := grid.newPart(voxels)
+		if depth >= dt.MaxDepth || len(voxels) < 2 || p.concavity() <= maxConcavity {
+			parts = append(parts, p)
+			return
+		}
+
+		left, right, ok := grid.bestSplit(voxels)
+		if !ok {
+			parts = append(parts, p)
+			return
+		}
+		split(left, depth+1)
+		split(right, depth+1)
+	}
+	split(grid.voxels, 0)
+
+	parts = merge(parts, dt.MaxHulls)
+
+	hulls := make([]modeling.Mesh, 0, len(parts))
+	for _, p := range parts {
+		if p.hull.PrimitiveCount() > 0 {
+			hulls = append(hulls, p.hull)
+		}
+	}
+	return hulls, nil
+}
+
+// ConvexDecomposition approximates the mesh with a set of convex hulls. See
+// DecompositionTransformer.Decompose for details.
+func ConvexDecomposition(m modeling.Mesh, maxHulls int) []modeling.Mesh {
+	hulls, err := DecompositionTransformer{MaxHulls: maxHulls}.Decompose(m)
+	check(err)
+	return hulls
+}
+
+// ============================================================================
+
+type voxelGrid struct {
+	origin vector3.Float64
+	size   float64
+	voxels []vector3.Int
+}
+
+func (g voxelGrid) voxelVolume() float64 {
+	return g.size * g.size * g.size
+}
+
+func (g voxelGrid) corner(v vector3.Int) vector3.Float64 {
+	return g.origin.Add(v.ToFloat64().Scale(g.size))
+}
+
+// voxelizeSolid finds every voxel whose center lies within the mesh by
+// casting a ray along X through each row of voxels and filling between pairs
+// of crossings
+func voxelizeSolid(m modeling.Mesh, resolution int) voxelGrid {
+	bounds := m.BoundingBox(modeling.PositionAttribute)
+	size := bounds.Size().MaxComponent() / float64(resolution)
+	if size <= 0 || math.IsNaN(size) {
+		return voxelGrid{}
+	}
+
+	counts := bounds.Size().DivByConstant(size).CeilToInt().Add(vector3.One[int]())
+	grid := voxelGrid{origin: bounds.Min(), size: size}
+
+	type triangle struct{ a, b, c vector3.Float64 }
+	rows := make(map[[2]int][]triangle)
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	indices := m.Indices()
+	for i := 0; i+3 <= indices.Len(); i += 3 {
+		tri := triangle{
+			positions.At(indices.At(i)),
+			positions.At(indices.At(i + 1)),
+			positions.At(indices.At(i + 2)),
+		}
+
+		min := vector3.Min(tri.a, vector3.Min(tri.b, tri.c)).Sub(grid.origin).DivByConstant(size)
+		max := vector3.Max(tri.a, vector3.Max(tri.b, tri.c)).Sub(grid.origin).DivByConstant(size)
+		for y := int(math.Floor(min.Y() - 0.5)); y <= int(math.Ceil(max.Y()-0.5)); y++ {
+			for z := int(math.Floor(min.Z() - 0.5)); z <= int(math.Ceil(max.Z()-0.5)); z++ {
+				rows[[2]int{y, z}] = append(rows[[2]int{y, z}], tri)
+			}
+		}
+	}
+
+	// Nudge rays off of voxel centers so they don't run exactly along the
+	// edges shared by neighboring triangles
+	nudgeY := size * 1e-5 * math.Sqrt2
+	nudgeZ := size * 1e-5 * math.Pi
+
+	crossings := make([]float64, 0)
+	for y := range counts.Y() {
+		for z := range counts.Z() {
+			tris := rows[[2]int{y, z}]
+			if len(tris) == 0 {
+				continue
+			}
+
+			py := grid.origin.Y() + (float64(y)+0.5)*size + nudgeY
+			pz := grid.origin.Z() + (float64(z)+0.5)*size + nudgeZ
+
+			crossings = crossings[:0]
+			for _, tri := range tris {
+				if x, ok := rayCrossing(tri.a, tri.b, tri.c, py, pz); ok {
+					crossings = append(crossings, x)
+				}
+			}
+			sort.Float64s(crossings)
+
+			for i := 0; i+1 < len(crossings); i += 2 {
+				start := int(math.Ceil((crossings[i]-grid.origin.X())/size - 0.5))
+				end := int(math.Floor((crossings[i+1]-grid.origin.X())/size - 0.5))
+				for x := max(start, 0); x <= min(end, counts.X()-1); x++ {
+					grid.voxels = append(grid.voxels, vector3.New(x, y, z))
+				}
+			}
+		}
+	}
+
+	return grid
+}
+
+// rayCrossing finds where the line running along X through (y, z) crosses
+// the triangle
+func rayCrossing(a, b, c vector3.Float64, y, z float64) (float64, bool) {
+	edge := func(p, q vector3.Float64) float64 {
+		return (q.Y()-p.Y())*(z-p.Z()) - (q.Z()-p.Z())*(y-p.Y())
+	}
+
+	w0 := edge(b, c)
+	w1 := edge(c, a)
+	w2 := edge(a, b)
+	if !((w0 >= 0 && w1 >= 0 && w2 >= 0) || (w0 <= 0 && w1 <= 0 && w2 <= 0)) {
+		return 0, false
+	}
+
+	total := w0 + w1 + w2
+	if total == 0 {
+		return 0, false
+	}
+	return (w0*a.X() + w1*b.X() + w2*c.X()) / total, true
+}
+
+// part is a group of voxels along with the hull wrapping them
+type part struct {
+	points     []vector3.Float64
+	volume     float64
+	hull       modeling.Mesh
+	hullVolume float64
+}
+
+func (p *part) concavity() float64 {
+	return math.Max(0, p.hullVolume-p.volume)
+}
+
+func newPartFromPoints(points []vector3.Float64, volume float64) *part {
+	hull := Points(points)
+	return &part{
+		points:     points,
+		volume:     volume,
+		hull:       hull,
+		hullVolume: Volume(hull),
+	}
+}
+
+// hullPoints of a group of voxels. The hull of a group of voxels is the same
+// as the hull of the first and last voxel in each row, so only their
+// corners are needed.
+func (g voxelGrid) hullPoints(voxels []vector3.Int) []vector3.Float64 {
+	type extent struct{ min, max int }
+	rows := make(map[[2]int]extent)
+	for _, v := range voxels {
+		key := [2]int{v.Y(), v.Z()}
+		e, ok := rows[key]
+		if !ok {
+			e = extent{v.X(), v.X()}
+		}
+		e.min = min(e.min, v.X())
+		e.max = max(e.max, v.X())
+		rows[key] = e
+	}
+
+	points := make([]vector3.Float64, 0, len(rows)*8)
+	for key, e := range rows {
+		for _, x := range []int{e.min, e.max + 1} {
+			for _, dy := range []int{0, 1} {
+				for _, dz := range []int{0, 1} {
+					points = append(points, g.corner(vector3.New(x, key[0]+dy, key[1]+dz)))
+				}
+			}
+		}
+	}
+	return points
+}
+
+func (g voxelGrid) newPart(voxels []vector3.Int) *part {
+	return newPartFromPoints(g.hullPoints(voxels), float64(len(voxels))*g.voxelVolume())
+}
+
+// Most planes tried along each axis when splitting a part
+const splitCandidates = 8
+
+// bestSplit tries axis aligned planes through the voxels, picking the one
+// leaving the least empty space within the hulls of the two halves
+func (g voxelGrid) bestSplit(voxels []vector3.Int) ([]vector3.Int, []vector3.Int, bool) {
+	lowest := voxels[0]
+	highest := voxels[0]
+	for _, v := range voxels {
+		lowest = vector3.Min(lowest, v)
+		highest = vector3.Max(highest, v)
+	}
+
+	bestCost := math.Inf(1)
+	bestAxis, bestPlane := -1, 0
+	try := func(axis, plane int) {
+		left, right := partition(voxels, axis, plane)
+		if len(left) == 0 || len(right) == 0 {
+			return
+		}
+
+		a, b := g.newPart(left), g.newPart(right)
+		cost := a.concavity() + b.concavity()
+
+		// Prefer planes through the middle when all else is equal
+		balance := math.Abs(float64(len(left)-len(right))) / float64(len(voxels))
+		cost += balance * g.voxelVolume()
+
+		if cost < bestCost {
+			bestCost = cost
+			bestAxis, bestPlane = axis, plane
+		}
+	}
+
+	// Coarse pass over every axis, then refine around the best plane found
+	step := 1
+	for axis := range 3 {
+		lo, hi := axisComponent(lowest, axis), axisComponent(highest, axis)
+		span := hi - lo
+		if span < 1 {
+			continue
+		}
+
+		axisStep := max(1, (span+splitCandidates-1)/splitCandidates)
+		step = max(step, axisStep)
+		for plane := lo + 1; plane <= hi; plane += axisStep {
+			try(axis, plane)
+		}
+	}
+
+	if bestAxis != -1 {
+		axis, center := bestAxis, bestPlane
+		lo, hi := axisComponent(lowest, axis), axisComponent(highest, axis)
+		for plane := max(lo+1, center-step+1); plane <= min(hi, center+step-1); plane++ {
+			try(axis, plane)
+		}
+	}
+
+	if bestAxis == -1 {
+		return nil, nil, false
+	}
+
+	left, right := partition(voxels, bestAxis, bestPlane)
+	return left, right, true
+}
+
+func axisComponent(v vector3.Int, axis int) int {
+	switch axis {
+	case 0:
+		return v.X()
+	case 1:
+		return v.Y()
+	}
+	return v.Z()
+}
+
+func partition(voxels []vector3.Int, axis, plane int) ([]vector3.Int, []vector3.Int) {
+	left := make([]vector3.Int, 0)
+	right := make([]vector3.Int, 0)
+	for _, v := range voxels {
+		if axisComponent(v, axis) < plane {
+			left = append(left, v)
+		} else {
+			right = append(right, v)
+		}
+	}
+	return left, right
+}
+
+// merge combines parts, picking the pair whose combined hull adds the least
+// empty space each time, until at most maxHulls remain
+func merge(parts []*part, maxHulls int) []*part {
+	if len(parts) <= maxHulls {
+		return parts
+	}
+
+	combine := func(a, b *part) *part {
+		points := append(append(make([]vector3.Float64, 0, len(a.points)+len(b.points)), a.points...), b.points...)
+		return newPartFromPoints(points, a.volume+b.volume)
+	}
+
+	// Cost of merging every pair, cached between merges
+	type pair struct{ a, b *part }
+	merged := make(map[pair]*part)
+	cost := func(a, b *part) (*part, float64) {
+		c, ok := merged[pair{a, b}]
+		if !ok {
+			c = combine(a, b)
+			merged[pair{a, b}] = c
+		}
+		return c, c.hullVolume - a.hullVolume - b.hullVolume
+	}
+
+	for len(parts) > maxHulls {
+		bestI, bestJ := -1, -1
+		var best *part
+		bestCost := math.Inf(1)
+		for i := range parts {
+			for j := i + 1; j < len(parts); j++ {
+				c, value := cost(parts[i], parts[j])
+				if value < bestCost {
+					bestCost = value
+					bestI, bestJ = i, j
+					best = c
+				}
+			}
+		}
+
+		parts[bestI] = best
+		parts = append(parts[:bestJ], parts[bestJ+1:]...)
+	}
+
+	return parts
+}
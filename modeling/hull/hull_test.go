@@ -0,0 +1,245 @@
+package hull_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/hull"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertConvexAndClosed checks every edge of the mesh is shared by exactly
+// two faces wound in opposite directions, and no point lies in front of any
+// face
+func assertConvexAndClosed(t *testing.T, m modeling.Mesh, points []vector3.Float64) {
+	t.Helper()
+	require.Positive(t, m.PrimitiveCount())
+
+	type edge struct{ a, b vector3.Float64 }
+	edges := make(map[edge]int)
+	for i := range m.PrimitiveCount() {
+		tri := m.Tri(i)
+		a := tri.P1Vec3Attr(modeling.PositionAttribute)
+		b := tri.P2Vec3Attr(modeling.PositionAttribute)
+		c := tri.P3Vec3Attr(modeling.PositionAttribute)
+		edges[edge{a, b}]++
+		edges[edge{b, c}]++
+		edges[edge{c, a}]++
+
+		normal := b.Sub(a).Cross(c.Sub(a)).Normalized()
+		furthest := math.Inf(-1)
+		for _, p := range points {
+			furthest = math.Max(furthest, normal.Dot(p.Sub(a)))
+		}
+		require.LessOrEqual(t, furthest, 1e-9, "point in front of face %d", i)
+	}
+
+	for e, count := range edges {
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, edges[edge{e.b, e.a}], "edge isn't shared")
+	}
+}
+
+func TestPoints_Cube(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := primitives.UnitCube()
+	points := make([]vector3.Float64, 0)
+	positions := cube.Float3Attribute(modeling.PositionAttribute)
+	for i := range positions.Len() {
+		points = append(points, positions.At(i))
+	}
+
+	// Points inside the cube and on its faces shouldn't change anything
+	points = append(points, vector3.Zero[float64](), vector3.New(0.5, 0., 0.), vector3.New(0.1, 0.2, -0.3))
+
+	// ACT ====================================================================
+	result := hull.Points(points)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 8, result.AttributeLength())
+	assert.Equal(t, 12, result.PrimitiveCount())
+	assert.InDelta(t, 1., hull.Volume(result), 1e-9)
+	assertConvexAndClosed(t, result, points)
+}
+
+func TestPoints_Sphere(t *testing.T) {
+	// ARRANGE ================================================================
+	rng := rand.New(rand.NewSource(42))
+	points := make([]vector3.Float64, 2000)
+	for i := range points {
+		p := vector3.New(rng.NormFloat64(), rng.NormFloat64(), rng.NormFloat64()).Normalized()
+		if i%2 == 1 {
+			// Half the points are inside the sphere
+			p = p.Scale(rng.Float64() * 0.9)
+		}
+		points[i] = p
+	}
+
+	// ACT ====================================================================
+	result := hull.Points(points)
+
+	// ASSERT =================================================================
+	assertConvexAndClosed(t, result, points)
+	assert.Equal(t, 1000, result.AttributeLength(), "every point on the sphere should be on the hull")
+	assert.InDelta(t, 4./3.*math.Pi, hull.Volume(result), 0.1)
+}
+
+func TestPoints_Degenerate(t *testing.T) {
+	tests := map[string][]vector3.Float64{
+		"empty":     nil,
+		"too few":   {vector3.New(0., 0., 0.), vector3.New(1., 0., 0.), vector3.New(0., 1., 0.)},
+		"collinear": {vector3.New(0., 0., 0.), vector3.New(1., 0., 0.), vector3.New(2., 0., 0.), vector3.New(3., 0., 0.)},
+		"coplanar":  {vector3.New(0., 0., 0.), vector3.New(1., 0., 0.), vector3.New(0., 1., 0.), vector3.New(1., 1., 0.)},
+	}
+
+	for name, points := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := hull.Points(points)
+			assert.Equal(t, 0, result.PrimitiveCount())
+			assert.Equal(t, modeling.TriangleTopology, result.Topology())
+		})
+	}
+}
+
+func TestConvexHull_PointCloud(t *testing.T) {
+	cloud := modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {
+				vector3.New(0., 0., 0.),
+				vector3.New(1., 0., 0.),
+				vector3.New(0., 1., 0.),
+				vector3.New(0., 0., 1.),
+			},
+		},
+		nil,
+		nil,
+	)
+
+	result, err := hull.Transformer{}.Transform(cloud)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.PrimitiveCount())
+	assert.InDelta(t, 1./6., hull.Volume(result), 1e-9)
+}
+
+// lShape is three unit cubes arranged in an L
+func lShape() modeling.Mesh {
+	return meshops.Union(
+		meshops.Union(primitives.UnitCube(), primitives.UnitCube().Translate(vector3.New(1., 0., 0.))),
+		primitives.UnitCube().Translate(vector3.New(0., 1., 0.)),
+	)
+}
+
+func TestDecompose(t *testing.T) {
+	tests := map[string]struct {
+		mesh        modeling.Mesh
+		transformer hull.DecompositionTransformer
+		hulls       int
+		volume      float64
+	}{
+		"convex mesh stays whole": {
+			mesh:        primitives.UnitCube(),
+			transformer: hull.DefaultDecompositionTransformer(),
+			hulls:       1,
+			volume:      1,
+		},
+		"l shape is split": {
+			mesh:        lShape(),
+			transformer: hull.DecompositionTransformer{Resolution: 16, MaxHulls: 16, MaxConcavity: 0.01, MaxDepth: 6},
+			hulls:       2,
+			volume:      3,
+		},
+		"l shape is split with no concavity allowed": {
+			mesh:        lShape(),
+			transformer: hull.DecompositionTransformer{Resolution: 16, MaxHulls: 16, MaxConcavity: 0, MaxDepth: 6},
+			hulls:       2,
+			volume:      3,
+		},
+		"limited to a single hull": {
+			mesh:        lShape(),
+			transformer: hull.DecompositionTransformer{Resolution: 16, MaxHulls: 1, MaxConcavity: 0.01, MaxDepth: 6},
+			hulls:       1,
+			volume:      3.5,
+		},
+		"no splitting allowed": {
+			mesh:        lShape(),
+			transformer: hull.DecompositionTransformer{Resolution: 16, MaxHulls: 16, MaxConcavity: 0.01, MaxDepth: 0},
+			hulls:       1,
+			volume:      3.5,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ACT ============================================================
+			hulls, err := tc.transformer.Decompose(tc.mesh)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			require.Len(t, hulls, tc.hulls)
+
+			bounds := tc.mesh.BoundingBox(modeling.PositionAttribute)
+			volume := 0.
+			for _, h := range hulls {
+				volume += hull.Volume(h)
+
+				// Hulls stay within the mesh
+				hullBounds := h.BoundingBox(modeling.PositionAttribute)
+				assert.True(t, bounds.Contains(hullBounds.Min()))
+				assert.True(t, bounds.Contains(hullBounds.Max()))
+			}
+			assert.InDelta(t, tc.volume, volume, 1e-6)
+		})
+	}
+}
+
+func TestDecompose_Errors(t *testing.T) {
+	tests := map[string]struct {
+		mesh        modeling.Mesh
+		transformer hull.DecompositionTransformer
+	}{
+		"points": {
+			mesh: modeling.EmptyMesh(modeling.PointTopology),
+		},
+		"negative hulls": {
+			mesh:        primitives.UnitCube(),
+			transformer: hull.DecompositionTransformer{MaxHulls: -1},
+		},
+		"resolution too small": {
+			mesh:        primitives.UnitCube(),
+			transformer: hull.DecompositionTransformer{Resolution: 1, MaxHulls: 16},
+		},
+		"no hulls": {
+			mesh:        primitives.UnitCube(),
+			transformer: hull.DecompositionTransformer{Resolution: 16},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.transformer.Decompose(tc.mesh)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConvexDecompositionNode(t *testing.T) {
+	node := &nodes.Struct[hull.ConvexDecompositionNode]{
+		Data: hull.ConvexDecompositionNode{
+			Mesh:       nodes.ConstOutput[modeling.Mesh]{Val: lShape()},
+			Resolution: nodes.ConstOutput[int]{Val: 16},
+		},
+	}
+
+	hulls := nodes.GetNodeOutputPort[[]modeling.Mesh](node, "Out").Value()
+
+	assert.Len(t, hulls, 2)
+}
@@ -0,0 +1,75 @@
+package hull
+
+import (
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+
+	refutil.RegisterType[nodes.Struct[ConvexHullNode]](factory)
+	refutil.RegisterType[nodes.Struct[ConvexDecompositionNode]](factory)
+
+	generator.RegisterTypes(factory)
+}
+
+type ConvexHullNode struct {
+	Mesh nodes.Output[modeling.Mesh] `description:"Mesh or point cloud to wrap."`
+}
+
+func (chn ConvexHullNode) Description() string {
+	return "Builds the smallest convex triangle mesh enclosing every position of the mesh."
+}
+
+func (chn ConvexHullNode) CacheVersion() int {
+	return 1
+}
+
+func (chn ConvexHullNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	if chn.Mesh == nil {
+		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
+		return
+	}
+
+	result, err := Transformer{}.Transform(nodes.GetOutputValue(out, chn.Mesh))
+	if err != nil {
+		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
+		out.CaptureError(err)
+		return
+	}
+	out.Set(result)
+}
+
+type ConvexDecompositionNode struct {
+	Mesh         nodes.Output[modeling.Mesh] `description:"Closed triangle mesh to approximate."`
+	MaxHulls     nodes.Output[int]           `description:"Most hulls to produce. Defaults to 16."`
+	Resolution   nodes.Output[int]           `description:"Number of voxels along the longest side of the mesh. Defaults to 32."`
+	MaxConcavity nodes.Output[float64]       `description:"Empty space a hull can enclose before it's split further, as a fraction of the mesh's volume. Defaults to 0.01."`
+	MaxDepth     nodes.Output[int]           `description:"Most times the mesh can be recursively split in two. Defaults to 6."`
+}
+
+func (cdn ConvexDecompositionNode) Description() string {
+	return "Approximates the mesh with a set of convex hulls, such as for use as collision shapes."
+}
+
+func (cdn ConvexDecompositionNode) Out(out *nodes.StructOutput[[]modeling.Mesh]) {
+	if cdn.Mesh == nil {
+		out.Set(nil)
+		return
+	}
+
+	defaults := DefaultDecompositionTransformer()
+	hulls, err := DecompositionTransformer{
+		MaxHulls:     nodes.TryGetOutputValue(out, cdn.MaxHulls, defaults.MaxHulls),
+		Resolution:   nodes.TryGetOutputValue(out, cdn.Resolution, defaults.Resolution),
+		MaxConcavity: nodes.TryGetOutputValue(out, cdn.MaxConcavity, defaults.MaxConcavity),
+		MaxDepth:     nodes.TryGetOutputValue(out, cdn.MaxDepth, defaults.MaxDepth),
+	}.Decompose(nodes.GetOutputValue(out, cdn.Mesh))
+	if err != nil {
+		out.CaptureError(err)
+	}
+	out.Set(hulls)
+}
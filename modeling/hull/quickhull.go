@@ -0,0 +1,338 @@
+package hull
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+type Transformer struct{}
+
+// Transform builds the convex hull of the mesh's positions, regardless of
+// the mesh's topology, so point clouds work just as well as triangle meshes.
+func (t Transformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = meshops.RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return ConvexHull(m), nil
+}
+
+// ConvexHull builds the convex hull of every position within the mesh. See
+// Points for details on the resulting mesh.
+func ConvexHull(m modeling.Mesh) modeling.Mesh {
+	check(meshops.RequireV3Attribute(m, modeling.PositionAttribute))
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	points := make([]vector3.Float64, positions.Len())
+	for i := range points {
+		points[i] = positions.At(i)
+	}
+	return Points(points)
+}
+
+// Points builds the convex hull of the points using the quickhull algorithm.
+// The result is a closed triangle mesh with its faces wound counter clockwise
+// when viewed from outside, containing only positions. Points that are all
+// coplanar, or too few to enclose any volume, produce an empty mesh.
+func Points(points []vector3.Float64) modeling.Mesh {
+	qh := newQuickhull(points)
+	if qh == nil {
+		return modeling.EmptyMesh(modeling.TriangleTopology)
+	}
+	qh.build()
+	return qh.mesh()
+}
+
+func check(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+type hullFace struct {
+	vertices [3]int
+	normal   vector3.Float64
+	offset   float64
+
+	// Points yet to be processed that lie in front of this face
+	outside []int
+	removed bool
+}
+
+func (f hullFace) distance(p vector3.Float64) float64 {
+	return f.normal.Dot(p) - f.offset
+}
+
+type hullEdge struct{ a, b int }
+
+type quickhull struct {
+	points  []vector3.Float64
+	epsilon float64
+	faces   []*hullFace
+
+	// Face containing each directed edge
+	edges map[hullEdge]int
+}
+
+func newQuickhull(input []vector3.Float64) *quickhull {
+	// Duplicate points only slow things down
+	seen := make(map[vector3.Float64]struct{}, len(input))
+	points := make([]vector3.Float64, 0, len(input))
+	for _, p := range input {
+		if p.ContainsNaN() {
+			continue
+		}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		points = append(points, p)
+	}
+
+	if len(points) < 4 {
+		return nil
+	}
+
+	// Tolerance relative to the magnitude of the coordinates involved
+	scale := 0.
+	for _, p := range points {
+		scale = math.Max(scale, p.Abs().MaxComponent())
+	}
+	epsilon := 1e-10 * math.Max(scale, 1e-12) * 3
+
+	// The points furthest apart along an axis are the first two corners of
+	// the starting tetrahedron
+	var a, b int
+	best := -1.
+	for axis := range 3 {
+		minI, maxI := 0, 0
+		for i, p := range points {
+			if component(p, axis) < component(points[minI], axis) {
+				minI = i
+			}
+			if component(p, axis) > component(points[maxI], axis) {
+				maxI = i
+			}
+		}
+		if d := points[maxI].Sub(points[minI]).LengthSquared(); d > best {
+			best = d
+			a, b = minI, maxI
+		}
+	}
+
+	// Third corner is furthest from the line between the first two
+	ab := points[b].Sub(points[a])
+	c := -1
+	best = 0
+	for i, p := range points {
+		if d := ab.Cross(p.Sub(points[a])).LengthSquared(); d > best {
+			best = d
+			c = i
+		}
+	}
+	if c == -1 || math.Sqrt(best) <= epsilon*ab.Length() {
+		return nil
+	}
+
+	// Fourth is furthest from the plane of the first three
+	normal := ab.Cross(points[c].Sub(points[a])).Normalized()
+	d := -1
+	best = 0
+	for i, p := range points {
+		if dist := math.Abs(normal.Dot(p.Sub(points[a]))); dist > best {
+			best = dist
+			d = i
+		}
+	}
+	if d == -1 || best <= epsilon {
+		return nil
+	}
+
+	qh := &quickhull{
+		points:  points,
+		epsilon: epsilon,
+		edges:   make(map[hullEdge]int),
+	}
+
+	// Wind the tetrahedron so every face points away from the fourth corner
+	if normal.Dot(points[d].Sub(points[a])) > 0 {
+		a, b = b, a
+	}
+	qh.addFace(a, b, c)
+	qh.addFace(a, d, b)
+	qh.addFace(b, d, c)
+	qh.addFace(c, d, a)
+
+	for i, p := range points {
+		if i == a || i == b || i == c || i == d {
+			continue
+		}
+		qh.assign(i, p, qh.faces)
+	}
+
+	return qh
+}
+
+func component(v vector3.Float64, axis int) float64 {
+	switch axis {
+	case 0:
+		return v.X()
+	case 1:
+		return v.Y()
+	}
+	return v.Z()
+}
+
+func (qh *quickhull) addFace(a, b, c int) *hullFace {
+	pa, pb, pc := qh.points[a], qh.points[b], qh.points[c]
+	normal := pb.Sub(pa).Cross(pc.Sub(pa))
+	if length := normal.Length(); length > 0 {
+		normal = normal.DivByConstant(length)
+	}
+
+	face := &hullFace{
+		vertices: [3]int{a, b, c},
+		normal:   normal,
+		offset:   normal.Dot(pa),
+	}
+
+	index := len(qh.faces)
+	qh.faces = append(qh.faces, face)
+	qh.edges[hullEdge{a, b}] = index
+	qh.edges[hullEdge{b, c}] = index
+	qh.edges[hullEdge{c, a}] = index
+	return face
+}
+
+// assign the point to the first of the faces it lies in front of, if any
+func (qh *quickhull) assign(i int, p vector3.Float64, faces []*hullFace) {
+	for _, f := range faces {
+		if f.distance(p) > qh.epsilon {
+			f.outside = append(f.outside, i)
+			return
+		}
+	}
+}
+
+func (qh *quickhull) build() {
+	for f := 0; f < len(qh.faces); f++ {
+		face := qh.faces[f]
+		for !face.removed && len(face.outside) > 0 {
+			qh.expand(f)
+		}
+	}
+}
+
+// expand the hull to include the point furthest in front of the face
+func (qh *quickhull) expand(start int) {
+	face := qh.faces[start]
+	eye := face.outside[0]
+	furthest := face.distance(qh.points[eye])
+	for _, i := range face.outside[1:] {
+		if d := face.distance(qh.points[i]); d > furthest {
+			furthest = d
+			eye = i
+		}
+	}
+	eyePoint := qh.points[eye]
+
+	// Find every face the eye can see, which are all connected
+	visible := map[int]bool{start: true}
+	queue := []int{start}
+	for len(queue) > 0 {
+		current := qh.faces[queue[0]]
+		queue = queue[1:]
+		for e := range 3 {
+			a, b := current.vertices[e], current.vertices[(e+1)%3]
+			neighbor, ok := qh.edges[hullEdge{b, a}]
+			if !ok || visible[neighbor] {
+				continue
+			}
+			if qh.faces[neighbor].distance(eyePoint) > qh.epsilon {
+				visible[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	// The horizon is every edge between a visible face and one that isn't
+	horizon := make([]hullEdge, 0)
+	orphans := make([]int, 0)
+	for f := range visible {
+		current := qh.faces[f]
+		for e := range 3 {
+			a, b := current.vertices[e], current.vertices[(e+1)%3]
+			if neighbor, ok := qh.edges[hullEdge{b, a}]; ok && !visible[neighbor] {
+				horizon = append(horizon, hullEdge{a, b})
+			}
+		}
+		orphans = append(orphans, current.outside...)
+	}
+
+	for f := range visible {
+		current := qh.faces[f]
+		for e := range 3 {
+			edge := hullEdge{current.vertices[e], current.vertices[(e+1)%3]}
+			if qh.edges[edge] == f {
+				delete(qh.edges, edge)
+			}
+		}
+		current.removed = true
+		current.outside = nil
+	}
+
+	created := make([]*hullFace, len(horizon))
+	for i, edge := range horizon {
+		created[i] = qh.addFace(edge.a, edge.b, eye)
+	}
+
+	for _, i := range orphans {
+		if i == eye {
+			continue
+		}
+		qh.assign(i, qh.points[i], created)
+	}
+}
+
+func (qh *quickhull) mesh() modeling.Mesh {
+	lookup := make(map[int]int)
+	positions := make([]vector3.Float64, 0)
+	indices := make([]int, 0)
+	for _, face := range qh.faces {
+		if face.removed {
+			continue
+		}
+		for _, v := range face.vertices {
+			index, ok := lookup[v]
+			if !ok {
+				index = len(positions)
+				lookup[v] = index
+				positions = append(positions, qh.points[v])
+			}
+			indices = append(indices, index)
+		}
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions)
+}
+
+// Volume of a closed triangle mesh, such as a convex hull
+func Volume(m modeling.Mesh) float64 {
+	if m.PrimitiveCount() == 0 {
+		return 0
+	}
+
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	indices := m.Indices()
+	volume := 0.
+	for i := 0; i+3 <= indices.Len(); i += 3 {
+		a := positions.At(indices.At(i))
+		b := positions.At(indices.At(i + 1))
+		c := positions.At(indices.At(i + 2))
+		volume += a.Dot(b.Cross(c))
+	}
+	return volume / 6
+}
@@ -1,6 +1,7 @@
 package marching
 
 import (
+	"context"
 	"log"
 	"math"
 
@@ -247,7 +248,12 @@ func marchCracked(field sample.Vec3ToFloat, initialBounds geometry.AABB, cubeSiz
 	log.Printf("Total Results: %d\n", len(res))
 }
 
-func marchRecurse(field sample.Vec3ToFloat, bounds geometry.AABB, cubeSize, surface float64, res map[vector3.Int32]float64) {
+func marchRecurse(done <-chan struct{}, field sample.Vec3ToFloat, bounds geometry.AABB, cubeSize, surface float64, res map[vector3.Int32]float64) {
+	select {
+	case <-done:
+		return
+	default:
+	}
 
 	center := bounds.Center()
 	centerIndex := center.DivByConstant(cubeSize).RoundToInt()
@@ -270,14 +276,14 @@ func marchRecurse(field sample.Vec3ToFloat, bounds geometry.AABB, cubeSize, surf
 
 	halfSize := size.Scale(0.5)
 	qs := halfSize.Scale(0.5)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(qs.X(), qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(qs.X(), qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(qs.X(), -qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(qs.X(), -qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), -qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
-	marchRecurse(field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), -qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(qs.X(), qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(qs.X(), qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(qs.X(), -qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(qs.X(), -qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), -qs.Y(), qs.Z())), halfSize), cubeSize, surface, res)
+	marchRecurse(done, field, geometry.NewAABB(center.Add(vector3.New(-qs.X(), -qs.Y(), -qs.Z())), halfSize), cubeSize, surface, res)
 }
 
 func dedup(data *workingData, vert vector3.Float64, size float64) int {
@@ -295,9 +301,20 @@ func dedup(data *workingData, vert vector3.Float64, size float64) int {
 }
 
 func March(field sample.Vec3ToFloat, domain geometry.AABB, cubeSize, surface float64) modeling.Mesh {
+	m, _ := MarchWithContext(context.Background(), field, domain, cubeSize, surface)
+	return m
+}
+
+// MarchWithContext is March, abandoning tesselation and returning the
+// context's error once it's done
+func MarchWithContext(ctx context.Context, field sample.Vec3ToFloat, domain geometry.AABB, cubeSize, surface float64) (modeling.Mesh, error) {
+	done := ctx.Done()
 	results := make(map[vector3.Int32]float64)
 	// sdfCompute := time.Now()
-	marchRecurse(field, domain, cubeSize, surface, results)
+	marchRecurse(done, field, domain, cubeSize, surface, results)
+	if err := ctx.Err(); err != nil {
+		return modeling.EmptyMesh(modeling.TriangleTopology), err
+	}
 	// log.Printf("Time To Compute SDFs %s", time.Since(sdfCompute))
 
 	// marchCompute := time.Now()
@@ -310,6 +327,12 @@ func March(field sample.Vec3ToFloat, domain geometry.AABB, cubeSize, surface flo
 	cubeCorners := make([]float64, 8)
 	cubeCornerPositions := make([]vector3.Float64, 8)
 	for key, nnn := range results {
+		select {
+		case <-done:
+			return modeling.EmptyMesh(modeling.TriangleTopology), ctx.Err()
+		default:
+		}
+
 		cubeCorners[0] = nnn
 
 		var ok bool
@@ -415,10 +438,10 @@ func March(field sample.Vec3ToFloat, domain geometry.AABB, cubeSize, surface flo
 		SetFloat3Attribute(modeling.PositionAttribute, marchingWorkingData.verts)
 
 	if len(marchingWorkingData.tris) == 0 {
-		return m
+		return m, nil
 	}
 
 	// log.Printf("Time To March Mesh %s", time.Since(marchCompute))
 
-	return meshops.RemoveNullFaces3D(m, modeling.PositionAttribute, 0)
+	return meshops.RemoveNullFaces3D(m, modeling.PositionAttribute, 0), nil
 }
@@ -27,6 +27,10 @@ type MarchNode struct {
 	Domain     nodes.Output[geometry.AABB]      `description:"The region in which the marching cubes algorithm runs"`
 }
 
+func (cn MarchNode) CacheVersion() int {
+	return 1
+}
+
 func (cn MarchNode) Mesh(out *nodes.StructOutput[modeling.Mesh]) {
 	if cn.Field == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -42,7 +46,10 @@ func (cn MarchNode) Mesh(out *nodes.StructOutput[modeling.Mesh]) {
 		return
 	}
 
-	out.Set(March(
+	// Cancellation is already recorded by the evaluation itself, so the error
+	// is of no further use
+	mesh, _ := MarchWithContext(
+		out.Context(),
 		nodes.GetOutputValue(out, cn.Field),
 		nodes.TryGetOutputValue(
 			out,
@@ -51,5 +58,6 @@ func (cn MarchNode) Mesh(out *nodes.StructOutput[modeling.Mesh]) {
 		),
 		1/resolution,
 		nodes.TryGetOutputValue(out, cn.Surface, 0.),
-	))
+	)
+	out.Set(mesh)
 }
@@ -0,0 +1,415 @@
+package meshops
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+type BooleanOperation int
+
+const (
+	BooleanUnion BooleanOperation = iota
+	BooleanDifference
+	BooleanIntersection
+)
+
+func (op BooleanOperation) String() string {
+	switch op {
+	case BooleanUnion:
+		return "union"
+
+	case BooleanDifference:
+		return "difference"
+
+	case BooleanIntersection:
+		return "intersection"
+	}
+	return fmt.Sprintf("BooleanOperation(%d)", int(op))
+}
+
+// BooleanTransformer combines the mesh being transformed (A) with Other (B)
+type BooleanTransformer struct {
+	Operation BooleanOperation
+	Other     modeling.Mesh
+}
+
+func (bt BooleanTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	for _, mesh := range []modeling.Mesh{m, bt.Other} {
+		if err = RequireTopology(mesh, modeling.TriangleTopology); err != nil {
+			return
+		}
+
+		if mesh.AttributeLength() > 0 {
+			if err = RequireV3Attribute(mesh, modeling.PositionAttribute); err != nil {
+				return
+			}
+		}
+	}
+
+	switch bt.Operation {
+	case BooleanUnion, BooleanDifference, BooleanIntersection:
+		return boolean(m, bt.Other, bt.Operation), nil
+	}
+	return m, fmt.Errorf("unrecognized boolean operation: %s", bt.Operation)
+}
+
+// Union is the space contained by either mesh. Both meshes are expected to be
+// closed triangle meshes. Attributes of both meshes are carried over to the
+// faces of the result, with attributes only one of the meshes has being
+// zeroed on the faces of the other, like modeling.Mesh.Append.
+func Union(a, b modeling.Mesh) modeling.Mesh {
+	return mustBoolean(a, b, BooleanUnion)
+}
+
+// Difference is the space contained by a that isn't contained by b. See
+// Union for requirements of the meshes.
+func Difference(a, b modeling.Mesh) modeling.Mesh {
+	return mustBoolean(a, b, BooleanDifference)
+}
+
+// Intersection is the space contained by both meshes. See Union for
+// requirements of the meshes.
+func Intersection(a, b modeling.Mesh) modeling.Mesh {
+	return mustBoolean(a, b, BooleanIntersection)
+}
+
+func mustBoolean(a, b modeling.Mesh, op BooleanOperation) modeling.Mesh {
+	result, err := BooleanTransformer{Operation: op, Other: b}.Transform(a)
+	check(err)
+	return result
+}
+
+// csgLayout is the order attributes are flattened into a csgVertex's data
+type csgLayout struct {
+	v4, v3, v2, v1 []string
+
+	// Offset of the normal within the vertex data, -1 if there is none
+	normal int
+	size   int
+}
+
+func newCSGLayout(m modeling.Mesh) csgLayout {
+	layout := csgLayout{
+		v4:     m.Float4Attributes(),
+		v3:     make([]string, 0),
+		v2:     m.Float2Attributes(),
+		v1:     m.Float1Attributes(),
+		normal: -1,
+	}
+	layout.size = len(layout.v4) * 4
+
+	for _, attr := range m.Float3Attributes() {
+		if attr == modeling.PositionAttribute {
+			continue
+		}
+		if attr == modeling.NormalAttribute {
+			layout.normal = layout.size
+		}
+		layout.v3 = append(layout.v3, attr)
+		layout.size += 3
+	}
+
+	layout.size += len(layout.v2)*2 + len(layout.v1)
+	return layout
+}
+
+func (l csgLayout) vertices(m modeling.Mesh) []csgVertex {
+	vertices := make([]csgVertex, m.AttributeLength())
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	for i := range vertices {
+		vertices[i] = csgVertex{position: positions.At(i), data: make([]float64, 0, l.size)}
+	}
+
+	for _, attr := range l.v4 {
+		data := m.Float4Attribute(attr)
+		for i := range vertices {
+			v := data.At(i)
+			vertices[i].data = append(vertices[i].data, v.X(), v.Y(), v.Z(), v.W())
+		}
+	}
+
+	for _, attr := range l.v3 {
+		data := m.Float3Attribute(attr)
+		for i := range vertices {
+			v := data.At(i)
+			vertices[i].data = append(vertices[i].data, v.X(), v.Y(), v.Z())
+		}
+	}
+
+	for _, attr := range l.v2 {
+		data := m.Float2Attribute(attr)
+		for i := range vertices {
+			v := data.At(i)
+			vertices[i].data = append(vertices[i].data, v.X(), v.Y())
+		}
+	}
+
+	for _, attr := range l.v1 {
+		data := m.Float1Attribute(attr)
+		for i := range vertices {
+			vertices[i].data = append(vertices[i].data, data.At(i))
+		}
+	}
+
+	return vertices
+}
+
+func (l csgLayout) flipData(data []float64) []float64 {
+	if l.normal < 0 {
+		return data
+	}
+	flipped := append([]float64{}, data...)
+	for i := l.normal; i < l.normal+3; i++ {
+		flipped[i] = -flipped[i]
+	}
+	return flipped
+}
+
+func (l csgLayout) mesh(polygons []csgPolygon) modeling.Mesh {
+	vertices := make([]csgVertex, 0)
+	lookup := make(map[string]int)
+	key := make([]byte, 0, (l.size+3)*8)
+	vertexIndex := func(v csgVertex) int {
+		key = key[:0]
+		for _, f := range append([]float64{v.position.X(), v.position.Y(), v.position.Z()}, v.data...) {
+			key = binary.LittleEndian.AppendUint64(key, math.Float64bits(f))
+		}
+		if i, ok := lookup[string(key)]; ok {
+			return i
+		}
+		i := len(vertices)
+		lookup[string(key)] = i
+		vertices = append(vertices, v)
+		return i
+	}
+
+	indices := make([]int, 0)
+	for _, polygon := range polygons {
+		first := vertexIndex(polygon.vertices[0])
+		prev := vertexIndex(polygon.vertices[1])
+		for _, v := range polygon.vertices[2:] {
+			cur := vertexIndex(v)
+			indices = append(indices, first, prev, cur)
+			prev = cur
+		}
+	}
+
+	positions := make([]vector3.Float64, len(vertices))
+	for i, v := range vertices {
+		positions[i] = v.position
+	}
+	result := modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions)
+
+	offset := 0
+	for _, attr := range l.v4 {
+		data := make([]vector4.Float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = vector4.New(v.data[offset], v.data[offset+1], v.data[offset+2], v.data[offset+3])
+		}
+		result = result.SetFloat4Attribute(attr, data)
+		offset += 4
+	}
+
+	for _, attr := range l.v3 {
+		data := make([]vector3.Float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = vector3.New(v.data[offset], v.data[offset+1], v.data[offset+2])
+		}
+		result = result.SetFloat3Attribute(attr, data)
+		offset += 3
+	}
+
+	for _, attr := range l.v2 {
+		data := make([]vector2.Float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = vector2.New(v.data[offset], v.data[offset+1])
+		}
+		result = result.SetFloat2Attribute(attr, data)
+		offset += 2
+	}
+
+	for _, attr := range l.v1 {
+		data := make([]float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = v.data[offset]
+		}
+		result = result.SetFloat1Attribute(attr, data)
+		offset++
+	}
+
+	return result
+}
+
+func csgPolygons(vertices []csgVertex, m modeling.Mesh, offset int) []csgPolygon {
+	indices := m.Indices()
+	polygons := make([]csgPolygon, 0, indices.Len()/3)
+	for i := 0; i+3 <= indices.Len(); i += 3 {
+		a := vertices[offset+indices.At(i)]
+		b := vertices[offset+indices.At(i+1)]
+		c := vertices[offset+indices.At(i+2)]
+
+		// Degenerate triangles have no plane to split by
+		plane, ok := newCSGPlane(a.position, b.position, c.position)
+		if !ok {
+			continue
+		}
+
+		polygons = append(polygons, csgPolygon{
+			vertices: []csgVertex{a, b, c},
+			plane:    plane,
+		})
+	}
+	return polygons
+}
+
+func boolean(a, b modeling.Mesh, op BooleanOperation) modeling.Mesh {
+	// An empty BSP tree has no planes to clip anything by, so operations
+	// involving an empty mesh are resolved up front
+	if a.PrimitiveCount() == 0 || b.PrimitiveCount() == 0 {
+		switch op {
+		case BooleanUnion:
+			if a.PrimitiveCount() == 0 {
+				return b
+			}
+			return a
+
+		case BooleanDifference:
+			return a
+		}
+		return modeling.EmptyMesh(modeling.TriangleTopology)
+	}
+
+	// Appending gives both meshes the same set of attributes
+	combined := a.Append(b)
+	if combined.AttributeLength() == 0 {
+		return modeling.EmptyMesh(modeling.TriangleTopology)
+	}
+
+	layout := newCSGLayout(combined)
+	vertices := layout.vertices(combined)
+
+	// Tolerance for considering a point on a plane, relative to the size of
+	// the geometry involved
+	bounds := combined.BoundingBox(modeling.PositionAttribute)
+	epsilon := 1e-5 * math.Max(bounds.Size().Length(), 1e-3)
+
+	treeA := newBSPTree(csgPolygons(vertices, a, 0), epsilon)
+	treeB := newBSPTree(csgPolygons(vertices, b, a.AttributeLength()), epsilon)
+
+	switch op {
+	case BooleanUnion:
+		treeA.clipTo(treeB, epsilon)
+		treeB.clipTo(treeA, epsilon)
+		treeB.invert(layout.flipData)
+		treeB.clipTo(treeA, epsilon)
+		treeB.invert(layout.flipData)
+		treeA.build(treeB.allPolygons(), epsilon)
+
+	case BooleanDifference:
+		treeA.invert(layout.flipData)
+		treeA.clipTo(treeB, epsilon)
+		treeB.clipTo(treeA, epsilon)
+		treeB.invert(layout.flipData)
+		treeB.clipTo(treeA, epsilon)
+		treeB.invert(layout.flipData)
+		treeA.build(treeB.allPolygons(), epsilon)
+		treeA.invert(layout.flipData)
+
+	case BooleanIntersection:
+		treeA.invert(layout.flipData)
+		treeB.clipTo(treeA, epsilon)
+		treeB.invert(layout.flipData)
+		treeA.clipTo(treeB, epsilon)
+		treeB.clipTo(treeA, epsilon)
+		treeA.build(treeB.allPolygons(), epsilon)
+		treeA.invert(layout.flipData)
+	}
+
+	return layout.mesh(treeA.allPolygons())
+}
+
+// ============================================================================
+
+type booleanNode struct {
+	A nodes.Output[modeling.Mesh]
+	B nodes.Output[modeling.Mesh]
+}
+
+func (n booleanNode) run(out *nodes.StructOutput[modeling.Mesh], op BooleanOperation) {
+	a := modeling.EmptyMesh(modeling.TriangleTopology)
+	if n.A != nil {
+		a = nodes.GetOutputValue(out, n.A)
+	}
+
+	b := modeling.EmptyMesh(modeling.TriangleTopology)
+	if n.B != nil {
+		b = nodes.GetOutputValue(out, n.B)
+	}
+
+	result, err := BooleanTransformer{Operation: op, Other: b}.Transform(a)
+	if err != nil {
+		out.CaptureError(err)
+		out.Set(a)
+		return
+	}
+	out.Set(result)
+}
+
+type BooleanUnionNode struct {
+	A nodes.Output[modeling.Mesh] `description:"Closed triangle mesh"`
+	B nodes.Output[modeling.Mesh] `description:"Closed triangle mesh"`
+}
+
+func (BooleanUnionNode) Description() string {
+	return "Merges two closed meshes into one, removing the geometry where they overlap"
+}
+
+func (n BooleanUnionNode) CacheVersion() int {
+	return 1
+}
+
+func (n BooleanUnionNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	booleanNode(n).run(out, BooleanUnion)
+}
+
+type BooleanDifferenceNode struct {
+	A nodes.Output[modeling.Mesh] `description:"Closed triangle mesh to cut from"`
+	B nodes.Output[modeling.Mesh] `description:"Closed triangle mesh to cut away"`
+}
+
+func (BooleanDifferenceNode) Description() string {
+	return "Cuts the volume of B out of A"
+}
+
+func (n BooleanDifferenceNode) CacheVersion() int {
+	return 1
+}
+
+func (n BooleanDifferenceNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	booleanNode(n).run(out, BooleanDifference)
+}
+
+type BooleanIntersectionNode struct {
+	A nodes.Output[modeling.Mesh] `description:"Closed triangle mesh"`
+	B nodes.Output[modeling.Mesh] `description:"Closed triangle mesh"`
+}
+
+func (BooleanIntersectionNode) Description() string {
+	return "Keeps only the volume shared by both meshes"
+}
+
+func (n BooleanIntersectionNode) CacheVersion() int {
+	return 1
+}
+
+func (n BooleanIntersectionNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	booleanNode(n).run(out, BooleanIntersection)
+}
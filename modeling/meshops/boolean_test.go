@@ -0,0 +1,160 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedVolume of a closed mesh using the divergence theorem
+func signedVolume(m modeling.Mesh) float64 {
+	volume := 0.
+	for i := range m.PrimitiveCount() {
+		tri := m.Tri(i)
+		a := tri.P1Vec3Attr(modeling.PositionAttribute)
+		b := tri.P2Vec3Attr(modeling.PositionAttribute)
+		c := tri.P3Vec3Attr(modeling.PositionAttribute)
+		volume += a.Dot(b.Cross(c)) / 6.
+	}
+	return volume
+}
+
+func TestBoolean_OverlappingCubes(t *testing.T) {
+	a := primitives.UnitCube()
+	b := primitives.UnitCube().Translate(vector3.New(0.5, 0.25, 0.))
+
+	tests := map[string]struct {
+		op     meshops.BooleanOperation
+		volume float64
+		min    vector3.Float64
+		max    vector3.Float64
+	}{
+		"union": {
+			op:     meshops.BooleanUnion,
+			volume: 2 - 0.5*0.75,
+			min:    vector3.New(-0.5, -0.5, -0.5),
+			max:    vector3.New(1., 0.75, 0.5),
+		},
+		"difference": {
+			op:     meshops.BooleanDifference,
+			volume: 1 - 0.5*0.75,
+			min:    vector3.New(-0.5, -0.5, -0.5),
+			max:    vector3.New(0.5, 0.5, 0.5),
+		},
+		"intersection": {
+			op:     meshops.BooleanIntersection,
+			volume: 0.5 * 0.75,
+			min:    vector3.New(0., -0.25, -0.5),
+			max:    vector3.New(0.5, 0.5, 0.5),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ACT ========================================================
+			result, err := meshops.BooleanTransformer{Operation: tc.op, Other: b}.Transform(a)
+
+			// ASSERT =====================================================
+			require.NoError(t, err)
+			assert.Equal(t, modeling.TriangleTopology, result.Topology())
+			assert.InDelta(t, tc.volume, signedVolume(result), 1e-9)
+
+			bounds := result.BoundingBox(modeling.PositionAttribute)
+			assert.InDelta(t, 0, bounds.Min().Distance(tc.min), 1e-9)
+			assert.InDelta(t, 0, bounds.Max().Distance(tc.max), 1e-9)
+		})
+	}
+}
+
+func TestBoolean_DisjointUnionKeepsBoth(t *testing.T) {
+	a := primitives.UnitCube()
+	b := primitives.UnitCube().Translate(vector3.New(3., 0., 0.))
+
+	result := meshops.Union(a, b)
+
+	assert.InDelta(t, 2, signedVolume(result), 1e-9)
+	assert.Equal(t, 24, result.PrimitiveCount())
+	assert.Equal(t, 0, meshops.Intersection(a, b).PrimitiveCount())
+}
+
+func TestBoolean_EmptyOperands(t *testing.T) {
+	cube := primitives.UnitCube()
+	empty := modeling.EmptyMesh(modeling.TriangleTopology)
+
+	tests := map[string]struct {
+		result     modeling.Mesh
+		primitives int
+		volume     float64
+	}{
+		"union with empty a":        {result: meshops.Union(empty, cube), primitives: 12, volume: 1},
+		"union with empty b":        {result: meshops.Union(cube, empty), primitives: 12, volume: 1},
+		"difference with empty a":   {result: meshops.Difference(empty, cube), primitives: 0, volume: 0},
+		"difference with empty b":   {result: meshops.Difference(cube, empty), primitives: 12, volume: 1},
+		"intersection with empty a": {result: meshops.Intersection(empty, cube), primitives: 0, volume: 0},
+		"intersection with empty b": {result: meshops.Intersection(cube, empty), primitives: 0, volume: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.primitives, tc.result.PrimitiveCount())
+			assert.InDelta(t, tc.volume, signedVolume(tc.result), 1e-9)
+		})
+	}
+}
+
+func TestBoolean_PreservesAttributes(t *testing.T) {
+	// ARRANGE ================================================================
+	a := primitives.Cube{Width: 1, Height: 1, Depth: 1, UVs: primitives.DefaultCubeUVs()}.Welded()
+	b := primitives.UnitCube().Translate(vector3.New(0.5, 0.5, 0.5))
+
+	// ACT ====================================================================
+	result := meshops.Difference(a, b)
+
+	// ASSERT =================================================================
+	require.True(t, result.HasFloat2Attribute(modeling.TexCoordAttribute))
+	require.True(t, result.HasFloat3Attribute(modeling.NormalAttribute))
+
+	// Faces from the cutter are turned inside out, along with their normals
+	positions := result.Float3Attribute(modeling.PositionAttribute)
+	normals := result.Float3Attribute(modeling.NormalAttribute)
+	uvs := result.Float2Attribute(modeling.TexCoordAttribute)
+	cutterCorners := 0
+	for i := range positions.Len() {
+		p := positions.At(i)
+		if p == vector3.New(0., 0., 0.) {
+			cutterCorners++
+			assert.Equal(t, vector3.Fill(1.).Normalized(), normals.At(i))
+			assert.Equal(t, vector2.Zero[float64](), uvs.At(i), "cutter didn't have UVs")
+		}
+	}
+	assert.Positive(t, cutterCorners)
+	assert.InDelta(t, 1-0.125, signedVolume(result), 1e-9)
+}
+
+func TestBoolean_RequiresTriangles(t *testing.T) {
+	_, err := meshops.BooleanTransformer{
+		Operation: meshops.BooleanUnion,
+		Other:     modeling.EmptyMesh(modeling.PointTopology),
+	}.Transform(primitives.UnitCube())
+	assert.Error(t, err)
+}
+
+func TestBooleanDifferenceNode(t *testing.T) {
+	node := &nodes.Struct[meshops.BooleanDifferenceNode]{
+		Data: meshops.BooleanDifferenceNode{
+			A: nodes.ConstOutput[modeling.Mesh]{Val: primitives.UnitCube()},
+			B: nodes.ConstOutput[modeling.Mesh]{Val: primitives.UnitCube().Scale(vector3.New(2., 0.5, 0.5))},
+		},
+	}
+
+	result := nodes.GetNodeOutputPort[modeling.Mesh](node, "Out").Value()
+
+	assert.InDelta(t, 0.75, signedVolume(result), 1e-9)
+}
@@ -0,0 +1,254 @@
+package meshops
+
+import (
+	"math"
+
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Constructive solid geometry using BSP trees, following the approach of
+// Evan Wallace's csg.js. Each mesh is built into a BSP tree whose splitting
+// planes are the planes of its own polygons, and polygons of one tree are
+// clipped against the other to discard the parts that end up inside or
+// outside of the result.
+
+type csgVertex struct {
+	position vector3.Float64
+
+	// Every other attribute of the vertex, flattened
+	data []float64
+}
+
+func (v csgVertex) lerp(other csgVertex, t float64) csgVertex {
+	data := make([]float64, len(v.data))
+	for i := range data {
+		data[i] = v.data[i] + (other.data[i]-v.data[i])*t
+	}
+	return csgVertex{
+		position: v.position.Add(other.position.Sub(v.position).Scale(t)),
+		data:     data,
+	}
+}
+
+type csgPlane struct {
+	normal vector3.Float64
+	w      float64
+}
+
+func newCSGPlane(a, b, c vector3.Float64) (csgPlane, bool) {
+	n := b.Sub(a).Cross(c.Sub(a))
+	length := n.Length()
+	if length == 0 || math.IsNaN(length) {
+		return csgPlane{}, false
+	}
+	n = n.DivByConstant(length)
+	return csgPlane{normal: n, w: n.Dot(a)}, true
+}
+
+func (p csgPlane) flipped() csgPlane {
+	return csgPlane{normal: p.normal.Scale(-1), w: -p.w}
+}
+
+type csgPolygon struct {
+	vertices []csgVertex
+	plane    csgPlane
+}
+
+// flipped reverses the winding of the polygon. flipData flips any attribute
+// data that follows the orientation of the surface, such as normals.
+func (p csgPolygon) flipped(flipData func([]float64) []float64) csgPolygon {
+	vertices := make([]csgVertex, len(p.vertices))
+	for i, v := range p.vertices {
+		vertices[len(vertices)-1-i] = csgVertex{position: v.position, data: flipData(v.data)}
+	}
+	return csgPolygon{vertices: vertices, plane: p.plane.flipped()}
+}
+
+const (
+	csgCoplanar = 0
+	csgFront    = 1
+	csgBack     = 2
+	csgSpanning = 3
+)
+
+// split places the polygon, or the pieces of it on either side of the
+// plane, into the corresponding lists
+func (p csgPlane) split(polygon csgPolygon, epsilon float64, coplanarFront, coplanarBack, front, back *[]csgPolygon) {
+	polygonType := 0
+	types := make([]int, len(polygon.vertices))
+	for i, v := range polygon.vertices {
+		t := p.normal.Dot(v.position) - p.w
+		vertexType := csgCoplanar
+		if t < -epsilon {
+			vertexType = csgBack
+		} else if t > epsilon {
+			vertexType = csgFront
+		}
+		polygonType |= vertexType
+		types[i] = vertexType
+	}
+
+	switch polygonType {
+	case csgCoplanar:
+		if p.normal.Dot(polygon.plane.normal) > 0 {
+			*coplanarFront = append(*coplanarFront, polygon)
+		} else {
+			*coplanarBack = append(*coplanarBack, polygon)
+		}
+
+	case csgFront:
+		*front = append(*front, polygon)
+
+	case csgBack:
+		*back = append(*back, polygon)
+
+	case csgSpanning:
+		f := make([]csgVertex, 0, len(polygon.vertices)+1)
+		b := make([]csgVertex, 0, len(polygon.vertices)+1)
+		for i, vi := range polygon.vertices {
+			j := (i + 1) % len(polygon.vertices)
+			ti, tj := types[i], types[j]
+			vj := polygon.vertices[j]
+
+			if ti != csgBack {
+				f = append(f, vi)
+			}
+			if ti != csgFront {
+				b = append(b, vi)
+			}
+
+			if (ti | tj) == csgSpanning {
+				t := (p.w - p.normal.Dot(vi.position)) / p.normal.Dot(vj.position.Sub(vi.position))
+				v := vi.lerp(vj, t)
+				f = append(f, v)
+				b = append(b, v)
+			}
+		}
+
+		if len(f) >= 3 {
+			*front = append(*front, csgPolygon{vertices: f, plane: polygon.plane})
+		}
+		if len(b) >= 3 {
+			*back = append(*back, csgPolygon{vertices: b, plane: polygon.plane})
+		}
+	}
+}
+
+type bspNode struct {
+	plane    *csgPlane
+	front    *bspNode
+	back     *bspNode
+	polygons []csgPolygon
+}
+
+func newBSPTree(polygons []csgPolygon, epsilon float64) *bspNode {
+	node := &bspNode{}
+	node.build(polygons, epsilon)
+	return node
+}
+
+func (n *bspNode) build(polygons []csgPolygon, epsilon float64) {
+	if len(polygons) == 0 {
+		return
+	}
+
+	if n.plane == nil {
+		plane := polygons[0].plane
+		n.plane = &plane
+	}
+
+	front := make([]csgPolygon, 0)
+	back := make([]csgPolygon, 0)
+	for _, polygon := range polygons {
+		n.plane.split(polygon, epsilon, &n.polygons, &n.polygons, &front, &back)
+	}
+
+	if len(front) > 0 {
+		if n.front == nil {
+			n.front = &bspNode{}
+		}
+		n.front.build(front, epsilon)
+	}
+
+	if len(back) > 0 {
+		if n.back == nil {
+			n.back = &bspNode{}
+		}
+		n.back.build(back, epsilon)
+	}
+}
+
+// invert swaps solid space and empty space
+func (n *bspNode) invert(flipData func([]float64) []float64) {
+	for i, polygon := range n.polygons {
+		n.polygons[i] = polygon.flipped(flipData)
+	}
+
+	if n.plane != nil {
+		flipped := n.plane.flipped()
+		n.plane = &flipped
+	}
+
+	if n.front != nil {
+		n.front.invert(flipData)
+	}
+
+	if n.back != nil {
+		n.back.invert(flipData)
+	}
+
+	n.front, n.back = n.back, n.front
+}
+
+// clipPolygons removes the parts of the polygons that are inside of the
+// solid this tree represents
+func (n *bspNode) clipPolygons(polygons []csgPolygon, epsilon float64) []csgPolygon {
+	if n.plane == nil {
+		return append([]csgPolygon{}, polygons...)
+	}
+
+	front := make([]csgPolygon, 0)
+	back := make([]csgPolygon, 0)
+	for _, polygon := range polygons {
+		n.plane.split(polygon, epsilon, &front, &back, &front, &back)
+	}
+
+	if n.front != nil {
+		front = n.front.clipPolygons(front, epsilon)
+	}
+
+	if n.back != nil {
+		back = n.back.clipPolygons(back, epsilon)
+	} else {
+		back = nil
+	}
+
+	return append(front, back...)
+}
+
+// clipTo removes every polygon of this tree that's inside of the other
+func (n *bspNode) clipTo(other *bspNode, epsilon float64) {
+	n.polygons = other.clipPolygons(n.polygons, epsilon)
+
+	if n.front != nil {
+		n.front.clipTo(other, epsilon)
+	}
+
+	if n.back != nil {
+		n.back.clipTo(other, epsilon)
+	}
+}
+
+func (n *bspNode) allPolygons() []csgPolygon {
+	polygons := append([]csgPolygon{}, n.polygons...)
+
+	if n.front != nil {
+		polygons = append(polygons, n.front.allPolygons()...)
+	}
+
+	if n.back != nil {
+		polygons = append(polygons, n.back.allPolygons()...)
+	}
+
+	return polygons
+}
@@ -0,0 +1,128 @@
+package meshops
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type CatmullClarkTransformer struct {
+	Iterations int
+
+	// Edges where the faces on either side meet at this angle or greater, in
+	// radians, are kept sharp. 0 smooths every edge that isn't a boundary.
+	CreaseAngle float64
+}
+
+func (cct CatmullClarkTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = requireSubdivisionTopology(m); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return CatmullClark(m, cct.Iterations, cct.CreaseAngle), nil
+}
+
+func requireSubdivisionTopology(m modeling.Mesh) error {
+	if m.Topology() != modeling.TriangleTopology && m.Topology() != modeling.QuadTopology {
+		return fmt.Errorf("%w: subdivision requires a triangle or quad topology, received %s", ErrRequireDifferentTopology, m.Topology())
+	}
+	return nil
+}
+
+// CatmullClark subdivides each face of a triangle or quad mesh into quads,
+// converging on a smooth surface. Boundary edges, and edges meeting at or
+// beyond the crease angle, are kept sharp. Every vertex attribute is carried
+// over, with normals re-normalized after interpolation. The resulting mesh
+// has a quad topology, see TriangulateQuads.
+func CatmullClark(m modeling.Mesh, iterations int, creaseAngle float64) modeling.Mesh {
+	check(requireSubdivisionTopology(m))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	if iterations <= 0 {
+		return m
+	}
+
+	return subdivide(m, catmullClarkScheme, iterations, creaseAngle)
+}
+
+// TriangulateQuads splits every quad of the mesh in two along its first
+// diagonal
+func TriangulateQuads(m modeling.Mesh) modeling.Mesh {
+	check(RequireTopology(m, modeling.QuadTopology))
+
+	quads := m.Indices()
+	tris := make([]int, 0, (quads.Len()/4)*6)
+	for i := 0; i+4 <= quads.Len(); i += 4 {
+		a, b, c, d := quads.At(i), quads.At(i+1), quads.At(i+2), quads.At(i+3)
+		tris = append(tris, a, b, c, a, c, d)
+	}
+
+	result := modeling.NewTriangleMesh(tris)
+	for _, attr := range m.Float4Attributes() {
+		result = result.CopyFloat4Attribute(m, attr)
+	}
+	for _, attr := range m.Float3Attributes() {
+		result = result.CopyFloat3Attribute(m, attr)
+	}
+	for _, attr := range m.Float2Attributes() {
+		result = result.CopyFloat2Attribute(m, attr)
+	}
+	for _, attr := range m.Float1Attributes() {
+		result = result.CopyFloat1Attribute(m, attr)
+	}
+	return result
+}
+
+type CatmullClarkNode struct {
+	Mesh        nodes.Output[modeling.Mesh] `description:"Triangle or quad mesh to subdivide"`
+	Iterations  nodes.Output[int]           `description:"Number of times to subdivide the mesh. Defaults to 1"`
+	CreaseAngle nodes.Output[float64]       `description:"Edges whose faces meet at this angle or greater, in radians, are kept sharp. Defaults to 0, smoothing everything"`
+}
+
+func (CatmullClarkNode) Description() string {
+	return "Subdivides the mesh into a smooth surface made of quads"
+}
+
+func (n CatmullClarkNode) subdivide(out nodes.ExecutionRecorder) modeling.Mesh {
+	if n.Mesh == nil {
+		return modeling.EmptyMesh(modeling.QuadTopology)
+	}
+
+	mesh := nodes.GetOutputValue(out, n.Mesh)
+	iterations := nodes.TryGetOutputValue(out, n.Iterations, 1)
+	if iterations < 0 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Iterations, Message: "iterations can not be negative"})
+		return mesh
+	}
+
+	result, err := CatmullClarkTransformer{
+		Iterations:  iterations,
+		CreaseAngle: nodes.TryGetOutputValue(out, n.CreaseAngle, 0),
+	}.Transform(mesh)
+	if err != nil {
+		out.CaptureError(err)
+		return mesh
+	}
+	return result
+}
+
+func (n CatmullClarkNode) CacheVersion() int {
+	return 1
+}
+
+func (n CatmullClarkNode) Quads(out *nodes.StructOutput[modeling.Mesh]) {
+	out.Set(n.subdivide(out))
+}
+
+func (n CatmullClarkNode) Triangles(out *nodes.StructOutput[modeling.Mesh]) {
+	mesh := n.subdivide(out)
+	if mesh.Topology() == modeling.QuadTopology {
+		mesh = TriangulateQuads(mesh)
+	}
+	out.Set(mesh)
+}
@@ -52,6 +52,10 @@ type CenterAttribute3DNode struct {
 	Mesh      nodes.Output[modeling.Mesh]
 }
 
+func (ca3dn CenterAttribute3DNode) CacheVersion() int {
+	return 1
+}
+
 func (ca3dn CenterAttribute3DNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if ca3dn.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -9,6 +9,10 @@ type CombineNode struct {
 	Meshes []nodes.Output[modeling.Mesh]
 }
 
+func (cnd CombineNode) CacheVersion() int {
+	return 1
+}
+
 func (cnd CombineNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	fallback := modeling.EmptyMesh(modeling.TriangleTopology)
 
@@ -56,6 +56,10 @@ type FlatNormalsNode struct {
 	Mesh nodes.Output[modeling.Mesh]
 }
 
+func (fnnd FlatNormalsNode) CacheVersion() int {
+	return 1
+}
+
 func (fnnd FlatNormalsNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
 	if fnnd.Mesh == nil {
@@ -39,6 +39,10 @@ type FlipTriangleWindingNode struct {
 	Mesh nodes.Output[modeling.Mesh]
 }
 
+func (n FlipTriangleWindingNode) CacheVersion() int {
+	return 1
+}
+
 func (n FlipTriangleWindingNode) Flipped(out *nodes.StructOutput[modeling.Mesh]) {
 	out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
 	if n.Mesh == nil {
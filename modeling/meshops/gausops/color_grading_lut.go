@@ -66,6 +66,10 @@ type ColorGradingLutNode struct {
 	LUT       nodes.Output[image.Image]
 }
 
+func (ca3dn ColorGradingLutNode) CacheVersion() int {
+	return 1
+}
+
 func (ca3dn ColorGradingLutNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if ca3dn.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.PointTopology))
@@ -17,6 +17,10 @@ type FilterNode struct {
 	MaxVolume  nodes.Output[float64]
 }
 
+func (fnd FilterNode) CacheVersion() int {
+	return 1
+}
+
 func (fnd FilterNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if fnd.Splat == nil {
 		out.Set(modeling.EmptyPointcloud())
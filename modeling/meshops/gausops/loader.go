@@ -14,6 +14,10 @@ type LoaderNode struct {
 	Data nodes.Output[[]byte]
 }
 
+func (pn LoaderNode) CacheVersion() int {
+	return 1
+}
+
 func (pn LoaderNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	bufReader := bufio.NewReader(bytes.NewReader(nodes.TryGetOutputValue(out, pn.Data, nil)))
 
@@ -31,6 +35,10 @@ type SpzLoaderNode struct {
 	Data nodes.Output[[]byte]
 }
 
+func (pn SpzLoaderNode) CacheVersion() int {
+	return 1
+}
+
 func (pn SpzLoaderNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	bufReader := bufio.NewReader(bytes.NewReader(nodes.TryGetOutputValue(out, pn.Data, nil)))
 
@@ -32,6 +32,10 @@ type RotateAttributeNode struct {
 	Amount    nodes.Output[quaternion.Quaternion]
 }
 
+func (rand RotateAttributeNode) CacheVersion() int {
+	return 1
+}
+
 func (rand RotateAttributeNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if rand.Mesh == nil {
 		out.Set(modeling.EmptyPointcloud())
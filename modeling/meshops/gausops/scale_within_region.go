@@ -15,6 +15,10 @@ type ScaleWithinRegionNode struct {
 	Position nodes.Output[vector3.Float64]
 }
 
+func (swrnd ScaleWithinRegionNode) CacheVersion() int {
+	return 1
+}
+
 func (swrnd ScaleWithinRegionNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if swrnd.Mesh == nil {
 		out.Set(modeling.EmptyPointcloud())
@@ -193,6 +193,10 @@ type LaplacianSmoothNode struct {
 	SmoothingFactor nodes.Output[float64]
 }
 
+func (lp LaplacianSmoothNode) CacheVersion() int {
+	return 1
+}
+
 func (lp LaplacianSmoothNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if lp.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -223,6 +227,10 @@ type LaplacianSmoothImplicitWeldNode struct {
 	WeldDistance    nodes.Output[float64]
 }
 
+func (lp LaplacianSmoothImplicitWeldNode) CacheVersion() int {
+	return 1
+}
+
 func (lp LaplacianSmoothImplicitWeldNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if lp.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
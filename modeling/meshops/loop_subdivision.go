@@ -0,0 +1,82 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type LoopSubdivisionTransformer struct {
+	Iterations int
+
+	// Edges where the triangles on either side meet at this angle or
+	// greater, in radians, are kept sharp. 0 smooths every edge that isn't a
+	// boundary.
+	CreaseAngle float64
+}
+
+func (lst LoopSubdivisionTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return LoopSubdivision(m, lst.Iterations, lst.CreaseAngle), nil
+}
+
+// LoopSubdivision splits every triangle of the mesh into four, converging on
+// a smooth surface. Boundary edges, and edges meeting at or beyond the crease
+// angle, are kept sharp. Every vertex attribute is carried over, with normals
+// re-normalized after interpolation.
+func LoopSubdivision(m modeling.Mesh, iterations int, creaseAngle float64) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	if iterations <= 0 {
+		return m
+	}
+
+	return subdivide(m, loopScheme, iterations, creaseAngle)
+}
+
+type LoopSubdivisionNode struct {
+	Mesh        nodes.Output[modeling.Mesh] `description:"Triangle mesh to subdivide"`
+	Iterations  nodes.Output[int]           `description:"Number of times to subdivide the mesh. Defaults to 1"`
+	CreaseAngle nodes.Output[float64]       `description:"Edges whose triangles meet at this angle or greater, in radians, are kept sharp. Defaults to 0, smoothing everything"`
+}
+
+func (LoopSubdivisionNode) Description() string {
+	return "Subdivides each triangle of the mesh into four, smoothing the surface"
+}
+
+func (n LoopSubdivisionNode) CacheVersion() int {
+	return 1
+}
+
+func (n LoopSubdivisionNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	if n.Mesh == nil {
+		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
+		return
+	}
+
+	mesh := nodes.GetOutputValue(out, n.Mesh)
+	out.Set(mesh)
+
+	iterations := nodes.TryGetOutputValue(out, n.Iterations, 1)
+	if iterations < 0 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Iterations, Message: "iterations can not be negative"})
+		return
+	}
+
+	result, err := LoopSubdivisionTransformer{
+		Iterations:  iterations,
+		CreaseAngle: nodes.TryGetOutputValue(out, n.CreaseAngle, 0),
+	}.Transform(mesh)
+	if err != nil {
+		out.CaptureError(err)
+		return
+	}
+	out.Set(result)
+}
@@ -46,6 +46,10 @@ type RotateAttribute3DNode struct {
 	Amount    nodes.Output[quaternion.Quaternion]
 }
 
+func (ra3dn RotateAttribute3DNode) CacheVersion() int {
+	return 1
+}
+
 func (ra3dn RotateAttribute3DNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if ra3dn.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
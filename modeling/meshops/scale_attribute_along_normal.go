@@ -94,6 +94,10 @@ type ScaleAttributeAlongNormalNode struct {
 	UvAttribute nodes.Output[string]
 }
 
+func (sa3dn ScaleAttributeAlongNormalNode) CacheVersion() int {
+	return 1
+}
+
 func (sa3dn ScaleAttributeAlongNormalNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if sa3dn.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
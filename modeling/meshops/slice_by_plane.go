@@ -73,6 +73,10 @@ func (n SliceAttributeByPlaneNode) slice(out *nodes.StructOutput[modeling.Mesh],
 	}
 }
 
+func (n SliceAttributeByPlaneNode) CacheVersion() int {
+	return 1
+}
+
 func (n SliceAttributeByPlaneNode) AbovePlane(out *nodes.StructOutput[modeling.Mesh]) {
 	n.slice(out, true)
 }
@@ -139,6 +139,10 @@ func (snn SmoothNormalsNode) Description() string {
 	return "Recomputes smooth per-vertex normals by averaging the face normals of every triangle touching each vertex. Vertices are only grouped together if their positions are exactly identical."
 }
 
+func (snn SmoothNormalsNode) CacheVersion() int {
+	return 1
+}
+
 func (snn SmoothNormalsNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if snn.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -163,6 +167,10 @@ func (snn SmoothNormalsImplicitWeldNode) Description() string {
 	return "Recomputes smooth per-vertex normals, welding together normals from any vertices within Distance of each other, not just exactly-identical positions."
 }
 
+func (snn SmoothNormalsImplicitWeldNode) CacheVersion() int {
+	return 1
+}
+
 func (snn SmoothNormalsImplicitWeldNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if snn.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
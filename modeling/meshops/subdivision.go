@@ -0,0 +1,558 @@
+package meshops
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// Subdivision refines a mesh by building every new vertex as a weighted sum
+// of the vertices of the previous level. The weights (stencils) are computed
+// twice per level: once on a copy of the mesh welded by position, which
+// determines the shape of the surface, and once on the mesh's own indices,
+// which is used for every other attribute. Vertices split along UV or normal
+// seams then share a position while their attributes stay discontinuous, as
+// the seam appears as a boundary to the second set of stencils.
+
+type subdivisionScheme int
+
+const (
+	catmullClarkScheme subdivisionScheme = iota
+	loopScheme
+)
+
+type stencilWeight struct {
+	vertex int
+	weight float64
+}
+
+type stencil []stencilWeight
+
+func (s stencil) scaled(amount float64) stencil {
+	scaled := make(stencil, len(s))
+	for i, w := range s {
+		scaled[i] = stencilWeight{vertex: w.vertex, weight: w.weight * amount}
+	}
+	return scaled
+}
+
+type edgeKey [2]int
+
+func newEdgeKey(a, b int) edgeKey {
+	if a > b {
+		return edgeKey{b, a}
+	}
+	return edgeKey{a, b}
+}
+
+type subdivisionEdge struct {
+	faces []int
+	sharp bool
+}
+
+// subdivisionTopology is the connectivity of a polygon mesh
+type subdivisionTopology struct {
+	faces         [][]int
+	vertexCount   int
+	edges         map[edgeKey]*subdivisionEdge
+	edgeOrder     []edgeKey
+	vertexEdges   [][]edgeKey
+	vertexFaces   [][]int
+	vertexIsInUse []bool
+}
+
+func newSubdivisionTopology(faces [][]int, vertexCount int, sharp func(a, b int) bool) *subdivisionTopology {
+	topo := &subdivisionTopology{
+		faces:         faces,
+		vertexCount:   vertexCount,
+		edges:         make(map[edgeKey]*subdivisionEdge),
+		edgeOrder:     make([]edgeKey, 0),
+		vertexEdges:   make([][]edgeKey, vertexCount),
+		vertexFaces:   make([][]int, vertexCount),
+		vertexIsInUse: make([]bool, vertexCount),
+	}
+
+	for f, face := range faces {
+		for i, v := range face {
+			topo.vertexFaces[v] = append(topo.vertexFaces[v], f)
+			topo.vertexIsInUse[v] = true
+
+			key := newEdgeKey(v, face[(i+1)%len(face)])
+			edge, ok := topo.edges[key]
+			if !ok {
+				edge = &subdivisionEdge{}
+				topo.edges[key] = edge
+				topo.edgeOrder = append(topo.edgeOrder, key)
+				topo.vertexEdges[key[0]] = append(topo.vertexEdges[key[0]], key)
+				if key[0] != key[1] {
+					topo.vertexEdges[key[1]] = append(topo.vertexEdges[key[1]], key)
+				}
+			}
+			edge.faces = append(edge.faces, f)
+		}
+	}
+
+	for key, edge := range topo.edges {
+		// Boundaries and non-manifold edges are always kept sharp
+		edge.sharp = len(edge.faces) != 2 || key[0] == key[1] || sharp(key[0], key[1])
+	}
+
+	return topo
+}
+
+func (st subdivisionTopology) sharpEdges(v int) []edgeKey {
+	sharp := make([]edgeKey, 0)
+	for _, key := range st.vertexEdges[v] {
+		if st.edges[key].sharp {
+			sharp = append(sharp, key)
+		}
+	}
+	return sharp
+}
+
+func otherVertex(key edgeKey, v int) int {
+	if key[0] == v {
+		return key[1]
+	}
+	return key[0]
+}
+
+// creaseStencil handles vertices lying on a boundary or crease, returning
+// false if the vertex should be smoothed instead
+func (st subdivisionTopology) creaseStencil(v int, center, neighbor float64) (stencil, bool) {
+	if !st.vertexIsInUse[v] {
+		return stencil{{vertex: v, weight: 1}}, true
+	}
+
+	sharp := st.sharpEdges(v)
+	switch {
+	case len(sharp) < 2:
+		return nil, false
+
+	case len(sharp) == 2:
+		return stencil{
+			{vertex: v, weight: center},
+			{vertex: otherVertex(sharp[0], v), weight: neighbor},
+			{vertex: otherVertex(sharp[1], v), weight: neighbor},
+		}, true
+	}
+
+	// Corners stay in place
+	return stencil{{vertex: v, weight: 1}}, true
+}
+
+type subdivisionStencils struct {
+	vertices []stencil
+	edges    []stencil
+	faces    []stencil
+}
+
+func (st subdivisionTopology) faceStencil(f int) stencil {
+	face := st.faces[f]
+	s := make(stencil, len(face))
+	for i, v := range face {
+		s[i] = stencilWeight{vertex: v, weight: 1. / float64(len(face))}
+	}
+	return s
+}
+
+func midpointStencil(key edgeKey) stencil {
+	return stencil{{vertex: key[0], weight: 0.5}, {vertex: key[1], weight: 0.5}}
+}
+
+func (st subdivisionTopology) catmullClarkStencils() subdivisionStencils {
+	stencils := subdivisionStencils{
+		vertices: make([]stencil, st.vertexCount),
+		edges:    make([]stencil, len(st.edgeOrder)),
+		faces:    make([]stencil, len(st.faces)),
+	}
+
+	for f := range st.faces {
+		stencils.faces[f] = st.faceStencil(f)
+	}
+
+	for i, key := range st.edgeOrder {
+		edge := st.edges[key]
+		if edge.sharp {
+			stencils.edges[i] = midpointStencil(key)
+			continue
+		}
+
+		s := stencil{{vertex: key[0], weight: 0.25}, {vertex: key[1], weight: 0.25}}
+		s = append(s, stencils.faces[edge.faces[0]].scaled(0.25)...)
+		s = append(s, stencils.faces[edge.faces[1]].scaled(0.25)...)
+		stencils.edges[i] = s
+	}
+
+	for v := range st.vertexCount {
+		if s, ok := st.creaseStencil(v, 6./8., 1./8.); ok {
+			stencils.vertices[v] = s
+			continue
+		}
+
+		// (F + 2R + (n - 3)P) / n
+		n := float64(len(st.vertexEdges[v]))
+		s := stencil{{vertex: v, weight: (n - 3) / n}}
+
+		faceWeight := 1. / (n * float64(len(st.vertexFaces[v])))
+		for _, f := range st.vertexFaces[v] {
+			s = append(s, stencils.faces[f].scaled(faceWeight)...)
+		}
+
+		for _, key := range st.vertexEdges[v] {
+			s = append(s, midpointStencil(key).scaled(2./(n*n))...)
+		}
+		stencils.vertices[v] = s
+	}
+
+	return stencils
+}
+
+// oppositeVertex is the vertex of a triangle not on the edge
+func oppositeVertex(face []int, key edgeKey) int {
+	for _, v := range face {
+		if v != key[0] && v != key[1] {
+			return v
+		}
+	}
+	return face[0]
+}
+
+func (st subdivisionTopology) loopStencils() subdivisionStencils {
+	stencils := subdivisionStencils{
+		vertices: make([]stencil, st.vertexCount),
+		edges:    make([]stencil, len(st.edgeOrder)),
+	}
+
+	for i, key := range st.edgeOrder {
+		edge := st.edges[key]
+		if edge.sharp {
+			stencils.edges[i] = midpointStencil(key)
+			continue
+		}
+
+		stencils.edges[i] = stencil{
+			{vertex: key[0], weight: 3. / 8.},
+			{vertex: key[1], weight: 3. / 8.},
+			{vertex: oppositeVertex(st.faces[edge.faces[0]], key), weight: 1. / 8.},
+			{vertex: oppositeVertex(st.faces[edge.faces[1]], key), weight: 1. / 8.},
+		}
+	}
+
+	for v := range st.vertexCount {
+		if s, ok := st.creaseStencil(v, 3./4., 1./8.); ok {
+			stencils.vertices[v] = s
+			continue
+		}
+
+		// Loop's original weights
+		n := float64(len(st.vertexEdges[v]))
+		inner := 3./8. + math.Cos(2*math.Pi/n)/4.
+		beta := (5./8. - inner*inner) / n
+
+		s := stencil{{vertex: v, weight: 1 - n*beta}}
+		for _, key := range st.vertexEdges[v] {
+			s = append(s, stencilWeight{vertex: otherVertex(key, v), weight: beta})
+		}
+		stencils.vertices[v] = s
+	}
+
+	return stencils
+}
+
+func (st subdivisionTopology) stencils(scheme subdivisionScheme) subdivisionStencils {
+	if scheme == loopScheme {
+		return st.loopStencils()
+	}
+	return st.catmullClarkStencils()
+}
+
+// polygonNormal uses Newell's method so non-planar quads are handled
+func polygonNormal(face []int, positions []vector3.Float64) vector3.Float64 {
+	normal := vector3.Zero[float64]()
+	for i, v := range face {
+		cur := positions[v]
+		next := positions[face[(i+1)%len(face)]]
+		normal = normal.Add(vector3.New(
+			(cur.Y()-next.Y())*(cur.Z()+next.Z()),
+			(cur.Z()-next.Z())*(cur.X()+next.X()),
+			(cur.X()-next.X())*(cur.Y()+next.Y()),
+		))
+	}
+	return normal
+}
+
+// flatAttribute stores the components of every element of an attribute
+// back to back
+type flatAttribute struct {
+	data       []float64
+	components int
+}
+
+func (fa flatAttribute) apply(stencils []stencil) flatAttribute {
+	out := flatAttribute{
+		data:       make([]float64, len(stencils)*fa.components),
+		components: fa.components,
+	}
+	for i, s := range stencils {
+		dst := out.data[i*fa.components : (i+1)*fa.components]
+		for _, w := range s {
+			src := fa.data[w.vertex*fa.components : (w.vertex+1)*fa.components]
+			for c := range dst {
+				dst[c] += src[c] * w.weight
+			}
+		}
+	}
+	return out
+}
+
+type subdivisionLevel struct {
+	mesh modeling.Mesh
+
+	// Edges kept sharp, in terms of the mesh's own indices
+	creases map[edgeKey]bool
+}
+
+func subdivideOnce(level subdivisionLevel, scheme subdivisionScheme, creaseAngle float64) subdivisionLevel {
+	m := level.mesh
+	size := m.Topology().IndexSize()
+	indices := m.Indices()
+	attributeCount := m.AttributeLength()
+
+	faces := make([][]int, 0, indices.Len()/size)
+	for start := 0; start+size <= indices.Len(); start += size {
+		face := make([]int, size)
+		for i := range face {
+			face[i] = indices.At(start + i)
+		}
+		faces = append(faces, face)
+	}
+
+	// Weld by position =======================================================
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	weld := make([]int, attributeCount)
+	welded := make([]vector3.Float64, 0)
+	lookup := make(map[vector3.Float64]int)
+	for i := range attributeCount {
+		p := positions.At(i)
+		v, ok := lookup[p]
+		if !ok {
+			v = len(welded)
+			lookup[p] = v
+			welded = append(welded, p)
+		}
+		weld[i] = v
+	}
+
+	weldedFaces := make([][]int, len(faces))
+	for f, face := range faces {
+		weldedFaces[f] = make([]int, len(face))
+		for i, v := range face {
+			weldedFaces[f][i] = weld[v]
+		}
+	}
+
+	weldedCreases := make(map[edgeKey]bool)
+	for key := range level.creases {
+		weldedCreases[newEdgeKey(weld[key[0]], weld[key[1]])] = true
+	}
+
+	var faceNormals []vector3.Float64
+	if creaseAngle > 0 {
+		faceNormals = make([]vector3.Float64, len(weldedFaces))
+		for f, face := range weldedFaces {
+			faceNormals[f] = polygonNormal(face, welded)
+		}
+	}
+
+	shape := newSubdivisionTopology(weldedFaces, len(welded), func(a, b int) bool {
+		return weldedCreases[newEdgeKey(a, b)]
+	})
+
+	if creaseAngle > 0 {
+		for _, edge := range shape.edges {
+			if edge.sharp {
+				continue
+			}
+			a := faceNormals[edge.faces[0]]
+			b := faceNormals[edge.faces[1]]
+			if a.Length() == 0 || b.Length() == 0 {
+				continue
+			}
+			edge.sharp = a.Angle(b) >= creaseAngle
+		}
+	}
+
+	attributes := newSubdivisionTopology(faces, attributeCount, func(a, b int) bool {
+		return shape.edges[newEdgeKey(weld[a], weld[b])].sharp
+	})
+
+	shapeStencils := shape.stencils(scheme)
+	attributeStencils := attributes.stencils(scheme)
+
+	// Layout of the new level ================================================
+	// [original vertices | edge points | face points]
+	edgeIndices := make(map[edgeKey]int, len(attributes.edgeOrder))
+	for i, key := range attributes.edgeOrder {
+		edgeIndices[key] = attributeCount + i
+	}
+	faceStart := attributeCount + len(attributes.edgeOrder)
+
+	newPositions := make([]stencil, 0, faceStart+len(faces))
+	for i := range attributeCount {
+		newPositions = append(newPositions, shapeStencils.vertices[weld[i]])
+	}
+
+	shapeEdgeIndices := make(map[edgeKey]int, len(shape.edgeOrder))
+	for i, key := range shape.edgeOrder {
+		shapeEdgeIndices[key] = i
+	}
+	for _, key := range attributes.edgeOrder {
+		newPositions = append(newPositions, shapeStencils.edges[shapeEdgeIndices[newEdgeKey(weld[key[0]], weld[key[1]])]])
+	}
+
+	vertexStencils := append(append([]stencil{}, attributeStencils.vertices...), attributeStencils.edges...)
+	if scheme == catmullClarkScheme {
+		newPositions = append(newPositions, shapeStencils.faces...)
+		vertexStencils = append(vertexStencils, attributeStencils.faces...)
+	}
+
+	// Connectivity of the new level ==========================================
+	newIndices := make([]int, 0)
+	creases := make(map[edgeKey]bool)
+	for f, face := range faces {
+		for i, v := range face {
+			next := face[(i+1)%len(face)]
+			if weld[v] == weld[next] {
+				continue
+			}
+
+			// Boundaries are found again at the next level, only creases
+			// need to be carried over
+			shapeEdge := shape.edges[newEdgeKey(weld[v], weld[next])]
+			if shapeEdge.sharp && len(shapeEdge.faces) == 2 {
+				mid := edgeIndices[newEdgeKey(v, next)]
+				creases[newEdgeKey(v, mid)] = true
+				creases[newEdgeKey(mid, next)] = true
+			}
+		}
+
+		if scheme == catmullClarkScheme {
+			for i, v := range face {
+				next := edgeIndices[newEdgeKey(v, face[(i+1)%len(face)])]
+				prev := edgeIndices[newEdgeKey(face[(i+len(face)-1)%len(face)], v)]
+				newIndices = append(newIndices, v, next, faceStart+f, prev)
+			}
+			continue
+		}
+
+		ab := edgeIndices[newEdgeKey(face[0], face[1])]
+		bc := edgeIndices[newEdgeKey(face[1], face[2])]
+		ca := edgeIndices[newEdgeKey(face[2], face[0])]
+		newIndices = append(newIndices,
+			face[0], ab, ca,
+			ab, face[1], bc,
+			ca, bc, face[2],
+			ab, bc, ca,
+		)
+	}
+
+	// Interpolate attributes =================================================
+	topology := modeling.TriangleTopology
+	if scheme == catmullClarkScheme {
+		topology = modeling.QuadTopology
+	}
+	result := modeling.NewMesh(topology, newIndices)
+
+	weldedPositions := flatAttribute{data: make([]float64, len(welded)*3), components: 3}
+	for i, p := range welded {
+		weldedPositions.data[i*3] = p.X()
+		weldedPositions.data[i*3+1] = p.Y()
+		weldedPositions.data[i*3+2] = p.Z()
+	}
+	interpolated := weldedPositions.apply(newPositions).data
+	newVertexPositions := make([]vector3.Float64, len(newPositions))
+	for i := range newVertexPositions {
+		newVertexPositions[i] = vector3.New(interpolated[i*3], interpolated[i*3+1], interpolated[i*3+2])
+	}
+	result = result.SetFloat3Attribute(modeling.PositionAttribute, newVertexPositions)
+
+	for _, attr := range m.Float4Attributes() {
+		data := m.Float4Attribute(attr)
+		flat := flatAttribute{data: make([]float64, 0, data.Len()*4), components: 4}
+		for i := range data.Len() {
+			v := data.At(i)
+			flat.data = append(flat.data, v.X(), v.Y(), v.Z(), v.W())
+		}
+		interpolated := flat.apply(vertexStencils).data
+		values := make([]vector4.Float64, len(vertexStencils))
+		for i := range values {
+			values[i] = vector4.New(interpolated[i*4], interpolated[i*4+1], interpolated[i*4+2], interpolated[i*4+3])
+		}
+		result = result.SetFloat4Attribute(attr, values)
+	}
+
+	for _, attr := range m.Float3Attributes() {
+		if attr == modeling.PositionAttribute {
+			continue
+		}
+		data := m.Float3Attribute(attr)
+		flat := flatAttribute{data: make([]float64, 0, data.Len()*3), components: 3}
+		for i := range data.Len() {
+			v := data.At(i)
+			flat.data = append(flat.data, v.X(), v.Y(), v.Z())
+		}
+		interpolated := flat.apply(vertexStencils).data
+		values := make([]vector3.Float64, len(vertexStencils))
+		for i := range values {
+			values[i] = vector3.New(interpolated[i*3], interpolated[i*3+1], interpolated[i*3+2])
+			if attr == modeling.NormalAttribute && values[i].Length() > 0 {
+				values[i] = values[i].Normalized()
+			}
+		}
+		result = result.SetFloat3Attribute(attr, values)
+	}
+
+	for _, attr := range m.Float2Attributes() {
+		data := m.Float2Attribute(attr)
+		flat := flatAttribute{data: make([]float64, 0, data.Len()*2), components: 2}
+		for i := range data.Len() {
+			v := data.At(i)
+			flat.data = append(flat.data, v.X(), v.Y())
+		}
+		interpolated := flat.apply(vertexStencils).data
+		values := make([]vector2.Float64, len(vertexStencils))
+		for i := range values {
+			values[i] = vector2.New(interpolated[i*2], interpolated[i*2+1])
+		}
+		result = result.SetFloat2Attribute(attr, values)
+	}
+
+	for _, attr := range m.Float1Attributes() {
+		data := m.Float1Attribute(attr)
+		flat := flatAttribute{data: make([]float64, data.Len()), components: 1}
+		for i := range data.Len() {
+			flat.data[i] = data.At(i)
+		}
+		result = result.SetFloat1Attribute(attr, flat.apply(vertexStencils).data)
+	}
+
+	return subdivisionLevel{mesh: result, creases: creases}
+}
+
+func subdivide(m modeling.Mesh, scheme subdivisionScheme, iterations int, creaseAngle float64) modeling.Mesh {
+	level := subdivisionLevel{mesh: m, creases: make(map[edgeKey]bool)}
+	for i := range iterations {
+		// Creases found by angle carry over to each following level, rather
+		// than being measured again on the smoothed surface
+		angle := creaseAngle
+		if i > 0 {
+			angle = 0
+		}
+		level = subdivideOnce(level, scheme, angle)
+	}
+	return level.mesh
+}
@@ -0,0 +1,208 @@
+package meshops_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quadCube is a cube spanning -1 to 1 built from 6 quads sharing 8 vertices
+func quadCube() modeling.Mesh {
+	return modeling.NewMesh(modeling.QuadTopology, []int{
+		0, 1, 3, 2, // left
+		4, 6, 7, 5, // right
+		0, 4, 5, 1, // bottom
+		2, 3, 7, 6, // top
+		0, 2, 6, 4, // back
+		1, 5, 7, 3, // front
+	}).SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+		vector3.New(-1., -1., -1.),
+		vector3.New(-1., -1., 1.),
+		vector3.New(-1., 1., -1.),
+		vector3.New(-1., 1., 1.),
+		vector3.New(1., -1., -1.),
+		vector3.New(1., -1., 1.),
+		vector3.New(1., 1., -1.),
+		vector3.New(1., 1., 1.),
+	})
+}
+
+func assertVectorInDelta(t *testing.T, expected, actual vector3.Float64) {
+	t.Helper()
+	assert.InDelta(t, expected.X(), actual.X(), 1e-9)
+	assert.InDelta(t, expected.Y(), actual.Y(), 1e-9)
+	assert.InDelta(t, expected.Z(), actual.Z(), 1e-9)
+}
+
+func uniquePositions(m modeling.Mesh) map[vector3.Float64]bool {
+	unique := make(map[vector3.Float64]bool)
+	m.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		unique[v] = true
+	})
+	return unique
+}
+
+func TestCatmullClark_Cube(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := quadCube()
+
+	// ACT ====================================================================
+	subdivided := cube.Transform(meshops.CatmullClarkTransformer{Iterations: 1})
+
+	// ASSERT =================================================================
+	assert.Equal(t, modeling.QuadTopology, subdivided.Topology())
+	assert.Equal(t, 24, subdivided.PrimitiveCount())
+	require.Equal(t, 8+12+6, subdivided.AttributeLength())
+
+	positions := subdivided.Float3Attribute(modeling.PositionAttribute)
+
+	// Original vertices: (F + 2R + (n-3)P) / n
+	assertVectorInDelta(t, vector3.Fill(5./9.), positions.At(7))
+	assertVectorInDelta(t, vector3.Fill(-5./9.), positions.At(0))
+
+	// Face points: centroid of the face
+	assertVectorInDelta(t, vector3.New(-1., 0., 0.), positions.At(8+12))
+
+	// Edge points: average of the edge's endpoints and neighboring face points
+	assertVectorInDelta(t, vector3.New(-0.75, -0.75, 0.), positions.At(8))
+}
+
+func TestCatmullClark_CreaseAngleKeepsCubeSharp(t *testing.T) {
+	// ACT ====================================================================
+	subdivided := meshops.CatmullClark(quadCube(), 2, math.Pi/4)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 6*16, subdivided.PrimitiveCount())
+	subdivided.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 1., v.Abs().MaxComponent(), 1e-9, "vertex %d left the surface of the cube", i)
+	})
+}
+
+func TestCatmullClark_SeamsDontCrack(t *testing.T) {
+	// ARRANGE ================================================================
+	// Every face has its own vertices, giving each face its own UVs
+	welded := quadCube()
+	positions := welded.Float3Attribute(modeling.PositionAttribute)
+	indices := welded.Indices()
+
+	unweldedPositions := make([]vector3.Float64, indices.Len())
+	uvs := make([]vector2.Float64, indices.Len())
+	corners := []vector2.Float64{vector2.New(0., 0.), vector2.New(1., 0.), vector2.New(1., 1.), vector2.New(0., 1.)}
+	unweldedIndices := make([]int, indices.Len())
+	for i := range indices.Len() {
+		unweldedPositions[i] = positions.At(indices.At(i))
+		uvs[i] = corners[i%4]
+		unweldedIndices[i] = i
+	}
+
+	cube := modeling.NewMesh(modeling.QuadTopology, unweldedIndices).
+		SetFloat3Attribute(modeling.PositionAttribute, unweldedPositions).
+		SetFloat2Attribute(modeling.TexCoordAttribute, uvs)
+
+	// ACT ====================================================================
+	subdivided := meshops.CatmullClark(cube, 1, 0)
+
+	// ASSERT =================================================================
+	// Shape matches that of the welded cube
+	expected := uniquePositions(meshops.CatmullClark(welded, 1, 0))
+	assert.Equal(t, expected, uniquePositions(subdivided))
+
+	// Each face keeps the full UV square
+	faceUVs := subdivided.Float2Attribute(modeling.TexCoordAttribute)
+	subdivided.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		uv := faceUVs.At(i)
+		assert.GreaterOrEqual(t, uv.MinComponent(), 0.)
+		assert.LessOrEqual(t, uv.MaxComponent(), 1.)
+	})
+}
+
+func TestCatmullClark_OpenQuadBoundary(t *testing.T) {
+	// ARRANGE ================================================================
+	quad := modeling.NewMesh(modeling.QuadTopology, []int{0, 1, 2, 3}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(0., 1., 0.),
+		})
+
+	// ACT ====================================================================
+	subdivided := meshops.CatmullClark(quad, 1, 0)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 4, subdivided.PrimitiveCount())
+	positions := subdivided.Float3Attribute(modeling.PositionAttribute)
+
+	// Boundary vertices follow the boundary curve: 6/8 P + 1/8 (A + B)
+	assertVectorInDelta(t, vector3.New(0.125, 0.125, 0.), positions.At(0))
+
+	// Boundary edges are split at their midpoint
+	assertVectorInDelta(t, vector3.New(0.5, 0., 0.), positions.At(4))
+
+	assertVectorInDelta(t, vector3.New(0.5, 0.5, 0.), positions.At(8))
+}
+
+func TestLoopSubdivision_Octahedron(t *testing.T) {
+	// ARRANGE ================================================================
+	octahedron := modeling.NewTriangleMesh([]int{
+		0, 2, 4, 2, 1, 4, 1, 3, 4, 3, 0, 4,
+		2, 0, 5, 1, 2, 5, 3, 1, 5, 0, 3, 5,
+	}).SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+		vector3.New(1., 0., 0.),
+		vector3.New(-1., 0., 0.),
+		vector3.New(0., 0., 1.),
+		vector3.New(0., 0., -1.),
+		vector3.New(0., 1., 0.),
+		vector3.New(0., -1., 0.),
+	})
+
+	// ACT ====================================================================
+	subdivided := octahedron.Transform(meshops.LoopSubdivisionTransformer{Iterations: 1})
+
+	// ASSERT =================================================================
+	assert.Equal(t, modeling.TriangleTopology, subdivided.Topology())
+	assert.Equal(t, 32, subdivided.PrimitiveCount())
+	require.Equal(t, 6+12, subdivided.AttributeLength())
+
+	positions := subdivided.Float3Attribute(modeling.PositionAttribute)
+
+	// Valence 4: beta = (5/8 - (3/8 + cos(pi/2)/4)^2) / 4
+	beta := (5./8. - (3./8.)*(3./8.)) / 4.
+	assertVectorInDelta(t, vector3.New(1-4*beta, 0., 0.), positions.At(0))
+
+	// Edge points: 3/8 of each endpoint and 1/8 of each opposite vertex
+	assertVectorInDelta(t, vector3.New(3./8., 0., 3./8.), positions.At(6))
+}
+
+func TestLoopSubdivision_RequiresTriangles(t *testing.T) {
+	_, err := meshops.LoopSubdivisionTransformer{Iterations: 1}.Transform(quadCube())
+	assert.EqualError(t, err, "mesh is required to have a triangle topology")
+}
+
+func TestCatmullClarkNode_Triangles(t *testing.T) {
+	// ARRANGE ================================================================
+	node := &nodes.Struct[meshops.CatmullClarkNode]{
+		Data: meshops.CatmullClarkNode{
+			Mesh: nodes.ConstOutput[modeling.Mesh]{Val: primitives.UnitCube()},
+		},
+	}
+
+	// ACT ====================================================================
+	triangles := nodes.GetNodeOutputPort[modeling.Mesh](node, "Triangles").Value()
+	quads := nodes.GetNodeOutputPort[modeling.Mesh](node, "Quads").Value()
+
+	// ASSERT =================================================================
+	assert.Equal(t, modeling.TriangleTopology, triangles.Topology())
+	assert.Equal(t, modeling.QuadTopology, quads.Topology())
+	assert.Equal(t, 12*3, quads.PrimitiveCount())
+	assert.Equal(t, quads.PrimitiveCount()*2, triangles.PrimitiveCount())
+	assert.True(t, triangles.HasFloat3Attribute(modeling.NormalAttribute))
+}
@@ -0,0 +1,272 @@
+package meshops
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+type TangentsTransformer struct{}
+
+func (tt TangentsTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.NormalAttribute); err != nil {
+		return
+	}
+
+	if err = RequireV2Attribute(m, modeling.TexCoordAttribute); err != nil {
+		return
+	}
+
+	return Tangents(m), nil
+}
+
+// tangentKey groups the corners of every triangle that MikkTSpace considers
+// to be the same vertex. Corners are only shared when their position, normal
+// and texture coordinate match exactly, and the UVs of their triangles are
+// wound the same way.
+type tangentKey struct {
+	position vector3.Float64
+	normal   vector3.Float64
+	uv       vector2.Float64
+	mirrored bool
+}
+
+// projectOntoPlane removes the component of v along the normal
+func projectOntoPlane(v, normal vector3.Float64) vector3.Float64 {
+	return v.Sub(normal.Scale(normal.Dot(v)))
+}
+
+// Tangents computes a tangent for each vertex following the same approach as
+// MikkTSpace, the convention used by Blender, Unity, Unreal and the glTF
+// sample viewer. The tangent of each triangle is taken from its texture
+// coordinates, made perpendicular to the vertex normal, and averaged with
+// the other triangles sharing the vertex weighted by the angle of each
+// triangle's corner.
+//
+// Tangents are written to modeling.TangentAttribute, with W holding the
+// handedness of the bitangent, such that
+// bitangent = cross(normal, tangent.xyz) * tangent.w. Vertices shared by
+// triangles with both mirrored and unmirrored texture coordinates are
+// duplicated, as no single tangent can serve both.
+func Tangents(m modeling.Mesh) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+	check(RequireV3Attribute(m, modeling.NormalAttribute))
+	check(RequireV2Attribute(m, modeling.TexCoordAttribute))
+
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	normals := m.Float3Attribute(modeling.NormalAttribute)
+	uvs := m.Float2Attribute(modeling.TexCoordAttribute)
+	indices := m.Indices()
+
+	keyOf := func(v int, mirrored bool) tangentKey {
+		return tangentKey{
+			position: positions.At(v),
+			normal:   normals.At(v),
+			uv:       uvs.At(v),
+			mirrored: mirrored,
+		}
+	}
+
+	sums := make(map[tangentKey]vector3.Float64)
+	mirroredFaces := make([]bool, indices.Len()/3)
+	for f := range mirroredFaces {
+		corners := [3]int{indices.At(f * 3), indices.At(f*3 + 1), indices.At(f*3 + 2)}
+
+		p0 := positions.At(corners[0])
+		d1 := positions.At(corners[1]).Sub(p0)
+		d2 := positions.At(corners[2]).Sub(p0)
+
+		t0 := uvs.At(corners[0])
+		t1 := uvs.At(corners[1]).Sub(t0)
+		t2 := uvs.At(corners[2]).Sub(t0)
+
+		// Triangles with no area in UV space still contribute their corners
+		// to a group, but not a direction
+		signedUVArea := t1.X()*t2.Y() - t1.Y()*t2.X()
+		mirrored := signedUVArea < 0
+		mirroredFaces[f] = mirrored
+
+		tangent := d1.Scale(t2.Y()).Sub(d2.Scale(t1.Y()))
+		if mirrored {
+			tangent = tangent.Scale(-1)
+		}
+		if signedUVArea == 0 || tangent.ContainsNaN() {
+			tangent = vector3.Zero[float64]()
+		}
+
+		for c, v := range corners {
+			key := keyOf(v, mirrored)
+			normal := key.normal
+
+			// Weight by the angle of the corner, measured in the plane the
+			// vertex's normal defines
+			next := projectOntoPlane(positions.At(corners[(c+1)%3]).Sub(key.position), normal)
+			prev := projectOntoPlane(positions.At(corners[(c+2)%3]).Sub(key.position), normal)
+			weight := 0.
+			if next.LengthSquared() > 0 && prev.LengthSquared() > 0 {
+				weight = math.Acos(math.Max(-1, math.Min(1, next.Normalized().Dot(prev.Normalized()))))
+			}
+
+			projected := projectOntoPlane(tangent, normal)
+			if projected.LengthSquared() > 0 {
+				projected = projected.Normalized().Scale(weight)
+			}
+			sums[key] = sums[key].Add(projected)
+		}
+	}
+
+	tangentOf := func(key tangentKey) vector4.Float64 {
+		tangent := sums[key]
+		if tangent.LengthSquared() > 0 {
+			tangent = tangent.Normalized()
+		} else {
+			// Nothing to go off of, so any direction perpendicular to the
+			// normal will do
+			helper := vector3.Right[float64]()
+			if math.Abs(key.normal.Dot(helper)) > 0.9 {
+				helper = vector3.Up[float64]()
+			}
+			tangent = projectOntoPlane(helper, key.normal)
+			if tangent.LengthSquared() > 0 {
+				tangent = tangent.Normalized()
+			}
+		}
+
+		handedness := 1.
+		if key.mirrored {
+			handedness = -1
+		}
+		return vector4.New(tangent.X(), tangent.Y(), tangent.Z(), handedness)
+	}
+
+	// Each vertex keeps its index for the first orientation it's used with,
+	// and is duplicated if it's also used with the other
+	tangents := make([]vector4.Float64, m.AttributeLength())
+	orientation := make([]int, m.AttributeLength()) // 0 unused, 1 unmirrored, 2 mirrored
+	duplicates := make(map[int]int)
+	duplicated := make([]int, 0)
+	newIndices := make([]int, indices.Len())
+	for i := range newIndices {
+		v := indices.At(i)
+		mirrored := false
+		if i/3 < len(mirroredFaces) {
+			mirrored = mirroredFaces[i/3]
+		}
+
+		want := 1
+		if mirrored {
+			want = 2
+		}
+
+		switch orientation[v] {
+		case 0:
+			orientation[v] = want
+			tangents[v] = tangentOf(keyOf(v, mirrored))
+			newIndices[i] = v
+
+		case want:
+			newIndices[i] = v
+
+		default:
+			d, ok := duplicates[v]
+			if !ok {
+				d = m.AttributeLength() + len(duplicated)
+				duplicates[v] = d
+				duplicated = append(duplicated, v)
+				tangents = append(tangents, tangentOf(keyOf(v, mirrored)))
+			}
+			newIndices[i] = d
+		}
+	}
+
+	for v, o := range orientation {
+		if o == 0 {
+			tangents[v] = tangentOf(keyOf(v, false))
+		}
+	}
+
+	if len(duplicated) == 0 {
+		return m.SetFloat4Attribute(modeling.TangentAttribute, tangents)
+	}
+
+	v4 := readAllFloat4Data(m)
+	for attr, data := range v4 {
+		for _, v := range duplicated {
+			data = append(data, data[v])
+		}
+		v4[attr] = data
+	}
+	v4[modeling.TangentAttribute] = tangents
+
+	v3 := readAllFloat3Data(m)
+	for attr, data := range v3 {
+		for _, v := range duplicated {
+			data = append(data, data[v])
+		}
+		v3[attr] = data
+	}
+
+	v2 := readAllFloat2Data(m)
+	for attr, data := range v2 {
+		for _, v := range duplicated {
+			data = append(data, data[v])
+		}
+		v2[attr] = data
+	}
+
+	v1 := readAllFloat1Data(m)
+	for attr, data := range v1 {
+		for _, v := range duplicated {
+			data = append(data, data[v])
+		}
+		v1[attr] = data
+	}
+
+	return modeling.NewTriangleMesh(newIndices).
+		SetFloat4Data(v4).
+		SetFloat3Data(v3).
+		SetFloat2Data(v2).
+		SetFloat1Data(v1)
+}
+
+type TangentsNode struct {
+	Mesh nodes.Output[modeling.Mesh] `description:"The triangle mesh with normals and texture coordinates to compute tangents for."`
+}
+
+func (tn TangentsNode) Description() string {
+	return "Computes MikkTSpace compatible tangents from the positions, normals and texture coordinates of the mesh, for use with tangent space normal maps."
+}
+
+func (tn TangentsNode) CacheVersion() int {
+	return 1
+}
+
+func (tn TangentsNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	if tn.Mesh == nil {
+		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
+		return
+	}
+
+	mesh := nodes.GetOutputValue(out, tn.Mesh)
+	result, err := TangentsTransformer{}.Transform(mesh)
+	if err != nil {
+		out.Set(mesh)
+		out.CaptureError(fmt.Errorf("can't calculate tangents: %w", err))
+		return
+	}
+	out.Set(result)
+}
@@ -0,0 +1,158 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tangentStrip builds a row of quads in the XY plane facing +Z, with U
+// running along X for each quad unless it's mirrored
+func tangentStrip(mirrored ...bool) modeling.Mesh {
+	positions := make([]vector3.Float64, 0)
+	normals := make([]vector3.Float64, 0)
+	uvs := make([]vector2.Float64, 0)
+	u := 0.
+	for i := 0; i <= len(mirrored); i++ {
+		for _, y := range []float64{0, 1} {
+			positions = append(positions, vector3.New(float64(i), y, 0))
+			normals = append(normals, vector3.Forward[float64]())
+			uvs = append(uvs, vector2.New(u, y))
+		}
+		if i < len(mirrored) && mirrored[i] {
+			u--
+		} else {
+			u++
+		}
+	}
+
+	indices := make([]int, 0)
+	for i := range mirrored {
+		bottom := i * 2
+		indices = append(indices, bottom, bottom+2, bottom+3, bottom, bottom+3, bottom+1)
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat3Attribute(modeling.NormalAttribute, normals).
+		SetFloat2Attribute(modeling.TexCoordAttribute, uvs)
+}
+
+func TestTangents(t *testing.T) {
+	tests := map[string]struct {
+		input    modeling.Mesh
+		vertices int
+		tangents map[vector3.Float64][]vector4.Float64
+	}{
+		"single quad": {
+			input:    tangentStrip(false),
+			vertices: 4,
+			tangents: map[vector3.Float64][]vector4.Float64{
+				vector3.New(0., 0., 0.): {vector4.New(1., 0., 0., 1.)},
+				vector3.New(1., 1., 0.): {vector4.New(1., 0., 0., 1.)},
+			},
+		},
+		"mirrored quad": {
+			input:    tangentStrip(true),
+			vertices: 4,
+			tangents: map[vector3.Float64][]vector4.Float64{
+				vector3.New(0., 0., 0.): {vector4.New(-1., 0., 0., -1.)},
+				vector3.New(1., 1., 0.): {vector4.New(-1., 0., 0., -1.)},
+			},
+		},
+		"mirror seam splits vertices": {
+			input:    tangentStrip(false, true),
+			vertices: 8,
+			tangents: map[vector3.Float64][]vector4.Float64{
+				vector3.New(0., 0., 0.): {vector4.New(1., 0., 0., 1.)},
+				vector3.New(1., 0., 0.): {vector4.New(1., 0., 0., 1.), vector4.New(-1., 0., 0., -1.)},
+				vector3.New(2., 0., 0.): {vector4.New(-1., 0., 0., -1.)},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ACT ============================================================
+			result, err := meshops.TangentsTransformer{}.Transform(tc.input)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			require.True(t, result.HasFloat4Attribute(modeling.TangentAttribute))
+			assert.Equal(t, tc.vertices, result.AttributeLength())
+			assert.Equal(t, tc.input.PrimitiveCount(), result.PrimitiveCount())
+
+			positions := result.Float3Attribute(modeling.PositionAttribute)
+			tangents := result.Float4Attribute(modeling.TangentAttribute)
+			found := make(map[vector3.Float64][]vector4.Float64)
+			for i := range positions.Len() {
+				found[positions.At(i)] = append(found[positions.At(i)], tangents.At(i))
+			}
+
+			for position, expected := range tc.tangents {
+				assert.ElementsMatch(t, expected, found[position], "tangents at %v", position)
+			}
+
+			// Every triangle should still face the same way
+			for i := range result.PrimitiveCount() {
+				tri := result.Tri(i)
+				normal := tri.P2Vec3Attr(modeling.PositionAttribute).Sub(tri.P1Vec3Attr(modeling.PositionAttribute)).
+					Cross(tri.P3Vec3Attr(modeling.PositionAttribute).Sub(tri.P1Vec3Attr(modeling.PositionAttribute)))
+				assert.Positive(t, normal.Z())
+			}
+		})
+	}
+}
+
+func TestTangents_OrthogonalToNormals(t *testing.T) {
+	// ARRANGE ================================================================
+	input := tangentStrip(false, false, false)
+	normals := make([]vector3.Float64, input.AttributeLength())
+	for i := range normals {
+		normals[i] = vector3.New(float64(i%3)-1, 0.5, 1).Normalized()
+	}
+	input = input.SetFloat3Attribute(modeling.NormalAttribute, normals)
+
+	// ACT ====================================================================
+	result := meshops.Tangents(input)
+
+	// ASSERT =================================================================
+	tangents := result.Float4Attribute(modeling.TangentAttribute)
+	for i := range tangents.Len() {
+		tangent := tangents.At(i)
+		assert.InDelta(t, 1, tangent.XYZ().Length(), 1e-9)
+		assert.InDelta(t, 0, tangent.XYZ().Dot(normals[i]), 1e-9)
+		assert.Equal(t, 1., tangent.W())
+	}
+}
+
+func TestTangents_RequiresTexCoords(t *testing.T) {
+	input := tangentStrip(false)
+
+	_, err := meshops.TangentsTransformer{}.Transform(
+		modeling.NewTriangleMesh([]int{0, 1, 2}).
+			CopyFloat3Attribute(input, modeling.PositionAttribute).
+			CopyFloat3Attribute(input, modeling.NormalAttribute),
+	)
+
+	assert.EqualError(t, err, "mesh is required to have the vector2 attribute: 'TexCoord'")
+}
+
+func TestTangentsNode(t *testing.T) {
+	node := &nodes.Struct[meshops.TangentsNode]{
+		Data: meshops.TangentsNode{
+			Mesh: nodes.ConstOutput[modeling.Mesh]{Val: tangentStrip(false)},
+		},
+	}
+
+	result := nodes.GetNodeOutputPort[modeling.Mesh](node, "Out").Value()
+
+	assert.Equal(t, vector4.New(1., 0., 0., 1.), result.Float4Attribute(modeling.TangentAttribute).At(0))
+}
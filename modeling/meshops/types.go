@@ -24,6 +24,7 @@ func init() {
 	refutil.RegisterType[nodes.Struct[SmoothNormalsNode]](factory)
 	refutil.RegisterType[nodes.Struct[SmoothNormalsImplicitWeldNode]](factory)
 	refutil.RegisterType[nodes.Struct[FlatNormalsNode]](factory)
+	refutil.RegisterType[nodes.Struct[TangentsNode]](factory)
 
 	refutil.RegisterType[nodes.Struct[ScaleAttribute3DNode]](factory)
 	refutil.RegisterType[nodes.Struct[ScaleAttributeAlongNormalNode]](factory)
@@ -31,5 +32,12 @@ func init() {
 	refutil.RegisterType[nodes.Struct[SliceAttributeByPlaneNode]](factory)
 	refutil.RegisterType[nodes.Struct[FlipTriangleWindingNode]](factory)
 
+	refutil.RegisterType[nodes.Struct[CatmullClarkNode]](factory)
+	refutil.RegisterType[nodes.Struct[LoopSubdivisionNode]](factory)
+
+	refutil.RegisterType[nodes.Struct[BooleanUnionNode]](factory)
+	refutil.RegisterType[nodes.Struct[BooleanDifferenceNode]](factory)
+	refutil.RegisterType[nodes.Struct[BooleanIntersectionNode]](factory)
+
 	generator.RegisterTypes(factory)
 }
@@ -75,6 +75,10 @@ type SrgbToLinearNode struct {
 	Mesh      nodes.Output[modeling.Mesh]
 }
 
+func (n SrgbToLinearNode) CacheVersion() int {
+	return 1
+}
+
 func (n SrgbToLinearNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if n.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -92,6 +96,10 @@ type LinearToSRGBNode struct {
 	Mesh      nodes.Output[modeling.Mesh]
 }
 
+func (n LinearToSRGBNode) CacheVersion() int {
+	return 1
+}
+
 func (n LinearToSRGBNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if n.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
@@ -132,6 +132,10 @@ func collapseMapEntries[T any](recorder nodes.ExecutionRecorder, entries []nodes
 	return result
 }
 
+func (nmn NewMeshNode) CacheVersion() int {
+	return 1
+}
+
 func (nmn NewMeshNode) Mesh(out *nodes.StructOutput[Mesh]) {
 	mesh := NewMesh(
 		nodes.TryGetOutputValue(out, nmn.Topology, TriangleTopology),
@@ -259,6 +263,10 @@ type SetAttribute1DNode struct {
 	Data      nodes.Output[[]float64]
 }
 
+func (n SetAttribute1DNode) CacheVersion() int {
+	return 1
+}
+
 func (n SetAttribute1DNode) Out(out *nodes.StructOutput[Mesh]) {
 	setAttribute(out, n.Mesh, n.Attribute, n.Data, Mesh.SetFloat1Attribute)
 }
@@ -269,6 +277,10 @@ type SetAttribute2DNode struct {
 	Data      nodes.Output[[]vector2.Float64]
 }
 
+func (n SetAttribute2DNode) CacheVersion() int {
+	return 1
+}
+
 func (n SetAttribute2DNode) Out(out *nodes.StructOutput[Mesh]) {
 	setAttribute(out, n.Mesh, n.Attribute, n.Data, Mesh.SetFloat2Attribute)
 }
@@ -279,6 +291,10 @@ type SetAttribute3DNode struct {
 	Data      nodes.Output[[]vector3.Float64]
 }
 
+func (n SetAttribute3DNode) CacheVersion() int {
+	return 1
+}
+
 func (n SetAttribute3DNode) Out(out *nodes.StructOutput[Mesh]) {
 	setAttribute(out, n.Mesh, n.Attribute, n.Data, Mesh.SetFloat3Attribute)
 }
@@ -289,6 +305,10 @@ type SetAttribute4DNode struct {
 	Data      nodes.Output[[]vector4.Float64]
 }
 
+func (n SetAttribute4DNode) CacheVersion() int {
+	return 1
+}
+
 func (n SetAttribute4DNode) Out(out *nodes.StructOutput[Mesh]) {
 	setAttribute(out, n.Mesh, n.Attribute, n.Data, Mesh.SetFloat4Attribute)
 }
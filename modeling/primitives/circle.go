@@ -97,6 +97,10 @@ type CircleNode struct {
 	UVs    nodes.Output[CircleUVs]
 }
 
+func (c CircleNode) CacheVersion() int {
+	return 1
+}
+
 func (c CircleNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	circle := Circle{
 		Radius: nodes.TryGetOutputValue(out, c.Radius, 0.5),
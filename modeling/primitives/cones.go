@@ -43,6 +43,10 @@ type ConeNode struct {
 	Sides  nodes.Output[int]
 }
 
+func (r ConeNode) CacheVersion() int {
+	return 1
+}
+
 func (r ConeNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	cone := Cone{
 		Height: nodes.TryGetOutputValue(out, r.Height, 1),
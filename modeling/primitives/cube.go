@@ -290,6 +290,10 @@ func (c CubeNode) Description() string {
 	return "An axis-aligned box, centered on the origin, built from 6 quads."
 }
 
+func (c CubeNode) CacheVersion() int {
+	return 1
+}
+
 func (c CubeNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	strip := &StripUVs{
 		Start: vector2.New(0, 0.5),
@@ -164,6 +164,10 @@ type CylinderNode struct {
 	UVs     nodes.Output[CylinderUVs]
 }
 
+func (hnd CylinderNode) CacheVersion() int {
+	return 1
+}
+
 func (hnd CylinderNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	bottomRadius := nodes.TryGetOutputValue(out, hnd.Radius, 0.5)
 	topRadius := nodes.TryGetOutputValue(out, hnd.Radius2, bottomRadius)
@@ -101,6 +101,10 @@ type HemisphereNode struct {
 	Capped  nodes.Output[bool]
 }
 
+func (hnd HemisphereNode) CacheVersion() int {
+	return 1
+}
+
 func (hnd HemisphereNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 
 	radius := nodes.TryGetOutputValue(out, hnd.Radius, 0.5)
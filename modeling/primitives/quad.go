@@ -125,6 +125,10 @@ type QuadNode struct {
 	UVs     nodes.Output[StripUVs]
 }
 
+func (c QuadNode) CacheVersion() int {
+	return 1
+}
+
 func (c QuadNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	quad := Quad{
 		Width:   nodes.TryGetOutputValue(out, c.Width, 1.),
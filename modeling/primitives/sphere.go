@@ -323,6 +323,10 @@ func (c UvSphereNode) Description() string {
 	return "A spherical mesh that is created by starting with a square grid, turning it into a cylinder, and then squeezing the top and bottom. It is the simplest way to create a sphere, but it has a poor vertex distribution."
 }
 
+func (c UvSphereNode) CacheVersion() int {
+	return 1
+}
+
 func (c UvSphereNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	radius := nodes.TryGetOutputValue(out, c.Radius, .5)
 	rows := max(nodes.TryGetOutputValue(out, c.Rows, 10), 2)
@@ -343,6 +347,10 @@ type QuadSphereNode struct {
 	UVs        nodes.Output[CubeUVs]
 }
 
+func (c QuadSphereNode) CacheVersion() int {
+	return 1
+}
+
 func (c QuadSphereNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	strip := &StripUVs{
 		Start: vector2.New(0, 0.5),
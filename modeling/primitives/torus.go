@@ -112,6 +112,10 @@ type TorusNode struct {
 	UVs             nodes.Output[TorusUVs]
 }
 
+func (c TorusNode) CacheVersion() int {
+	return 1
+}
+
 func (c TorusNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	circle := Torus{
 		MajorRadius:     nodes.TryGetOutputValue(out, c.MajorRadius, .5),
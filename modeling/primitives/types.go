@@ -42,6 +42,10 @@ var bunnyPLY []byte
 type StanfordBunny struct {
 }
 
+func (c StanfordBunny) CacheVersion() int {
+	return 1
+}
+
 func (c StanfordBunny) Bunny(out *nodes.StructOutput[modeling.Mesh]) {
 	bunny, err := ply.ReadMesh(bytes.NewReader(bunnyPLY))
 	if err != nil {
@@ -23,6 +23,10 @@ func (rnd MeshNode) Description() string {
 	return "Duplicates Mesh once per entry in Transforms and bakes every copy into one combined mesh."
 }
 
+func (rnd MeshNode) CacheVersion() int {
+	return 1
+}
+
 func (rnd MeshNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
 	if rnd.Mesh == nil {
 		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
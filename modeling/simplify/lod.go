@@ -0,0 +1,36 @@
+package simplify
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+// LODChain builds progressively simplified versions of a triangle mesh, each
+// level keeping ratio of the triangles from the level before it. The
+// returned chain starts with the first simplified level and does not include
+// the original mesh. The chain ends early once a level can no longer be
+// reduced any further.
+func LODChain(m modeling.Mesh, levels int, ratio float64, lockBoundary bool) []modeling.Mesh {
+	chain := make([]modeling.Mesh, 0, max(levels, 0))
+	if ratio <= 0 || ratio >= 1 {
+		return chain
+	}
+
+	current := m
+	for range levels {
+		count := current.PrimitiveCount()
+		target := int(float64(count) * ratio)
+		if target < 1 {
+			break
+		}
+
+		next := QuadricDecimation(current, target, 0, lockBoundary)
+		if next.PrimitiveCount() >= count {
+			break
+		}
+
+		chain = append(chain, next)
+		current = next
+	}
+
+	return chain
+}
@@ -0,0 +1,74 @@
+package simplify
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+
+	refutil.RegisterType[nodes.Struct[QuadricDecimationNode]](factory)
+
+	generator.RegisterTypes(factory)
+}
+
+type QuadricDecimationNode struct {
+	Mesh           nodes.Output[modeling.Mesh] `description:"The triangle mesh to simplify."`
+	TriangleCount  nodes.Output[int]           `description:"Number of triangles to reduce the mesh to. Takes priority over Ratio when set."`
+	Ratio          nodes.Output[float64]       `description:"Fraction of the original triangles to keep when TriangleCount isn't set. Defaults to 0.5."`
+	MaxError       nodes.Output[float64]       `description:"Largest error a single edge collapse is allowed to introduce. Unbounded when not set."`
+	LockBoundaries nodes.Output[bool]          `description:"Prevent vertices along the boundary of the mesh from being moved or collapsed."`
+}
+
+func (qdn QuadricDecimationNode) Description() string {
+	return "Simplifies a triangle mesh by repeatedly collapsing the edge that introduces the least quadric error, interpolating all vertex attributes across each collapse."
+}
+
+func (qdn QuadricDecimationNode) CacheVersion() int {
+	return 1
+}
+
+func (qdn QuadricDecimationNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	if qdn.Mesh == nil {
+		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
+		return
+	}
+
+	mesh := nodes.GetOutputValue(out, qdn.Mesh)
+	if mesh.Topology() != modeling.TriangleTopology {
+		out.Set(mesh)
+		out.CaptureError(fmt.Errorf("can only simplify triangle meshes, received %s topology", mesh.Topology()))
+		return
+	}
+
+	if !mesh.HasFloat3Attribute(modeling.PositionAttribute) {
+		out.Set(mesh)
+		out.CaptureError(fmt.Errorf("can't simplify a mesh without position data"))
+		return
+	}
+
+	target := mesh.PrimitiveCount()
+	if qdn.TriangleCount != nil {
+		target = nodes.GetOutputValue(out, qdn.TriangleCount)
+	} else {
+		ratio := nodes.TryGetOutputValue(out, qdn.Ratio, 0.5)
+		target = int(float64(target) * max(0, min(1, ratio)))
+	}
+
+	simplified := QuadricDecimation(
+		mesh,
+		max(1, target),
+		max(0, nodes.TryGetOutputValue(out, qdn.MaxError, 0)),
+		nodes.TryGetOutputValue(out, qdn.LockBoundaries, false),
+	)
+	out.Set(simplified)
+
+	if target < mesh.PrimitiveCount() && simplified.PrimitiveCount() == mesh.PrimitiveCount() {
+		out.CaptureError(fmt.Errorf("unable to collapse any edges of the %d triangle mesh", mesh.PrimitiveCount()))
+	}
+}
@@ -1,11 +1,26 @@
 package simplify
 
 import (
+	"container/heap"
+	"encoding/binary"
+	"math"
+	"sort"
+
 	"github.com/EliCDavis/polyform/math/mat"
 	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector2"
 	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
 )
 
+// How heavily the planes running perpendicular along an unlocked boundary
+// edge are weighted relative to the planes of the faces themselves.
+const boundaryPenalty = 1000.
+
+// Weight applied to the squared length of an edge when ranking collapses
+const tieBreakWeight = 1e-9
+
 func QuadricVector(a mat.Matrix4x4) vector3.Float64 {
 	b := mat.Matrix4x4{
 		X00: a.X00, X01: a.X01, X02: a.X02, X03: a.X03,
@@ -23,6 +38,688 @@ func QuadricErrorForVector(a mat.Matrix4x4, v vector3.Float64) float64 {
 		v.X()*a.X03 + v.Y()*a.X13 + v.Z()*a.X23 + a.X33)
 }
 
-func QuadricDecimation(m modeling.Mesh) modeling.Mesh {
-	return m
+// PlaneQuadric builds the fundamental error quadric for the plane with the
+// provided normal passing through the point
+func PlaneQuadric(normal, point vector3.Float64) mat.Matrix4x4 {
+	a, b, c := normal.X(), normal.Y(), normal.Z()
+	d := -normal.Dot(point)
+	return mat.Matrix4x4{
+		X00: a * a, X01: a * b, X02: a * c, X03: a * d,
+		X10: a * b, X11: b * b, X12: b * c, X13: b * d,
+		X20: a * c, X21: b * c, X22: c * c, X23: c * d,
+		X30: a * d, X31: b * d, X32: c * d, X33: d * d,
+	}
+}
+
+func scaleQuadric(q mat.Matrix4x4, s float64) mat.Matrix4x4 {
+	return mat.Matrix4x4{
+		X00: q.X00 * s, X01: q.X01 * s, X02: q.X02 * s, X03: q.X03 * s,
+		X10: q.X10 * s, X11: q.X11 * s, X12: q.X12 * s, X13: q.X13 * s,
+		X20: q.X20 * s, X21: q.X21 * s, X22: q.X22 * s, X23: q.X23 * s,
+		X30: q.X30 * s, X31: q.X31 * s, X32: q.X32 * s, X33: q.X33 * s,
+	}
+}
+
+type QuadricDecimationTransformer struct {
+	// Number of triangles to reduce the mesh to. Values <= 0 leave MaxError
+	// as the only stopping condition.
+	TargetTriangleCount int
+
+	// Largest error a single edge collapse is allowed to introduce. Values <=
+	// 0 leave TargetTriangleCount as the only stopping condition.
+	MaxError float64
+
+	// Prevents vertices along the boundary of the mesh from being moved or
+	// collapsed.
+	LockBoundary bool
+}
+
+func (qdt QuadricDecimationTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = meshops.RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = meshops.RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return QuadricDecimation(m, qdt.TargetTriangleCount, qdt.MaxError, qdt.LockBoundary), nil
+}
+
+// QuadricDecimation simplifies the mesh using Garland and Heckbert's quadric
+// error metric, repeatedly collapsing the edge that introduces the least
+// error until either the mesh has been reduced to targetTriangleCount
+// triangles, or every remaining collapse would introduce more than maxError.
+//
+// All vertex attributes are interpolated along the collapsed edge. Vertices
+// that share a position, such as those along UV or normal seams or across
+// an entire flat shaded mesh, are collapsed together so the mesh never tears
+// open. Each keeps its own attributes, and seams are held in place the same
+// way boundaries are.
+func QuadricDecimation(m modeling.Mesh, targetTriangleCount int, maxError float64, lockBoundary bool) modeling.Mesh {
+	check(meshops.RequireTopology(m, modeling.TriangleTopology))
+	check(meshops.RequireV3Attribute(m, modeling.PositionAttribute))
+
+	if targetTriangleCount <= 0 && maxError <= 0 {
+		return m
+	}
+
+	d := newDecimator(m, lockBoundary)
+	d.run(targetTriangleCount, maxError)
+	return d.mesh(m.Topology())
+}
+
+func check(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+type edgeCollapse struct {
+	a, b     int
+	versionA int
+	versionB int
+	error    float64
+	cost     float64
+	position vector3.Float64
+	t        float64 // How far along a => b the collapse lands, for attribute interpolation
+}
+
+type edgeCollapseQueue []edgeCollapse
+
+func (q edgeCollapseQueue) Len() int           { return len(q) }
+func (q edgeCollapseQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q edgeCollapseQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *edgeCollapseQueue) Push(x any) {
+	*q = append(*q, x.(edgeCollapse))
+}
+
+func (q *edgeCollapseQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+	return item
+}
+
+// decimator simplifies the mesh welded by position, so vertices split along
+// UV or normal seams move together. Each distinct position is a node that
+// edges are collapsed between, while the original vertices are kept as the
+// wedges making up each face's corners, which keeps the attribute
+// discontinuities along seams intact.
+type decimator struct {
+	// Indexed by node
+	positions []vector3.Float64
+	quadrics  []mat.Matrix4x4
+	locked    []bool
+	removed   []bool
+	version   []int
+	nodeFaces [][]int
+
+	// Indexed by wedge
+	wedgeNode []int
+	v4Data    map[string][]vector4.Float64
+	v3Data    map[string][]vector3.Float64
+	v2Data    map[string][]vector2.Float64
+	v1Data    map[string][]float64
+
+	faces     [][3]int
+	faceAlive []bool
+	faceCount int
+
+	queue edgeCollapseQueue
+}
+
+func copyAttributes[T any](attributes []string, skip string, read func(string) []T) map[string][]T {
+	data := make(map[string][]T)
+	for _, attr := range attributes {
+		if attr == skip {
+			continue
+		}
+		data[attr] = read(attr)
+	}
+	return data
+}
+
+func readAll[T any](length int, at func(int) T) []T {
+	data := make([]T, length)
+	for i := range data {
+		data[i] = at(i)
+	}
+	return data
+}
+
+func newDecimator(m modeling.Mesh, lockBoundary bool) *decimator {
+	positionData := m.Float3Attribute(modeling.PositionAttribute)
+
+	d := &decimator{
+		wedgeNode: make([]int, positionData.Len()),
+		v4Data: copyAttributes(m.Float4Attributes(), "", func(s string) []vector4.Float64 {
+			it := m.Float4Attribute(s)
+			return readAll(it.Len(), it.At)
+		}),
+		v3Data: copyAttributes(m.Float3Attributes(), modeling.PositionAttribute, func(s string) []vector3.Float64 {
+			it := m.Float3Attribute(s)
+			return readAll(it.Len(), it.At)
+		}),
+		v2Data: copyAttributes(m.Float2Attributes(), "", func(s string) []vector2.Float64 {
+			it := m.Float2Attribute(s)
+			return readAll(it.Len(), it.At)
+		}),
+		v1Data: copyAttributes(m.Float1Attributes(), "", func(s string) []float64 {
+			it := m.Float1Attribute(s)
+			return readAll(it.Len(), it.At)
+		}),
+	}
+
+	// Wedges ==============================================================
+	// Vertices that are identical in every way are merged, leaving distinct
+	// wedges only where the attributes actually differ
+	wedges := make([]int, positionData.Len())
+	wedgeAtKey := make(map[string]int)
+	order := attributeOrder{
+		v4: sortedKeys(d.v4Data),
+		v3: sortedKeys(d.v3Data),
+		v2: sortedKeys(d.v2Data),
+		v1: sortedKeys(d.v1Data),
+	}
+	for v := range positionData.Len() {
+		key := d.vertexKey(order, v, positionData.At(v))
+		w, ok := wedgeAtKey[key]
+		if !ok {
+			w = v
+			wedgeAtKey[key] = w
+		}
+		wedges[v] = w
+	}
+
+	// Nodes ==================================================================
+	nodeAtPosition := make(map[vector3.Float64]int)
+	for w := range positionData.Len() {
+		p := positionData.At(w)
+		node, ok := nodeAtPosition[p]
+		if !ok {
+			node = len(d.positions)
+			nodeAtPosition[p] = node
+			d.positions = append(d.positions, p)
+		}
+		d.wedgeNode[w] = node
+	}
+
+	nodeCount := len(d.positions)
+	d.quadrics = make([]mat.Matrix4x4, nodeCount)
+	d.locked = make([]bool, nodeCount)
+	d.removed = make([]bool, nodeCount)
+	d.version = make([]int, nodeCount)
+	d.nodeFaces = make([][]int, nodeCount)
+
+	// Faces ==================================================================
+	type edge [2]int
+
+	// The wedges a face uses at either end of an edge, ordered the same as
+	// the edge's nodes
+	type edgeSide struct {
+		face   int
+		wedges [2]int
+	}
+
+	indices := m.Indices()
+	edgeSides := make(map[edge][]edgeSide)
+	for i := 0; i+3 <= indices.Len(); i += 3 {
+		face := [3]int{wedges[indices.At(i)], wedges[indices.At(i+1)], wedges[indices.At(i+2)]}
+		nodes := d.faceNodes(face)
+		if nodes[0] == nodes[1] || nodes[1] == nodes[2] || nodes[0] == nodes[2] {
+			continue
+		}
+
+		faceIndex := len(d.faces)
+		d.faces = append(d.faces, face)
+		d.faceAlive = append(d.faceAlive, true)
+		d.faceCount++
+
+		normal := d.faceNormal(face)
+		var q mat.Matrix4x4
+		if normal.Length() > 0 && !normal.ContainsNaN() {
+			q = PlaneQuadric(normal.Normalized(), d.positions[nodes[0]])
+		}
+
+		for c := range 3 {
+			v := nodes[c]
+			d.nodeFaces[v] = append(d.nodeFaces[v], faceIndex)
+			d.quadrics[v] = d.quadrics[v].Add(q)
+
+			e := edge{v, nodes[(c+1)%3]}
+			wedges := [2]int{face[c], face[(c+1)%3]}
+			if e[0] > e[1] {
+				e[0], e[1] = e[1], e[0]
+				wedges[0], wedges[1] = wedges[1], wedges[0]
+			}
+			edgeSides[e] = append(edgeSides[e], edgeSide{face: faceIndex, wedges: wedges})
+		}
+	}
+
+	// Boundaries and Seams ===================================================
+	for e, sides := range edgeSides {
+		seam := false
+		for _, side := range sides[1:] {
+			if side.wedges != sides[0].wedges {
+				seam = true
+			}
+		}
+
+		if len(sides) == 1 && lockBoundary {
+			d.locked[e[0]] = true
+			d.locked[e[1]] = true
+			continue
+		}
+
+		if len(sides) != 1 && !seam {
+			continue
+		}
+
+		// Constrain the boundary or seam with a plane perpendicular to each
+		// face that runs along the edge, so it keeps its shape as the
+		// surrounding surface simplifies
+		start := d.positions[e[0]]
+		dir := d.positions[e[1]].Sub(start)
+		for _, side := range sides {
+			perpendicular := dir.Cross(d.faceNormal(d.faces[side.face]))
+			if perpendicular.Length() == 0 || perpendicular.ContainsNaN() {
+				continue
+			}
+			q := scaleQuadric(PlaneQuadric(perpendicular.Normalized(), start), boundaryPenalty)
+			d.quadrics[e[0]] = d.quadrics[e[0]].Add(q)
+			d.quadrics[e[1]] = d.quadrics[e[1]].Add(q)
+		}
+	}
+
+	for e := range edgeSides {
+		if c, ok := d.evaluateEdge(e[0], e[1]); ok {
+			d.queue = append(d.queue, c)
+		}
+	}
+	heap.Init(&d.queue)
+
+	return d
+}
+
+func sortedKeys[T any](data map[string]T) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type attributeOrder struct {
+	v4, v3, v2, v1 []string
+}
+
+// vertexKey builds a key that is shared only by vertices with the same
+// position and attribute values
+func (d *decimator) vertexKey(order attributeOrder, v int, position vector3.Float64) string {
+	values := []float64{position.X(), position.Y(), position.Z()}
+	for _, attr := range order.v4 {
+		p := d.v4Data[attr][v]
+		values = append(values, p.X(), p.Y(), p.Z(), p.W())
+	}
+	for _, attr := range order.v3 {
+		p := d.v3Data[attr][v]
+		values = append(values, p.X(), p.Y(), p.Z())
+	}
+	for _, attr := range order.v2 {
+		p := d.v2Data[attr][v]
+		values = append(values, p.X(), p.Y())
+	}
+	for _, attr := range order.v1 {
+		values = append(values, d.v1Data[attr][v])
+	}
+
+	key := make([]byte, 0, len(values)*8)
+	for _, value := range values {
+		key = binary.LittleEndian.AppendUint64(key, math.Float64bits(value))
+	}
+	return string(key)
+}
+
+func (d *decimator) faceNodes(face [3]int) [3]int {
+	return [3]int{d.wedgeNode[face[0]], d.wedgeNode[face[1]], d.wedgeNode[face[2]]}
+}
+
+func (d *decimator) faceNormal(face [3]int) vector3.Float64 {
+	nodes := d.faceNodes(face)
+	a := d.positions[nodes[0]]
+	return d.positions[nodes[1]].Sub(a).Cross(d.positions[nodes[2]].Sub(a))
+}
+
+// wedgeAt returns the wedge the face uses for the node, or -1 if the face
+// doesn't contain the node
+func (d *decimator) wedgeAt(face [3]int, node int) int {
+	for _, w := range face {
+		if d.wedgeNode[w] == node {
+			return w
+		}
+	}
+	return -1
+}
+
+func (d *decimator) hasNode(face [3]int, node int) bool {
+	return d.wedgeAt(face, node) != -1
+}
+
+// evaluateEdge determines where collapsing the edge would place the resulting
+// vertex and how much error doing so would introduce
+func (d *decimator) evaluateEdge(a, b int) (edgeCollapse, bool) {
+	if d.locked[a] && d.locked[b] {
+		return edgeCollapse{}, false
+	}
+
+	q := d.quadrics[a].Add(d.quadrics[b])
+	pa := d.positions[a]
+	pb := d.positions[b]
+	edgeDir := pb.Sub(pa)
+	edgeLength := edgeDir.Length()
+
+	collapse := edgeCollapse{
+		a:        a,
+		b:        b,
+		versionA: d.version[a],
+		versionB: d.version[b],
+	}
+
+	switch {
+	case d.locked[a]:
+		collapse.position = pa
+		collapse.t = 0
+
+	case d.locked[b]:
+		collapse.position = pb
+		collapse.t = 1
+
+	default:
+		candidates := []vector3.Float64{pa, pb, pa.Add(pb).Scale(0.5)}
+
+		// Keep the optimal position constrained to the edge itself, so that
+		// interpolating attributes along the edge stays faithful
+		optimal := QuadricVector(q)
+		if edgeLength > 0 && !optimal.ContainsNaN() {
+			t := optimal.Sub(pa).Dot(edgeDir) / (edgeLength * edgeLength)
+			if !math.IsNaN(t) && !math.IsInf(t, 0) {
+				candidates = append(candidates, pa.Add(edgeDir.Scale(math.Max(0, math.Min(1, t)))))
+			}
+		}
+
+		best := math.Inf(1)
+		for _, c := range candidates {
+			err := QuadricErrorForVector(q, c)
+			if err < best {
+				best = err
+				collapse.position = c
+			}
+		}
+
+		if edgeLength > 0 {
+			collapse.t = math.Max(0, math.Min(1, collapse.position.Sub(pa).Dot(edgeDir)/(edgeLength*edgeLength)))
+		}
+	}
+
+	// Flat regions leave many collapses without any error at all. Favoring the
+	// shorter edges between them keeps vertex valences from ballooning
+	collapse.error = math.Max(0, QuadricErrorForVector(q, collapse.position))
+	collapse.cost = collapse.error + (edgeLength * edgeLength * tieBreakWeight)
+	return collapse, true
+}
+
+func (d *decimator) stale(c edgeCollapse) bool {
+	return d.removed[c.a] ||
+		d.removed[c.b] ||
+		d.version[c.a] != c.versionA ||
+		d.version[c.b] != c.versionB
+}
+
+// neighbors returns all nodes that share an alive face with v
+func (d *decimator) neighbors(v int) map[int]struct{} {
+	out := make(map[int]struct{})
+	for _, f := range d.nodeFaces[v] {
+		if !d.faceAlive[f] {
+			continue
+		}
+		for _, other := range d.faceNodes(d.faces[f]) {
+			if other != v {
+				out[other] = struct{}{}
+			}
+		}
+	}
+	return out
+}
+
+// valid determines whether or not the collapse keeps the surface manifold and
+// avoids flipping any of the surrounding triangles
+func (d *decimator) valid(c edgeCollapse) bool {
+	// Link condition: the only nodes a and b can have in common are the ones
+	// opposite of the edge across the faces the edge belongs to
+	shared := 0
+	for _, f := range d.nodeFaces[c.a] {
+		if d.faceAlive[f] && d.hasNode(d.faces[f], c.b) {
+			shared++
+		}
+	}
+	if shared == 0 {
+		return false
+	}
+
+	aNeighbors := d.neighbors(c.a)
+	common := 0
+	for n := range d.neighbors(c.b) {
+		if _, ok := aNeighbors[n]; ok {
+			common++
+		}
+	}
+	if common != shared {
+		return false
+	}
+
+	// Triangle flips
+	for _, v := range [2]int{c.a, c.b} {
+		for _, f := range d.nodeFaces[v] {
+			face := d.faces[f]
+			if !d.faceAlive[f] || (d.hasNode(face, c.a) && d.hasNode(face, c.b)) {
+				continue
+			}
+
+			before := d.faceNormal(face)
+			original := d.positions[v]
+			d.positions[v] = c.position
+			after := d.faceNormal(face)
+			d.positions[v] = original
+
+			if after.Length() == 0 || before.Dot(after) <= 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func lerpAttribute[T any](data map[string][]T, a, b int, t float64, lerp func(a, b T, t float64) T) {
+	for _, values := range data {
+		values[a] = lerp(values[a], values[b], t)
+	}
+}
+
+// interpolateWedge moves the attributes of wedge a towards those of b
+func (d *decimator) interpolateWedge(a, b int, t float64) {
+	lerpAttribute(d.v4Data, a, b, t, func(a, b vector4.Float64, t float64) vector4.Float64 {
+		return a.Add(b.Sub(a).Scale(t))
+	})
+	lerpAttribute(d.v3Data, a, b, t, func(a, b vector3.Float64, t float64) vector3.Float64 {
+		return a.Add(b.Sub(a).Scale(t))
+	})
+	lerpAttribute(d.v2Data, a, b, t, func(a, b vector2.Float64, t float64) vector2.Float64 {
+		return a.Add(b.Sub(a).Scale(t))
+	})
+	lerpAttribute(d.v1Data, a, b, t, func(a, b float64, t float64) float64 {
+		return a + ((b - a) * t)
+	})
+	if normals, ok := d.v3Data[modeling.NormalAttribute]; ok {
+		if n := normals[a]; n.Length() > 0 {
+			normals[a] = n.Normalized()
+		}
+	}
+}
+
+func (d *decimator) collapse(c edgeCollapse) {
+	a, b := c.a, c.b
+
+	// The faces running along the edge pair each wedge of b with the wedge of
+	// a on the same side of any seam the edge is a part of
+	type wedgePair struct{ a, b int }
+	pairs := make([]wedgePair, 0, 2)
+	pairedWith := make(map[int]int)
+	for _, f := range d.nodeFaces[b] {
+		face := d.faces[f]
+		if !d.faceAlive[f] || !d.hasNode(face, a) {
+			continue
+		}
+
+		wa, wb := d.wedgeAt(face, a), d.wedgeAt(face, b)
+		if _, ok := pairedWith[wb]; ok {
+			continue
+		}
+		pairedWith[wb] = wa
+		pairs = append(pairs, wedgePair{a: wa, b: wb})
+	}
+
+	d.positions[a] = c.position
+	d.quadrics[a] = d.quadrics[a].Add(d.quadrics[b])
+	d.locked[a] = d.locked[a] || d.locked[b]
+	d.removed[b] = true
+	d.version[a]++
+
+	interpolated := make(map[int]struct{})
+	for _, pair := range pairs {
+		if _, ok := interpolated[pair.a]; ok {
+			continue
+		}
+		interpolated[pair.a] = struct{}{}
+		d.interpolateWedge(pair.a, pair.b, c.t)
+	}
+
+	// Wedges of b without a counterpart keep their attributes and move along
+	// with the node
+	unpaired := make([]int, 0)
+	for _, f := range d.nodeFaces[b] {
+		if !d.faceAlive[f] {
+			continue
+		}
+
+		face := d.faces[f]
+		if d.hasNode(face, a) {
+			d.faceAlive[f] = false
+			d.faceCount--
+			continue
+		}
+
+		for i, w := range face {
+			if d.wedgeNode[w] != b {
+				continue
+			}
+			if wa, ok := pairedWith[w]; ok {
+				d.faces[f][i] = wa
+			} else {
+				unpaired = append(unpaired, w)
+			}
+		}
+		d.nodeFaces[a] = append(d.nodeFaces[a], f)
+	}
+	for _, w := range unpaired {
+		d.wedgeNode[w] = a
+	}
+	d.nodeFaces[b] = nil
+
+	alive := d.nodeFaces[a][:0]
+	for _, f := range d.nodeFaces[a] {
+		if d.faceAlive[f] {
+			alive = append(alive, f)
+		}
+	}
+	d.nodeFaces[a] = alive
+
+	for n := range d.neighbors(a) {
+		if c, ok := d.evaluateEdge(a, n); ok {
+			heap.Push(&d.queue, c)
+		}
+	}
+}
+
+func (d *decimator) run(targetTriangleCount int, maxError float64) {
+	for d.queue.Len() > 0 {
+		if targetTriangleCount > 0 && d.faceCount <= targetTriangleCount {
+			return
+		}
+
+		c := heap.Pop(&d.queue).(edgeCollapse)
+		if d.stale(c) {
+			continue
+		}
+
+		if maxError > 0 && c.error > maxError {
+			return
+		}
+
+		if !d.valid(c) {
+			continue
+		}
+
+		d.collapse(c)
+	}
+}
+
+func compact[T any](data map[string][]T, used []int) map[string][]T {
+	out := make(map[string][]T)
+	for attr, values := range data {
+		compacted := make([]T, len(used))
+		for i, v := range used {
+			compacted[i] = values[v]
+		}
+		out[attr] = compacted
+	}
+	return out
+}
+
+func (d *decimator) mesh(topo modeling.Topology) modeling.Mesh {
+	remap := make([]int, len(d.wedgeNode))
+	for i := range remap {
+		remap[i] = -1
+	}
+
+	used := make([]int, 0)
+	indices := make([]int, 0, d.faceCount*3)
+	for f, face := range d.faces {
+		if !d.faceAlive[f] {
+			continue
+		}
+		for _, v := range face {
+			if remap[v] == -1 {
+				remap[v] = len(used)
+				used = append(used, v)
+			}
+			indices = append(indices, remap[v])
+		}
+	}
+
+	positions := make([]vector3.Float64, len(used))
+	for i, w := range used {
+		positions[i] = d.positions[d.wedgeNode[w]]
+	}
+
+	return modeling.NewMesh(topo, indices).
+		SetFloat4Data(compact(d.v4Data, used)).
+		SetFloat3Data(compact(d.v3Data, used)).
+		SetFloat2Data(compact(d.v2Data, used)).
+		SetFloat1Data(compact(d.v1Data, used)).
+		SetFloat3Attribute(modeling.PositionAttribute, positions)
 }
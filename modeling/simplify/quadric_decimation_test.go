@@ -0,0 +1,238 @@
+package simplify_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/math/mat"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/simplify"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Welded, flat grid spanning [0, 1] on the XY plane, with UVs matching the
+// XY coordinates of each vertex
+func grid(size int) modeling.Mesh {
+	positions := make([]vector3.Float64, 0)
+	uvs := make([]vector2.Float64, 0)
+	for y := 0; y <= size; y++ {
+		for x := 0; x <= size; x++ {
+			p := vector2.New(float64(x), float64(y)).DivByConstant(float64(size))
+			positions = append(positions, vector3.New(p.X(), p.Y(), 0))
+			uvs = append(uvs, p)
+		}
+	}
+
+	indices := make([]int, 0)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			bl := y*(size+1) + x
+			br := bl + 1
+			tl := bl + size + 1
+			tr := tl + 1
+			indices = append(indices, bl, br, tr, bl, tr, tl)
+		}
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat2Attribute(modeling.TexCoordAttribute, uvs)
+}
+
+func TestQuadricErrorForVector_PlaneQuadric(t *testing.T) {
+	q := simplify.PlaneQuadric(vector3.New(0., 0., 1.), vector3.New(0., 0., 2.))
+	assert.InDelta(t, 0., simplify.QuadricErrorForVector(q, vector3.New(5., -3., 2.)), 1e-9)
+	assert.InDelta(t, 9., simplify.QuadricErrorForVector(q, vector3.New(1., 1., 5.)), 1e-9)
+}
+
+func TestQuadricVector_IntersectionOfPlanes(t *testing.T) {
+	q := mat.Matrix4x4{}.
+		Add(simplify.PlaneQuadric(vector3.New(1., 0., 0.), vector3.New(1., 0., 0.))).
+		Add(simplify.PlaneQuadric(vector3.New(0., 1., 0.), vector3.New(0., 2., 0.))).
+		Add(simplify.PlaneQuadric(vector3.New(0., 0., 1.), vector3.New(0., 0., 3.)))
+
+	v := simplify.QuadricVector(q)
+	assert.InDelta(t, 1., v.X(), 1e-9)
+	assert.InDelta(t, 2., v.Y(), 1e-9)
+	assert.InDelta(t, 3., v.Z(), 1e-9)
+}
+
+func TestQuadricDecimation_NoStoppingCondition(t *testing.T) {
+	mesh := grid(4)
+	assert.Equal(t, mesh, simplify.QuadricDecimation(mesh, 0, 0, false))
+}
+
+func TestQuadricDecimation_FlatGrid(t *testing.T) {
+	mesh := grid(10)
+	require.Equal(t, 200, mesh.PrimitiveCount())
+
+	simplified := simplify.QuadricDecimation(mesh, 2, 0, false)
+
+	assert.Equal(t, 2, simplified.PrimitiveCount())
+	assert.Equal(t, mesh.BoundingBox(modeling.PositionAttribute), simplified.BoundingBox(modeling.PositionAttribute))
+
+	positions := simplified.Float3Attribute(modeling.PositionAttribute)
+	uvs := simplified.Float2Attribute(modeling.TexCoordAttribute)
+	require.Equal(t, positions.Len(), uvs.Len())
+	for i := range positions.Len() {
+		p := positions.At(i)
+		assert.InDelta(t, 0., p.Z(), 1e-9)
+		assert.InDelta(t, p.X(), uvs.At(i).X(), 1e-9)
+		assert.InDelta(t, p.Y(), uvs.At(i).Y(), 1e-9)
+	}
+}
+
+func TestQuadricDecimation_Unwelded(t *testing.T) {
+	mesh := meshops.Unweld(grid(10))
+	require.Equal(t, 600, mesh.Float3Attribute(modeling.PositionAttribute).Len())
+
+	simplified := simplify.QuadricDecimation(mesh, 2, 0, false)
+
+	assert.Equal(t, 2, simplified.PrimitiveCount())
+	assert.Equal(t, mesh.BoundingBox(modeling.PositionAttribute), simplified.BoundingBox(modeling.PositionAttribute))
+}
+
+func TestQuadricDecimation_Seam(t *testing.T) {
+	mesh := grid(10)
+
+	// Split the grid down the middle, shifting the UVs on the right half over
+	// by one so the two halves share positions but not texture coordinates
+	positions := mesh.Float3Attribute(modeling.PositionAttribute)
+	uvs := mesh.Float2Attribute(modeling.TexCoordAttribute)
+	seam := make(map[int]int)
+	for i := range positions.Len() {
+		if positions.At(i).X() == 0.5 {
+			seam[i] = positions.Len() + len(seam)
+		}
+	}
+
+	newPositions := make([]vector3.Float64, positions.Len()+len(seam))
+	newUVs := make([]vector2.Float64, len(newPositions))
+	for i := range positions.Len() {
+		newPositions[i] = positions.At(i)
+		newUVs[i] = uvs.At(i)
+		if positions.At(i).X() > 0.5 {
+			newUVs[i] = newUVs[i].SetX(newUVs[i].X() + 1)
+		}
+		if split, ok := seam[i]; ok {
+			newPositions[split] = positions.At(i)
+			newUVs[split] = newUVs[i].SetX(newUVs[i].X() + 1)
+		}
+	}
+
+	indices := mesh.Indices()
+	newIndices := make([]int, indices.Len())
+	for tri := 0; tri < indices.Len(); tri += 3 {
+		right := false
+		for c := range 3 {
+			if positions.At(indices.At(tri+c)).X() > 0.5 {
+				right = true
+			}
+		}
+		for c := range 3 {
+			index := indices.At(tri + c)
+			if split, ok := seam[index]; ok && right {
+				index = split
+			}
+			newIndices[tri+c] = index
+		}
+	}
+
+	mesh = modeling.NewTriangleMesh(newIndices).
+		SetFloat3Attribute(modeling.PositionAttribute, newPositions).
+		SetFloat2Attribute(modeling.TexCoordAttribute, newUVs)
+
+	simplified := simplify.QuadricDecimation(mesh, 4, 0, false)
+
+	assert.Equal(t, 4, simplified.PrimitiveCount())
+
+	// Each half keeps its own texture coordinates, and the seam stays put
+	seamVertices := 0
+	simplifiedPositions := simplified.Float3Attribute(modeling.PositionAttribute)
+	simplifiedUVs := simplified.Float2Attribute(modeling.TexCoordAttribute)
+	for i := range simplifiedPositions.Len() {
+		p := simplifiedPositions.At(i)
+		uv := simplifiedUVs.At(i)
+		assert.InDelta(t, p.Y(), uv.Y(), 1e-9)
+
+		offset := uv.X() - p.X()
+		if math.Abs(offset) > 1e-9 {
+			assert.InDelta(t, 1., offset, 1e-9)
+		}
+
+		if p.X() == 0.5 {
+			seamVertices++
+		}
+	}
+	assert.Equal(t, 4, seamVertices)
+}
+
+func TestQuadricDecimation_LockBoundary(t *testing.T) {
+	mesh := grid(10)
+
+	simplified := simplify.QuadricDecimation(mesh, 2, 0, true)
+
+	// Every one of the 40 boundary vertices must remain, which takes at least
+	// 38 triangles to span
+	assert.GreaterOrEqual(t, simplified.PrimitiveCount(), 38)
+	assert.Less(t, simplified.PrimitiveCount(), 50)
+
+	boundary := make(map[vector3.Float64]struct{})
+	simplified.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		if v.X() == 0 || v.X() == 1 || v.Y() == 0 || v.Y() == 1 {
+			boundary[v] = struct{}{}
+		}
+	})
+	assert.Len(t, boundary, 40)
+}
+
+func TestQuadricDecimation_MaxError(t *testing.T) {
+	mesh := grid(10)
+
+	// Bend the grid in half along the x axis
+	mesh = mesh.ModifyFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) vector3.Float64 {
+		if v.X() > 0.5 {
+			return v.SetZ(v.X() - 0.5)
+		}
+		return v
+	})
+
+	simplified := simplify.QuadricDecimation(mesh, 0, 1e-9, false)
+	assert.Less(t, simplified.PrimitiveCount(), mesh.PrimitiveCount())
+
+	// The crease must survive
+	simplified.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		if v.X() > 0.5 {
+			assert.InDelta(t, v.X()-0.5, v.Z(), 1e-6)
+		} else {
+			assert.InDelta(t, 0., v.Z(), 1e-6)
+		}
+	})
+}
+
+func TestQuadricDecimationTransformer_RequiresTriangles(t *testing.T) {
+	_, err := simplify.QuadricDecimationTransformer{TargetTriangleCount: 1}.Transform(modeling.EmptyPointcloud())
+	assert.Error(t, err)
+}
+
+func TestLODChain(t *testing.T) {
+	mesh := grid(10)
+
+	chain := simplify.LODChain(mesh, 3, 0.5, false)
+	require.Len(t, chain, 3)
+
+	previous := mesh.PrimitiveCount()
+	for _, level := range chain {
+		assert.LessOrEqual(t, level.PrimitiveCount(), previous/2)
+		assert.Equal(t, mesh.BoundingBox(modeling.PositionAttribute), level.BoundingBox(modeling.PositionAttribute))
+		previous = level.PrimitiveCount()
+	}
+
+	// Nothing left to remove after a couple of triangles
+	assert.Len(t, simplify.LODChain(mesh, 20, 0.1, false), 2)
+	assert.Empty(t, simplify.LODChain(mesh, 3, 1, false))
+}
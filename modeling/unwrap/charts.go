@@ -0,0 +1,165 @@
+package unwrap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// chart is a group of connected faces flattened together
+type chart struct {
+	faces []int
+
+	// Direction every face of the chart roughly faces
+	normal vector3.Float64
+}
+
+// surface is the welded connectivity of a triangle mesh
+type surface struct {
+	// Positions of each unique vertex
+	positions []vector3.Float64
+
+	// Unique vertex each of the mesh's vertices was welded to
+	weld []int
+
+	// Welded and original vertices of each triangle with a non-zero area
+	faces         [][3]int
+	originalFaces [][3]int
+
+	normals []vector3.Float64
+	areas   []float64
+
+	// Faces across each edge of a face, -1 where the edge is a boundary or
+	// shared by more than two faces
+	neighbors [][3]int
+}
+
+func newSurface(m modeling.Mesh) *surface {
+	positionData := m.Float3Attribute(modeling.PositionAttribute)
+	s := &surface{
+		positions: make([]vector3.Float64, 0),
+		weld:      make([]int, positionData.Len()),
+	}
+
+	lookup := make(map[vector3.Float64]int)
+	for i := range positionData.Len() {
+		p := positionData.At(i)
+		v, ok := lookup[p]
+		if !ok {
+			v = len(s.positions)
+			lookup[p] = v
+			s.positions = append(s.positions, p)
+		}
+		s.weld[i] = v
+	}
+
+	type edge struct{ a, b int }
+	edgeFaces := make(map[edge][]int)
+
+	indices := m.Indices()
+	for i := 0; i+3 <= indices.Len(); i += 3 {
+		original := [3]int{indices.At(i), indices.At(i + 1), indices.At(i + 2)}
+		face := [3]int{s.weld[original[0]], s.weld[original[1]], s.weld[original[2]]}
+
+		// Faces without any area can't be flattened, and have nothing to
+		// texture anyways
+		a, b, c := s.positions[face[0]], s.positions[face[1]], s.positions[face[2]]
+		cross := b.Sub(a).Cross(c.Sub(a))
+		area := cross.Length() / 2
+		if area == 0 || math.IsNaN(area) || math.IsInf(area, 0) {
+			continue
+		}
+
+		f := len(s.faces)
+		s.faces = append(s.faces, face)
+		s.originalFaces = append(s.originalFaces, original)
+		s.areas = append(s.areas, area)
+		s.normals = append(s.normals, cross.Normalized())
+
+		for e := range 3 {
+			key := edge{face[e], face[(e+1)%3]}
+			if key.a > key.b {
+				key = edge{key.b, key.a}
+			}
+			edgeFaces[key] = append(edgeFaces[key], f)
+		}
+	}
+
+	s.neighbors = make([][3]int, len(s.faces))
+	for f, face := range s.faces {
+		for e := range 3 {
+			s.neighbors[f][e] = -1
+
+			key := edge{face[e], face[(e+1)%3]}
+			if key.a > key.b {
+				key = edge{key.b, key.a}
+			}
+			shared := edgeFaces[key]
+			if len(shared) != 2 || key.a == key.b {
+				continue
+			}
+
+			if shared[0] == f {
+				s.neighbors[f][e] = shared[1]
+			} else {
+				s.neighbors[f][e] = shared[0]
+			}
+		}
+	}
+
+	return s
+}
+
+// segment grows charts out from the largest faces of the surface, adding
+// neighboring faces to a chart as long as their normal stays within
+// maxAngle of the normal of the face the chart started from. Keeping every
+// face of a chart facing roughly the same direction guarantees the chart can
+// be flattened without folding over itself. Edges between charts become
+// seams.
+func (s *surface) segment(maxAngle float64) []chart {
+	chartOf := make([]int, len(s.faces))
+	for i := range chartOf {
+		chartOf[i] = -1
+	}
+
+	// Seed from the largest faces first, so small slivers join their
+	// neighbors rather than starting charts of their own
+	order := make([]int, len(s.faces))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return s.areas[order[i]] > s.areas[order[j]]
+	})
+
+	charts := make([]chart, 0)
+	for _, seed := range order {
+		if chartOf[seed] != -1 {
+			continue
+		}
+
+		c := chart{faces: []int{seed}, normal: s.normals[seed]}
+		chartOf[seed] = len(charts)
+
+		for i := 0; i < len(c.faces); i++ {
+			for _, neighbor := range s.neighbors[c.faces[i]] {
+				if neighbor == -1 || chartOf[neighbor] != -1 {
+					continue
+				}
+
+				if s.normals[neighbor].Angle(c.normal) > maxAngle {
+					continue
+				}
+
+				chartOf[neighbor] = len(charts)
+				c.faces = append(c.faces, neighbor)
+			}
+		}
+
+		charts = append(charts, c)
+	}
+
+	return charts
+}
@@ -0,0 +1,321 @@
+package unwrap
+
+import (
+	"math"
+
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// flatChart is a chart laid out in 2D
+type flatChart struct {
+	// Welded vertices of the chart, and where each lies in UV space
+	vertices []int
+	uvs      []vector2.Float64
+
+	// Triangles in terms of the chart's own vertices
+	faces [][3]int
+
+	// Surface area of the chart in 3D
+	area float64
+}
+
+func newFlatChart(s *surface, c chart) *flatChart {
+	fc := &flatChart{faces: make([][3]int, len(c.faces))}
+	local := make(map[int]int)
+	for i, f := range c.faces {
+		for v := range 3 {
+			welded := s.faces[f][v]
+			index, ok := local[welded]
+			if !ok {
+				index = len(fc.vertices)
+				local[welded] = index
+				fc.vertices = append(fc.vertices, welded)
+			}
+			fc.faces[i][v] = index
+		}
+		fc.area += s.areas[f]
+	}
+	fc.uvs = make([]vector2.Float64, len(fc.vertices))
+	return fc
+}
+
+func (fc *flatChart) signedArea(face [3]int) float64 {
+	a, b, c := fc.uvs[face[0]], fc.uvs[face[1]], fc.uvs[face[2]]
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	return (ab.X()*ac.Y() - ab.Y()*ac.X()) / 2
+}
+
+// orient mirrors the chart if it came out flipped, reporting whether every
+// triangle then faces the same way
+func (fc *flatChart) orient() bool {
+	total := 0.
+	for _, face := range fc.faces {
+		total += fc.signedArea(face)
+	}
+
+	if total < 0 {
+		for i, uv := range fc.uvs {
+			fc.uvs[i] = vector2.New(-uv.X(), uv.Y())
+		}
+		total = -total
+	}
+
+	if math.IsNaN(total) || total == 0 {
+		return false
+	}
+
+	for _, face := range fc.faces {
+		if fc.signedArea(face) < -1e-12*total {
+			return false
+		}
+	}
+	return true
+}
+
+// planarBasis builds two axes perpendicular to the normal
+func planarBasis(normal vector3.Float64) (vector3.Float64, vector3.Float64) {
+	helper := vector3.Up[float64]()
+	if math.Abs(normal.Dot(helper)) > 0.9 {
+		helper = vector3.Right[float64]()
+	}
+	u := helper.Cross(normal).Normalized()
+	v := normal.Cross(u)
+	return u, v
+}
+
+// project flattens the chart by projecting it onto the plane perpendicular
+// to the direction the chart faces
+func (fc *flatChart) project(s *surface, normal vector3.Float64) {
+	if normal == vector3.Zero[float64]() {
+		normal = vector3.Forward[float64]()
+	}
+	u, v := planarBasis(normal)
+	for i, welded := range fc.vertices {
+		p := s.positions[welded]
+		fc.uvs[i] = vector2.New(p.Dot(u), p.Dot(v))
+	}
+}
+
+// sparseRow is a single row of a sparse linear system
+type sparseRow struct {
+	columns []int
+	values  []float64
+	rhs     float64
+}
+
+// lscm flattens the chart with Lévy et al's least squares conformal maps,
+// which preserves angles as well as possible. Two vertices far apart from
+// one another are pinned in place, at their projected positions, and the
+// rest are solved for in the least squares sense. Returns false if the
+// system couldn't be solved.
+func (fc *flatChart) lscm(s *surface) bool {
+	if len(fc.vertices) < 3 {
+		return false
+	}
+
+	// Pin the two vertices furthest apart along the chart's widest axis
+	pinA, pinB := 0, 0
+	for i, uv := range fc.uvs {
+		if uv.X() < fc.uvs[pinA].X() {
+			pinA = i
+		}
+		if uv.X() > fc.uvs[pinB].X() {
+			pinB = i
+		}
+	}
+	if pinA == pinB {
+		return false
+	}
+
+	// Unknowns are laid out as [u of free vertices | v of free vertices]
+	free := make([]int, len(fc.vertices))
+	freeCount := 0
+	for i := range free {
+		if i == pinA || i == pinB {
+			free[i] = -1
+			continue
+		}
+		free[i] = freeCount
+		freeCount++
+	}
+
+	rows := make([]sparseRow, 0, len(fc.faces)*2)
+	for _, face := range fc.faces {
+		p0 := s.positions[fc.vertices[face[0]]]
+		p1 := s.positions[fc.vertices[face[1]]]
+		p2 := s.positions[fc.vertices[face[2]]]
+
+		// Triangle within its own 2D frame
+		e1 := p1.Sub(p0)
+		normal := e1.Cross(p2.Sub(p0))
+		doubleArea := normal.Length()
+		if doubleArea <= 0 || e1.Length() == 0 {
+			continue
+		}
+		xAxis := e1.Normalized()
+		yAxis := normal.Normalized().Cross(xAxis)
+		local := [3]vector2.Float64{
+			vector2.Zero[float64](),
+			vector2.New(e1.Length(), 0),
+			vector2.New(p2.Sub(p0).Dot(xAxis), p2.Sub(p0).Dot(yAxis)),
+		}
+
+		weight := 1 / math.Sqrt(doubleArea)
+		re := sparseRow{}
+		im := sparseRow{}
+		for j := range 3 {
+			// W_j = z_{j+2} - z_{j+1}
+			w := local[(j+2)%3].Sub(local[(j+1)%3]).Scale(weight)
+			a, b := w.X(), w.Y()
+
+			// (a + ib)(u + iv) = (au - bv) + i(bu + av)
+			vertex := face[j]
+			if f := free[vertex]; f != -1 {
+				re.columns = append(re.columns, f, freeCount+f)
+				re.values = append(re.values, a, -b)
+				im.columns = append(im.columns, f, freeCount+f)
+				im.values = append(im.values, b, a)
+				continue
+			}
+
+			pinned := fc.uvs[vertex]
+			re.rhs -= a*pinned.X() - b*pinned.Y()
+			im.rhs -= b*pinned.X() + a*pinned.Y()
+		}
+		rows = append(rows, re, im)
+	}
+
+	// Start from the projection, which is usually close to the answer
+	x := make([]float64, freeCount*2)
+	for i, f := range free {
+		if f == -1 {
+			continue
+		}
+		x[f] = fc.uvs[i].X()
+		x[freeCount+f] = fc.uvs[i].Y()
+	}
+
+	if !solveLeastSquares(rows, x) {
+		return false
+	}
+
+	for i, f := range free {
+		if f == -1 {
+			continue
+		}
+		fc.uvs[i] = vector2.New(x[f], x[freeCount+f])
+	}
+	return true
+}
+
+const (
+	// How far the residual of the normal equations must fall, relative to
+	// that of the initial guess, before the solver stops
+	solverTolerance = 1e-6
+
+	// Most iterations the solver runs before settling for what it has
+	solverMaxIterations = 1000
+)
+
+// solveLeastSquares minimizes |Ax - b| using conjugate gradients on the
+// normal equations (CGLS), refining the initial guess held in x
+func solveLeastSquares(rows []sparseRow, x []float64) bool {
+	if len(x) == 0 {
+		return true
+	}
+
+	multiply := func(v []float64, out []float64) {
+		for i, row := range rows {
+			sum := 0.
+			for c, column := range row.columns {
+				sum += row.values[c] * v[column]
+			}
+			out[i] = sum
+		}
+	}
+
+	multiplyTranspose := func(v []float64, out []float64) {
+		for i := range out {
+			out[i] = 0
+		}
+		for i, row := range rows {
+			for c, column := range row.columns {
+				out[column] += row.values[c] * v[i]
+			}
+		}
+	}
+
+	dot := func(a, b []float64) float64 {
+		sum := 0.
+		for i := range a {
+			sum += a[i] * b[i]
+		}
+		return sum
+	}
+
+	r := make([]float64, len(rows))
+	multiply(x, r)
+	for i, row := range rows {
+		r[i] = row.rhs - r[i]
+	}
+
+	s := make([]float64, len(x))
+	multiplyTranspose(r, s)
+	p := append([]float64{}, s...)
+	q := make([]float64, len(rows))
+	gamma := dot(s, s)
+	tolerance := solverTolerance * solverTolerance * gamma
+
+	for range solverMaxIterations {
+		if gamma <= tolerance {
+			break
+		}
+
+		multiply(p, q)
+		qq := dot(q, q)
+		if qq == 0 {
+			break
+		}
+		alpha := gamma / qq
+
+		for i := range x {
+			x[i] += alpha * p[i]
+		}
+		for i := range r {
+			r[i] -= alpha * q[i]
+		}
+
+		multiplyTranspose(r, s)
+		next := dot(s, s)
+		beta := next / gamma
+		gamma = next
+		for i := range p {
+			p[i] = s[i] + beta*p[i]
+		}
+	}
+
+	for _, v := range x {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// flatten lays the chart out in 2D, preferring a conformal map and falling
+// back to a planar projection if the conformal map folds over itself
+func flatten(s *surface, c chart) *flatChart {
+	fc := newFlatChart(s, c)
+	fc.project(s, c.normal)
+	projection := append([]vector2.Float64{}, fc.uvs...)
+
+	if fc.lscm(s) && fc.orient() {
+		return fc
+	}
+
+	fc.uvs = projection
+	fc.orient()
+	return fc
+}
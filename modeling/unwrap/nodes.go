@@ -0,0 +1,62 @@
+package unwrap
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+
+	refutil.RegisterType[nodes.Struct[UnwrapNode]](factory)
+
+	generator.RegisterTypes(factory)
+}
+
+type UnwrapNode struct {
+	Mesh          nodes.Output[modeling.Mesh] `description:"The triangle mesh to generate texture coordinates for."`
+	MaxChartAngle nodes.Output[float64]       `description:"Largest angle in degrees a face can turn away from the rest of its chart before a seam is cut. Defaults to 60."`
+	Padding       nodes.Output[float64]       `description:"Space left between charts, as a fraction of the atlas's width. Defaults to 0.01."`
+}
+
+func (un UnwrapNode) Description() string {
+	return "Cuts the mesh into charts along sharp edges, flattens each chart with a least squares conformal map, and packs the charts into the 0 to 1 UV square."
+}
+
+func (un UnwrapNode) CacheVersion() int {
+	return 1
+}
+
+func (un UnwrapNode) Out(out *nodes.StructOutput[modeling.Mesh]) {
+	if un.Mesh == nil {
+		out.Set(modeling.EmptyMesh(modeling.TriangleTopology))
+		return
+	}
+
+	mesh := nodes.GetOutputValue(out, un.Mesh)
+	angle := nodes.TryGetOutputValue(out, un.MaxChartAngle, 60.)
+	if angle <= 0 || angle >= 90 {
+		out.Set(mesh)
+		out.CaptureError(nodes.InvalidInputError{
+			Input:   un.MaxChartAngle,
+			Message: fmt.Sprintf("max chart angle must be between 0 and 90 degrees, received %g", angle),
+		})
+		return
+	}
+
+	result, err := Transformer{
+		MaxChartAngle: angle * math.Pi / 180,
+		Padding:       nodes.TryGetOutputValue(out, un.Padding, 0.01),
+	}.Transform(mesh)
+	if err != nil {
+		out.Set(mesh)
+		out.CaptureError(err)
+		return
+	}
+	out.Set(result)
+}
@@ -0,0 +1,200 @@
+package unwrap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/vector/vector2"
+)
+
+// convexHull of the points using Andrew's monotone chain, counter clockwise
+func convexHull(points []vector2.Float64) []vector2.Float64 {
+	sorted := append([]vector2.Float64{}, points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X() == sorted[j].X() {
+			return sorted[i].Y() < sorted[j].Y()
+		}
+		return sorted[i].X() < sorted[j].X()
+	})
+
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	cross := func(o, a, b vector2.Float64) float64 {
+		return (a.X()-o.X())*(b.Y()-o.Y()) - (a.Y()-o.Y())*(b.X()-o.X())
+	}
+
+	hull := make([]vector2.Float64, 0, len(sorted)*2)
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	return hull[:len(hull)-1]
+}
+
+func rotate(p vector2.Float64, cos, sin float64) vector2.Float64 {
+	return vector2.New(p.X()*cos-p.Y()*sin, p.X()*sin+p.Y()*cos)
+}
+
+func bounds(points []vector2.Float64) (vector2.Float64, vector2.Float64) {
+	min := vector2.Fill(math.Inf(1))
+	max := vector2.Fill(math.Inf(-1))
+	for _, p := range points {
+		min = vector2.Min(min, p)
+		max = vector2.Max(max, p)
+	}
+	return min, max
+}
+
+// normalize scales the chart to match its area in 3D, keeping texel density
+// consistent across charts, then rotates it to the orientation with the
+// smallest bounding box, lying wider than it is tall, with its corner at the
+// origin
+func (fc *flatChart) normalize() {
+	uvArea := 0.
+	for _, face := range fc.faces {
+		uvArea += fc.signedArea(face)
+	}
+
+	scale := 1.
+	if uvArea > 0 && fc.area > 0 {
+		scale = math.Sqrt(fc.area / uvArea)
+	}
+	for i, uv := range fc.uvs {
+		fc.uvs[i] = uv.Scale(scale)
+	}
+
+	// The smallest bounding box has a side collinear with an edge of the
+	// convex hull
+	hull := convexHull(fc.uvs)
+	bestCos, bestSin := 1., 0.
+	bestArea := math.Inf(1)
+	for i := range hull {
+		edge := hull[(i+1)%len(hull)].Sub(hull[i])
+		length := edge.Length()
+		if length == 0 {
+			continue
+		}
+
+		cos, sin := edge.X()/length, -edge.Y()/length
+		rotated := make([]vector2.Float64, len(hull))
+		for j, p := range hull {
+			rotated[j] = rotate(p, cos, sin)
+		}
+		min, max := bounds(rotated)
+		size := max.Sub(min)
+		if area := size.X() * size.Y(); area < bestArea {
+			bestArea = area
+			bestCos, bestSin = cos, sin
+		}
+	}
+
+	for i, uv := range fc.uvs {
+		fc.uvs[i] = rotate(uv, bestCos, bestSin)
+	}
+
+	min, max := bounds(fc.uvs)
+	size := max.Sub(min)
+	for i, uv := range fc.uvs {
+		uv = uv.Sub(min)
+		if size.Y() > size.X() {
+			// Rotating a quarter turn keeps the chart's winding
+			uv = vector2.New(size.Y()-uv.Y(), uv.X())
+		}
+		fc.uvs[i] = uv
+	}
+}
+
+func (fc *flatChart) size() vector2.Float64 {
+	_, max := bounds(fc.uvs)
+	return max
+}
+
+// shelfPack places the charts into rows no wider than width, tallest first,
+// with padding between each chart and around the edge. Returns the offset of
+// each chart and the total size used.
+func shelfPack(charts []*flatChart, width, padding float64) ([]vector2.Float64, vector2.Float64) {
+	order := make([]int, len(charts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return charts[order[i]].size().Y() > charts[order[j]].size().Y()
+	})
+
+	offsets := make([]vector2.Float64, len(charts))
+	x, y := padding, padding
+	shelfHeight := 0.
+	usedWidth := 0.
+	for _, c := range order {
+		size := charts[c].size()
+		if x > padding && x+size.X()+padding > width {
+			x = padding
+			y += shelfHeight + padding
+			shelfHeight = 0
+		}
+
+		offsets[c] = vector2.New(x, y)
+		x += size.X() + padding
+		usedWidth = math.Max(usedWidth, x)
+		shelfHeight = math.Max(shelfHeight, size.Y())
+	}
+
+	return offsets, vector2.New(usedWidth, y+shelfHeight+padding)
+}
+
+// pack arranges the charts within the 0 to 1 UV square. Padding is in terms
+// of the final atlas, so it's refined until the packed charts, once scaled
+// to fit, keep at least that much space between them.
+func pack(charts []*flatChart, padding float64) {
+	if len(charts) == 0 {
+		return
+	}
+
+	area := 0.
+	widest := 0.
+	for _, c := range charts {
+		c.normalize()
+		size := c.size()
+		area += size.X() * size.Y()
+		widest = math.Max(widest, size.X())
+	}
+
+	extent := math.Max(math.Sqrt(area), widest)
+	if extent == 0 {
+		extent = 1
+	}
+
+	var offsets []vector2.Float64
+	for range 16 {
+		pad := padding * extent
+		width := math.Max(math.Sqrt(area)*1.1+pad, widest+2*pad)
+
+		var used vector2.Float64
+		offsets, used = shelfPack(charts, width, pad)
+		next := math.Max(used.X(), used.Y())
+		if next <= extent {
+			break
+		}
+		extent = next
+	}
+
+	for i, c := range charts {
+		for j, uv := range c.uvs {
+			c.uvs[j] = uv.Add(offsets[i]).DivByConstant(extent)
+		}
+	}
+}
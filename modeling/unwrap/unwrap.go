@@ -0,0 +1,139 @@
+package unwrap
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// Largest angle between a chart's faces and the direction the chart faces
+// when none is specified
+const DefaultMaxChartAngle = math.Pi / 3
+
+type Transformer struct {
+	// Largest angle in radians a face's normal can deviate from the normal of
+	// the face its chart started from. Must be between 0 and π/2, defaults to
+	// DefaultMaxChartAngle when 0.
+	MaxChartAngle float64
+
+	// Space left between charts and around the edge of the atlas, as a
+	// fraction of the atlas's width
+	Padding float64
+
+	// Attribute to write UVs to, defaults to modeling.TexCoordAttribute
+	Attribute string
+}
+
+func (t Transformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = meshops.RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = meshops.RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	maxAngle := t.MaxChartAngle
+	if maxAngle == 0 {
+		maxAngle = DefaultMaxChartAngle
+	}
+	if maxAngle < 0 || maxAngle >= math.Pi/2 {
+		return m, fmt.Errorf("max chart angle must be between 0 and π/2, received %g", maxAngle)
+	}
+
+	if t.Padding < 0 || t.Padding >= 0.5 {
+		return m, fmt.Errorf("padding must be between 0 and 0.5, received %g", t.Padding)
+	}
+
+	attr := t.Attribute
+	if attr == "" {
+		attr = modeling.TexCoordAttribute
+	}
+
+	return unwrap(m, maxAngle, t.Padding, attr), nil
+}
+
+// Unwrap generates texture coordinates for the triangle mesh. The mesh is cut
+// into charts of faces pointing roughly the same direction, each chart is
+// flattened with a least squares conformal map, and the charts are packed
+// into the 0 to 1 UV square with padding between them.
+//
+// Vertices that lie along the seam between two charts are duplicated, along
+// with all of their attributes. Any texture coordinates the mesh already had
+// are overwritten, and triangles without any area are left out.
+func Unwrap(m modeling.Mesh, maxChartAngle, padding float64) modeling.Mesh {
+	result, err := Transformer{MaxChartAngle: maxChartAngle, Padding: padding}.Transform(m)
+	check(err)
+	return result
+}
+
+func check(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func unwrap(m modeling.Mesh, maxAngle, padding float64, attr string) modeling.Mesh {
+	s := newSurface(m)
+	charts := s.segment(maxAngle)
+
+	flattened := make([]*flatChart, len(charts))
+	for i, c := range charts {
+		flattened[i] = flatten(s, c)
+	}
+	pack(flattened, padding)
+
+	// Each original vertex gets a copy for every chart it's used in
+	type chartVertex struct{ chart, original int }
+	lookup := make(map[chartVertex]int)
+	remap := make([]int, 0, m.AttributeLength())
+	uvs := make([]vector2.Float64, 0, m.AttributeLength())
+	indices := make([]int, len(s.faces)*3)
+
+	for i, c := range charts {
+		fc := flattened[i]
+		for j, f := range c.faces {
+			for v := range 3 {
+				key := chartVertex{chart: i, original: s.originalFaces[f][v]}
+				index, ok := lookup[key]
+				if !ok {
+					index = len(remap)
+					lookup[key] = index
+					remap = append(remap, key.original)
+					uvs = append(uvs, fc.uvs[fc.faces[j][v]])
+				}
+				indices[f*3+v] = index
+			}
+		}
+	}
+
+	result := modeling.NewTriangleMesh(indices)
+	for _, name := range m.Float4Attributes() {
+		result = result.SetFloat4Attribute(name, remapAttribute(m.Float4Attribute(name), remap))
+	}
+	for _, name := range m.Float3Attributes() {
+		result = result.SetFloat3Attribute(name, remapAttribute(m.Float3Attribute(name), remap))
+	}
+	for _, name := range m.Float2Attributes() {
+		result = result.SetFloat2Attribute(name, remapAttribute(m.Float2Attribute(name), remap))
+	}
+	for _, name := range m.Float1Attributes() {
+		result = result.SetFloat1Attribute(name, remapAttribute(m.Float1Attribute(name), remap))
+	}
+
+	return result.SetFloat2Attribute(attr, uvs)
+}
+
+func remapAttribute[T float64 | vector2.Float64 | vector3.Float64 | vector4.Float64](data *iter.ArrayIterator[T], remap []int) []T {
+	out := make([]T, len(remap))
+	for i, original := range remap {
+		out[i] = data.At(original)
+	}
+	return out
+}
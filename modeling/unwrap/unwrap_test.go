@@ -0,0 +1,205 @@
+package unwrap_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/modeling/unwrap"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grid(size int) modeling.Mesh {
+	positions := make([]vector3.Float64, 0)
+	for y := 0; y <= size; y++ {
+		for x := 0; x <= size; x++ {
+			positions = append(positions, vector3.New(float64(x), float64(y)*2, 0.))
+		}
+	}
+
+	indices := make([]int, 0)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			bottomLeft := y*(size+1) + x
+			topLeft := bottomLeft + size + 1
+			indices = append(indices, bottomLeft, bottomLeft+1, topLeft+1, bottomLeft, topLeft+1, topLeft)
+		}
+	}
+
+	return modeling.NewTriangleMesh(indices).SetFloat3Attribute(modeling.PositionAttribute, positions)
+}
+
+func uvTriangle(m modeling.Mesh, i int) (vector2.Float64, vector2.Float64, vector2.Float64) {
+	tri := m.Tri(i)
+	return tri.P1Vec2Attr(modeling.TexCoordAttribute),
+		tri.P2Vec2Attr(modeling.TexCoordAttribute),
+		tri.P3Vec2Attr(modeling.TexCoordAttribute)
+}
+
+func signedArea(a, b, c vector2.Float64) float64 {
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	return (ab.X()*ac.Y() - ab.Y()*ac.X()) / 2
+}
+
+// assertValidAtlas checks every UV lies within the 0 to 1 square, no
+// triangle is flipped, and no triangle overlaps another
+func assertValidAtlas(t *testing.T, m modeling.Mesh) {
+	t.Helper()
+	require.True(t, m.HasFloat2Attribute(modeling.TexCoordAttribute))
+
+	uvs := m.Float2Attribute(modeling.TexCoordAttribute)
+	for i := range uvs.Len() {
+		uv := uvs.At(i)
+		assert.GreaterOrEqual(t, uv.MinComponent(), 0.)
+		assert.LessOrEqual(t, uv.MaxComponent(), 1.)
+	}
+
+	for i := range m.PrimitiveCount() {
+		a, b, c := uvTriangle(m, i)
+		require.Positive(t, signedArea(a, b, c), "triangle %d is flipped", i)
+	}
+
+	for i := range m.PrimitiveCount() {
+		a, b, c := uvTriangle(m, i)
+		centroid := a.Add(b).Add(c).DivByConstant(3)
+		for j := range m.PrimitiveCount() {
+			if i == j {
+				continue
+			}
+			d, e, f := uvTriangle(m, j)
+			inside := signedArea(d, e, centroid) > 0 &&
+				signedArea(e, f, centroid) > 0 &&
+				signedArea(f, d, centroid) > 0
+			require.False(t, inside, "triangle %d overlaps triangle %d", i, j)
+		}
+	}
+}
+
+func TestUnwrap_FlatGridKeepsShape(t *testing.T) {
+	// ARRANGE ================================================================
+	m := grid(4)
+
+	// ACT ====================================================================
+	result := unwrap.Unwrap(m, 0, 0.05)
+
+	// ASSERT =================================================================
+	assert.Equal(t, m.AttributeLength(), result.AttributeLength(), "a flat grid shouldn't need seams")
+	assertValidAtlas(t, result)
+
+	// Every edge is scaled by the same amount
+	scale := -1.
+	for i := range result.PrimitiveCount() {
+		tri := result.Tri(i)
+		p1 := tri.P1Vec3Attr(modeling.PositionAttribute)
+		p2 := tri.P2Vec3Attr(modeling.PositionAttribute)
+		uv1 := tri.P1Vec2Attr(modeling.TexCoordAttribute)
+		uv2 := tri.P2Vec2Attr(modeling.TexCoordAttribute)
+
+		ratio := uv1.Distance(uv2) / p1.Distance(p2)
+		if scale < 0 {
+			scale = ratio
+		}
+		assert.InDelta(t, scale, ratio, 1e-6)
+	}
+
+	// The grid is twice as tall as it is wide, so it spans the width of the
+	// atlas once laid on its side, minus the padding
+	bounds := result.BoundingBox(modeling.PositionAttribute)
+	assert.InDelta(t, 0.9, scale*math.Max(bounds.Size().X(), bounds.Size().Y()), 1e-6)
+}
+
+func TestUnwrap_DropsDegenerateFaces(t *testing.T) {
+	// ARRANGE ================================================================
+	// A collapsed triangle along the grid's bottom edge, and another with a
+	// repeated vertex
+	m := grid(4)
+	indices := make([]int, 0, m.Indices().Len()+6)
+	for i := range m.Indices().Len() {
+		indices = append(indices, m.Indices().At(i))
+	}
+	m = m.SetIndices(append(indices, 0, 1, 2, 3, 3, 8))
+
+	// ACT ====================================================================
+	result := unwrap.Unwrap(m, 0, 0.05)
+
+	// ASSERT =================================================================
+	assert.Equal(t, m.PrimitiveCount()-2, result.PrimitiveCount())
+	assertValidAtlas(t, result)
+}
+
+func TestUnwrap_CubeCutsSeams(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := primitives.UnitCube()
+
+	// ACT ====================================================================
+	result := unwrap.Unwrap(cube, 0, 0.01)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 24, result.AttributeLength(), "every face of the cube should be its own chart")
+	assert.Equal(t, cube.PrimitiveCount(), result.PrimitiveCount())
+	assertValidAtlas(t, result)
+
+	// Positions are carried over to the split vertices
+	for i := range result.PrimitiveCount() {
+		assert.Equal(t, cube.Tri(i).P1Vec3Attr(modeling.PositionAttribute), result.Tri(i).P1Vec3Attr(modeling.PositionAttribute))
+	}
+}
+
+func TestUnwrap_Sphere(t *testing.T) {
+	sphere := primitives.UVSphere(1, 8, 16)
+
+	result, err := unwrap.Transformer{Padding: 0.01}.Transform(sphere)
+
+	require.NoError(t, err)
+	assert.Greater(t, result.AttributeLength(), sphere.AttributeLength())
+	assert.True(t, result.HasFloat3Attribute(modeling.NormalAttribute))
+	assertValidAtlas(t, result)
+}
+
+func TestUnwrap_Errors(t *testing.T) {
+	tests := map[string]struct {
+		transformer unwrap.Transformer
+		mesh        modeling.Mesh
+	}{
+		"lines": {
+			mesh: modeling.EmptyMesh(modeling.LineTopology),
+		},
+		"no positions": {
+			mesh: modeling.NewTriangleMesh([]int{0, 1, 2}),
+		},
+		"angle too large": {
+			transformer: unwrap.Transformer{MaxChartAngle: math.Pi / 2},
+			mesh:        primitives.UnitCube(),
+		},
+		"negative padding": {
+			transformer: unwrap.Transformer{Padding: -0.1},
+			mesh:        primitives.UnitCube(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.transformer.Transform(tc.mesh)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUnwrapNode(t *testing.T) {
+	node := &nodes.Struct[unwrap.UnwrapNode]{
+		Data: unwrap.UnwrapNode{
+			Mesh: nodes.ConstOutput[modeling.Mesh]{Val: primitives.UnitCube()},
+		},
+	}
+
+	result := nodes.GetNodeOutputPort[modeling.Mesh](node, "Out").Value()
+
+	assert.Equal(t, 24, result.AttributeLength())
+	assertValidAtlas(t, result)
+}
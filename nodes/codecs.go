@@ -0,0 +1,44 @@
+package nodes
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+)
+
+func init() {
+	RegisterOutputCodec[[]byte](bytesCodec{})
+	RegisterOutputCodec[image.Image](imageCodec{})
+}
+
+type bytesCodec struct{}
+
+func (bytesCodec) Encode(w io.Writer, value []byte) error {
+	_, err := w.Write(value)
+	return err
+}
+
+func (bytesCodec) Decode(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+// imageCodec stores images as PNGs, which is lossless for everything but
+// the concrete image type the decoded image comes back as. Nil images are
+// stored as empty entries.
+type imageCodec struct{}
+
+func (imageCodec) Encode(w io.Writer, value image.Image) error {
+	if value == nil {
+		return nil
+	}
+	return png.Encode(w, value)
+}
+
+func (imageCodec) Decode(r io.Reader) (image.Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	return png.Decode(bytes.NewReader(data))
+}
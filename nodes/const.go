@@ -29,6 +29,10 @@ func (co ConstOutput[T]) Description() string {
 	return co.PortDescription
 }
 
+func (co ConstOutput[T]) ContentHash() (string, bool) {
+	return HashContent(co.Type(), co.Val)
+}
+
 func (co ConstOutput[T]) BuildProxyOutput(source ProxySource) OutputPort {
 	return NewProxyOutput[T](source)
 }
@@ -0,0 +1,46 @@
+package nodes
+
+import (
+	"context"
+	"errors"
+)
+
+// ContextualOutput is implemented by outputs that can abandon their
+// evaluation once the provided context is done
+type ContextualOutput[T any] interface {
+	Output[T]
+	ValueWithContext(ctx context.Context) T
+}
+
+// ContextProvider is implemented by execution recorders that carry the
+// context of the evaluation they're recording, which is how cancellation
+// makes its way to every output evaluated along the way.
+type ContextProvider interface {
+	Context() context.Context
+}
+
+// ValueWithContext evaluates the output, stopping evaluation of any output
+// along the way that hasn't already started once the context is done.
+// Outputs that don't support cancellation are evaluated as is.
+func ValueWithContext[T any](ctx context.Context, output Output[T]) T {
+	if contextual, ok := output.(ContextualOutput[T]); ok {
+		return contextual.ValueWithContext(ctx)
+	}
+	return output.Value()
+}
+
+func recorderValue[T any](recorder ExecutionRecorder, output Output[T]) T {
+	if provider, ok := recorder.(ContextProvider); ok {
+		return ValueWithContext(provider.Context(), output)
+	}
+	return output.Value()
+}
+
+// interruptedMessage describes why an evaluation was stopped early for the
+// execution report
+func interruptedMessage(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "execution timed out: " + err.Error()
+	}
+	return "execution cancelled: " + err.Error()
+}
@@ -0,0 +1,116 @@
+package nodes_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CountingTestStructNode struct {
+	Evaluations *int
+}
+
+func (n CountingTestStructNode) Out(out *nodes.StructOutput[int]) {
+	*n.Evaluations++
+	out.Set(*n.Evaluations)
+}
+
+type CancellingTestStructNode struct {
+	Cancel context.CancelFunc
+	Input  nodes.Output[int]
+}
+
+func (n CancellingTestStructNode) Out(out *nodes.StructOutput[int]) {
+	n.Cancel()
+	out.Set(nodes.GetOutputValue(out, n.Input))
+}
+
+type WaitingTestStructNode struct{}
+
+func (WaitingTestStructNode) Out(out *nodes.StructOutput[int]) {
+	<-out.Context().Done()
+	out.Set(1)
+}
+
+func TestStructOutput_ValueWithContext_AlreadyCancelled(t *testing.T) {
+	// ARRANGE ================================================================
+	evaluations := 0
+	node := &nodes.Struct[CountingTestStructNode]{
+		Data: CountingTestStructNode{Evaluations: &evaluations},
+	}
+	out := nodes.GetNodeOutputPort[int](node, "Out")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT ====================================================================
+	cancelledVal := nodes.ValueWithContext(ctx, out)
+	cancelledReport := out.(nodes.ObservableExecution).ExecutionReport()
+	val := out.Value()
+
+	// ASSERT =================================================================
+	assert.Equal(t, 0, cancelledVal)
+	assert.Equal(t, []string{"execution cancelled: context canceled"}, cancelledReport.Errors)
+
+	// Cancelled evaluations aren't cached
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 1, evaluations)
+	assert.Empty(t, out.(nodes.ObservableExecution).ExecutionReport().Errors)
+}
+
+func TestStructOutput_ValueWithContext_PropagatesToInputs(t *testing.T) {
+	// ARRANGE ================================================================
+	evaluations := 0
+	upstream := &nodes.Struct[CountingTestStructNode]{
+		Data: CountingTestStructNode{Evaluations: &evaluations},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	downstream := &nodes.Struct[CancellingTestStructNode]{
+		Data: CancellingTestStructNode{
+			Cancel: cancel,
+			Input:  nodes.GetNodeOutputPort[int](upstream, "Out"),
+		},
+	}
+	out := nodes.GetNodeOutputPort[int](downstream, "Out")
+
+	// ACT ====================================================================
+	nodes.ValueWithContext(ctx, out)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 0, evaluations)
+	assert.Equal(t, []string{"execution cancelled: context canceled"}, out.(nodes.ObservableExecution).ExecutionReport().Errors)
+
+	upstreamReport := nodes.GetNodeOutputPort[int](upstream, "Out").(nodes.ObservableExecution).ExecutionReport()
+	assert.Equal(t, []string{"execution cancelled: context canceled"}, upstreamReport.Errors)
+}
+
+func TestStructOutput_ValueWithContext_Timeout(t *testing.T) {
+	// ARRANGE ================================================================
+	node := &nodes.Struct[WaitingTestStructNode]{}
+	out := nodes.GetNodeOutputPort[int](node, "Out")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// ACT ====================================================================
+	nodes.ValueWithContext(ctx, out)
+	report := out.(nodes.ObservableExecution).ExecutionReport()
+
+	// ASSERT =================================================================
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "execution timed out: context deadline exceeded", report.Errors[0])
+}
+
+func TestValueWithContext_NonContextualOutput(t *testing.T) {
+	out := nodes.GetNodeOutputPort[int](nodes.NewValue(3), "Value")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, 3, nodes.ValueWithContext(ctx, out))
+}
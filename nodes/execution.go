@@ -15,6 +15,10 @@ type ExecutionReport struct {
 	TotalTime time.Duration  `json:"totalTime"`          // Total time taken to compute the output
 	SelfTime  *time.Duration `json:"selfTime,omitempty"` // Time spent within the node itself, not counting waiting on other outputs
 	Steps     []StepTiming   `json:"steps,omitempty"`    // Detailed timing of sub-operations, all sub operation times should result in TotalTime - SelfTime
+
+	// Chain of upstream outputs that bounded TotalTime when inputs were
+	// evaluated in parallel, starting with the slowest direct input
+	CriticalPath []StepTiming `json:"criticalPath,omitempty"`
 }
 
 // ObservableOutput represents an output whose execution report can be inspected.
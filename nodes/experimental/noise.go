@@ -15,6 +15,10 @@ type SeamlessPerlinNode struct {
 	Negative   nodes.Output[coloring.Color]
 }
 
+func (an SeamlessPerlinNode) CacheVersion() int {
+	return 1
+}
+
 func (an SeamlessPerlinNode) Out(out *nodes.StructOutput[image.Image]) {
 	dim := nodes.TryGetOutputValue(out, an.Dimensions, 256)
 	img := image.NewRGBA(image.Rect(0, 0, dim, dim))
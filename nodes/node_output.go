@@ -16,7 +16,7 @@ func GetOutputValues[G any](recorder ExecutionRecorder, output []Output[G]) []G
 			continue
 		}
 		start := time.Now()
-		v := out.Value()
+		v := recorderValue(recorder, out)
 		recorder.CaptureTiming(out.Name(), time.Since(start))
 		results = append(results, v)
 	}
@@ -29,14 +29,14 @@ func TryGetOutputValue[G any](recorder ExecutionRecorder, output Output[G], fall
 		return fallback
 	}
 	start := time.Now()
-	v := output.Value()
+	v := recorderValue(recorder, output)
 	recorder.CaptureTiming(output.Name(), time.Since(start))
 	return v
 }
 
 func GetOutputValue[G any](recorder ExecutionRecorder, output Output[G]) G {
 	start := time.Now()
-	v := output.Value()
+	v := recorderValue(recorder, output)
 	recorder.CaptureTiming(output.Name(), time.Since(start))
 	return v
 }
@@ -46,14 +46,14 @@ func TryGetOutputReference[G any](result ExecutionRecorder, output Output[G], fa
 		return fallback
 	}
 	start := time.Now()
-	v := output.Value()
+	v := recorderValue(result, output)
 	result.CaptureTiming(output.Name(), time.Since(start))
 	return &v
 }
 
 func GetOutputReference[G any](result ExecutionRecorder, output Output[G]) *G {
 	start := time.Now()
-	v := output.Value()
+	v := recorderValue(result, output)
 	result.CaptureTiming(output.Name(), time.Since(start))
 	return &v
 }
@@ -0,0 +1,161 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type parallelSchedulerKey struct{}
+
+// parallelScheduler bounds how many goroutines an evaluation spreads across.
+// It's shared by every output evaluated under the same context, so the bound
+// holds for the evaluation as a whole rather than per node.
+type parallelScheduler struct {
+	slots chan struct{}
+}
+
+// WithParallelEvaluation returns a context that, when passed to
+// ValueWithContext, evaluates every input connected to an outdated node
+// concurrently before running the node itself. At most workers goroutines
+// evaluate outputs at any one time, including the caller's.
+//
+// Inputs are evaluated whether or not the node ends up reading them, so
+// graphs that choose between branches will evaluate every branch.
+func WithParallelEvaluation(ctx context.Context, workers int) context.Context {
+	if workers < 2 {
+		return ctx
+	}
+	return context.WithValue(ctx, parallelSchedulerKey{}, &parallelScheduler{
+		slots: make(chan struct{}, workers-1),
+	})
+}
+
+func schedulerFromContext(ctx context.Context) *parallelScheduler {
+	scheduler, _ := ctx.Value(parallelSchedulerKey{}).(*parallelScheduler)
+	return scheduler
+}
+
+// contextualEvaluation is implemented by outputs that can be evaluated
+// without knowing the type of value they produce
+type contextualEvaluation interface {
+	OutputPort
+	evaluate(ctx context.Context)
+}
+
+// upstreamEvaluations collects every distinct output connected to the node's
+// inputs that can be evaluated ahead of time
+func upstreamEvaluations(node Node) []contextualEvaluation {
+	seen := make(map[OutputPort]struct{})
+	var upstream []contextualEvaluation
+	add := func(port OutputPort) {
+		evaluation, ok := port.(contextualEvaluation)
+		if !ok {
+			return
+		}
+		if _, ok := seen[port]; ok {
+			return
+		}
+		seen[port] = struct{}{}
+		upstream = append(upstream, evaluation)
+	}
+
+	for _, input := range node.Inputs() {
+		switch v := input.(type) {
+		case SingleValueInputPort:
+			if port := v.Value(); port != nil {
+				add(port)
+			}
+
+		case ArrayValueInputPort:
+			for _, port := range v.Value() {
+				if port != nil {
+					add(port)
+				}
+			}
+		}
+	}
+	return upstream
+}
+
+func outputLabel(port OutputPort) string {
+	if named, ok := port.Node().(Named); ok {
+		return fmt.Sprintf("%s.%s", named.Name(), port.Name())
+	}
+	return port.Name()
+}
+
+// evaluateAll evaluates the outputs concurrently, handing each to a new
+// goroutine while the scheduler has room and evaluating the rest on the
+// calling goroutine. Since a goroutine never waits on a free slot, nested
+// evaluations can't deadlock each other. It returns how long each output
+// took along with the critical path through them.
+func (ps *parallelScheduler) evaluateAll(ctx context.Context, outputs []contextualEvaluation) ([]StepTiming, []StepTiming) {
+	timings := make([]StepTiming, len(outputs))
+
+	var (
+		wg        sync.WaitGroup
+		panicLock sync.Mutex
+		panicked  any
+	)
+
+	for i, output := range outputs {
+		run := func() {
+			start := time.Now()
+			output.evaluate(ctx)
+			timings[i] = StepTiming{
+				Label:    outputLabel(output),
+				Duration: time.Since(start),
+			}
+		}
+
+		// Keep the last output for ourselves rather than sitting idle
+		if i == len(outputs)-1 {
+			run()
+			continue
+		}
+
+		select {
+		case ps.slots <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-ps.slots }()
+				defer func() {
+					if r := recover(); r != nil {
+						panicLock.Lock()
+						panicked = r
+						panicLock.Unlock()
+					}
+				}()
+				run()
+			}()
+		default:
+			run()
+		}
+	}
+	wg.Wait()
+
+	// Surface panics on the goroutine that asked for the value, same as
+	// sequential evaluation would
+	if panicked != nil {
+		panic(panicked)
+	}
+
+	critical := -1
+	for i, timing := range timings {
+		if critical == -1 || timing.Duration > timings[critical].Duration {
+			critical = i
+		}
+	}
+	if critical == -1 {
+		return timings, nil
+	}
+
+	path := []StepTiming{timings[critical]}
+	if observable, ok := outputs[critical].(ObservableExecution); ok {
+		path = append(path, observable.ExecutionReport().CriticalPath...)
+	}
+	return timings, path
+}
@@ -0,0 +1,134 @@
+package nodes_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RendezvousTestStructNode only finishes once every node sharing its
+// WaitGroup has started, which can only happen when they're evaluated
+// concurrently
+type RendezvousTestStructNode struct {
+	Arrived     *sync.WaitGroup
+	Evaluations *int
+	Delay       time.Duration
+}
+
+func (n RendezvousTestStructNode) Out(out *nodes.StructOutput[float64]) {
+	*n.Evaluations++
+	n.Arrived.Done()
+
+	done := make(chan struct{})
+	go func() {
+		n.Arrived.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		out.CaptureError(context.DeadlineExceeded)
+		return
+	}
+
+	time.Sleep(n.Delay)
+	out.Set(1)
+}
+
+func rendezvousBranches(count int) (*ArrayTestStructNode, []int) {
+	arrived := &sync.WaitGroup{}
+	arrived.Add(count)
+
+	evaluations := make([]int, count)
+	values := make([]nodes.Output[float64], count)
+	for i := range values {
+		values[i] = nodes.GetNodeOutputPort[float64](&nodes.Struct[RendezvousTestStructNode]{
+			Data: RendezvousTestStructNode{
+				Arrived:     arrived,
+				Evaluations: &evaluations[i],
+				Delay:       time.Duration(i) * 10 * time.Millisecond,
+			},
+		}, "Out")
+	}
+
+	return &ArrayTestStructNode{
+		Data: ArrayTestStruct{Values: values},
+	}, evaluations
+}
+
+func TestWithParallelEvaluation_EvaluatesBranchesConcurrently(t *testing.T) {
+	// ARRANGE ================================================================
+	node, evaluations := rendezvousBranches(3)
+	out := nodes.GetNodeOutputPort[float64](node, "Sum")
+	ctx := nodes.WithParallelEvaluation(context.Background(), 3)
+
+	// ACT ====================================================================
+	sum := nodes.ValueWithContext(ctx, out)
+	report := out.(nodes.ObservableExecution).ExecutionReport()
+	again := nodes.ValueWithContext(ctx, out)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 3., sum)
+	assert.Equal(t, 3., again)
+	assert.Equal(t, []int{1, 1, 1}, evaluations)
+
+	require.Len(t, report.Steps, 1)
+	assert.Equal(t, "Parallel Inputs", report.Steps[0].Label)
+	assert.Len(t, report.Steps[0].Steps, 3)
+
+	// The slowest branch bounds the evaluation
+	require.Len(t, report.CriticalPath, 1)
+	assert.Equal(t, "Rendezvous Test Struct.Out", report.CriticalPath[0].Label)
+	assert.GreaterOrEqual(t, report.CriticalPath[0].Duration, 20*time.Millisecond)
+}
+
+func TestWithParallelEvaluation_DiamondEvaluatesSharedInputOnce(t *testing.T) {
+	// ARRANGE ================================================================
+	evaluations := 0
+	shared := nodes.GetNodeOutputPort[int](&nodes.Struct[CountingTestStructNode]{
+		Data: CountingTestStructNode{Evaluations: &evaluations},
+	}, "Out")
+
+	left := nodes.GetNodeOutputPort[float64](&nodes.Struct[IntToFloatTestStructNode]{
+		Data: IntToFloatTestStructNode{In: shared},
+	}, "Out")
+	right := nodes.GetNodeOutputPort[float64](&nodes.Struct[IntToFloatTestStructNode]{
+		Data: IntToFloatTestStructNode{In: shared},
+	}, "Out")
+
+	out := nodes.GetNodeOutputPort[float64](&nodes.Struct[SimpleAddTestStructNode]{
+		Data: SimpleAddTestStructNode{A: left, B: right},
+	}, "Sum")
+
+	ctx := nodes.WithParallelEvaluation(context.Background(), 4)
+
+	// ACT ====================================================================
+	sum := nodes.ValueWithContext(ctx, out)
+	report := out.(nodes.ObservableExecution).ExecutionReport()
+
+	// ASSERT =================================================================
+	assert.Equal(t, 2., sum)
+	assert.Equal(t, 1, evaluations)
+	require.Len(t, report.CriticalPath, 2)
+	assert.Equal(t, "Int To Float Test Struct.Out", report.CriticalPath[0].Label)
+	assert.Equal(t, "Counting Test Struct.Out", report.CriticalPath[1].Label)
+}
+
+func TestWithParallelEvaluation_SingleWorkerIsSequential(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, nodes.WithParallelEvaluation(ctx, 1))
+}
+
+type IntToFloatTestStructNode struct {
+	In nodes.Output[int]
+}
+
+func (n IntToFloatTestStructNode) Out(out *nodes.StructOutput[float64]) {
+	out.Set(float64(nodes.GetOutputValue(out, n.In)))
+}
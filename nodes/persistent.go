@@ -0,0 +1,171 @@
+package nodes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// PersistentCache stores evaluated outputs somewhere that outlives the
+// process, addressed by the content hash of the output that produced them
+type PersistentCache interface {
+	// Load passes the contents of the entry stored under the key to read,
+	// reporting false if there is no such entry
+	Load(key string, read func(io.Reader) error) (bool, error)
+
+	// Store writes a new entry under the key
+	Store(key string, write func(io.Writer) error) error
+}
+
+type persistentCacheKey struct{}
+
+// WithPersistentCache returns a context that, when passed to
+// ValueWithContext, serves outputs from the cache whenever an entry exists
+// for their content hash, and stores outputs it has to evaluate.
+func WithPersistentCache(ctx context.Context, cache PersistentCache) context.Context {
+	if cache == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, persistentCacheKey{}, cache)
+}
+
+func persistentCacheFromContext(ctx context.Context) PersistentCache {
+	cache, _ := ctx.Value(persistentCacheKey{}).(PersistentCache)
+	return cache
+}
+
+// ============================================================================
+
+// OutputCodec serializes values of a single output type for the persistent
+// cache. Outputs only take part in persistent caching once a codec has been
+// registered for their type. Entries aren't keyed by codec, so a codec that
+// changes its layout must recognize entries in the old one and return an
+// error decoding them, which evicts them from the cache.
+type OutputCodec[T any] interface {
+	Encode(w io.Writer, value T) error
+	Decode(r io.Reader) (T, error)
+}
+
+var (
+	outputCodecsLock sync.RWMutex
+	outputCodecs     = make(map[reflect.Type]any)
+)
+
+// RegisterOutputCodec opts every output producing T into persistent
+// caching, replacing any codec previously registered for T
+func RegisterOutputCodec[T any](codec OutputCodec[T]) {
+	outputCodecsLock.Lock()
+	defer outputCodecsLock.Unlock()
+	outputCodecs[reflect.TypeFor[T]()] = codec
+}
+
+// LookupOutputCodec returns the codec registered for T, if any
+func LookupOutputCodec[T any]() (OutputCodec[T], bool) {
+	outputCodecsLock.RLock()
+	defer outputCodecsLock.RUnlock()
+	codec, ok := outputCodecs[reflect.TypeFor[T]()].(OutputCodec[T])
+	return codec, ok
+}
+
+// ============================================================================
+
+// ContentAddressable is implemented by outputs that can summarize
+// everything their value depends on as a hash. Outputs that can't, whether
+// because they aren't implemented or they report false, are never
+// persistently cached and neither is anything downstream of them.
+type ContentAddressable interface {
+	ContentHash() (string, bool)
+}
+
+// CacheVersioned is implemented by node data to version the node's
+// implementation within its content hash. Content hashes only cover a
+// node's type, configuration and inputs, so a persistent cache would keep
+// serving outputs computed by older code for the same inputs. Node authors
+// must bump the version whenever a change alters what the node outputs.
+// Nodes that don't implement it are at version 0.
+type CacheVersioned interface {
+	CacheVersion() int
+}
+
+func cacheVersion(data any) int {
+	if versioned, ok := data.(CacheVersioned); ok {
+		return versioned.CacheVersion()
+	}
+	return 0
+}
+
+// HashContent hashes the JSON representation of a value together with its
+// kind, for outputs whose value is entirely described by its JSON
+func HashContent(kind string, value any) (string, bool) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(kind))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+func outputContentHash(port OutputPort) (string, bool) {
+	if port == nil {
+		return "nil", true
+	}
+
+	if addressable, ok := port.(ContentAddressable); ok {
+		return addressable.ContentHash()
+	}
+	return "", false
+}
+
+var outputPortType = reflect.TypeFor[OutputPort]()
+
+// hashStructData hashes every exported field of the data that isn't an
+// input port, which is everything a struct node is configured with beyond
+// what's connected to it. Fields tagged `json:"-"` are left out. Data with
+// unexported fields can't be hashed, as nothing outside the node can tell
+// what state they hold.
+func hashStructData(data any) (string, bool) {
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "nil", true
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return HashContent(value.Type().String(), value.Interface())
+	}
+
+	fields := make(map[string]any)
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			return "", false
+		}
+
+		if field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Interface && fieldType.Implements(outputPortType) {
+			continue
+		}
+
+		fields[field.Name] = value.Field(i).Interface()
+	}
+
+	return HashContent(fmt.Sprintf("%s.%s", value.Type().PkgPath(), value.Type().Name()), fields)
+}
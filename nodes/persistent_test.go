@@ -0,0 +1,232 @@
+package nodes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryPersistentCache map[string][]byte
+
+func (m memoryPersistentCache) Load(key string, read func(io.Reader) error) (bool, error) {
+	data, ok := m[key]
+	if !ok {
+		return false, nil
+	}
+	return true, read(bytes.NewReader(data))
+}
+
+func (m memoryPersistentCache) Store(key string, write func(io.Writer) error) error {
+	buf := &bytes.Buffer{}
+	if err := write(buf); err != nil {
+		return err
+	}
+	m[key] = buf.Bytes()
+	return nil
+}
+
+type RepeatTestStructNode struct {
+	Text        nodes.Output[string]
+	Times       int
+	Evaluations *int `json:"-"`
+}
+
+func (n RepeatTestStructNode) Out(out *nodes.StructOutput[[]byte]) {
+	*n.Evaluations++
+	text := nodes.GetOutputValue(out, n.Text)
+	if text == "" {
+		out.CaptureError(errors.New("no text"))
+		return
+	}
+	out.Set(bytes.Repeat([]byte(text), n.Times))
+}
+
+func (n RepeatTestStructNode) Length(out *nodes.StructOutput[int]) {
+	*n.Evaluations++
+	out.Set(len(nodes.GetOutputValue(out, n.Text)) * n.Times)
+}
+
+func repeatNode(text string, times int, evaluations *int) *nodes.Struct[RepeatTestStructNode] {
+	return &nodes.Struct[RepeatTestStructNode]{
+		Data: RepeatTestStructNode{
+			Text:        nodes.GetNodeOutputPort[string](nodes.NewValue(text), "Value"),
+			Times:       times,
+			Evaluations: evaluations,
+		},
+	}
+}
+
+func TestPersistentCache_ServesOutputsAcrossGraphs(t *testing.T) {
+	// ARRANGE ================================================================
+	cache := memoryPersistentCache{}
+	ctx := nodes.WithPersistentCache(context.Background(), cache)
+
+	firstEvaluations := 0
+	first := nodes.GetNodeOutputPort[[]byte](repeatNode("ab", 2, &firstEvaluations), "Out")
+
+	// An identical graph built from scratch, like a later run would
+	secondEvaluations := 0
+	second := nodes.GetNodeOutputPort[[]byte](repeatNode("ab", 2, &secondEvaluations), "Out")
+
+	// ACT ====================================================================
+	firstVal := nodes.ValueWithContext(ctx, first)
+	secondVal := nodes.ValueWithContext(ctx, second)
+
+	// ASSERT =================================================================
+	assert.Equal(t, []byte("abab"), firstVal)
+	assert.Equal(t, []byte("abab"), secondVal)
+	assert.Equal(t, 1, firstEvaluations)
+	assert.Equal(t, 0, secondEvaluations)
+	assert.Len(t, cache, 1)
+
+	report := second.(nodes.ObservableExecution).ExecutionReport()
+	assert.Equal(t, []string{"loaded from persistent cache"}, report.Logs)
+}
+
+func TestPersistentCache_KeyedByContent(t *testing.T) {
+	tests := map[string]struct {
+		text  string
+		times int
+	}{
+		"different input": {text: "cd", times: 2},
+		"different data":  {text: "ab", times: 3},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			cache := memoryPersistentCache{}
+			ctx := nodes.WithPersistentCache(context.Background(), cache)
+
+			evaluations := 0
+			nodes.ValueWithContext(ctx, nodes.GetNodeOutputPort[[]byte](repeatNode("ab", 2, &evaluations), "Out"))
+
+			// ACT ============================================================
+			nodes.ValueWithContext(ctx, nodes.GetNodeOutputPort[[]byte](repeatNode(tc.text, tc.times, &evaluations), "Out"))
+
+			// ASSERT =========================================================
+			assert.Equal(t, 2, evaluations)
+			assert.Len(t, cache, 2)
+		})
+	}
+}
+
+func TestPersistentCache_SkipsErroredAndUncodecedOutputs(t *testing.T) {
+	// ARRANGE ================================================================
+	cache := memoryPersistentCache{}
+	ctx := nodes.WithPersistentCache(context.Background(), cache)
+
+	evaluations := 0
+	node := repeatNode("", 2, &evaluations)
+
+	// ACT ====================================================================
+	nodes.ValueWithContext(ctx, nodes.GetNodeOutputPort[[]byte](node, "Out"))
+	nodes.ValueWithContext(ctx, nodes.GetNodeOutputPort[int](node, "Length"))
+
+	// ASSERT =================================================================
+	assert.Equal(t, 2, evaluations)
+	assert.Empty(t, cache)
+}
+
+func TestPersistentCache_IgnoresUndecodableEntries(t *testing.T) {
+	// ARRANGE ================================================================
+	evaluations := 0
+	out := nodes.GetNodeOutputPort[[]byte](repeatNode("ab", 2, &evaluations), "Out")
+	hash, ok := out.(nodes.ContentAddressable).ContentHash()
+	require.True(t, ok)
+
+	cache := failingPersistentCache{memoryPersistentCache{hash: []byte("stale")}}
+	ctx := nodes.WithPersistentCache(context.Background(), cache)
+
+	// ACT ====================================================================
+	val := nodes.ValueWithContext(ctx, out)
+
+	// ASSERT =================================================================
+	assert.Equal(t, []byte("abab"), val)
+	assert.Equal(t, 1, evaluations)
+	assert.Equal(t, []string{"ignoring persistent cache entry: corrupt"}, out.(nodes.ObservableExecution).ExecutionReport().Logs)
+}
+
+type failingPersistentCache struct {
+	memoryPersistentCache
+}
+
+func (f failingPersistentCache) Load(key string, read func(io.Reader) error) (bool, error) {
+	if _, ok := f.memoryPersistentCache[key]; ok {
+		return false, errors.New("corrupt")
+	}
+	return false, nil
+}
+
+func TestStructOutput_ContentHash(t *testing.T) {
+	evaluations := 0
+	a := nodes.GetNodeOutputPort[[]byte](repeatNode("ab", 2, &evaluations), "Out").(nodes.ContentAddressable)
+	b := nodes.GetNodeOutputPort[[]byte](repeatNode("ab", 2, &evaluations), "Out").(nodes.ContentAddressable)
+	length := nodes.GetNodeOutputPort[int](repeatNode("ab", 2, &evaluations), "Length").(nodes.ContentAddressable)
+
+	aHash, ok := a.ContentHash()
+	require.True(t, ok)
+	bHash, _ := b.ContentHash()
+	lengthHash, _ := length.ContentHash()
+
+	assert.Equal(t, aHash, bHash)
+	assert.NotEqual(t, aHash, lengthHash)
+	assert.Equal(t, 0, evaluations)
+}
+
+type VersionedTestStructNode struct {
+	Text    nodes.Output[string]
+	Version int `json:"-"`
+}
+
+func (n VersionedTestStructNode) CacheVersion() int {
+	return n.Version
+}
+
+func (n VersionedTestStructNode) Out(out *nodes.StructOutput[[]byte]) {
+	out.Set([]byte(nodes.GetOutputValue(out, n.Text)))
+}
+
+func TestStructOutput_ContentHashIncludesCacheVersion(t *testing.T) {
+	hashAt := func(version int) string {
+		node := &nodes.Struct[VersionedTestStructNode]{
+			Data: VersionedTestStructNode{
+				Text:    nodes.GetNodeOutputPort[string](nodes.NewValue("ab"), "Value"),
+				Version: version,
+			},
+		}
+		hash, ok := nodes.GetNodeOutputPort[[]byte](node, "Out").(nodes.ContentAddressable).ContentHash()
+		require.True(t, ok)
+		return hash
+	}
+
+	assert.Equal(t, hashAt(1), hashAt(1))
+	assert.NotEqual(t, hashAt(1), hashAt(2))
+}
+
+type UnexportedStateTestStructNode struct {
+	Text  nodes.Output[string]
+	state int
+}
+
+func (n UnexportedStateTestStructNode) Out(out *nodes.StructOutput[[]byte]) {
+	out.Set([]byte(nodes.GetOutputValue(out, n.Text) + strconv.Itoa(n.state)))
+}
+
+func TestStructOutput_ContentHashRejectsUnexportedState(t *testing.T) {
+	node := &nodes.Struct[UnexportedStateTestStructNode]{
+		Data: UnexportedStateTestStructNode{
+			Text: nodes.GetNodeOutputPort[string](nodes.NewValue("ab"), "Value"),
+		},
+	}
+
+	_, ok := nodes.GetNodeOutputPort[[]byte](node, "Out").(nodes.ContentAddressable).ContentHash()
+	assert.False(t, ok)
+}
@@ -1,5 +1,7 @@
 package nodes
 
+import "context"
+
 type ProxySource interface {
 	Port
 	Version() int
@@ -45,15 +47,27 @@ func (p proxyOutput[T]) Type() string {
 }
 
 func (p proxyOutput[T]) Value() T {
+	return p.ValueWithContext(context.Background())
+}
+
+func (p proxyOutput[T]) ValueWithContext(ctx context.Context) T {
 	if src := p.source.CurrentSource(); src != nil {
 		if typed, ok := src.(Output[T]); ok {
-			return typed.Value()
+			return ValueWithContext(ctx, typed)
 		}
 	}
 	var zero T
 	return zero
 }
 
+func (p proxyOutput[T]) ContentHash() (string, bool) {
+	return outputContentHash(p.source.CurrentSource())
+}
+
+func (p proxyOutput[T]) evaluate(ctx context.Context) {
+	p.ValueWithContext(ctx)
+}
+
 func (p proxyOutput[T]) BuildProxyOutput(source ProxySource) OutputPort {
 	return proxyOutput[T]{source: source}
 }
@@ -1,7 +1,12 @@
 package nodes
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +19,10 @@ type inputVersions interface {
 	inputVersions() string
 }
 
+type contentHasher interface {
+	contentHash(functionName string) (string, bool)
+}
+
 // ============================================================================
 type outputPortBuilder interface {
 	build(node Node, cache *structOutputCache, data any, functionName, displayName string, mutex *sync.Mutex) OutputPort
@@ -25,13 +34,35 @@ func NewStructOutput[T any](val T) StructOutput[T] {
 	}
 }
 
+// structOutputCache is shared by every output of a node. Evaluation holds the
+// node's mutex, but downstream nodes check versions without it, which under
+// parallel evaluation can happen while the cache is being written.
 type structOutputCache struct {
 	versioner inputVersions
+	hasher    contentHasher
 	cache     map[string]cachedStructOutput
+	hashes    map[string]cachedContentHash
+	lock      sync.RWMutex
 }
 
-func (soc structOutputCache) Version(key string) int {
+// cachedContentHash is the content hash of an output as of the input
+// versions it was computed against, saving a walk up the graph every time
+// it's asked for
+type cachedContentHash struct {
+	nodeInputVersions string
+	hash              string
+	ok                bool
+}
+
+func (soc *structOutputCache) get(key string) (cachedStructOutput, bool) {
+	soc.lock.RLock()
+	defer soc.lock.RUnlock()
 	val, ok := soc.cache[key]
+	return val, ok
+}
+
+func (soc *structOutputCache) Version(key string) int {
+	val, ok := soc.get(key)
 
 	if !ok {
 		return -1
@@ -45,10 +76,10 @@ func (soc structOutputCache) Version(key string) int {
 	return version
 }
 
-func (soc structOutputCache) Outdated(key string) bool {
-	val, ok := soc.cache[key]
+func (soc *structOutputCache) Outdated(key string) bool {
+	val, ok := soc.get(key)
 
-	if !ok {
+	if !ok || val.interrupted {
 		return true
 	}
 
@@ -56,11 +87,40 @@ func (soc structOutputCache) Outdated(key string) bool {
 	return val.nodeInputVersions != newVersion
 }
 
-func (soc structOutputCache) InputString() string {
+func (soc *structOutputCache) InputString() string {
 	return soc.versioner.inputVersions()
 }
 
 func (soc *structOutputCache) Cache(key string, val any) {
+	soc.set(key, soc.entry(key, val))
+}
+
+// CacheInterrupted records the result of an evaluation that was stopped
+// early. It's kept around so its execution report can be inspected, but
+// never served as a value.
+func (soc *structOutputCache) CacheInterrupted(key string, val any) {
+	entry := soc.entry(key, val)
+	entry.interrupted = true
+	soc.set(key, entry)
+}
+
+func (soc *structOutputCache) set(key string, entry cachedStructOutput) {
+	soc.lock.Lock()
+	defer soc.lock.Unlock()
+	soc.cache[key] = entry
+}
+
+// Report returns the cached value for the key, as long as it was computed
+// from the node's current inputs, whether or not it was interrupted
+func (soc *structOutputCache) Report(key string) (any, bool) {
+	val, ok := soc.get(key)
+	if !ok || val.nodeInputVersions != soc.versioner.inputVersions() {
+		return nil, false
+	}
+	return val.val, true
+}
+
+func (soc *structOutputCache) entry(key string, val any) cachedStructOutput {
 	version := soc.Version(key)
 	newVersion := version + 1
 
@@ -71,21 +131,45 @@ func (soc *structOutputCache) Cache(key string, val any) {
 		newVersion--
 	}
 
-	soc.cache[key] = cachedStructOutput{
+	return cachedStructOutput{
 		nodeInputVersions: soc.versioner.inputVersions(),
 		val:               val,
 		version:           newVersion,
 	}
 }
 
-func (soc structOutputCache) Get(key string) any {
-	return soc.cache[key].val
+func (soc *structOutputCache) ContentHash(key string) (string, bool) {
+	versions := soc.versioner.inputVersions()
+
+	soc.lock.RLock()
+	cached, ok := soc.hashes[key]
+	soc.lock.RUnlock()
+	if ok && cached.nodeInputVersions == versions {
+		return cached.hash, cached.ok
+	}
+
+	hash, hashed := soc.hasher.contentHash(key)
+
+	soc.lock.Lock()
+	defer soc.lock.Unlock()
+	soc.hashes[key] = cachedContentHash{
+		nodeInputVersions: versions,
+		hash:              hash,
+		ok:                hashed,
+	}
+	return hash, hashed
+}
+
+func (soc *structOutputCache) Get(key string) any {
+	val, _ := soc.get(key)
+	return val.val
 }
 
 type cachedStructOutput struct {
 	nodeInputVersions string
 	version           int
 	val               any
+	interrupted       bool
 }
 
 type StructOutput[T any] struct {
@@ -97,6 +181,7 @@ type StructOutput[T any] struct {
 	cache        *structOutputCache
 	report       ExecutionReport
 	mutex        *sync.Mutex
+	ctx          context.Context
 }
 
 func (so StructOutput[T]) Name() string {
@@ -108,14 +193,32 @@ func (so StructOutput[T]) Node() Node {
 }
 
 func (so *StructOutput[T]) Value() T {
+	return so.ValueWithContext(context.Background())
+}
+
+// ValueWithContext evaluates the output, skipping evaluation entirely if the
+// context is already done. Evaluations interrupted by the context are
+// recorded in the execution report but never cached, so the next request
+// for the value evaluates it again.
+func (so *StructOutput[T]) ValueWithContext(ctx context.Context) T {
 	so.mutex.Lock()
 	defer so.mutex.Unlock()
 	var val StructOutput[T]
 	if !so.cache.Outdated(so.functionName) {
 		val = so.cache.Get(so.functionName).(StructOutput[T])
+	} else if err := ctx.Err(); err != nil {
+		val.report.Errors = append(val.report.Errors, interruptedMessage(err))
+		so.cache.CacheInterrupted(so.functionName, val)
+	} else if persistent := persistentCacheFromContext(ctx); persistent != nil && so.loadPersistent(persistent, &val) {
+		so.cache.Cache(so.functionName, val)
 	} else {
+		val.ctx = ctx
 		start := time.Now()
+		if scheduler := schedulerFromContext(ctx); scheduler != nil {
+			so.evaluateInputs(scheduler, &val)
+		}
 		refutil.CallStructMethod(so.data, so.functionName, &val)
+		val.ctx = nil
 		val.report.TotalTime = time.Since(start)
 		self := val.report.TotalTime
 		for _, v := range val.report.Steps {
@@ -124,11 +227,110 @@ func (so *StructOutput[T]) Value() T {
 		val.report.SelfTime = &self
 		// val.report.Errors = append(val.report.Errors, fmt.Sprintf("Version: %d", so.cache.Version(so.functionName)))
 		// val.report.Errors = append(val.report.Errors, fmt.Sprintf("Input: %s", so.cache.InputString()))
-		so.cache.Cache(so.functionName, val)
+		if err := ctx.Err(); err != nil {
+			val.report.Errors = append(val.report.Errors, interruptedMessage(err))
+			so.cache.CacheInterrupted(so.functionName, val)
+		} else {
+			if persistent != nil {
+				so.storePersistent(persistent, &val)
+			}
+			so.cache.Cache(so.functionName, val)
+		}
 	}
 	return val.val
 }
 
+// loadPersistent attempts to serve the output from the persistent cache,
+// which requires a codec for the output's type and a content hash for
+// everything upstream of it
+func (so *StructOutput[T]) loadPersistent(persistent PersistentCache, val *StructOutput[T]) bool {
+	codec, ok := LookupOutputCodec[T]()
+	if !ok {
+		return false
+	}
+
+	key, ok := so.ContentHash()
+	if !ok {
+		return false
+	}
+
+	start := time.Now()
+	found, err := persistent.Load(key, func(r io.Reader) error {
+		v, err := codec.Decode(r)
+		if err != nil {
+			return err
+		}
+		val.val = v
+		return nil
+	})
+	if err != nil {
+		val.report.Logs = append(val.report.Logs, fmt.Sprintf("ignoring persistent cache entry: %s", err.Error()))
+		return false
+	}
+
+	if !found {
+		return false
+	}
+
+	val.report.TotalTime = time.Since(start)
+	val.report.Logs = append(val.report.Logs, "loaded from persistent cache")
+	return true
+}
+
+// storePersistent writes a freshly evaluated output to the persistent
+// cache. Outputs that captured errors are left out, so the error surfaces
+// again the next time they're evaluated.
+func (so *StructOutput[T]) storePersistent(persistent PersistentCache, val *StructOutput[T]) {
+	if len(val.report.Errors) > 0 {
+		return
+	}
+
+	codec, ok := LookupOutputCodec[T]()
+	if !ok {
+		return
+	}
+
+	key, ok := so.ContentHash()
+	if !ok {
+		return
+	}
+
+	err := persistent.Store(key, func(w io.Writer) error {
+		return codec.Encode(w, val.val)
+	})
+	if err != nil {
+		val.report.Logs = append(val.report.Logs, fmt.Sprintf("unable to write to persistent cache: %s", err.Error()))
+	}
+}
+
+// ContentHash identifies the output by the node's type and configuration
+// along with the content hashes of everything connected to it
+func (so StructOutput[T]) ContentHash() (string, bool) {
+	return so.cache.ContentHash(so.functionName)
+}
+
+// evaluateInputs warms every output feeding the node in parallel, so the
+// node's own sequential reads of its inputs are served from their caches
+func (so *StructOutput[T]) evaluateInputs(scheduler *parallelScheduler, val *StructOutput[T]) {
+	upstream := upstreamEvaluations(so.node)
+	if len(upstream) == 0 {
+		return
+	}
+
+	start := time.Now()
+	timings, path := scheduler.evaluateAll(val.ctx, upstream)
+	val.report.Steps = append(val.report.Steps, StepTiming{
+		Label:    "Parallel Inputs",
+		Duration: time.Since(start),
+		Steps:    timings,
+	})
+	val.report.CriticalPath = path
+}
+
+func (so *StructOutput[T]) evaluate(ctx context.Context) {
+	so.ValueWithContext(ctx)
+}
+
 func (so StructOutput[T]) Version() int {
 	return so.cache.Version(so.functionName)
 }
@@ -163,9 +365,8 @@ func (so StructOutput[T]) Description() string {
 func (so StructOutput[T]) ExecutionReport() ExecutionReport {
 
 	// More song and dance of function return vs node return
-	if !so.cache.Outdated(so.functionName) {
-		val := so.cache.Get(so.functionName).(StructOutput[T])
-		return val.report
+	if val, ok := so.cache.Report(so.functionName); ok {
+		return val.(StructOutput[T]).report
 	}
 	return so.report
 }
@@ -182,6 +383,15 @@ func (so *StructOutput[T]) Set(v T) {
 	so.val = v
 }
 
+// Context of the evaluation in progress. Long running outputs should check
+// it periodically and stop early once it's done.
+func (so *StructOutput[T]) Context() context.Context {
+	if so.ctx == nil {
+		return context.Background()
+	}
+	return so.ctx
+}
+
 func (so *StructOutput[T]) CaptureError(err error) {
 	if err == nil {
 		return
@@ -322,7 +532,10 @@ type Struct[T any] struct {
 	Data T
 
 	outputCache *structOutputCache
-	mutex       sync.Mutex
+
+	// Held by a pointer so methods with value receivers can copy the node
+	// while one of its outputs is being evaluated on another goroutine
+	mutex *sync.Mutex
 }
 
 func (s *Struct[T]) Outputs() map[string]OutputPort {
@@ -337,13 +550,19 @@ func (s *Struct[T]) Outputs() map[string]OutputPort {
 	if s.outputCache == nil {
 		s.outputCache = &structOutputCache{
 			versioner: s,
+			hasher:    s,
 			cache:     make(map[string]cachedStructOutput),
+			hashes:    make(map[string]cachedContentHash),
 		}
 	}
 
+	if s.mutex == nil {
+		s.mutex = &sync.Mutex{}
+	}
+
 	for functionName, zero := range funcs {
 		portName := utils.CamelCaseToSpaceCase(functionName)
-		out[portName] = zero.build(s, s.outputCache, &s.Data, functionName, portName, &s.mutex)
+		out[portName] = zero.build(s, s.outputCache, &s.Data, functionName, portName, s.mutex)
 	}
 
 	return out
@@ -422,6 +641,51 @@ func (s *Struct[T]) inputVersions() string {
 	return builder.String()
 }
 
+func (s *Struct[T]) contentHash(functionName string) (string, bool) {
+	dataHash, ok := hashStructData(s.Data)
+	if !ok {
+		return "", false
+	}
+
+	version := cacheVersion(s.Data)
+	if version == 0 {
+		version = cacheVersion(&s.Data)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(dataHash))
+	hash.Write([]byte(functionName))
+	hash.Write([]byte(strconv.Itoa(version)))
+	hash.Write([]byte{0})
+
+	inputs := utils.SortMapByKey(s.Inputs())
+	for _, input := range inputs {
+		hash.Write([]byte(input.Key))
+
+		switch v := input.Val.(type) {
+		case SingleValueInputPort:
+			inputHash, ok := outputContentHash(v.Value())
+			if !ok {
+				return "", false
+			}
+			hash.Write([]byte(inputHash))
+
+		case ArrayValueInputPort:
+			for _, port := range v.Value() {
+				inputHash, ok := outputContentHash(port)
+				if !ok {
+					return "", false
+				}
+				hash.Write([]byte(inputHash))
+			}
+		}
+
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
 func collapseCommonPackages(dirty string) string {
 	result := dirty
 
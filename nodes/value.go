@@ -1,5 +1,7 @@
 package nodes
 
+import "fmt"
+
 const valueOutputPortName = "Value"
 
 // Implements Output[T any]
@@ -23,6 +25,10 @@ func (sno valueOutputPort[T]) Version() int {
 	return sno.Val.Version()
 }
 
+func (sno valueOutputPort[T]) ContentHash() (string, bool) {
+	return HashContent(fmt.Sprintf("%T", sno.Val.value), sno.Val.value)
+}
+
 func (sno valueOutputPort[T]) BuildProxyOutput(source ProxySource) OutputPort {
 	return NewProxyOutput[T](source)
 }
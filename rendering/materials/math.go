@@ -1,6 +1,9 @@
 package materials
 
-import "math"
+import (
+	"math"
+	"math/rand"
+)
 
 // Reflectance uses Schlick's approximation for reflectance.
 func Reflectance(cosine, refIdx float64) float64 {
@@ -8,3 +11,18 @@ func Reflectance(cosine, refIdx float64) float64 {
 	r0 = r0 * r0
 	return r0 + (1-r0)*math.Pow((1-cosine), 5)
 }
+
+// concurrentSource draws from the top level math/rand functions, which unlike
+// a source built with rand.NewSource are safe to use from every goroutine
+// rendering the scene at once
+type concurrentSource struct{}
+
+func (concurrentSource) Int63() int64 { return rand.Int63() }
+
+func (concurrentSource) Uint64() uint64 { return rand.Uint64() }
+
+func (concurrentSource) Seed(int64) {}
+
+func newRand() *rand.Rand {
+	return rand.New(concurrentSource{})
+}
@@ -0,0 +1,89 @@
+package materials
+
+import (
+	"github.com/EliCDavis/polyform/drawing/coloring"
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/polyform/rendering/textures"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+
+	refutil.RegisterType[nodes.Struct[LambertianNode]](factory)
+	refutil.RegisterType[nodes.Struct[MetalNode]](factory)
+	refutil.RegisterType[nodes.Struct[DielectricNode]](factory)
+	refutil.RegisterType[nodes.Struct[DiffuseLightNode]](factory)
+
+	generator.RegisterTypes(factory)
+}
+
+func colorOrWhite(out nodes.ExecutionRecorder, c nodes.Output[coloring.Color]) vector3.Float64 {
+	col := nodes.TryGetOutputValue(out, c, coloring.White())
+	return vector3.New(col.R, col.G, col.B)
+}
+
+type LambertianNode struct {
+	Color nodes.Output[coloring.Color] `description:"Color of the surface. Defaults to white"`
+}
+
+func (LambertianNode) Description() string {
+	return "Matte surface that scatters light evenly in all directions"
+}
+
+func (n LambertianNode) Out(out *nodes.StructOutput[rendering.Material]) {
+	out.Set(NewLambertian(textures.NewSolidColorTexture(colorOrWhite(out, n.Color))))
+}
+
+type MetalNode struct {
+	Color     nodes.Output[coloring.Color] `description:"Tint of the reflections. Defaults to white"`
+	Roughness nodes.Output[float64]        `description:"How blurry reflections are, 0 being a perfect mirror and 1 being completely rough"`
+}
+
+func (MetalNode) Description() string {
+	return "Reflective surface"
+}
+
+func (n MetalNode) Out(out *nodes.StructOutput[rendering.Material]) {
+	roughness := nodes.TryGetOutputValue(out, n.Roughness, 0)
+	if roughness < 0 || roughness > 1 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Roughness, Message: "roughness must be between 0 and 1"})
+		return
+	}
+	out.Set(NewFuzzyMetal(colorOrWhite(out, n.Color), roughness))
+}
+
+type DielectricNode struct {
+	IndexOfRefraction nodes.Output[float64]        `description:"How much light bends when entering the surface. Defaults to 1.5, roughly that of glass"`
+	Color             nodes.Output[coloring.Color] `description:"Tint of light passing through the surface. Defaults to white"`
+}
+
+func (DielectricNode) Description() string {
+	return "Transparent surface like glass or water that both reflects and refracts light"
+}
+
+func (n DielectricNode) Out(out *nodes.StructOutput[rendering.Material]) {
+	ior := nodes.TryGetOutputValue(out, n.IndexOfRefraction, 1.5)
+	if ior <= 0 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.IndexOfRefraction, Message: "index of refraction must be greater than 0"})
+		return
+	}
+	out.Set(NewDielectricWithColor(ior, colorOrWhite(out, n.Color)))
+}
+
+type DiffuseLightNode struct {
+	Color     nodes.Output[coloring.Color] `description:"Color of the emitted light. Defaults to white"`
+	Intensity nodes.Output[float64]        `description:"Multiplier applied to the emitted light. Defaults to 1"`
+}
+
+func (DiffuseLightNode) Description() string {
+	return "Surface that emits light"
+}
+
+func (n DiffuseLightNode) Out(out *nodes.StructOutput[rendering.Material]) {
+	intensity := nodes.TryGetOutputValue(out, n.Intensity, 1)
+	out.Set(NewDiffuseLightWithColor(colorOrWhite(out, n.Color).Scale(intensity)))
+}
@@ -0,0 +1,199 @@
+package rendering
+
+import (
+	"errors"
+	"image"
+
+	"github.com/EliCDavis/polyform/drawing/coloring"
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+
+	refutil.RegisterType[nodes.Struct[MeshNode]](factory)
+	refutil.RegisterType[nodes.Struct[CameraNode]](factory)
+	refutil.RegisterType[nodes.Struct[RenderNode]](factory)
+
+	generator.RegisterTypes(factory)
+}
+
+func colorToVector(c coloring.Color) vector3.Float64 {
+	return vector3.New(c.R, c.G, c.B)
+}
+
+// skyBackground is the white to light blue gradient used when a camera isn't
+// given a background color
+func skyBackground(direction vector3.Float64) vector3.Float64 {
+	t := 0.5 * (direction.Normalized().Y() + 1.0)
+	return vector3.One[float64]().Scale(1.0 - t).Add(vector3.New(0.5, 0.7, 1.0).Scale(t))
+}
+
+// ============================================================================
+
+type MeshNode struct {
+	Mesh     nodes.Output[modeling.Mesh] `description:"Triangle mesh to place within the scene"`
+	Material nodes.Output[Material]      `description:"How light interacts with the surface of the mesh"`
+}
+
+func (MeshNode) Description() string {
+	return "Converts a mesh into an object that can be rendered"
+}
+
+func (n MeshNode) Out(out *nodes.StructOutput[Hittable]) {
+	if n.Mesh == nil {
+		return
+	}
+
+	if n.Material == nil {
+		out.CaptureError(errors.New("a material is required to render a mesh"))
+		return
+	}
+
+	mesh := nodes.GetOutputValue(out, n.Mesh)
+	if mesh.PrimitiveCount() == 0 {
+		return
+	}
+
+	if mesh.Topology() != modeling.TriangleTopology {
+		out.CaptureError(errors.New("only meshes with a triangle topology can be rendered"))
+		return
+	}
+
+	if !mesh.HasFloat3Attribute(modeling.PositionAttribute) {
+		out.CaptureError(meshops.RequireV3Attribute(mesh, modeling.PositionAttribute))
+		return
+	}
+
+	// Shading requires normals
+	if !mesh.HasFloat3Attribute(modeling.NormalAttribute) {
+		mesh = meshops.SmoothNormals(mesh)
+	}
+
+	material := nodes.GetOutputValue(out, n.Material)
+	if material == nil {
+		out.CaptureError(nodes.NilInputError{Input: n.Material})
+		return
+	}
+
+	out.Set(NewBVHFromMesh(mesh, material))
+}
+
+// ============================================================================
+
+type CameraNode struct {
+	Position    nodes.Output[vector3.Float64] `description:"Location of the camera. Defaults to (0, 0, 1)"`
+	LookAt      nodes.Output[vector3.Float64] `description:"Point the camera is facing"`
+	Up          nodes.Output[vector3.Float64] `description:"Direction considered up. Defaults to (0, 1, 0)"`
+	FieldOfView nodes.Output[float64]         `description:"Vertical field of view in degrees. Defaults to 60"`
+	AspectRatio nodes.Output[float64]         `description:"Width of the image divided by its height. Defaults to 1"`
+	Aperture    nodes.Output[float64]         `description:"Size of the lens, with larger values blurring everything not at the LookAt point. Defaults to 0"`
+	Background  nodes.Output[coloring.Color]  `description:"Color of rays that hit nothing. Defaults to a sky gradient"`
+}
+
+func (CameraNode) Description() string {
+	return "Viewpoint a scene is rendered from"
+}
+
+func (n CameraNode) Out(out *nodes.StructOutput[Camera]) {
+	position := nodes.TryGetOutputValue(out, n.Position, vector3.New(0., 0., 1.))
+	lookAt := nodes.TryGetOutputValue(out, n.LookAt, vector3.Zero[float64]())
+	up := nodes.TryGetOutputValue(out, n.Up, vector3.Up[float64]())
+	fov := nodes.TryGetOutputValue(out, n.FieldOfView, 60)
+	aspectRatio := nodes.TryGetOutputValue(out, n.AspectRatio, 1)
+	aperture := nodes.TryGetOutputValue(out, n.Aperture, 0)
+
+	if position.Distance(lookAt) == 0 {
+		out.CaptureError(errors.New("camera position and look at point can not be the same"))
+		return
+	}
+
+	if up.Cross(position.Sub(lookAt)).Length() == 0 {
+		out.CaptureError(errors.New("camera up direction can not be parallel to the view direction"))
+		return
+	}
+
+	if fov <= 0 || fov >= 180 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.FieldOfView, Message: "field of view must be between 0 and 180 degrees"})
+		return
+	}
+
+	if aspectRatio <= 0 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.AspectRatio, Message: "aspect ratio must be greater than 0"})
+		return
+	}
+
+	if aperture < 0 {
+		out.CaptureError(nodes.InvalidInputError{Input: n.Aperture, Message: "aperture can not be negative"})
+		return
+	}
+
+	background := skyBackground
+	if n.Background != nil {
+		color := colorToVector(nodes.GetOutputValue(out, n.Background))
+		background = func(vector3.Float64) vector3.Float64 { return color }
+	}
+
+	out.Set(NewCamera(
+		fov, aspectRatio, aperture, position.Distance(lookAt),
+		position, lookAt, up,
+		0, 0,
+		background,
+	))
+}
+
+// ============================================================================
+
+type RenderNode struct {
+	Objects         []nodes.Output[Hittable] `description:"Everything within the scene"`
+	Camera          nodes.Output[Camera]     `description:"Viewpoint to render the scene from"`
+	Width           nodes.Output[int]        `description:"Width of the image in pixels, with the height derived from the camera's aspect ratio. Defaults to 256"`
+	SamplesPerPixel nodes.Output[int]        `description:"Number of rays traced per pixel, with more reducing noise at the cost of time. Defaults to 16"`
+	MaxRayBounce    nodes.Output[int]        `description:"Number of times a ray can bounce before it's considered absorbed. Defaults to 8"`
+}
+
+func (RenderNode) Description() string {
+	return "Path traces the scene into an image"
+}
+
+func (n RenderNode) CacheVersion() int {
+	return 1
+}
+
+func (n RenderNode) Out(out *nodes.StructOutput[image.Image]) {
+	if n.Camera == nil {
+		out.CaptureError(errors.New("a camera is required to render the scene"))
+		return
+	}
+
+	objects := make([]Hittable, 0, len(n.Objects))
+	for _, object := range nodes.GetOutputValues(out, n.Objects) {
+		if object != nil {
+			objects = append(objects, object)
+		}
+	}
+
+	img, err := RenderWithContext(
+		out.Context(),
+		nodes.TryGetOutputValue(out, n.MaxRayBounce, 8),
+		nodes.TryGetOutputValue(out, n.SamplesPerPixel, 16),
+		nodes.TryGetOutputValue(out, n.Width, 256),
+		objects,
+		nodes.GetOutputValue(out, n.Camera),
+		nil,
+	)
+	if err != nil {
+		// Cancellation is already recorded by the evaluation itself
+		if out.Context().Err() == nil {
+			out.CaptureError(err)
+		}
+		return
+	}
+
+	out.Set(img)
+}
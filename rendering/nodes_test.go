@@ -0,0 +1,94 @@
+package rendering_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/EliCDavis/polyform/drawing/coloring"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/polyform/rendering/materials"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderNode(t *testing.T) {
+	// ARRANGE ================================================================
+	light := &nodes.Struct[materials.DiffuseLightNode]{
+		Data: materials.DiffuseLightNode{
+			Color: nodes.ConstOutput[coloring.Color]{Val: coloring.White()},
+		},
+	}
+
+	sphere := &nodes.Struct[rendering.MeshNode]{
+		Data: rendering.MeshNode{
+			Mesh:     nodes.ConstOutput[modeling.Mesh]{Val: primitives.UVSphere(1, 12, 12)},
+			Material: nodes.GetNodeOutputPort[rendering.Material](light, "Out"),
+		},
+	}
+
+	camera := &nodes.Struct[rendering.CameraNode]{
+		Data: rendering.CameraNode{
+			Position:    nodes.ConstOutput[vector3.Float64]{Val: vector3.New(0., 0., 5.)},
+			AspectRatio: nodes.ConstOutput[float64]{Val: 2},
+			Background:  nodes.ConstOutput[coloring.Color]{Val: coloring.Black()},
+		},
+	}
+
+	render := &nodes.Struct[rendering.RenderNode]{
+		Data: rendering.RenderNode{
+			Objects:         []nodes.Output[rendering.Hittable]{nodes.GetNodeOutputPort[rendering.Hittable](sphere, "Out")},
+			Camera:          nodes.GetNodeOutputPort[rendering.Camera](camera, "Out"),
+			Width:           nodes.ConstOutput[int]{Val: 32},
+			SamplesPerPixel: nodes.ConstOutput[int]{Val: 2},
+		},
+	}
+
+	// ACT ====================================================================
+	img := nodes.GetNodeOutputPort[image.Image](render, "Out").Value()
+
+	// ASSERT =================================================================
+	require.NotNil(t, img)
+	assert.Equal(t, image.Rect(0, 0, 32, 16), img.Bounds())
+	assert.Equal(t, color.RGBAModel.Convert(color.White), color.RGBAModel.Convert(img.At(16, 8)))
+	assert.Equal(t, color.RGBAModel.Convert(color.Black), color.RGBAModel.Convert(img.At(0, 0)))
+}
+
+func TestRenderNode_RequiresCamera(t *testing.T) {
+	render := &nodes.Struct[rendering.RenderNode]{}
+
+	port := nodes.GetNodeOutputPort[image.Image](render, "Out")
+
+	assert.Nil(t, port.Value())
+	assert.NotEmpty(t, port.(nodes.ObservableExecution).ExecutionReport().Errors)
+}
+
+func TestCameraNode_Invalid(t *testing.T) {
+	tests := map[string]rendering.CameraNode{
+		"position at look at": {
+			Position: nodes.ConstOutput[vector3.Float64]{Val: vector3.Zero[float64]()},
+		},
+		"up parallel to view": {
+			Up: nodes.ConstOutput[vector3.Float64]{Val: vector3.New(0., 0., 1.)},
+		},
+		"field of view too wide": {
+			FieldOfView: nodes.ConstOutput[float64]{Val: 180},
+		},
+		"negative aperture": {
+			Aperture: nodes.ConstOutput[float64]{Val: -1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			camera := &nodes.Struct[rendering.CameraNode]{Data: tc}
+			port := nodes.GetNodeOutputPort[rendering.Camera](camera, "Out")
+			port.Value()
+			assert.NotEmpty(t, port.(nodes.ObservableExecution).ExecutionReport().Errors)
+		})
+	}
+}
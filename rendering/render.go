@@ -1,11 +1,15 @@
 package rendering
 
 import (
+	"context"
+	"fmt"
 	"image"
 	"image/color"
 	"image/png"
 	"math/rand"
 	"os"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/EliCDavis/polyform/math/geometry"
@@ -42,63 +46,240 @@ func colorFromRay(tr TemporalRay, world Hittable, background sample.Vec3ToVec3,
 	).MultByVector(attenuation).Add(emitted)
 }
 
-func RenderToFile(
+// Size of the square regions of the image each worker renders at a time
+const renderTileSize = 32
+
+// RadianceBuffer is the linear color of each pixel of a rendered image,
+// stored row by row starting from the top left of the image
+type RadianceBuffer struct {
+	Width  int
+	Height int
+	Pixels []vector3.Float64
+}
+
+func (rb RadianceBuffer) At(x, y int) vector3.Float64 {
+	return rb.Pixels[(y*rb.Width)+x]
+}
+
+// Image gamma corrects the buffer into an 8 bit image
+func (rb RadianceBuffer) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, rb.Width, rb.Height))
+	for y := 0; y < rb.Height; y++ {
+		for x := 0; x < rb.Width; x++ {
+			col := rb.At(x, y).
+				Sqrt().
+				Scale(255).
+				Clamp(0, 255)
+
+			img.SetRGBA(x, y, color.RGBA{
+				uint8(col.X()),
+				uint8(col.Y()),
+				uint8(col.Z()),
+				255,
+			})
+		}
+	}
+	return img
+}
+
+// newWorld places the hittables within a bounding volume hierarchy. Anything
+// without a bounding box can't be placed in the hierarchy, and is instead
+// tested against every ray.
+func newWorld(hittables []Hittable, startTime, endTime float64) Hittable {
+	bounded := make([]Hittable, 0, len(hittables))
+	var world HitList
+	for _, h := range hittables {
+		if h.BoundingBox(startTime, endTime) == nil {
+			world = append(world, h)
+			continue
+		}
+		bounded = append(bounded, h)
+	}
+
+	if len(bounded) > 0 {
+		world = append(world, NewBVHTree(bounded, 0, len(bounded), startTime, endTime))
+	}
+	return world
+}
+
+type renderTile struct {
+	minX, minY, maxX, maxY int
+}
+
+// RenderRadiance path traces the scene, splitting the image into tiles that
+// are rendered in parallel across every CPU. If provided, the fraction of
+// the image completed is sent over completion as each tile finishes, and
+// completion is closed once rendering is done.
+func RenderRadiance(
 	maxRayBounce, samplesPerPixel, imageWidth int,
 	hittables []Hittable,
 	camera Camera,
-	imgPath string,
 	completion chan<- float64,
-) error {
-	f, err := os.Create(imgPath)
-	if err != nil {
-		return err
-	}
+) (RadianceBuffer, error) {
+	return RenderRadianceWithContext(context.Background(), maxRayBounce, samplesPerPixel, imageWidth, hittables, camera, completion)
+}
 
-	defer f.Close()
+// RenderRadianceWithContext is RenderRadiance, abandoning the render and
+// returning the context's error once it's done
+func RenderRadianceWithContext(
+	ctx context.Context,
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	hittables []Hittable,
+	camera Camera,
+	completion chan<- float64,
+) (RadianceBuffer, error) {
+	if completion != nil {
+		defer close(completion)
+	}
 
 	imageHeight := int(float64(imageWidth) / camera.aspectRatio)
-	img := image.NewRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+	if imageWidth < 2 || imageHeight < 2 {
+		return RadianceBuffer{}, fmt.Errorf("image must be at least 2x2 pixels, received %dx%d", imageWidth, imageHeight)
+	}
 
-	var world HitList = hittables
-	// bvh := NewBVH(hittables, camera.timeStart, camera.timeEnd)
+	if samplesPerPixel < 1 {
+		return RadianceBuffer{}, fmt.Errorf("samples per pixel must be at least 1, received %d", samplesPerPixel)
+	}
 
-	totalPixels := float64(imageHeight * imageWidth)
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for y := 0; y < imageHeight; y++ {
-		for x := 0; x < imageWidth; x++ {
+	buffer := RadianceBuffer{
+		Width:  imageWidth,
+		Height: imageHeight,
+		Pixels: make([]vector3.Float64, imageWidth*imageHeight),
+	}
 
-			col := vector3.Zero[float64]()
+	world := newWorld(hittables, camera.timeStart, camera.timeEnd)
 
-			for s := 0; s < samplesPerPixel; s++ {
-				u := (float64(x) + rand.Float64()) / float64(imageWidth-1)
-				v := (float64(y) + rand.Float64()) / float64(imageHeight-1)
-				col = col.Add(colorFromRay(camera.GetRay(r, u, v), &world, camera.background, maxRayBounce))
+	tiles := make([]renderTile, 0)
+	for y := 0; y < imageHeight; y += renderTileSize {
+		for x := 0; x < imageWidth; x += renderTileSize {
+			tiles = append(tiles, renderTile{
+				minX: x,
+				minY: y,
+				maxX: min(x+renderTileSize, imageWidth),
+				maxY: min(y+renderTileSize, imageHeight),
+			})
+		}
+	}
+
+	jobs := make(chan renderTile, len(tiles))
+	for _, tile := range tiles {
+		jobs <- tile
+	}
+	close(jobs)
+
+	finished := make(chan int, len(tiles))
+	workers := min(runtime.NumCPU(), len(tiles))
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for tile := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				renderTilePixels(ctx, tile, buffer, maxRayBounce, samplesPerPixel, world, camera, r)
+				finished <- (tile.maxX - tile.minX) * (tile.maxY - tile.minY)
 			}
+		}(time.Now().UnixNano() + int64(i))
+	}
 
-			col = col.
-				DivByConstant(float64(samplesPerPixel)).
-				Sqrt().
-				Scale(255).
-				Clamp(0, 255)
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
 
-			img.Set(x, imageHeight-y, &color.RGBA{
-				uint8(col.X()),
-				uint8(col.Y()),
-				uint8(col.Z()),
-				255,
-			})
+	totalPixels := float64(len(buffer.Pixels))
+	pixelsRendered := 0
+	for pixels := range finished {
+		pixelsRendered += pixels
+		if completion != nil {
+			completion <- float64(pixelsRendered) / totalPixels
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return RadianceBuffer{}, err
+	}
 
-			if completion != nil {
-				completion <- float64((y*imageWidth)+x) / totalPixels
+	return buffer, nil
+}
+
+func renderTilePixels(
+	ctx context.Context,
+	tile renderTile,
+	buffer RadianceBuffer,
+	maxRayBounce, samplesPerPixel int,
+	world Hittable,
+	camera Camera,
+	r *rand.Rand,
+) {
+	for y := tile.minY; y < tile.maxY; y++ {
+		if ctx.Err() != nil {
+			return
+		}
+		for x := tile.minX; x < tile.maxX; x++ {
+			col := vector3.Zero[float64]()
+			for s := 0; s < samplesPerPixel; s++ {
+				u := (float64(x) + r.Float64()) / float64(buffer.Width-1)
+				v := (float64(y) + r.Float64()) / float64(buffer.Height-1)
+				col = col.Add(colorFromRay(camera.GetRay(r, u, v), world, camera.background, maxRayBounce))
 			}
+
+			// Rays are cast from the bottom of the image up
+			row := buffer.Height - 1 - y
+			buffer.Pixels[(row*buffer.Width)+x] = col.DivByConstant(float64(samplesPerPixel))
 		}
 	}
+}
 
-	err = png.Encode(f, img)
+// Render path traces the scene into an image. See RenderRadiance.
+func Render(
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	hittables []Hittable,
+	camera Camera,
+	completion chan<- float64,
+) (image.Image, error) {
+	return RenderWithContext(context.Background(), maxRayBounce, samplesPerPixel, imageWidth, hittables, camera, completion)
+}
 
-	if completion != nil {
-		close(completion)
+// RenderWithContext path traces the scene into an image. See
+// RenderRadianceWithContext.
+func RenderWithContext(
+	ctx context.Context,
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	hittables []Hittable,
+	camera Camera,
+	completion chan<- float64,
+) (image.Image, error) {
+	buffer, err := RenderRadianceWithContext(ctx, maxRayBounce, samplesPerPixel, imageWidth, hittables, camera, completion)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Image(), nil
+}
+
+func RenderToFile(
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	hittables []Hittable,
+	camera Camera,
+	imgPath string,
+	completion chan<- float64,
+) error {
+	f, err := os.Create(imgPath)
+	if err != nil {
+		if completion != nil {
+			close(completion)
+		}
+		return err
+	}
+	defer f.Close()
+
+	img, err := Render(maxRayBounce, samplesPerPixel, imageWidth, hittables, camera, completion)
+	if err != nil {
+		return err
 	}
 
-	return err
+	return png.Encode(f, img)
 }
@@ -1,6 +1,7 @@
 package rendering_test
 
 import (
+	"image/color"
 	"math/rand"
 	"testing"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/EliCDavis/polyform/rendering/materials"
 	"github.com/EliCDavis/polyform/rendering/textures"
 	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func randomScene() []rendering.Hittable {
@@ -160,3 +163,71 @@ func BenchmarkBunnyRender(b *testing.B) {
 		}
 	}
 }
+
+func TestRender(t *testing.T) {
+	// ARRANGE ================================================================
+	background := vector3.New(0., 0., 1.)
+	camera := rendering.NewCamera(
+		45., 2., 0., 5.,
+		vector3.New(0., 0., 5.), vector3.Zero[float64](), vector3.Up[float64](),
+		0, 0,
+		func(v vector3.Float64) vector3.Float64 { return background },
+	)
+
+	scene := []rendering.Hittable{
+		rendering.NewSphere(vector3.Zero[float64](), 1, materials.NewDiffuseLightWithColor(vector3.New(1., 0., 0.))),
+
+		// Empty lists have no bounds, and can't be placed in the BVH
+		rendering.HitList{},
+	}
+
+	completion := make(chan float64, 1)
+	progress := make([]float64, 0)
+	done := make(chan struct{})
+	go func() {
+		for p := range completion {
+			progress = append(progress, p)
+		}
+		close(done)
+	}()
+
+	// ACT ====================================================================
+	img, err := rendering.Render(2, 4, 100, scene, camera, completion)
+	<-done
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 100, img.Bounds().Dx())
+	assert.Equal(t, 50, img.Bounds().Dy())
+
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, img.At(50, 25))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, img.At(0, 0))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, img.At(99, 49))
+
+	require.NotEmpty(t, progress)
+	assert.IsNonDecreasing(t, progress)
+	assert.InDelta(t, 1., progress[len(progress)-1], 1e-9)
+}
+
+func TestRender_InvalidImageSize(t *testing.T) {
+	camera := rendering.NewDefaultCamera(1, vector3.New(0., 0., 5.), vector3.Zero[float64](), 0, 0)
+	completion := make(chan float64, 1)
+
+	_, err := rendering.Render(2, 1, 1, nil, camera, completion)
+	assert.Error(t, err)
+
+	_, open := <-completion
+	assert.False(t, open)
+}
+
+func TestRenderRadiance_EmptyScene(t *testing.T) {
+	camera := rendering.NewDefaultCamera(1, vector3.New(0., 0., 5.), vector3.Zero[float64](), 0, 0)
+
+	buffer, err := rendering.RenderRadiance(2, 1, 40, nil, camera, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 40, buffer.Width)
+	assert.Equal(t, 40, buffer.Height)
+	assert.Len(t, buffer.Pixels, 40*40)
+	assert.Equal(t, vector3.One[float64](), buffer.At(39, 39))
+}
@@ -396,6 +396,16 @@ export class PolyNodeController {
             })
         }
 
+        if (report.criticalPath && report.criticalPath.length > 0) {
+            const path = report.criticalPath
+                .map((step) => `${step.label} ${formatNanoseconds(step.duration)}`)
+                .join(" <- ");
+            this.flowNode.addMessage({
+                message: `${portName}: critical path ${path}`,
+                alwaysShow: true
+            })
+        }
+
         if (report.errors) {
             for (let errI = 0; errI < report.errors.length; errI++) {
                 this.flowNode.addMessage({
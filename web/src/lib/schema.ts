@@ -173,6 +173,7 @@ export interface ExecutionReport {
   totalTime: number;
   selfTime?: number;
   steps?: StepTiming[];
+  criticalPath?: StepTiming[];
 }
 
 export interface GraphExecutionReport {